@@ -49,7 +49,7 @@ func cmdInstanceMgmtAll(c *cli.Context) error {
 		return err
 	}
 
-	return adm.Perform(`get`, `/instance/`, `list`, nil, c)
+	return adm.Perform(`get`, `/instance/`, `instance::list`, nil, c)
 }
 
 func cmdInstanceMgmtShow(c *cli.Context) error {
@@ -17,12 +17,14 @@ import (
 type Config struct {
 	API        string       `json:"api"`
 	Cert       string       `json:"cert"`
+	Socket     string       `json:"socket"`
 	LogDir     string       `json:"logdir"`
 	Timeout    uint         `json:"timeout,string"`
 	Activation string       `json:"activation.mode"`
 	AsyncWait  bool         `json:"async.wait,string"`
 	JobSave    bool         `json:"save.jobs,string"`
 	ProcJSON   string       `json:"json.output.processor"`
+	Format     string       `json:"output.format"`
 	Auth       AuthConfig   `json:"auth"`
 	AdminAuth  AuthConfig   `json:"admin.auth"`
 	BoltDB     ConfigBoltDB `json:"boltdb"`
@@ -42,6 +44,7 @@ type ConfigBoltDB struct {
 	File    string `json:"file"`
 	Mode    string `json:"mode"`
 	Timeout uint   `json:"open.timeout,string"`
+	IDCache string `json:"idcache.ttl"`
 }
 
 // RunTimeConfig struct
@@ -51,7 +54,9 @@ type RunTimeConfig struct {
 	PathBoltDB    string        `json:"-"`
 	ModeBoltDB    uint64        `json:"-"`
 	CertPath      string        `json:"-"`
+	SocketPath    string        `json:"-"`
 	TimeoutBoltDB time.Duration `json:"-"`
+	TTLIDCache    time.Duration `json:"-"`
 	TimeoutResty  time.Duration `json:"-"`
 	Logger        *log.Logger   `json:"-"`
 }
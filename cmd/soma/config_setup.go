@@ -44,7 +44,7 @@ func configSetup(c *cli.Context) error {
 	}
 
 	// finish setting up runtime configuration
-	params := []string{"api", "timeout", "user", "logdir", "dbdir"}
+	params := []string{"api", "timeout", "user", "logdir", "dbdir", "socket"}
 
 	for p := range params {
 		// update configuration with cli argument overrides
@@ -65,6 +65,8 @@ func configSetup(c *cli.Context) error {
 				Cfg.BoltDB.Path = c.GlobalString(params[p])
 			case "logdir":
 				Cfg.LogDir = c.GlobalString(params[p])
+			case "socket":
+				Cfg.Socket = c.GlobalString(params[p])
 			}
 			continue
 		}
@@ -97,8 +99,29 @@ func configSetup(c *cli.Context) error {
 				"%s\n", err.Error())
 	}
 	Cfg.Run.TimeoutBoltDB = time.Duration(Cfg.BoltDB.Timeout) * time.Second
+	if Cfg.BoltDB.IDCache == `` {
+		Cfg.BoltDB.IDCache = `1h`
+	}
+	Cfg.Run.TTLIDCache, err = time.ParseDuration(Cfg.BoltDB.IDCache)
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to parse configuration field boltdb.idcache.ttl: "+
+				"%s\n", err.Error())
+	}
+	if Cfg.Run.TTLIDCache < 0 {
+		return fmt.Errorf(
+			"Configuration field boltdb.idcache.ttl must not be negative\n")
+	}
 	Cfg.Run.TimeoutResty = time.Duration(Cfg.Timeout) * time.Second
 
+	// requests via the admin socket use plain http, access is
+	// controlled by the socket's permissions
+	if Cfg.Socket != `` {
+		Cfg.Run.SocketPath = Cfg.Socket
+		Cfg.Run.SomaAPI = &url.URL{Scheme: `http`, Host: `localhost`}
+		return nil
+	}
+
 	Cfg.Run.SomaAPI, err = url.Parse(Cfg.API)
 	if err != nil {
 		return fmt.Errorf(
@@ -117,6 +117,7 @@ func registerNodes(app cli.App) *cli.App {
 						Usage:        `Assign a node to configuration bucket`,
 						Description:  help.Text(`node::assign`),
 						Action:       runtime(nodeAssign),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: comptime(bashCompNodeAssign),
 					},
 					{
@@ -124,6 +125,7 @@ func registerNodes(app cli.App) *cli.App {
 						Usage:        `Unassign a node from its configuration bucket`,
 						Description:  help.Text(`node::unassign`),
 						Action:       runtime(nodeUnassign),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: comptime(bashCompNodeUnassign),
 					},
 					{
@@ -148,6 +150,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Add a system property to a node`,
 										Description:  help.Text(`node-config::property-create`),
 										Action:       runtime(nodeConfigPropertyCreateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -155,6 +158,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Add a service property to a node`,
 										Description:  help.Text(`node-config::property-create`),
 										Action:       runtime(nodeConfigPropertyCreateService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -162,6 +166,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Add an oncall property to a node`,
 										Description:  help.Text(`node-config::property-create`),
 										Action:       runtime(nodeConfigPropertyCreateOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 									{
@@ -169,6 +174,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Add a custom property to a node`,
 										Description:  help.Text(`node-config::property-create`),
 										Action:       runtime(nodeConfigPropertyCreateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -183,6 +189,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Update a system property to a node`,
 										Description:  help.Text(`node-config::property-update`),
 										Action:       runtime(nodeConfigPropertyUpdateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -190,6 +197,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Update a custom property to a node`,
 										Description:  help.Text(`node-config::property-update`),
 										Action:       runtime(nodeConfigPropertyUpdateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -204,6 +212,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Delete a system property from a node`,
 										Description:  help.Text(`node-config::property-destroy`),
 										Action:       runtime(nodeConfigPropertyDestroySystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -211,6 +220,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Delete a service property from a node`,
 										Description:  help.Text(`node-config::property-destroy`),
 										Action:       runtime(nodeConfigPropertyDestroyService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -218,6 +228,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Delete an oncall property from a node`,
 										Description:  help.Text(`node-config::property-destroy`),
 										Action:       runtime(nodeConfigPropertyDestroyOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -225,6 +236,7 @@ func registerNodes(app cli.App) *cli.App {
 										Usage:        `Delete a custom property from a node`,
 										Description:  help.Text(`node-config::property-destroy`),
 										Action:       runtime(nodeConfigPropertyDestroyCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 								},
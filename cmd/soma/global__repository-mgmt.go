@@ -36,6 +36,7 @@ func registerRepository(app cli.App) *cli.App {
 						Usage:        `Destroy an existing repository`,
 						Description:  help.Text(`repository::destroy`),
 						Action:       runtime(repositoryDestroy),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.From,
 					},
 					{
@@ -43,6 +44,7 @@ func registerRepository(app cli.App) *cli.App {
 						Usage:        `Rename an existing repository`,
 						Description:  help.Text(`repository::rename`),
 						Action:       runtime(repositoryRename),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.FromTo,
 					},
 					{
@@ -50,8 +52,53 @@ func registerRepository(app cli.App) *cli.App {
 						Usage:        `Change the owner team of a repository`,
 						Description:  help.Text(`repository::repossess`),
 						Action:       runtime(repositoryRepossess),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.FromTo,
 					},
+					{
+						Name:         `audit`,
+						Usage:        `Show the change history of a repository`,
+						Description:  help.Text(`repository::audit`),
+						Action:       runtime(repositoryAudit),
+						BashComplete: cmpl.RepositoryAudit,
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  `detailed, d`,
+								Usage: `Include the tree actions recorded in the job logs`,
+							},
+						},
+					},
+					{
+						Name:         `batch`,
+						Usage:        `Apply a list of operations to a repository as one job`,
+						Description:  help.Text(`repository::batch`),
+						Action:       runtime(repositoryBatch),
+						Flags:        []cli.Flag{dryRunFlag},
+						BashComplete: cmpl.RepositoryBatch,
+					},
+					{
+						Name:        `export`,
+						Usage:       `Export the layout of a repository as YAML or JSON document`,
+						Description: help.Text(`repository::export`),
+						Action:      runtime(repositoryExport),
+					},
+					{
+						Name:        `apply`,
+						Usage:       `Change a repository to match an exported layout`,
+						Description: help.Text(`repository::apply`),
+						Action:      runtime(repositoryApply),
+						Flags: []cli.Flag{
+							dryRunFlag,
+							cli.StringFlag{
+								Name:  `file, f`,
+								Usage: `Path to the YAML or JSON layout document`,
+							},
+							cli.BoolFlag{
+								Name:  `yes, y`,
+								Usage: `Apply the changes without asking for confirmation`,
+							},
+						},
+					},
 					{
 						Name:        `list`,
 						Usage:       `List existing repositories`,
@@ -93,6 +140,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Add a system property to a repository`,
 										Description:  help.Text(`repository-config::property-create`),
 										Action:       runtime(repositoryConfigPropertyCreateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -100,6 +148,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Add a custom property to a repository`,
 										Description:  help.Text(`repository-config::property-create`),
 										Action:       runtime(repositoryConfigPropertyCreateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -107,6 +156,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Add a service property to a repository`,
 										Description:  help.Text(`repository-config::property-create`),
 										Action:       runtime(repositoryConfigPropertyCreateService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreate,
 									},
 									{
@@ -114,6 +164,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Add an oncall property to a repository`,
 										Description:  help.Text(`repository-config::property-create`),
 										Action:       runtime(repositoryConfigPropertyCreateOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreate,
 									},
 								},
@@ -128,6 +179,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Update a system property to a repository`,
 										Description:  help.Text(`repository-config::property-update`),
 										Action:       runtime(repositoryConfigPropertyUpdateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -135,6 +187,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Update a custom property to a repository`,
 										Description:  help.Text(`repository-config::property-update`),
 										Action:       runtime(repositoryConfigPropertyUpdateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 								},
@@ -149,6 +202,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Destroy a system property from a repository`,
 										Description:  help.Text(`repository-config::property-destroy`),
 										Action:       runtime(repositoryConfigPropertyDestroySystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -156,6 +210,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Destroy a custom property from a repository`,
 										Description:  help.Text(`repository-config::property-destroy`),
 										Action:       runtime(repositoryConfigPropertyDestroyCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -163,6 +218,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Destroy a service property from a repository`,
 										Description:  help.Text(`repository-config::property-destroy`),
 										Action:       runtime(repositoryConfigPropertyDestroyService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -170,6 +226,7 @@ func registerRepository(app cli.App) *cli.App {
 										Usage:        `Destroy an oncall property from a repository`,
 										Description:  help.Text(`repository-config::property-destroy`),
 										Action:       runtime(repositoryConfigPropertyDestroyOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 								},
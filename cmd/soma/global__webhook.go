@@ -0,0 +1,150 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/internal/cmpl"
+	"github.com/mjolnir42/soma/internal/help"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func registerWebhooks(app cli.App) *cli.App {
+	app.Commands = append(app.Commands,
+		[]cli.Command{
+			{
+				Name:        `webhook`,
+				Usage:       `SUBCOMMANDS for deployment event webhooks`,
+				Description: help.Text(`webhook::`),
+				Subcommands: []cli.Command{
+					{
+						Name:         `add`,
+						Usage:        `Register a new webhook`,
+						Description:  help.Text(`webhook::add`),
+						Action:       runtime(webhookAdd),
+						BashComplete: cmpl.WebhookAdd,
+					},
+					{
+						Name:         `remove`,
+						Usage:        `Remove a webhook`,
+						Description:  help.Text(`webhook::remove`),
+						Action:       runtime(webhookRemove),
+						BashComplete: cmpl.None,
+					},
+					{
+						Name:         `list`,
+						Usage:        `List all webhooks`,
+						Description:  help.Text(`webhook::list`),
+						Action:       runtime(webhookList),
+						BashComplete: cmpl.None,
+					},
+					{
+						Name:         `show`,
+						Usage:        `Show details about a webhook`,
+						Description:  help.Text(`webhook::show`),
+						Action:       runtime(webhookShow),
+						BashComplete: cmpl.None,
+					},
+				},
+			},
+		}...,
+	)
+	return &app
+}
+
+// webhookAdd function
+// soma webhook add ${name}
+//
+//	 url ${url}
+//	[repository ${repository}]
+//	[monitoring ${monitoring}]
+//	[state ${state} [state ...]]
+func webhookAdd(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{`state`},
+		[]string{`url`, `repository`, `monitoring`},
+		[]string{`url`},
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	req := proto.NewWebhookRequest()
+	req.Webhook.Name = c.Args().First()
+	if err = adm.ValidateRuneCountRange(req.Webhook.Name, 1, 256); err != nil {
+		return err
+	}
+	req.Webhook.URL = opts[`url`][0]
+	req.Webhook.States = opts[`state`]
+
+	if len(opts[`repository`]) == 1 {
+		if req.Webhook.RepositoryID, err = adm.LookupRepoID(
+			opts[`repository`][0]); err != nil {
+			return err
+		}
+	}
+
+	if len(opts[`monitoring`]) == 1 {
+		if req.Webhook.MonitoringID, err = adm.LookupMonitoringID(
+			opts[`monitoring`][0]); err != nil {
+			return err
+		}
+	}
+
+	return adm.Perform(`postbody`, `/webhook/`, `webhook::add`, req, c)
+}
+
+// webhookRemove function
+// soma webhook remove ${webhookID}
+func webhookRemove(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	path := fmt.Sprintf("/webhook/%s", c.Args().First())
+	return adm.Perform(`delete`, path, `command`, nil, c)
+}
+
+// webhookList function
+// soma webhook list
+func webhookList(c *cli.Context) error {
+	if err := adm.VerifyNoArgument(c); err != nil {
+		return err
+	}
+
+	return adm.Perform(`get`, `/webhook/`, `list`, nil, c)
+}
+
+// webhookShow function
+// soma webhook show ${webhookID}
+func webhookShow(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	path := fmt.Sprintf("/webhook/%s", c.Args().First())
+	return adm.Perform(`get`, path, `show`, nil, c)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
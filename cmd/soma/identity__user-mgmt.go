@@ -336,7 +336,7 @@ func userMgmtList(c *cli.Context) error {
 		return err
 	}
 
-	return adm.Perform(`get`, `/user/`, `list`, nil, c)
+	return adm.Perform(`get`, `/user/`, `user::list`, nil, c)
 }
 
 // userMgmtShow function
@@ -364,7 +364,7 @@ func userMgmtSync(c *cli.Context) error {
 		return err
 	}
 
-	return adm.Perform(`get`, `/sync/user/`, `list`, nil, c)
+	return adm.Perform(`get`, `/sync/user/`, `user::sync`, nil, c)
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
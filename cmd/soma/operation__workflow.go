@@ -11,6 +11,7 @@ package main // import "github.com/mjolnir42/soma/cmd/soma"
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/codegangsta/cli"
 	"github.com/mjolnir42/soma/internal/adm"
@@ -46,10 +47,23 @@ func registerWorkflow(app cli.App) *cli.App {
 						Action:      runtime(workflowSearch),
 					},
 					{
-						Name:        `retry`,
-						Usage:       `Reschedule an instance in a failed state`,
-						Description: help.Text(`workflow::retry`),
-						Action:      runtime(workflowRetry),
+						Name:        `history`,
+						Usage:       `Show the workflow state transitions of an instance`,
+						Description: help.Text(`workflow::history`),
+						Action:      runtime(workflowHistory),
+					},
+					{
+						Name:         `retry`,
+						Usage:        `Reschedule an instance in a failed state`,
+						Description:  help.Text(`workflow::retry`),
+						Action:       runtime(workflowRetry),
+						BashComplete: cmpl.WorkflowRetry,
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  `filter`,
+								Usage: `Reschedule all instances matching a filter`,
+							},
+						},
 					},
 					{
 						Name:         `set`,
@@ -62,6 +76,10 @@ func registerWorkflow(app cli.App) *cli.App {
 								Name:  `force, f`,
 								Usage: `Force is required to break the workflow`,
 							},
+							cli.BoolFlag{
+								Name:  `filter`,
+								Usage: `Set all instances matching a filter`,
+							},
 						},
 					},
 				},
@@ -107,9 +125,29 @@ func workflowSearch(c *cli.Context) error {
 	return adm.Perform(`postbody`, `/search/workflow/`, `list`, req, c)
 }
 
+// workflowHistory function
+// soma workflow history ${instanceID}
+func workflowHistory(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+	if err := adm.ValidateInstance(c.Args().First()); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf(
+		"/workflow/history/%s",
+		url.QueryEscape(c.Args().First()),
+	)
+	return adm.Perform(`get`, path, `list`, nil, c)
+}
+
 // workflowRetry function XXX UNTESTED
 // soma workflow retry ${instanceID}
 func workflowRetry(c *cli.Context) error {
+	if c.Bool(`filter`) {
+		return workflowBulkRetry(c)
+	}
 	if err := adm.VerifySingleArgument(c); err != nil {
 		return err
 	}
@@ -127,6 +165,10 @@ func workflowRetry(c *cli.Context) error {
 // workflowSet function XXX UNTESTED
 // soma workflow set ${instanceConfigID} status ${current} next ${nextStatus}
 func workflowSet(c *cli.Context) error {
+	if c.Bool(`filter`) {
+		return workflowBulkSet(c)
+	}
+
 	opts := map[string][]string{}
 	multipleAllowed := []string{}
 	uniqueOptions := []string{`status`, `next`}
@@ -152,6 +194,12 @@ func workflowSet(c *cli.Context) error {
 	if err := adm.ValidateStatus(opts[`next`][0]); err != nil {
 		return err
 	}
+	if next, _ := proto.DeploymentNextStatus(
+		opts[`status`][0],
+	); next != opts[`next`][0] {
+		return fmt.Errorf("Workflow status %s requires next status %s",
+			opts[`status`][0], next)
+	}
 	req := proto.NewWorkflowRequest()
 	req.Flags.Forced = c.Bool(`force`)
 	req.Workflow.InstanceConfigID = c.Args().First()
@@ -165,4 +213,115 @@ func workflowSet(c *cli.Context) error {
 	return adm.Perform(`patchbody`, path, `command`, req, c)
 }
 
+// workflowBulkRetry function
+// soma workflow retry --filter
+//
+//	[state ${status} [state ...]]
+//	[monitoring ${monitoring}]
+//	[repository ${repository}]
+//	[capability ${capability}]
+//	[age ${duration}]
+func workflowBulkRetry(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{`state`},
+		[]string{`monitoring`, `repository`, `capability`, `age`},
+		[]string{},
+		c.Args(),
+	); err != nil {
+		return err
+	}
+
+	req := proto.NewWorkflowBulkRequest()
+	if err := workflowFilter(opts, req.Filter.Workflow); err != nil {
+		return err
+	}
+
+	return adm.Perform(`patchbody`, `/workflow/bulk/retry`, `list`, req, c)
+}
+
+// workflowBulkSet function
+// soma workflow set --filter --force
+//
+//	 status ${status}
+//	 next ${nextStatus}
+//	 state ${status} [state ...]
+//	[monitoring ${monitoring}]
+//	[repository ${repository}]
+//	[capability ${capability}]
+//	[age ${duration}]
+func workflowBulkSet(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{`state`},
+		[]string{`status`, `next`, `monitoring`, `repository`,
+			`capability`, `age`},
+		[]string{`status`, `next`, `state`},
+		c.Args(),
+	); err != nil {
+		return err
+	}
+
+	if err := adm.ValidateStatus(opts[`status`][0]); err != nil {
+		return err
+	}
+	if next, _ := proto.DeploymentNextStatus(
+		opts[`status`][0],
+	); next != opts[`next`][0] {
+		return fmt.Errorf("Workflow status %s requires next status %s",
+			opts[`status`][0], next)
+	}
+
+	req := proto.NewWorkflowBulkRequest()
+	req.Flags.Forced = c.Bool(`force`)
+	req.Workflow.Status = opts[`status`][0]
+	req.Workflow.NextStatus = opts[`next`][0]
+	if err := workflowFilter(opts, req.Filter.Workflow); err != nil {
+		return err
+	}
+
+	return adm.Perform(`patchbody`, `/workflow/bulk/set`, `list`, req, c)
+}
+
+// workflowFilter fills f from the parsed filter arguments of a bulk
+// workflow command
+func workflowFilter(opts map[string][]string, f *proto.WorkflowFilter) error {
+	var err error
+
+	for _, state := range opts[`state`] {
+		if err = adm.ValidateStatus(state); err != nil {
+			return err
+		}
+	}
+	f.States = opts[`state`]
+
+	if len(opts[`monitoring`]) == 1 {
+		if f.MonitoringID, err = adm.LookupMonitoringID(
+			opts[`monitoring`][0]); err != nil {
+			return err
+		}
+	}
+	if len(opts[`repository`]) == 1 {
+		if f.RepositoryID, err = adm.LookupRepoID(
+			opts[`repository`][0]); err != nil {
+			return err
+		}
+	}
+	if len(opts[`capability`]) == 1 {
+		if f.CapabilityID, err = adm.LookupCapabilityID(
+			opts[`capability`][0]); err != nil {
+			return err
+		}
+	}
+	if len(opts[`age`]) == 1 {
+		if _, err = time.ParseDuration(opts[`age`][0]); err != nil {
+			return err
+		}
+		f.MinAge = opts[`age`][0]
+	}
+	return nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -26,6 +26,16 @@ func registerRights(app cli.App) *cli.App {
 						Action:       runtime(rightGrant),
 						Description:  help.Text(`right::grant`),
 						BashComplete: cmpl.TripleToOn,
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  `not-before`,
+								Usage: `Timestamp or duration from now at which the grant becomes valid`,
+							},
+							cli.StringFlag{
+								Name:  `not-after`,
+								Usage: `Timestamp or duration from now at which the grant expires`,
+							},
+						},
 					},
 					{
 						Name:         `revoke`,
@@ -58,7 +68,8 @@ func registerRights(app cli.App) *cli.App {
 }
 
 // rightGrant function
-// soma right grant $category::$permission
+// soma right grant [--not-before $time] [--not-after $time]
+//            $category::$permission
 //            to user|admin|team $name
 //           [on repository|bucket|monitoring|team $name]
 func rightGrant(c *cli.Context) error {
@@ -210,6 +221,20 @@ func rightGrant(c *cli.Context) error {
 		}
 	}
 
+	// optional validity period of the grant
+	if c.IsSet(`not-before`) {
+		if req.Grant.NotBefore, err = adm.ParseTimestamp(
+			c.String(`not-before`)); err != nil {
+			return err
+		}
+	}
+	if c.IsSet(`not-after`) {
+		if req.Grant.NotAfter, err = adm.ParseTimestamp(
+			c.String(`not-after`)); err != nil {
+			return err
+		}
+	}
+
 	path := fmt.Sprintf("/category/%s/permission/%s/grant/",
 		req.Grant.Category, req.Grant.PermissionID)
 	return adm.Perform(`postbody`, path, `right::grant`, req, c)
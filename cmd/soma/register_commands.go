@@ -40,8 +40,10 @@ func registerCommands(app cli.App) *cli.App {
 	}
 
 	app = *registerAction(app)
+	app = *registerAPIKey(app)
 	app = *registerAttributes(app)
 	app = *registerBucket(app)
+	app = *registerCache(app)
 	app = *registerCapability(app)
 	app = *registerCategories(app)
 	app = *registerChecks(app)
@@ -54,6 +56,7 @@ func registerCommands(app cli.App) *cli.App {
 	app = *registerInstances(app)
 	app = *registerJobs(app)
 	app = *registerLevels(app)
+	app = *registerMaintenance(app)
 	app = *registerMetrics(app)
 	app = *registerModes(app)
 	app = *registerMonitoringMgmt(app)
@@ -74,6 +77,7 @@ func registerCommands(app cli.App) *cli.App {
 	app = *registerUserMgmt(app)
 	app = *registerValidity(app)
 	app = *registerViews(app)
+	app = *registerWebhooks(app)
 	app = *registerWorkflow(app)
 	app = *registerOps(app)
 
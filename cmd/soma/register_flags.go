@@ -4,6 +4,13 @@ import (
 	"github.com/codegangsta/cli"
 )
 
+// dryRunFlag is accepted by all commands that modify a repository
+// tree
+var dryRunFlag = cli.BoolFlag{
+	Name:  "dry-run, n",
+	Usage: "Show the planned changes without applying them",
+}
+
 func registerFlags(app cli.App) *cli.App {
 	app.Flags = []cli.Flag{
 		cli.StringFlag{
@@ -18,6 +25,10 @@ func registerFlags(app cli.App) *cli.App {
 			Name:  "host, H",
 			Usage: "API URI to connect to",
 		},
+		cli.StringFlag{
+			Name:  "socket, S",
+			Usage: "admin UNIX socket to connect to instead of the API URI",
+		},
 		cli.StringFlag{
 			Name:  "dbdir, d",
 			Usage: "name of the db subdirectory",
@@ -39,6 +50,10 @@ func registerFlags(app cli.App) *cli.App {
 			Name:  "json, J",
 			Usage: "output reply as JSON",
 		},
+		cli.StringFlag{
+			Name:  "format, F",
+			Usage: "output format: json, table, csv or yaml",
+		},
 		cli.BoolFlag{
 			Name:  "volatile, o",
 			Usage: "Do not ensure that the BoltDB structure exists",
@@ -0,0 +1,65 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// repositoryBatch function
+// soma repository batch ${repository} file ${path}
+func repositoryBatch(c *cli.Context) error {
+	opts := map[string][]string{}
+	multipleAllowed := []string{}
+	uniqueOptions := []string{`file`}
+	mandatoryOptions := []string{`file`}
+
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multipleAllowed,
+		uniqueOptions,
+		mandatoryOptions,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	repositoryID, err := adm.LookupRepoID(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(opts[`file`][0])
+	if err != nil {
+		return err
+	}
+
+	req := proto.NewBatchRequest()
+	if err := json.Unmarshal(data, req.Batch); err != nil {
+		return fmt.Errorf("Invalid batch file %s: %s",
+			opts[`file`][0], err.Error())
+	}
+	if len(req.Batch.Operations) == 0 {
+		return fmt.Errorf("Batch file %s contains no operations",
+			opts[`file`][0])
+	}
+
+	path := fmt.Sprintf("/repository/%s/batch/",
+		url.QueryEscape(repositoryID),
+	)
+	return adm.Perform(`postbody`, path, `command`, req, c)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
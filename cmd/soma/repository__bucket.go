@@ -34,6 +34,7 @@ func registerBucket(app cli.App) *cli.App {
 						Usage:        `Create a new bucket inside a repository`,
 						Description:  help.Text(`bucket::create`),
 						Action:       runtime(bucketCreate),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.BucketCreate,
 					},
 					{
@@ -41,6 +42,7 @@ func registerBucket(app cli.App) *cli.App {
 						Usage:        `Mark an existing bucket as deleted`,
 						Description:  help.Text(`bucket::destroy`),
 						Action:       runtime(bucketDestroy),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.In,
 					},
 					//{
@@ -70,6 +72,66 @@ func registerBucket(app cli.App) *cli.App {
 						Action:       runtime(bucketTree),
 						BashComplete: cmpl.In,
 					},
+					{
+						Name:        `member`,
+						Usage:       `SUBCOMMANDS for bucket membership management`,
+						Description: help.Text(`bucket::`),
+						Subcommands: []cli.Command{
+							{
+								Name:         `list`,
+								Usage:        `List all direct members of a bucket`,
+								Description:  help.Text(`bucket::member-list`),
+								Action:       runtime(bucketMemberList),
+								BashComplete: cmpl.In,
+							},
+							{
+								Name:        `assign`,
+								Usage:       `SUBCOMMANDS for assigning objects directly to a bucket`,
+								Description: help.Text(`bucket::member-assign`),
+								Subcommands: []cli.Command{
+									{
+										Name:         `group`,
+										Usage:        `Move a group directly below its bucket`,
+										Description:  help.Text(`bucket::member-assign`),
+										Action:       runtime(bucketMemberAssignGroup),
+										Flags:        []cli.Flag{dryRunFlag},
+										BashComplete: cmpl.To,
+									},
+									{
+										Name:         `cluster`,
+										Usage:        `Move a cluster directly below its bucket`,
+										Description:  help.Text(`bucket::member-assign`),
+										Action:       runtime(bucketMemberAssignCluster),
+										Flags:        []cli.Flag{dryRunFlag},
+										BashComplete: cmpl.To,
+									},
+									{
+										Name:         `node`,
+										Usage:        `Move a node directly below its bucket`,
+										Description:  help.Text(`bucket::member-assign`),
+										Action:       runtime(bucketMemberAssignNode),
+										Flags:        []cli.Flag{dryRunFlag},
+										BashComplete: cmpl.To,
+									},
+								},
+							},
+							{
+								Name:        `unassign`,
+								Usage:       `SUBCOMMANDS to unassign objects from a bucket`,
+								Description: help.Text(`bucket::member-unassign`),
+								Subcommands: []cli.Command{
+									{
+										Name:         `node`,
+										Usage:        `Unassign a node from its bucket`,
+										Description:  help.Text(`bucket::member-unassign`),
+										Action:       runtime(bucketMemberUnassignNode),
+										Flags:        []cli.Flag{dryRunFlag},
+										BashComplete: cmpl.From,
+									},
+								},
+							},
+						},
+					},
 					//{
 					//Name:   `instances`,
 					//Usage:  `List check instances for a bucket`,
@@ -90,6 +152,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Add a system property to a bucket`,
 										Description:  help.Text(`bucket::property-create`),
 										Action:       runtime(bucketPropertyCreateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -97,6 +160,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Add a custom property to a bucket`,
 										Description:  help.Text(`bucket::property-create`),
 										Action:       runtime(bucketPropertyCreateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -104,6 +168,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Add a service property to a bucket`,
 										Description:  help.Text(`bucket::property-create`),
 										Action:       runtime(bucketPropertyCreateService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreate,
 									},
 									{
@@ -111,6 +176,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Add an oncall property to a bucket`,
 										Description:  help.Text(`bucket::property-create`),
 										Action:       runtime(bucketPropertyCreateOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreate,
 									},
 								},
@@ -125,6 +191,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Update a system property to a bucket`,
 										Description:  help.Text(`bucket::property-update`),
 										Action:       runtime(bucketPropertyUpdateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 									{
@@ -132,6 +199,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Update a custom property to a bucket`,
 										Description:  help.Text(`bucket::property-update`),
 										Action:       runtime(bucketPropertyUpdateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateValue,
 									},
 								},
@@ -146,6 +214,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Destroy a system property from a bucket`,
 										Description:  help.Text(`bucket::property-destroy`),
 										Action:       runtime(bucketPropertyDestroySystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -153,6 +222,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Destroy a custom property from a bucket`,
 										Description:  help.Text(`bucket::property-destroy`),
 										Action:       runtime(bucketPropertyDestroyCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -160,6 +230,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Destroy a service property from a bucket`,
 										Description:  help.Text(`bucket::property-destroy`),
 										Action:       runtime(bucketPropertyDestroyService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 									{
@@ -167,6 +238,7 @@ func registerBucket(app cli.App) *cli.App {
 										Usage:        `Destroy an oncall property from a bucket`,
 										Description:  help.Text(`bucket::property-destroy`),
 										Action:       runtime(bucketPropertyDestroyOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnView,
 									},
 								},
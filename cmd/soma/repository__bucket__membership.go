@@ -0,0 +1,212 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// bucketMemberAssignGroup function
+// soma bucket member assign group ${group} to ${bucket}
+func bucketMemberAssignGroup(c *cli.Context) error {
+	return bucketMemberAssign(c, proto.EntityGroup)
+}
+
+// bucketMemberAssignCluster function
+// soma bucket member assign cluster ${cluster} to ${bucket}
+func bucketMemberAssignCluster(c *cli.Context) error {
+	return bucketMemberAssign(c, proto.EntityCluster)
+}
+
+// bucketMemberAssignNode function
+// soma bucket member assign node ${node} to ${bucket}
+func bucketMemberAssignNode(c *cli.Context) error {
+	return bucketMemberAssign(c, proto.EntityNode)
+}
+
+// bucketMemberAssign function
+func bucketMemberAssign(c *cli.Context, childEntity string) error {
+	opts := map[string][]string{}
+	multipleAllowed := []string{}
+	uniqueOptions := []string{`to`}
+	mandatoryOptions := []string{`to`}
+
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multipleAllowed,
+		uniqueOptions,
+		mandatoryOptions,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	var bucketID, repositoryID, childID string
+	if bucketID, err = adm.LookupBucketID(opts[`to`][0]); err != nil {
+		return err
+	}
+	if repositoryID, err = adm.LookupRepoByBucket(bucketID); err != nil {
+		return err
+	}
+
+	req := proto.NewBucketRequest()
+	req.Bucket.ID = bucketID
+	req.Bucket.RepositoryID = repositoryID
+
+	switch childEntity {
+	case proto.EntityGroup:
+		if childID, err = adm.LookupGroupID(c.Args().First(), bucketID); err != nil {
+			return err
+		}
+		req.Bucket.MemberGroups = &[]proto.Group{
+			proto.Group{ID: childID},
+		}
+	case proto.EntityCluster:
+		if childID, err = adm.LookupClusterID(c.Args().First(), bucketID); err != nil {
+			return err
+		}
+		req.Bucket.MemberClusters = &[]proto.Cluster{
+			proto.Cluster{ID: childID},
+		}
+	case proto.EntityNode:
+		if childID, err = adm.LookupNodeID(c.Args().First()); err != nil {
+			return err
+		}
+		if err = bucketMemberNodeCheck(c.Args().First(), childID,
+			opts[`to`][0], bucketID); err != nil {
+			return err
+		}
+		req.Bucket.MemberNodes = &[]proto.Node{
+			proto.Node{ID: childID},
+		}
+	default:
+		return fmt.Errorf(
+			"Unknown child entity type in bucket membership assignment: %s",
+			childEntity)
+	}
+
+	path := fmt.Sprintf("/repository/%s/bucket/%s/member/",
+		url.QueryEscape(repositoryID),
+		url.QueryEscape(bucketID),
+	)
+	return adm.Perform(`postbody`, path, `bucket::member-assign`, req, c)
+}
+
+// bucketMemberUnassignNode function
+// soma bucket member unassign node ${node} from ${bucket}
+func bucketMemberUnassignNode(c *cli.Context) error {
+	opts := map[string][]string{}
+	multipleAllowed := []string{}
+	uniqueOptions := []string{`from`}
+	mandatoryOptions := []string{`from`}
+
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multipleAllowed,
+		uniqueOptions,
+		mandatoryOptions,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	var bucketID, repositoryID, nodeID string
+	if bucketID, err = adm.LookupBucketID(opts[`from`][0]); err != nil {
+		return err
+	}
+	if repositoryID, err = adm.LookupRepoByBucket(bucketID); err != nil {
+		return err
+	}
+	if nodeID, err = adm.LookupNodeID(c.Args().First()); err != nil {
+		return err
+	}
+	if err = bucketMemberNodeCheck(c.Args().First(), nodeID,
+		opts[`from`][0], bucketID); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/repository/%s/bucket/%s/member/%s/%s",
+		url.QueryEscape(repositoryID),
+		url.QueryEscape(bucketID),
+		url.QueryEscape(proto.EntityNode),
+		url.QueryEscape(nodeID),
+	)
+	return adm.Perform(`delete`, path, `bucket::member-unassign`, nil, c)
+}
+
+// bucketMemberList function
+// soma bucket member list ${bucket} [in ${repository}]
+func bucketMemberList(c *cli.Context) error {
+	opts := map[string][]string{}
+	multipleAllowed := []string{}
+	uniqueOptions := []string{`in`}
+	mandatoryOptions := []string{}
+
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multipleAllowed,
+		uniqueOptions,
+		mandatoryOptions,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	var repositoryID, repositoryControlID, bucketID string
+	if bucketID, err = adm.LookupBucketID(c.Args().First()); err != nil {
+		return err
+	}
+	if repositoryID, err = adm.LookupRepoByBucket(bucketID); err != nil {
+		return err
+	}
+
+	// optional argument, must be correct if provided
+	if _, ok := opts[`in`]; ok {
+		if repositoryControlID, err = adm.LookupRepoID(opts[`in`][0]); err != nil {
+			return err
+		} else if repositoryControlID != repositoryID {
+			return fmt.Errorf("bucket %s is not in repository %s", c.Args().First(), opts[`in`][0])
+		}
+	}
+
+	path := fmt.Sprintf("/repository/%s/bucket/%s/member/",
+		url.QueryEscape(repositoryID),
+		url.QueryEscape(bucketID),
+	)
+	return adm.Perform(`get`, path, `bucket::member-list`, nil, c)
+}
+
+// bucketMemberNodeCheck verifies that a node is assigned to the
+// specified bucket
+func bucketMemberNodeCheck(nodeName, nodeID, bucketName, bucketID string) error {
+	var err error
+	nodeConfig := &proto.NodeConfig{}
+	if nodeConfig, err = adm.LookupNodeConfig(nodeID); err != nil {
+		return err
+	}
+	if nodeConfig.BucketID != bucketID {
+		return fmt.Errorf(
+			"Invalid bucket %s(%s), node %s is assigned to %s",
+			bucketName,
+			bucketID,
+			nodeName,
+			nodeConfig.BucketID,
+		)
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
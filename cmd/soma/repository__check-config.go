@@ -25,6 +25,7 @@ func registerChecks(app cli.App) *cli.App {
 		[]cli.Command{
 			{
 				Name:        `check-config`,
+				Aliases:     []string{`check`},
 				Description: help.Text(`check-config::`),
 				Usage:       `SUBCOMMANDS for check configuration management`,
 				Subcommands: []cli.Command{
@@ -33,6 +34,7 @@ func registerChecks(app cli.App) *cli.App {
 						Usage:        `Create a new check configuration`,
 						Description:  help.Text(`check-config::create`),
 						Action:       runtime(checkConfigCreate),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.CheckConfigCreate,
 					},
 					{
@@ -40,8 +42,16 @@ func registerChecks(app cli.App) *cli.App {
 						Usage:        "Destroy a check configuration",
 						Description:  help.Text(`check-config::destroy`),
 						Action:       runtime(checkConfigDestroy),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.CheckConfigDestroy,
 					},
+					{
+						Name:         `explain`,
+						Usage:        `Explain why a check configuration does or does not apply to a node`,
+						Description:  help.Text(`check-config::explain`),
+						Action:       runtime(checkConfigExplain),
+						BashComplete: cmpl.None,
+					},
 					{
 						Name:         `list`,
 						Usage:        `List check configurations in a repository`,
@@ -56,6 +66,14 @@ func registerChecks(app cli.App) *cli.App {
 						Action:       runtime(checkConfigShow),
 						BashComplete: cmpl.In,
 					},
+					{
+						Name:         `update`,
+						Usage:        `Update an existing check configuration`,
+						Description:  help.Text(`check-config::update`),
+						Action:       runtime(checkConfigUpdate),
+						Flags:        []cli.Flag{dryRunFlag},
+						BashComplete: cmpl.CheckConfigCreate,
+					},
 				},
 			},
 		}...,
@@ -66,12 +84,49 @@ func registerChecks(app cli.App) *cli.App {
 // checkConfigCreate function
 // soma check-config create ...
 func checkConfigCreate(c *cli.Context) error {
+	req := proto.NewCheckConfigRequest()
+	if err := checkConfigBuild(c, &req); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/checkconfig/%s/",
+		url.QueryEscape(req.CheckConfig.RepositoryID),
+	)
+	return adm.Perform(`postbody`, path, `check-config::create`, req, c)
+}
+
+// checkConfigUpdate function
+// soma check-config update ...
+func checkConfigUpdate(c *cli.Context) error {
+	var err error
+	req := proto.NewCheckConfigRequest()
+	if err = checkConfigBuild(c, &req); err != nil {
+		return err
+	}
+
+	if req.CheckConfig.ID, _, err = adm.LookupCheckConfigID(
+		req.CheckConfig.Name,
+		req.CheckConfig.RepositoryID,
+		``,
+	); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/checkconfig/%s/%s",
+		url.QueryEscape(req.CheckConfig.RepositoryID),
+		url.QueryEscape(req.CheckConfig.ID),
+	)
+	return adm.Perform(`putbody`, path, `check-config::update`, req, c)
+}
+
+// checkConfigBuild assembles the check configuration specified on
+// the commandline into req
+func checkConfigBuild(c *cli.Context, req *proto.Request) error {
 	var err error
 	var teamID string
 	opts := map[string][]string{}
 	constraints := []proto.CheckConfigConstraint{}
 	thresholds := []proto.CheckConfigThreshold{}
-	req := proto.NewCheckConfigRequest()
 
 	if err = adm.ParseVariadicCheckArguments(
 		opts,
@@ -190,11 +245,7 @@ func checkConfigCreate(c *cli.Context) error {
 	); err != nil {
 		return err
 	}
-
-	path := fmt.Sprintf("/checkconfig/%s/",
-		url.QueryEscape(req.CheckConfig.RepositoryID),
-	)
-	return adm.Perform(`postbody`, path, `check-config::create`, req, c)
+	return nil
 }
 
 // checkConfigDestroy function
@@ -306,7 +357,41 @@ func checkConfigShow(c *cli.Context) error {
 		url.QueryEscape(repoID),
 		url.QueryEscape(checkID),
 	)
-	return adm.Perform(`get`, path, `check-config::list`, nil, c)
+	return adm.Perform(`get`, path, `check-config::show`, nil, c)
+}
+
+// checkConfigExplain function
+// soma check-config explain ${check} ${node}
+func checkConfigExplain(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf(
+			"Syntax error, expected check and node (received %d arguments)",
+			c.NArg())
+	}
+
+	var (
+		err             error
+		nodeID, checkID string
+		config          *proto.NodeConfig
+	)
+	if nodeID, err = adm.LookupNodeID(c.Args().Get(1)); err != nil {
+		return err
+	}
+	if config, err = adm.LookupNodeConfig(nodeID); err != nil {
+		return err
+	}
+	if checkID, _, err = adm.LookupCheckConfigID(c.Args().First(),
+		config.RepositoryID, ``); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/checkconfig/%s/%s/explain/%s/%s",
+		url.QueryEscape(config.RepositoryID),
+		url.QueryEscape(checkID),
+		proto.EntityNode,
+		url.QueryEscape(nodeID),
+	)
+	return adm.Perform(`get`, path, `explain`, nil, c)
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -47,6 +47,7 @@ func registerClusters(app cli.App) *cli.App {
 						Usage:        `Create a new cluster in a bucket`,
 						Description:  help.Text(`cluster-config::create`),
 						Action:       runtime(clusterConfigCreate),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.In,
 					},
 					{
@@ -61,6 +62,7 @@ func registerClusters(app cli.App) *cli.App {
 						Usage:        `Destroy a cluster`,
 						Description:  help.Text(`cluster-config::destroy`),
 						Action:       runtime(clusterConfigDestroy),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.In,
 					},
 					{
@@ -73,6 +75,7 @@ func registerClusters(app cli.App) *cli.App {
 								Usage:        `Assign a node to a cluster`,
 								Description:  help.Text(`cluster-config::member-assign`),
 								Action:       runtime(clusterConfigMemberAssign),
+								Flags:        []cli.Flag{dryRunFlag},
 								BashComplete: cmpl.InTo,
 							},
 							{
@@ -80,6 +83,7 @@ func registerClusters(app cli.App) *cli.App {
 								Usage:        `Unassign a node from a cluster`,
 								Description:  help.Text(`cluster-config::member-unassign`),
 								Action:       runtime(clusterConfigMemberUnassign),
+								Flags:        []cli.Flag{dryRunFlag},
 								BashComplete: cmpl.InFrom,
 							},
 							{
@@ -106,6 +110,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Add a system property to a cluster`,
 										Description:  help.Text(`cluster-config::property-create`),
 										Action:       runtime(clusterConfigPropertyCreateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -113,6 +118,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Add a service property to a cluster`,
 										Description:  help.Text(`cluster-config::property-create`),
 										Action:       runtime(clusterConfigPropertyCreateService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -120,6 +126,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Add an oncall property to a cluster`,
 										Description:  help.Text(`cluster-config::property-create`),
 										Action:       runtime(clusterConfigPropertyCreateOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 									{
@@ -127,6 +134,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Add a custom property to a cluster`,
 										Description:  help.Text(`cluster-config::property-create`),
 										Action:       runtime(clusterConfigPropertyCreateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -141,6 +149,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Update a system property to a cluster`,
 										Description:  help.Text(`cluster-config::property-update`),
 										Action:       runtime(clusterConfigPropertyUpdateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -148,6 +157,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Update a custom property to a cluster`,
 										Description:  help.Text(`cluster-config::property-update`),
 										Action:       runtime(clusterConfigPropertyUpdateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -162,6 +172,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Delete a system property from a cluster`,
 										Description:  help.Text(`cluster-config::property-destroy`),
 										Action:       runtime(clusterConfigPropertyDestroySystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -169,6 +180,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Delete a service property from a cluster`,
 										Description:  help.Text(`cluster-config::property-destroy`),
 										Action:       runtime(clusterConfigPropertyDestroyService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -176,6 +188,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Delete an oncall property from a cluster`,
 										Description:  help.Text(`cluster-config::property-destroy`),
 										Action:       runtime(clusterConfigPropertyDestroyOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -183,6 +196,7 @@ func registerClusters(app cli.App) *cli.App {
 										Usage:        `Delete a custom property from a cluster`,
 										Description:  help.Text(`cluster-config::property-destroy`),
 										Action:       runtime(clusterConfigPropertyDestroyCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 								},
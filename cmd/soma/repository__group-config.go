@@ -33,6 +33,7 @@ func registerGroups(app cli.App) *cli.App {
 						Usage:        `Create a new group`,
 						Description:  help.Text(`group-config::create`),
 						Action:       runtime(groupConfigCreate),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.In,
 					},
 					{
@@ -40,6 +41,7 @@ func registerGroups(app cli.App) *cli.App {
 						Usage:        `Destroy an existing group inside a tree bucket`,
 						Description:  help.Text(`group-config::destroy`),
 						Action:       runtime(groupConfigDestroy),
+						Flags:        []cli.Flag{dryRunFlag},
 						BashComplete: cmpl.In,
 					},
 					{
@@ -78,6 +80,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Add a system property to a group`,
 										Description:  help.Text(`group-config::property-create`),
 										Action:       runtime(groupConfigPropertyCreateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -85,6 +88,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Add a service property to a group`,
 										Description:  help.Text(`group-config::property-create`),
 										Action:       runtime(groupConfigPropertyCreateService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -92,6 +96,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Add an oncall property to a group`,
 										Description:  help.Text(`group-config::property-create`),
 										Action:       runtime(groupConfigPropertyCreateOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 									{
@@ -99,6 +104,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Add a custom property to a group`,
 										Description:  help.Text(`group-config::property-create`),
 										Action:       runtime(groupConfigPropertyCreateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -113,6 +119,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Update a system property to a group`,
 										Description:  help.Text(`group-config::property-update`),
 										Action:       runtime(groupConfigPropertyUpdateSystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateInValue,
 									},
 									{
@@ -120,6 +127,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Update a custom property to a group`,
 										Description:  help.Text(`group-config::property-update`),
 										Action:       runtime(groupConfigPropertyUpdateCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyCreateIn,
 									},
 								},
@@ -134,6 +142,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Delete a system property from a group`,
 										Description:  help.Text(`group-config::property-destroy`),
 										Action:       runtime(groupConfigPropertyDestroySystem),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -141,6 +150,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Delete a service property from a group`,
 										Description:  help.Text(`group-config::property-destroy`),
 										Action:       runtime(groupConfigPropertyDestroyService),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -148,6 +158,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Delete an oncall property from a group`,
 										Description:  help.Text(`group-config::property-destroy`),
 										Action:       runtime(groupConfigPropertyDestroyOncall),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 									{
@@ -155,6 +166,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Delete a custom property from a group`,
 										Description:  help.Text(`group-config::property-destroy`),
 										Action:       runtime(groupConfigPropertyDestroyCustom),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.PropertyOnInView,
 									},
 								},
@@ -183,6 +195,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Assign a group to another group`,
 										Description:  help.Text(`group-config::member-assign`),
 										Action:       runtime(groupConfigMemberAssignGroup),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InTo,
 									},
 									{
@@ -190,6 +203,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Assign a cluster to a group`,
 										Description:  help.Text(`group-config::member-assign`),
 										Action:       runtime(groupConfigMemberAssignCluster),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InTo,
 									},
 									{
@@ -197,6 +211,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Assign a node to a group`,
 										Description:  help.Text(`group-config::member-assign`),
 										Action:       runtime(groupConfigMemberAssignNode),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InTo,
 									},
 								},
@@ -211,6 +226,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Unassign a child group from its parent group`,
 										Description:  help.Text(`group-config::member-unassign`),
 										Action:       runtime(groupConfigMemberUnassignGroup),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InFrom,
 									},
 									{
@@ -218,6 +234,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Unassign a child cluster from its parent group`,
 										Description:  help.Text(`group-config::member-unassign`),
 										Action:       runtime(groupConfigMemberUnassignCluster),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InFrom,
 									},
 									{
@@ -225,6 +242,7 @@ func registerGroups(app cli.App) *cli.App {
 										Usage:        `Unassign a node from its parent group`,
 										Description:  help.Text(`group-config::member-unassign`),
 										Action:       runtime(groupConfigMemberUnassignNode),
+										Flags:        []cli.Flag{dryRunFlag},
 										BashComplete: cmpl.InFrom,
 									},
 								},
@@ -0,0 +1,648 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+	resty "gopkg.in/resty.v1"
+)
+
+// repositoryExport function
+// soma repository export ${repository}
+func repositoryExport(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	repositoryID, err := adm.LookupRepoID(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	layout, err := adm.FetchLayout(repositoryID)
+	if err != nil {
+		return err
+	}
+	layout.ClearIDs()
+
+	data, err := adm.FormatLayout(layout, c.GlobalBool(`json`))
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+// repositoryApply function
+// soma repository apply ${repository} --file ${path} [--yes]
+func repositoryApply(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+	if c.String(`file`) == `` {
+		return fmt.Errorf(`Missing mandatory flag --file`)
+	}
+
+	var err error
+	apply := &layoutApply{}
+	if apply.repositoryID, err = adm.LookupRepoID(
+		c.Args().First()); err != nil {
+		return err
+	}
+	if err = adm.LookupTeamByRepo(apply.repositoryID,
+		&apply.teamID); err != nil {
+		return err
+	}
+
+	desired, err := adm.ReadLayout(c.String(`file`))
+	if err != nil {
+		return err
+	}
+	live, err := adm.FetchLayout(apply.repositoryID)
+	if err != nil {
+		return err
+	}
+	if apply.diff, err = adm.DiffLayout(live, desired); err != nil {
+		return err
+	}
+
+	apply.diff.Print()
+	if apply.diff.Empty() || c.Bool(`dry-run`) {
+		return nil
+	}
+	fmt.Println()
+	if !c.Bool(`yes`) {
+		ok, err := adm.Confirm(`Apply these changes?`)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(`Layout changes not confirmed, aborted`)
+		}
+	}
+	return apply.run()
+}
+
+// layoutApply submits the jobs required to apply a layout diff to a
+// repository
+type layoutApply struct {
+	repositoryID string
+	teamID       string
+	diff         *adm.LayoutDiff
+	live         *adm.FlatLayout
+}
+
+// run applies the diff. Buckets have to exist before groups and
+// clusters can be created within them, and all objects have to exist
+// before the batch that arranges them can be built. Objects are
+// removed last, after their remaining members have been moved away.
+func (a *layoutApply) run() error {
+	for _, o := range a.diff.Create {
+		if o.Type != proto.EntityBucket {
+			continue
+		}
+		if err := a.create(o); err != nil {
+			return err
+		}
+	}
+
+	if err := a.refresh(); err != nil {
+		return err
+	}
+	for _, o := range a.diff.Create {
+		if o.Type == proto.EntityBucket {
+			continue
+		}
+		if err := a.create(o); err != nil {
+			return err
+		}
+	}
+
+	if err := a.refresh(); err != nil {
+		return err
+	}
+	if err := a.batch(); err != nil {
+		return err
+	}
+
+	for _, o := range a.diff.Remove {
+		if err := a.remove(o); err != nil {
+			return err
+		}
+	}
+	fmt.Println(`Layout applied.`)
+	return nil
+}
+
+// refresh reloads the live layout of the repository, which provides
+// the IDs of newly created objects
+func (a *layoutApply) refresh() error {
+	live, err := adm.FetchLayout(a.repositoryID)
+	if err != nil {
+		return err
+	}
+	a.live, err = adm.FlattenLayout(live)
+	return err
+}
+
+// lookup returns the live version of object type objectType with name
+func (a *layoutApply) lookup(objectType, name,
+	bucket string) (*adm.LayoutObject, error) {
+	if o := a.live.Lookup(objectType, name, bucket); o != nil {
+		return o, nil
+	}
+	return nil, fmt.Errorf("Could not find %s %s in the repository",
+		objectType, name)
+}
+
+// bucketID returns the ID of the bucket of object o
+func (a *layoutApply) bucketID(o *adm.LayoutObject) (string, error) {
+	if o.Type == proto.EntityBucket {
+		return o.ID, nil
+	}
+	bucket, err := a.lookup(proto.EntityBucket, o.Bucket, ``)
+	if err != nil {
+		return ``, err
+	}
+	return bucket.ID, nil
+}
+
+// create submits the job creating object o
+func (a *layoutApply) create(o *adm.LayoutObject) error {
+	var (
+		err      error
+		bucketID string
+		path     string
+		req      proto.Request
+		rqType   = `postbody`
+	)
+
+	if o.Type != proto.EntityBucket {
+		bucket, err := a.lookup(proto.EntityBucket, o.Bucket, ``)
+		if err != nil {
+			return err
+		}
+		bucketID = bucket.ID
+	}
+
+	switch o.Type {
+	case proto.EntityBucket:
+		req = proto.NewBucketRequest()
+		req.Bucket.Name = o.Name
+		req.Bucket.RepositoryID = a.repositoryID
+		req.Bucket.TeamID = a.teamID
+		req.Bucket.Environment = o.Environment
+		path = fmt.Sprintf("/repository/%s/bucket/",
+			url.QueryEscape(a.repositoryID))
+	case proto.EntityGroup:
+		req = proto.NewGroupRequest()
+		req.Group.Name = o.Name
+		req.Group.RepositoryID = a.repositoryID
+		req.Group.BucketID = bucketID
+		path = fmt.Sprintf("/repository/%s/bucket/%s/group/",
+			url.QueryEscape(a.repositoryID),
+			url.QueryEscape(bucketID))
+	case proto.EntityCluster:
+		req = proto.NewClusterRequest()
+		req.Cluster.Name = o.Name
+		req.Cluster.RepositoryID = a.repositoryID
+		req.Cluster.BucketID = bucketID
+		path = fmt.Sprintf("/repository/%s/bucket/%s/cluster/",
+			url.QueryEscape(a.repositoryID),
+			url.QueryEscape(bucketID))
+	case proto.EntityNode:
+		var nodeID, nodeTeamID string
+		if nodeID, err = adm.LookupNodeID(o.Name); err != nil {
+			return err
+		}
+		if nodeTeamID, err = adm.LookupTeamByNode(nodeID); err != nil {
+			return err
+		}
+		if nodeTeamID != a.teamID {
+			return fmt.Errorf("Cannot assign node %s since node and"+
+				" bucket belong to different teams.", o.Name)
+		}
+		req = proto.NewNodeRequest()
+		req.Node.ID = nodeID
+		req.Node.TeamID = nodeTeamID
+		req.Node.Config = &proto.NodeConfig{
+			RepositoryID: a.repositoryID,
+			BucketID:     bucketID,
+		}
+		path = fmt.Sprintf("/node/%s/config", url.QueryEscape(nodeID))
+		rqType = `putbody`
+	}
+	return a.submit(rqType, path, req, fmt.Sprintf("create %s", o))
+}
+
+// remove submits the job removing object o from the repository
+func (a *layoutApply) remove(o *adm.LayoutObject) error {
+	bucketID, err := a.bucketID(o)
+	if err != nil {
+		return err
+	}
+
+	var path string
+	switch o.Type {
+	case proto.EntityBucket:
+		path = fmt.Sprintf("/repository/%s/bucket/%s",
+			url.QueryEscape(a.repositoryID),
+			url.QueryEscape(o.ID))
+	case proto.EntityGroup, proto.EntityCluster:
+		path = fmt.Sprintf("/repository/%s/bucket/%s/%s/%s",
+			url.QueryEscape(a.repositoryID),
+			url.QueryEscape(bucketID),
+			url.QueryEscape(o.Type),
+			url.QueryEscape(o.ID))
+	case proto.EntityNode:
+		path = fmt.Sprintf("/repository/%s/bucket/%s/node/%s/config",
+			url.QueryEscape(a.repositoryID),
+			url.QueryEscape(bucketID),
+			url.QueryEscape(o.ID))
+	}
+	return a.submit(`delete`, path, nil, fmt.Sprintf("remove %s", o))
+}
+
+// batch submits a single batch with all membership, property and
+// check configuration changes
+func (a *layoutApply) batch() error {
+	req := proto.NewBatchRequest()
+
+	// new objects are created directly below their bucket and have to
+	// be moved to their parent like existing objects. Both lists are
+	// ordered parents first.
+	placements := []*adm.LayoutObject{}
+	for _, o := range a.diff.Create {
+		switch o.ParentType {
+		case proto.EntityGroup, proto.EntityCluster:
+			placements = append(placements, o)
+		}
+	}
+	placements = append(placements, a.diff.Move...)
+	for _, o := range placements {
+		op, err := a.memberAssign(o)
+		if err != nil {
+			return err
+		}
+		req.Batch.Operations = append(req.Batch.Operations, op)
+	}
+
+	for _, change := range []struct {
+		action string
+		list   []adm.LayoutPropertyChange
+	}{
+		{msg.ActionPropertyDestroy, a.diff.PropertyDestroy},
+		{msg.ActionPropertyCreate, a.diff.PropertyCreate},
+		{msg.ActionPropertyUpdate, a.diff.PropertyUpdate},
+	} {
+		for _, p := range change.list {
+			op, err := a.property(change.action, p)
+			if err != nil {
+				return err
+			}
+			req.Batch.Operations = append(req.Batch.Operations, op)
+		}
+	}
+
+	for _, change := range []struct {
+		action string
+		list   []proto.LayoutCheckConfig
+	}{
+		{msg.ActionDestroy, a.diff.CheckDestroy},
+		{msg.ActionCreate, a.diff.CheckCreate},
+		{msg.ActionUpdate, a.diff.CheckUpdate},
+	} {
+		for _, c := range change.list {
+			op, err := a.checkConfig(change.action, c)
+			if err != nil {
+				return err
+			}
+			req.Batch.Operations = append(req.Batch.Operations, op)
+		}
+	}
+
+	if len(req.Batch.Operations) == 0 {
+		return nil
+	}
+	path := fmt.Sprintf("/repository/%s/batch/",
+		url.QueryEscape(a.repositoryID))
+	return a.submit(`postbody`, path, req, fmt.Sprintf(
+		"batch of %d operations", len(req.Batch.Operations)))
+}
+
+// memberAssign returns the batch operation that assigns object o to
+// its parent
+func (a *layoutApply) memberAssign(o *adm.LayoutObject) (
+	proto.BatchOperation, error) {
+	op := proto.BatchOperation{Action: msg.ActionMemberAssign}
+
+	child, err := a.lookup(o.Type, o.Name, o.Bucket)
+	if err != nil {
+		return op, err
+	}
+	bucketID, err := a.bucketID(child)
+	if err != nil {
+		return op, err
+	}
+	parent, err := a.lookup(o.ParentType, o.Parent, o.Bucket)
+	if err != nil {
+		return op, err
+	}
+
+	var (
+		groups   *[]proto.Group
+		clusters *[]proto.Cluster
+		nodes    *[]proto.Node
+	)
+	switch o.Type {
+	case proto.EntityGroup:
+		groups = &[]proto.Group{{ID: child.ID}}
+	case proto.EntityCluster:
+		clusters = &[]proto.Cluster{{ID: child.ID}}
+	case proto.EntityNode:
+		nodes = &[]proto.Node{{
+			ID: child.ID,
+			Config: &proto.NodeConfig{
+				RepositoryID: a.repositoryID,
+				BucketID:     bucketID,
+			},
+		}}
+	}
+
+	switch o.ParentType {
+	case proto.EntityBucket:
+		op.Section = msg.SectionBucket
+		op.Request = proto.NewBucketRequest()
+		op.Request.Bucket.ID = bucketID
+		op.Request.Bucket.MemberGroups = groups
+		op.Request.Bucket.MemberClusters = clusters
+		op.Request.Bucket.MemberNodes = nodes
+	case proto.EntityGroup:
+		op.Section = msg.SectionGroup
+		op.Request = proto.NewGroupRequest()
+		op.Request.Group.ID = parent.ID
+		op.Request.Group.BucketID = bucketID
+		op.Request.Group.MemberGroups = groups
+		op.Request.Group.MemberClusters = clusters
+		op.Request.Group.MemberNodes = nodes
+	case proto.EntityCluster:
+		op.Section = msg.SectionCluster
+		op.Request = proto.NewClusterRequest()
+		op.Request.Cluster.ID = parent.ID
+		op.Request.Cluster.BucketID = bucketID
+		op.Request.Cluster.Members = nodes
+	}
+	return op, nil
+}
+
+// property returns the batch operation for a property change
+func (a *layoutApply) property(action string,
+	change adm.LayoutPropertyChange) (proto.BatchOperation, error) {
+	op := proto.BatchOperation{Action: action}
+
+	object, err := a.lookup(change.Object.Type, change.Object.Name,
+		change.Object.Bucket)
+	if err != nil {
+		return op, err
+	}
+	bucketID := ``
+	if object.Type != proto.EntityRepository {
+		if bucketID, err = a.bucketID(object); err != nil {
+			return op, err
+		}
+	}
+
+	prop := proto.Property{
+		Type:             change.Property.Type,
+		View:             change.Property.View,
+		Inheritance:      change.Property.Inheritance,
+		ChildrenOnly:     change.Property.ChildrenOnly,
+		SourceInstanceID: change.Property.SourceInstanceID,
+	}
+	if action != msg.ActionPropertyDestroy {
+		if err = a.propertyValue(&prop, change.Property); err != nil {
+			return op, err
+		}
+	}
+	props := &[]proto.Property{prop}
+
+	switch object.Type {
+	case proto.EntityRepository:
+		op.Section = msg.SectionRepositoryConfig
+		op.Request = proto.NewRepositoryRequest()
+		op.Request.Repository.ID = a.repositoryID
+		op.Request.Repository.Properties = props
+	case proto.EntityBucket:
+		op.Section = msg.SectionBucket
+		op.Request = proto.NewBucketRequest()
+		op.Request.Bucket.ID = object.ID
+		op.Request.Bucket.Properties = props
+	case proto.EntityGroup:
+		op.Section = msg.SectionGroup
+		op.Request = proto.NewGroupRequest()
+		op.Request.Group.ID = object.ID
+		op.Request.Group.BucketID = bucketID
+		op.Request.Group.Properties = props
+	case proto.EntityCluster:
+		op.Section = msg.SectionCluster
+		op.Request = proto.NewClusterRequest()
+		op.Request.Cluster.ID = object.ID
+		op.Request.Cluster.BucketID = bucketID
+		op.Request.Cluster.Properties = props
+	case proto.EntityNode:
+		op.Section = msg.SectionNodeConfig
+		op.Request = proto.NewNodeRequest()
+		op.Request.Node.ID = object.ID
+		op.Request.Node.Config = &proto.NodeConfig{
+			RepositoryID: a.repositoryID,
+			BucketID:     bucketID,
+		}
+		op.Request.Node.Properties = props
+	}
+	return op, nil
+}
+
+// propertyValue fills in the type specific part of a property
+func (a *layoutApply) propertyValue(prop *proto.Property,
+	p proto.LayoutProperty) error {
+	var err error
+
+	switch p.Type {
+	case proto.PropertyTypeSystem:
+		prop.System = &proto.PropertySystem{
+			Name:  p.Name,
+			Value: p.Value,
+		}
+	case proto.PropertyTypeCustom:
+		prop.Custom = &proto.PropertyCustom{
+			Name:         p.Name,
+			RepositoryID: a.repositoryID,
+			Value:        p.Value,
+		}
+		if prop.Custom.ID, err = adm.LookupCustomPropertyID(p.Name,
+			a.repositoryID); err != nil {
+			return err
+		}
+	case proto.PropertyTypeService:
+		// client-provided attributes are discarded by the server
+		prop.Service = &proto.PropertyService{
+			Name:       p.Name,
+			TeamID:     a.teamID,
+			Attributes: []proto.ServiceAttribute{},
+		}
+		if prop.Service.ID, err = adm.LookupServicePropertyID(p.Name,
+			a.teamID); err != nil {
+			return err
+		}
+	case proto.PropertyTypeOncall:
+		prop.Oncall = &proto.PropertyOncall{}
+		if prop.Oncall.ID, err = adm.LookupOncallID(p.Name); err != nil {
+			return err
+		}
+		if prop.Oncall.Name, prop.Oncall.Number, err = adm.LookupOncallDetails(
+			prop.Oncall.ID); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Property type %s can not be set on tree"+
+			" objects", p.Type)
+	}
+	return nil
+}
+
+// checkConfig returns the batch operation for a check configuration
+// change
+func (a *layoutApply) checkConfig(action string,
+	c proto.LayoutCheckConfig) (proto.BatchOperation, error) {
+	var err error
+	op := proto.BatchOperation{
+		Section: msg.SectionCheckConfig,
+		Action:  action,
+		Request: proto.NewCheckConfigRequest(),
+	}
+	op.Request.CheckConfig.ID = c.ID
+	op.Request.CheckConfig.RepositoryID = a.repositoryID
+	if action == msg.ActionDestroy {
+		return op, nil
+	}
+
+	cnf := op.Request.CheckConfig
+	cnf.Name = c.Name
+	cnf.Interval = c.Interval
+	cnf.Inheritance = c.Inheritance
+	cnf.ChildrenOnly = c.ChildrenOnly
+	cnf.ExternalID = c.ExternalID
+	cnf.ObjectType = c.ObjectType
+	if cnf.CapabilityID, err = adm.LookupCapabilityID(
+		c.Capability); err != nil {
+		return op, err
+	}
+
+	object, err := a.lookup(c.ObjectType, c.Object, c.Bucket)
+	if err != nil {
+		return op, err
+	}
+	cnf.ObjectID = object.ID
+	if object.Type != proto.EntityRepository {
+		if cnf.BucketID, err = a.bucketID(object); err != nil {
+			return op, err
+		}
+	}
+
+	thresholds := make([]proto.CheckConfigThreshold, 0, len(c.Thresholds))
+	for _, thr := range c.Thresholds {
+		thresholds = append(thresholds, proto.CheckConfigThreshold{
+			Predicate: proto.Predicate{Symbol: thr.Predicate},
+			Level:     proto.Level{Name: thr.Level},
+			Value:     thr.Value,
+		})
+	}
+	if cnf.Thresholds, err = adm.ValidateThresholds(
+		thresholds); err != nil {
+		return op, err
+	}
+
+	constraints := make([]proto.CheckConfigConstraint, 0,
+		len(c.Constraints))
+	for _, constr := range c.Constraints {
+		cc := proto.CheckConfigConstraint{
+			ConstraintType: constr.Type,
+			Operator:       constr.Operator,
+			Group:          constr.Group,
+		}
+		switch constr.Type {
+		case proto.PropertyTypeNative:
+			cc.Native = &proto.PropertyNative{Name: constr.Name,
+				Value: constr.Value}
+		case proto.PropertyTypeSystem:
+			cc.System = &proto.PropertySystem{Name: constr.Name,
+				Value: constr.Value}
+		case proto.PropertyTypeCustom:
+			cc.Custom = &proto.PropertyCustom{Name: constr.Name,
+				Value: constr.Value}
+		case `attribute`:
+			cc.Attribute = &proto.ServiceAttribute{Name: constr.Name,
+				Value: constr.Value}
+		case proto.PropertyTypeService:
+			cc.Service = &proto.PropertyService{Name: constr.Name}
+		case proto.PropertyTypeOncall:
+			cc.Oncall = &proto.PropertyOncall{Name: constr.Name}
+		default:
+			return op, fmt.Errorf("Unknown constraint type %s in check"+
+				" configuration %s", constr.Type, c.Name)
+		}
+		constraints = append(constraints, cc)
+	}
+	if cnf.Constraints, err = adm.ValidateCheckConstraints(
+		a.repositoryID, a.teamID, constraints); err != nil {
+		return op, err
+	}
+	return op, nil
+}
+
+// submit sends a request that results in a job and waits until the
+// job has been processed
+func (a *layoutApply) submit(rqType, path string, body interface{},
+	description string) error {
+	var (
+		err  error
+		resp *resty.Response
+		res  proto.Result
+	)
+
+	switch rqType {
+	case `postbody`:
+		resp, err = adm.PostReqBody(body, path)
+	case `putbody`:
+		resp, err = adm.PutReqBody(body, path)
+	case `delete`:
+		resp, err = adm.DeleteReq(path)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %s", description, err.Error())
+	}
+	if err = adm.DecodedResponse(resp, &res); err != nil {
+		return fmt.Errorf("%s: %s", description, err.Error())
+	}
+
+	fmt.Fprintf(os.Stderr, "Waiting for job %s: %s\n", res.JobID,
+		description)
+	if err = adm.WaitJob(res.JobID); err != nil {
+		return fmt.Errorf("%s: %s", description, err.Error())
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
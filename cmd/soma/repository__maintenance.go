@@ -0,0 +1,232 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/internal/cmpl"
+	"github.com/mjolnir42/soma/internal/help"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func registerMaintenance(app cli.App) *cli.App {
+	app.Commands = append(app.Commands,
+		[]cli.Command{
+			{
+				Name:        `maintenance`,
+				Usage:       `SUBCOMMANDS for scheduled maintenance windows`,
+				Description: help.Text(`maintenance::`),
+				Subcommands: []cli.Command{
+					{
+						Name:         `add`,
+						Usage:        `Schedule a maintenance window`,
+						Description:  help.Text(`maintenance::add`),
+						Action:       runtime(maintenanceAdd),
+						BashComplete: cmpl.MaintenanceAdd,
+					},
+					{
+						Name:         `remove`,
+						Usage:        `Remove a maintenance window`,
+						Description:  help.Text(`maintenance::remove`),
+						Action:       runtime(maintenanceRemove),
+						BashComplete: cmpl.In,
+					},
+					{
+						Name:         `list`,
+						Usage:        `List current and upcoming maintenance windows`,
+						Description:  help.Text(`maintenance::list`),
+						Action:       runtime(maintenanceList),
+						BashComplete: cmpl.DirectIn,
+					},
+					{
+						Name:         `show`,
+						Usage:        `Show details about a maintenance window`,
+						Description:  help.Text(`maintenance::show`),
+						Action:       runtime(maintenanceShow),
+						BashComplete: cmpl.In,
+					},
+				},
+			},
+		}...,
+	)
+	return &app
+}
+
+// maintenanceAdd function
+// soma maintenance add ${type} ${object}
+//
+//	[in ${bucket}]
+//	[from ${start}]
+//	 until ${end}
+//	 reason ${reason}
+func maintenanceAdd(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return fmt.Errorf("Missing object type or object name")
+	}
+
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{},
+		[]string{`in`, `from`, `until`, `reason`},
+		[]string{`until`, `reason`},
+		c.Args()[2:],
+	); err != nil {
+		return err
+	}
+
+	var err error
+	req := proto.NewMaintenanceRequest()
+	req.Maintenance.ObjectType = c.Args().First()
+	req.Maintenance.Reason = opts[`reason`][0]
+
+	for _, key := range []string{`from`, `until`} {
+		if len(opts[key]) == 0 {
+			continue
+		}
+		if _, err = time.Parse(time.RFC3339, opts[key][0]); err != nil {
+			return fmt.Errorf("Argument to %s is not an RFC3339 timestamp: %s",
+				key, err.Error())
+		}
+	}
+	if len(opts[`from`]) == 1 {
+		req.Maintenance.StartsAt = opts[`from`][0]
+	}
+	req.Maintenance.EndsAt = opts[`until`][0]
+
+	object := c.Args().Get(1)
+	switch req.Maintenance.ObjectType {
+	case `repository`:
+		if req.Maintenance.RepositoryID, err = adm.LookupRepoID(object); err != nil {
+			return err
+		}
+		req.Maintenance.ObjectID = req.Maintenance.RepositoryID
+	case `bucket`:
+		if req.Maintenance.ObjectID, err = adm.LookupBucketID(object); err != nil {
+			return err
+		}
+		if req.Maintenance.RepositoryID, err = adm.LookupRepoByBucket(
+			req.Maintenance.ObjectID); err != nil {
+			return err
+		}
+	case `node`:
+		if req.Maintenance.ObjectID, err = adm.LookupNodeID(object); err != nil {
+			return err
+		}
+		config := &proto.NodeConfig{}
+		if config, err = adm.LookupNodeConfig(req.Maintenance.ObjectID); err != nil {
+			return err
+		}
+		req.Maintenance.RepositoryID = config.RepositoryID
+	case `group`, `cluster`:
+		if len(opts[`in`]) == 0 {
+			return fmt.Errorf("Maintenance for a %s requires its bucket: in ${bucket}",
+				req.Maintenance.ObjectType)
+		}
+		var bucketID string
+		if bucketID, err = adm.LookupBucketID(opts[`in`][0]); err != nil {
+			return err
+		}
+		if req.Maintenance.RepositoryID, err = adm.LookupRepoByBucket(
+			bucketID); err != nil {
+			return err
+		}
+		if req.Maintenance.ObjectID, err = adm.LookupCheckObjectID(
+			req.Maintenance.ObjectType, object, bucketID,
+		); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Unknown object entity: %s", req.Maintenance.ObjectType)
+	}
+
+	path := fmt.Sprintf("/repository/%s/maintenance/",
+		url.QueryEscape(req.Maintenance.RepositoryID),
+	)
+	return adm.Perform(`postbody`, path, `maintenance::add`, req, c)
+}
+
+// maintenanceRemove function
+// soma maintenance remove ${maintenanceID} in ${repository}
+func maintenanceRemove(c *cli.Context) error {
+	path, err := maintenancePath(c)
+	if err != nil {
+		return err
+	}
+	return adm.Perform(`delete`, path, `command`, nil, c)
+}
+
+// maintenanceList function
+// soma maintenance list in ${repository}
+func maintenanceList(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{},
+		[]string{`in`},
+		[]string{`in`},
+		adm.AllArguments(c),
+	); err != nil {
+		return err
+	}
+
+	repoID, err := adm.LookupRepoID(opts[`in`][0])
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/repository/%s/maintenance/",
+		url.QueryEscape(repoID),
+	)
+	return adm.Perform(`get`, path, `list`, nil, c)
+}
+
+// maintenanceShow function
+// soma maintenance show ${maintenanceID} in ${repository}
+func maintenanceShow(c *cli.Context) error {
+	path, err := maintenancePath(c)
+	if err != nil {
+		return err
+	}
+	return adm.Perform(`get`, path, `show`, nil, c)
+}
+
+// maintenancePath returns the URL path of the maintenance window
+// given as ${maintenanceID} in ${repository}
+func maintenancePath(c *cli.Context) (string, error) {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{},
+		[]string{`in`},
+		[]string{`in`},
+		c.Args().Tail(),
+	); err != nil {
+		return ``, err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return ``, fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	repoID, err := adm.LookupRepoID(opts[`in`][0])
+	if err != nil {
+		return ``, err
+	}
+	return fmt.Sprintf("/repository/%s/maintenance/%s",
+		url.QueryEscape(repoID),
+		url.QueryEscape(c.Args().First()),
+	), nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
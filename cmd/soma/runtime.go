@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"strings"
 
@@ -39,7 +42,14 @@ func initCommon(c *cli.Context) {
 		SetHeader(`User-Agent`, fmt.Sprintf("%s %s", c.App.Name, c.App.Version)).
 		SetHostURL(Cfg.Run.SomaAPI.String())
 
-	if Cfg.Run.SomaAPI.Scheme == `https` {
+	if Cfg.Run.SocketPath != `` {
+		Client = Client.SetTransport(&http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, `unix`,
+					Cfg.Run.SocketPath)
+			},
+		})
+	} else if Cfg.Run.SomaAPI.Scheme == `https` {
 		session = tls.NewLRUClientSessionCache(64)
 
 		// SetTLSClientConfig replaces, SetRootCertificate updates the
@@ -87,7 +97,15 @@ func initCommon(c *cli.Context) {
 	adm.ActivateAsyncWait(Cfg.AsyncWait)
 	adm.AutomaticJobSave(Cfg.JobSave)
 	adm.ConfigureCache(&store)
+	adm.ConfigureIDCache(Cfg.Run.TTLIDCache)
 	adm.ConfigureJSONPostProcessor(Cfg.ProcJSON)
+	if c.GlobalIsSet(`format`) {
+		Cfg.Format = c.GlobalString(`format`)
+	}
+	if err = adm.ConfigureOutputFormat(Cfg.Format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 // boottime is the pre-run target for bootstrapping SOMA or user
@@ -0,0 +1,176 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/internal/cmpl"
+	"github.com/mjolnir42/soma/internal/help"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func registerAPIKey(app cli.App) *cli.App {
+	app.Commands = append(app.Commands,
+		[]cli.Command{
+			{
+				Name:        `apikey`,
+				Usage:       `SUBCOMMANDS for API keys`,
+				Description: help.Text(`apikey::`),
+				Subcommands: []cli.Command{
+					{
+						Name:         `create`,
+						Usage:        `Create a new API key`,
+						Description:  help.Text(`apikey::create`),
+						Action:       runtime(apiKeyCreate),
+						BashComplete: cmpl.APIKeyCreate,
+					},
+					{
+						Name:         `revoke`,
+						Usage:        `Revoke an API key`,
+						Description:  help.Text(`apikey::revoke`),
+						Action:       runtime(apiKeyRevoke),
+						BashComplete: cmpl.None,
+					},
+					{
+						Name:         `list`,
+						Usage:        `List all API keys of the account and its team`,
+						Description:  help.Text(`apikey::list`),
+						Action:       runtime(apiKeyList),
+						BashComplete: cmpl.None,
+					},
+					{
+						Name:         `show`,
+						Usage:        `Show details about an API key`,
+						Description:  help.Text(`apikey::show`),
+						Action:       runtime(apiKeyShow),
+						BashComplete: cmpl.None,
+					},
+				},
+			},
+		}...,
+	)
+	return &app
+}
+
+// apiKeyCreate function
+// soma apikey create ${name}
+//
+//	 permission ${category}::${permission} [permission ...]
+//	[network ${cidr} [network ...]]
+//	[team ${team}]
+//	[expires ${timestamp}]
+func apiKeyCreate(c *cli.Context) error {
+	opts := map[string][]string{}
+	if err := adm.ParseVariadicArguments(
+		opts,
+		[]string{`permission`, `network`},
+		[]string{`team`, `expires`},
+		[]string{`permission`},
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	var err error
+	req := proto.NewAPIKeyRequest()
+	req.APIKey.Name = c.Args().First()
+	if err = adm.ValidateRuneCountRange(req.APIKey.Name, 1, 256); err != nil {
+		return err
+	}
+
+	for _, perm := range opts[`permission`] {
+		permissionSlice := strings.Split(perm, `::`)
+		if len(permissionSlice) != 2 {
+			return fmt.Errorf("Invalid split of permission into %s",
+				permissionSlice)
+		}
+		if err = adm.ValidateCategory(permissionSlice[0]); err != nil {
+			return err
+		}
+		switch permissionSlice[0] {
+		case msg.CategoryOmnipotence, msg.CategorySystem:
+			return fmt.Errorf("Permissions in category %s can not be"+
+				" used with API keys", permissionSlice[0])
+		}
+		req.APIKey.Permissions = append(req.APIKey.Permissions,
+			proto.Permission{
+				Name:     permissionSlice[1],
+				Category: permissionSlice[0],
+			})
+	}
+
+	req.APIKey.Networks = opts[`network`]
+
+	if len(opts[`team`]) == 1 {
+		if err = adm.LookupTeamID(
+			opts[`team`][0],
+			&req.APIKey.TeamID,
+		); err != nil {
+			return err
+		}
+	}
+
+	if len(opts[`expires`]) == 1 {
+		if req.APIKey.ExpiresAt, err = adm.ParseTimestamp(
+			opts[`expires`][0]); err != nil {
+			return err
+		}
+	}
+
+	return adm.Perform(`postbody`, `/accounts/apikeys/`, `apikey::create`,
+		req, c)
+}
+
+// apiKeyRevoke function
+// soma apikey revoke ${keyID}
+func apiKeyRevoke(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	path := fmt.Sprintf("/accounts/apikeys/%s", c.Args().First())
+	return adm.Perform(`delete`, path, `apikey::revoke`, nil, c)
+}
+
+// apiKeyList function
+// soma apikey list
+func apiKeyList(c *cli.Context) error {
+	if err := adm.VerifyNoArgument(c); err != nil {
+		return err
+	}
+
+	return adm.Perform(`get`, `/accounts/apikeys/`, `apikey::list`, nil, c)
+}
+
+// apiKeyShow function
+// soma apikey show ${keyID}
+func apiKeyShow(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	path := fmt.Sprintf("/accounts/apikeys/%s", c.Args().First())
+	return adm.Perform(`get`, path, `show`, nil, c)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,77 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package main // import "github.com/mjolnir42/soma/cmd/soma"
+
+import (
+	"encoding/json"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/internal/adm"
+	"github.com/mjolnir42/soma/internal/cmpl"
+	"github.com/mjolnir42/soma/internal/help"
+)
+
+func registerCache(app cli.App) *cli.App {
+	app.Commands = append(app.Commands,
+		[]cli.Command{
+			{
+				Name:        `cache`,
+				Usage:       `SUBCOMMANDS for the local ID cache`,
+				Description: help.Text(`cache::`),
+				Subcommands: []cli.Command{
+					{
+						Name:         `show`,
+						Usage:        `Show all entries of the local ID cache`,
+						Description:  help.Text(`cache::show`),
+						Action:       boottime(clientlocalCacheShow),
+						BashComplete: cmpl.None,
+					},
+					{
+						Name:         `clear`,
+						Usage:        `Remove all entries from the local ID cache`,
+						Description:  help.Text(`cache::clear`),
+						Action:       boottime(clientlocalCacheClear),
+						BashComplete: cmpl.None,
+					},
+				},
+			},
+		}...,
+	)
+	return &app
+}
+
+// clientlocalCacheShow function
+// soma cache show
+func clientlocalCacheShow(c *cli.Context) error {
+	if err := adm.VerifyNoArgument(c); err != nil {
+		return err
+	}
+
+	entries, err := store.IDCacheEntries()
+	if err != nil {
+		return err
+	}
+
+	enc, err := json.Marshal(&entries)
+	if err != nil {
+		return err
+	}
+	return adm.FormatOut(c, enc, `cache`)
+}
+
+// clientlocalCacheClear function
+// soma cache clear
+func clientlocalCacheClear(c *cli.Context) error {
+	if err := adm.VerifyNoArgument(c); err != nil {
+		return err
+	}
+
+	return store.ClearIDCache()
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -58,6 +58,12 @@ func registerJobs(app cli.App) *cli.App {
 						Description: help.Text(`job::wait`),
 						Action:      runtime(jobWait),
 					},
+					{
+						Name:        `watch`,
+						Usage:       `Show the progress of a job while it is processed`,
+						Description: help.Text(`job::watch`),
+						Action:      runtime(jobWatch),
+					},
 					{
 						Name:        `list`,
 						Usage:       `SUBCOMMANDS for listing job information`,
@@ -215,7 +221,7 @@ func jobList(c *cli.Context) error {
 		return err
 	}
 
-	return adm.Perform(`get`, `/job/`, `list`, nil, c)
+	return adm.Perform(`get`, `/job/`, `job::list`, nil, c)
 }
 
 func jobShow(c *cli.Context) error {
@@ -246,6 +252,19 @@ func jobWait(c *cli.Context) error {
 	return adm.Perform(`get`, path, `wait`, nil, c)
 }
 
+func jobWatch(c *cli.Context) error {
+	if err := adm.VerifySingleArgument(c); err != nil {
+		return err
+	}
+
+	if !adm.IsUUID(c.Args().First()) {
+		return fmt.Errorf("Argument is not a UUID: %s",
+			c.Args().First())
+	}
+
+	return adm.WatchJob(c, c.Args().First())
+}
+
 func clientlocalJobListOutstanding(c *cli.Context) error {
 	jobs, err := store.ActiveJobs()
 	if err != nil && err != bolt.ErrBucketNotFound {
@@ -109,6 +109,10 @@ password_read:
 			" establish ownership.")
 		passKey = adm.ReadVerified(`password`)
 	case `mailtoken`:
+		if err = adm.RequestMailToken(Client,
+			Cfg.Auth.User); err != nil {
+			return err
+		}
 		fmt.Println(`Please provide the token you received via email.`)
 		passKey = adm.ReadVerified(`token`)
 	default:
@@ -251,7 +255,11 @@ password_read:
 				`establish ownership.`)
 			passKey = adm.ReadVerified(`password`)
 		case `mailtoken`:
-			fmt.Println(`Please provide the token you received` +
+			if err = adm.RequestMailToken(Client,
+				Cfg.Auth.User); err != nil {
+				return err
+			}
+			fmt.Println(`Please provide the token you received ` +
 				`via email.`)
 			passKey = adm.ReadVerified(`token`)
 		default:
@@ -340,7 +348,11 @@ password_read:
 			`establish ownership.`)
 		passKey = adm.ReadVerified(`password`)
 	case `mailtoken`:
-		fmt.Println(`Please provide the token you received` +
+		if err = adm.RequestMailToken(Client,
+			Cfg.Auth.User); err != nil {
+			return err
+		}
+		fmt.Println(`Please provide the token you received ` +
 			`via email.`)
 		passKey = adm.ReadVerified(`token`)
 	default:
@@ -12,6 +12,7 @@ package main // import "github.com/mjolnir42/soma/cmd/soma"
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/codegangsta/cli"
 	"github.com/mjolnir42/soma/internal/adm"
@@ -145,7 +146,127 @@ func repositoryShow(c *cli.Context) error {
 	return adm.Perform(`get`, path, `show`, nil, c)
 }
 
-// TODO repositoryAudit
+// repositoryAudit function
+// soma repository audit ${repository} [from ${team}] [${entity} ${object}] [user ${user}] [jobtype ${jobtype}] [since ${timestamp}] [until ${timestamp}]
+func repositoryAudit(c *cli.Context) error {
+	opts := map[string][]string{}
+	multipleAllowed := []string{}
+	uniqueOptions := []string{`from`, `bucket`, `group`, `cluster`,
+		`node`, `check`, `user`, `jobtype`, `since`, `until`}
+	mandatoryOptions := []string{}
+
+	if err := adm.ParseVariadicArguments(
+		opts,
+		multipleAllowed,
+		uniqueOptions,
+		mandatoryOptions,
+		c.Args().Tail(),
+	); err != nil {
+		return err
+	}
+
+	repositoryID, err := adm.LookupRepoID(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	var teamID string
+	if _, ok := opts[`from`]; ok {
+		if err := adm.LookupTeamID(opts[`from`][0], &teamID); err != nil {
+			return err
+		}
+	} else {
+		if err := adm.LookupTeamByRepo(repositoryID, &teamID); err != nil {
+			return err
+		}
+	}
+
+	// at most one object can be selected, groups and clusters are
+	// looked up within the bucket
+	objectCount := 0
+	for _, entity := range []string{`group`, `cluster`, `node`, `check`} {
+		if _, ok := opts[entity]; ok {
+			objectCount++
+		}
+	}
+	if objectCount > 1 {
+		return fmt.Errorf("Only one of group, cluster, node or check" +
+			" can be specified")
+	}
+
+	query := url.Values{}
+	var bucketID, objectID string
+	if _, ok := opts[`bucket`]; ok {
+		if bucketID, err = adm.LookupBucketID(opts[`bucket`][0]); err != nil {
+			return err
+		}
+		query.Set(`entity`, proto.EntityBucket)
+		query.Set(`object`, bucketID)
+	}
+	switch {
+	case len(opts[`group`]) > 0:
+		if bucketID == `` {
+			return fmt.Errorf("Group lookup requires a bucket")
+		}
+		if objectID, err = adm.LookupGroupID(opts[`group`][0], bucketID); err != nil {
+			return err
+		}
+		query.Set(`entity`, proto.EntityGroup)
+		query.Set(`object`, objectID)
+	case len(opts[`cluster`]) > 0:
+		if bucketID == `` {
+			return fmt.Errorf("Cluster lookup requires a bucket")
+		}
+		if objectID, err = adm.LookupClusterID(opts[`cluster`][0], bucketID); err != nil {
+			return err
+		}
+		query.Set(`entity`, proto.EntityCluster)
+		query.Set(`object`, objectID)
+	case len(opts[`node`]) > 0:
+		if objectID, err = adm.LookupNodeID(opts[`node`][0]); err != nil {
+			return err
+		}
+		query.Set(`entity`, proto.EntityNode)
+		query.Set(`object`, objectID)
+	case len(opts[`check`]) > 0:
+		if objectID, _, err = adm.LookupCheckConfigID(
+			opts[`check`][0],
+			repositoryID,
+			``,
+		); err != nil {
+			return err
+		}
+		query.Set(`entity`, proto.EntityCheck)
+		query.Set(`object`, objectID)
+	}
+
+	for _, ts := range []string{`since`, `until`} {
+		if _, ok := opts[ts]; ok {
+			if _, err = time.Parse(time.RFC3339, opts[ts][0]); err != nil {
+				return err
+			}
+			query.Set(ts, opts[ts][0])
+		}
+	}
+	if _, ok := opts[`user`]; ok {
+		query.Set(`user`, opts[`user`][0])
+	}
+	if _, ok := opts[`jobtype`]; ok {
+		query.Set(`jobtype`, opts[`jobtype`][0])
+	}
+	if c.Bool(`detailed`) {
+		query.Set(`detailed`, `true`)
+	}
+
+	path := fmt.Sprintf("/team/%s/repository/%s/audit",
+		url.QueryEscape(teamID),
+		url.QueryEscape(repositoryID),
+	)
+	if len(query) > 0 {
+		path = path + `?` + query.Encode()
+	}
+	return adm.Perform(`get`, path, `repository::audit`, nil, c)
+}
 
 // repositoryRepossess function
 // soma repository repossess ${repository} to ${newTeam} [from ${team}]
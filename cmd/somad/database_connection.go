@@ -56,8 +56,8 @@ func connectToDatabase(appLog, errLog *log.Logger) {
 	required := map[string]int64{
 		"inventory": 201811150001,
 		"root":      201605160001,
-		`auth`:      201811150001,
-		`soma`:      201905130001,
+		`auth`:      202610180001,
+		`soma`:      202610180006,
 	}
 
 	if rows, err = conn.Query(stmt.DatabaseSchemaVersion); err != nil {
@@ -236,7 +236,7 @@ func main() {
 	go metrics.CaptureRuntimeMemStats(Metrics[`golang`], time.Second*60)
 
 	Metrics[`soma`] = metrics.NewPrefixedRegistry(`soma`)
-	Metrics[`soma`].Register(`requests.latency`,
+	Metrics[`soma`].Register(`.requests.latency`,
 		// TODO NewCustomTimer(Histogram, Meter) so there is access
 		// to Histogram.Clear()
 		metrics.NewTimer())
@@ -275,9 +275,18 @@ func main() {
 	app.Start()
 
 	rst = rest.New(super.IsAuthorized, hm, &SomaCfg, reqLog, errLog)
+	app.RegisterFrontend(rst)
 
 	go rst.Run()
 
+	// optional restricted admin endpoint on a UNIX socket
+	if SomaCfg.AdminSocket.Path != `` {
+		adm := rest.NewRestricted(super.IsAuthorized, hm, &SomaCfg, reqLog, errLog)
+		app.RegisterFrontend(adm)
+
+		go adm.Run()
+	}
+
 	// signal handler for shutdown
 	sigChanShutdown := make(chan os.Signal, 1)
 	signal.Notify(sigChanShutdown, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
@@ -51,6 +51,10 @@ func commandInitialize(done chan<- bool, printOnly bool, verbose bool, version s
 
 	createTablesJobs(printOnly, verbose)
 
+	createTablesWebhooks(printOnly, verbose)
+
+	createTablesMaintenance(printOnly, verbose)
+
 	createTablesSchemaVersion(printOnly, verbose)
 
 	schemaInserts(printOnly, verbose)
@@ -18,6 +18,7 @@ var UpgradeVersions = map[string]map[int]func(int, string, bool) int{
 		201605150002: upgradeAuthTo201605190001,
 		201605190001: upgradeAuthTo201711080001,
 		201711080001: upgradeAuthTo201811150001,
+		201811150001: upgradeAuthTo202610180001,
 	},
 	`soma`: map[int]func(int, string, bool) int{
 		201605060001: upgradeSomaTo201605210001,
@@ -45,7 +46,14 @@ var UpgradeVersions = map[string]map[int]func(int, string, bool) int{
 		201811120002: upgradeSomaTo201811150001,
 		201811150001: upgradeSomaTo201901300001,
 		201901300001: upgradeSomaTo201903130001,
-		201905130001: upgradeSomaTo201905130001,
+		// upgrades from 201903130001, see upgradeSomaTo201905130001
+		201903130001: upgradeSomaTo201905130001,
+		201905130001: upgradeSomaTo202610180001,
+		202610180001: upgradeSomaTo202610180002,
+		202610180002: upgradeSomaTo202610180003,
+		202610180003: upgradeSomaTo202610180004,
+		202610180004: upgradeSomaTo202610180005,
+		202610180005: upgradeSomaTo202610180006,
 	},
 	`root`: map[int]func(int, string, bool) int{
 		000000000001: installRoot201605150001,
@@ -1141,7 +1149,11 @@ func upgradeSomaTo201903130001(curr int, tool string, printOnly bool) int {
 }
 
 func upgradeSomaTo201905130001(curr int, tool string, printOnly bool) int {
-	if curr != 201905130001 {
+	// this upgrade was registered as and guarded against its own
+	// target version 201905130001. Databases at 201903130001 had no
+	// upgrade path and databases at 201905130001 ran it again on every
+	// upgrade. It is the successor of 201903130001.
+	if curr != 201903130001 {
 		return 0
 	}
 	stmts := []string{
@@ -1181,6 +1193,144 @@ func upgradeSomaTo201905130001(curr int, tool string, printOnly bool) int {
 	return 201905130001
 }
 
+func upgradeSomaTo202610180001(curr int, tool string, printOnly bool) int {
+	if curr != 201905130001 {
+		return 0
+	}
+	stmts := []string{
+		`ALTER TABLE soma.constraints_custom_property ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_custom_property ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_system_property ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_system_property ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_native_property ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_native_property ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_service_property ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_service_property ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_service_attribute ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_service_attribute ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+		`ALTER TABLE soma.constraints_oncall_property ADD COLUMN match_operator varchar(8) NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) );`,
+		`ALTER TABLE soma.constraints_oncall_property ADD COLUMN match_group varchar(64) NOT NULL DEFAULT '';`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180001
+}
+
+func upgradeSomaTo202610180002(curr int, tool string, printOnly bool) int {
+	if curr != 202610180001 {
+		return 0
+	}
+	stmts := []string{}
+	for _, table := range []string{
+		`soma.authorizations_global`,
+		`soma.authorizations_repository`,
+		`soma.authorizations_monitoring`,
+		`soma.authorizations_team`,
+	} {
+		stmts = append(stmts,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN not_before timestamptz(3) NULL;", table),
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN not_after timestamptz(3) NULL;", table),
+			fmt.Sprintf("ALTER TABLE %s ADD CHECK ( not_before IS NULL OR not_after IS NULL OR not_before < not_after );", table),
+		)
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180002, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180002
+}
+
+func upgradeSomaTo202610180003(curr int, tool string, printOnly bool) int {
+	if curr != 202610180002 {
+		return 0
+	}
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS soma.webhooks ( webhook_id uuid PRIMARY KEY, name varchar(256) NOT NULL, url text NOT NULL, secret varchar(128) NOT NULL, repository_id uuid NULL REFERENCES soma.repository ( id ) ON DELETE CASCADE DEFERRABLE, monitoring_id uuid NULL REFERENCES soma.monitoring_systems ( monitoring_id ) ON DELETE CASCADE DEFERRABLE, states varchar(32)[] NULL, created_by uuid NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE, created_at timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), CONSTRAINT _webhook_unique_name UNIQUE ( name ), CONSTRAINT _webhook_timezone_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' ) );`,
+		`CREATE TABLE IF NOT EXISTS soma.deployment_events ( event_id bigserial PRIMARY KEY, check_instance_config_id uuid NOT NULL, check_instance_id uuid NOT NULL, check_id uuid NULL, repository_id uuid NULL, monitoring_id uuid NULL, version integer NOT NULL, previous_status varchar(32) NULL, status varchar(32) NOT NULL, next_status varchar(32) NOT NULL, occurred_at timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), dispatched boolean NOT NULL DEFAULT 'no' );`,
+		`CREATE INDEX _deployment_events_pending ON soma.deployment_events ( event_id ) WHERE NOT dispatched;`,
+		`CREATE TABLE IF NOT EXISTS soma.webhook_outbox ( delivery_id uuid NOT NULL DEFAULT public.gen_random_uuid(), webhook_id uuid NOT NULL REFERENCES soma.webhooks ( webhook_id ) ON DELETE CASCADE DEFERRABLE, event_id bigint NOT NULL REFERENCES soma.deployment_events ( event_id ) ON DELETE CASCADE DEFERRABLE, attempts integer NOT NULL DEFAULT 0, next_attempt_at timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), last_error text NULL, delivered_at timestamptz(3) NULL, failed_at timestamptz(3) NULL, CONSTRAINT _webhook_outbox_primary_key PRIMARY KEY ( delivery_id ), CONSTRAINT _webhook_outbox_unique_event UNIQUE ( webhook_id, event_id ) );`,
+		`CREATE INDEX _webhook_outbox_due ON soma.webhook_outbox ( next_attempt_at ) WHERE delivered_at IS NULL AND failed_at IS NULL;`,
+		`CREATE OR REPLACE FUNCTION soma.record_deployment_event() RETURNS trigger AS $$ DECLARE chk_id uuid; repo_id uuid; BEGIN IF TG_OP = 'UPDATE' AND NEW.status IS NOT DISTINCT FROM OLD.status THEN RETURN NEW; END IF; SELECT sc.check_id, sc.repository_id INTO chk_id, repo_id FROM soma.check_instances sci JOIN soma.checks sc ON sci.check_id = sc.check_id WHERE sci.check_instance_id = NEW.check_instance_id; INSERT INTO soma.deployment_events ( check_instance_config_id, check_instance_id, check_id, repository_id, monitoring_id, version, previous_status, status, next_status ) VALUES ( NEW.check_instance_config_id, NEW.check_instance_id, chk_id, repo_id, NEW.monitoring_id, NEW.version, CASE WHEN TG_OP = 'UPDATE' THEN OLD.status END, NEW.status, NEW.next_status ); RETURN NEW; END; $$ LANGUAGE plpgsql;`,
+		`CREATE TRIGGER _deployment_event AFTER INSERT OR UPDATE OF status ON soma.check_instance_configurations FOR EACH ROW EXECUTE PROCEDURE soma.record_deployment_event();`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA soma TO soma_svc;`,
+		`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA soma TO soma_svc;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180003, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180003
+}
+
+func upgradeSomaTo202610180004(curr int, tool string, printOnly bool) int {
+	if curr != 202610180003 {
+		return 0
+	}
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS soma.maintenance_windows ( window_id uuid PRIMARY KEY, repository_id uuid NOT NULL REFERENCES soma.repository ( id ) ON DELETE CASCADE DEFERRABLE, object_type varchar(64) NOT NULL REFERENCES soma.object_types ( object_type ) DEFERRABLE, object_id uuid NOT NULL, starts_at timestamptz(3) NOT NULL, ends_at timestamptz(3) NOT NULL, reason text NOT NULL, created_by uuid NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE, created_at timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), CONSTRAINT _maintenance_window_object_type CHECK ( object_type IN ( 'repository', 'bucket', 'group', 'cluster', 'node' ) ), CONSTRAINT _maintenance_window_ordered CHECK ( ends_at > starts_at ), CONSTRAINT _maintenance_window_timezone_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' ) );`,
+		`CREATE INDEX _maintenance_window_object ON soma.maintenance_windows ( object_id, ends_at );`,
+		`CREATE INDEX _maintenance_window_repository ON soma.maintenance_windows ( repository_id, ends_at );`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA soma TO soma_svc;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180004, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180004
+}
+
+func upgradeSomaTo202610180005(curr int, tool string, printOnly bool) int {
+	if curr != 202610180004 {
+		return 0
+	}
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS soma.deployment_transitions ( transition_id bigserial PRIMARY KEY, check_instance_config_id uuid NOT NULL, check_instance_id uuid NOT NULL, previous_status varchar(32) NOT NULL, previous_next_status varchar(32) NOT NULL, status varchar(32) NOT NULL, next_status varchar(32) NOT NULL, actor varchar(256) NOT NULL, occurred_at timestamptz(3) NOT NULL DEFAULT NOW()::timestamptz(3), CONSTRAINT _deployment_transition_timezone_utc CHECK( EXTRACT( TIMEZONE FROM occurred_at ) = '0' ) );`,
+		`CREATE INDEX _deployment_transition_instance ON soma.deployment_transitions ( check_instance_id, transition_id );`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA soma TO soma_svc;`,
+		`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA soma TO soma_svc;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180005, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180005
+}
+
+func upgradeSomaTo202610180006(curr int, tool string, printOnly bool) int {
+	if curr != 202610180005 {
+		return 0
+	}
+	stmts := []string{
+		`ALTER TABLE soma.check_instance_configurations ADD COLUMN constraint_values jsonb NOT NULL DEFAULT '{}'::jsonb;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('soma', 202610180006, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+	return 202610180006
+}
+
+func upgradeAuthTo202610180001(curr int, tool string, printOnly bool) int {
+	if curr != 201811150001 {
+		return 0
+	}
+
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS auth.api_keys ( id uuid NOT NULL DEFAULT public.gen_random_uuid(), name varchar(256) NOT NULL, key_hash varchar(128) NOT NULL, user_id uuid NULL, team_id uuid NULL, created_by uuid NOT NULL, created_at timestamptz(3) NOT NULL DEFAULT NOW(), expires_at timestamptz(3) NULL, revoked_at timestamptz(3) NULL, CONSTRAINT _api_key_primary_key PRIMARY KEY (id), CONSTRAINT _api_key_unique_hash UNIQUE (key_hash), CONSTRAINT _api_key_user_exists FOREIGN KEY (user_id) REFERENCES inventory.user (id) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_team_exists FOREIGN KEY (team_id) REFERENCES inventory.team (id) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_creator_exists FOREIGN KEY (created_by) REFERENCES inventory.user (id) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_single_owner CHECK ( ( user_id IS NOT NULL AND team_id IS NULL ) OR ( user_id IS NULL AND team_id IS NOT NULL ) ), CONSTRAINT _api_key_created_at_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' ), CONSTRAINT _api_key_expires_at_utc CHECK( EXTRACT( TIMEZONE FROM expires_at ) = '0' ), CONSTRAINT _api_key_revoked_at_utc CHECK( EXTRACT( TIMEZONE FROM revoked_at ) = '0' ) );`,
+		`CREATE TABLE IF NOT EXISTS auth.api_key_networks ( api_key_id uuid NOT NULL, network cidr NOT NULL, CONSTRAINT _api_key_network_key_exists FOREIGN KEY (api_key_id) REFERENCES auth.api_keys (id) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_network_unique UNIQUE (api_key_id, network) );`,
+		`CREATE TABLE IF NOT EXISTS auth.api_key_permissions ( api_key_id uuid NOT NULL, permission_id uuid NOT NULL, category varchar(32) NOT NULL, CONSTRAINT _api_key_permission_key_exists FOREIGN KEY (api_key_id) REFERENCES auth.api_keys (id) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_permission_exists FOREIGN KEY (permission_id, category) REFERENCES soma.permission (id, category) ON DELETE CASCADE DEFERRABLE, CONSTRAINT _api_key_permission_unique UNIQUE (api_key_id, permission_id) );`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA auth TO soma_svc;`,
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO public.schema_versions (schema, version, description) VALUES ('auth', 202610180001, 'Upgrade - somadbctl %s');", tool),
+	)
+	executeUpgrades(stmts, printOnly)
+
+	return 202610180001
+}
+
 func installRoot201605150001(curr int, tool string, printOnly bool) int {
 	if curr != 000000000001 {
 		return 0
@@ -231,6 +231,41 @@ create table if not exists auth.password_reset (
            OR ( user_id IS     NULL AND admin_id IS     NULL AND tool_id IS NOT NULL ) )
 );`
 	queries[idx] = "createTablePasswordReset"
+	idx++
+
+	queryMap[`create__auth.api_keys`] = `
+create table if not exists auth.api_keys (
+    id                          uuid            NOT NULL DEFAULT public.gen_random_uuid(),
+    name                        varchar(256)    NOT NULL,
+    key_hash                    varchar(128)    NOT NULL,
+    user_id                     uuid            NULL,
+    team_id                     uuid            NULL,
+    created_by                  uuid            NOT NULL,
+    created_at                  timestamptz(3)  NOT NULL DEFAULT NOW(),
+    expires_at                  timestamptz(3)  NULL,
+    revoked_at                  timestamptz(3)  NULL,
+    CONSTRAINT _api_key_primary_key PRIMARY KEY (id),
+    CONSTRAINT _api_key_unique_hash UNIQUE (key_hash),
+    CONSTRAINT _api_key_user_exists FOREIGN KEY (user_id) REFERENCES inventory.user (id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_team_exists FOREIGN KEY (team_id) REFERENCES inventory.team (id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_creator_exists FOREIGN KEY (created_by) REFERENCES inventory.user (id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_single_owner CHECK (   ( user_id IS NOT NULL AND team_id IS     NULL )
+                                            OR ( user_id IS     NULL AND team_id IS NOT NULL ) ),
+    CONSTRAINT _api_key_created_at_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' ),
+    CONSTRAINT _api_key_expires_at_utc CHECK( EXTRACT( TIMEZONE FROM expires_at ) = '0' ),
+    CONSTRAINT _api_key_revoked_at_utc CHECK( EXTRACT( TIMEZONE FROM revoked_at ) = '0' )
+);`
+	queries[idx] = `create__auth.api_keys`
+	idx++
+
+	queryMap[`create__auth.api_key_networks`] = `
+create table if not exists auth.api_key_networks (
+    api_key_id                  uuid            NOT NULL,
+    network                     cidr            NOT NULL,
+    CONSTRAINT _api_key_network_key_exists FOREIGN KEY (api_key_id) REFERENCES auth.api_keys (id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_network_unique UNIQUE (api_key_id, network)
+);`
+	queries[idx] = `create__auth.api_key_networks`
 
 	performDatabaseTask(printOnly, verbose, queries, queryMap)
 }
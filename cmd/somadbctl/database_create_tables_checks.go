@@ -140,6 +140,8 @@ create table if not exists soma.constraints_custom_property (
     custom_property_id          uuid            NOT NULL REFERENCES soma.custom_properties ( custom_property_id ) DEFERRABLE,
     repository_id               uuid            NOT NULL REFERENCES soma.repository (id) DEFERRABLE,
     property_value              text            NOT NULL,
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT '',
     -- ensure this custom property is defined for this repository
     FOREIGN KEY ( repository_id, custom_property_id ) REFERENCES soma.custom_properties ( repository_id, custom_property_id ) DEFERRABLE,
     -- ensure the configuration_id is for the repository the custom property is defined in
@@ -152,7 +154,9 @@ create table if not exists soma.constraints_custom_property (
 create table if not exists soma.constraints_system_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     system_property             varchar(128)    NOT NULL REFERENCES soma.system_properties ( system_property ) DEFERRABLE,
-    property_value              text            NOT NULL
+    property_value              text            NOT NULL,
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsSystemProperty"
 	idx++
@@ -161,7 +165,9 @@ create table if not exists soma.constraints_system_property (
 create table if not exists soma.constraints_native_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     native_property             varchar(128)    NOT NULL REFERENCES soma.native_properties ( native_property ) DEFERRABLE,
-    property_value              text            NOT NULL
+    property_value              text            NOT NULL,
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsNativeProperty"
 	idx++
@@ -171,7 +177,9 @@ create table if not exists soma.constraints_service_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     team_id                     uuid            NOT NULL,
     name                        varchar(128)    NOT NULL,
-    service_property_id         uuid            NOT NULL REFERENCES soma.service_property ( id ) DEFERRABLE
+    service_property_id         uuid            NOT NULL REFERENCES soma.service_property ( id ) DEFERRABLE,
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsServiceProperty"
 	idx++
@@ -180,7 +188,9 @@ create table if not exists soma.constraints_service_property (
 create table if not exists soma.constraints_service_attribute (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
     attribute                   varchar(128)    NOT NULL REFERENCES soma.attribute ( attribute ) DEFERRABLE,
-    value                       varchar(512)
+    value                       varchar(512),
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsServiceAttributes"
 	idx++
@@ -188,7 +198,9 @@ create table if not exists soma.constraints_service_attribute (
 	queryMap["createTableCheckConstraintsOncallProperty"] = `
 create table if not exists soma.constraints_oncall_property (
     configuration_id            uuid            NOT NULL REFERENCES soma.check_configurations ( configuration_id ) DEFERRABLE,
-    oncall_duty_id              uuid            NOT NULL REFERENCES inventory.oncall_team ( id ) DEFERRABLE
+    oncall_duty_id              uuid            NOT NULL REFERENCES inventory.oncall_team ( id ) DEFERRABLE,
+    match_operator              varchar(8)      NOT NULL DEFAULT '==' CHECK ( match_operator IN ( '==', '!=', '=~', '!~', 'glob', '!glob', '<', '<=', '>', '>=' ) ),
+    match_group                 varchar(64)     NOT NULL DEFAULT ''
 );`
 	queries[idx] = "createTableCheckConstraintsOncallProperty"
 
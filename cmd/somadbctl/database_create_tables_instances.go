@@ -58,6 +58,7 @@ create table if not exists soma.check_instance_configurations (
     next_status                 varchar(32)     NOT NULL REFERENCES soma.check_instance_status ( status ) DEFERRABLE,
     awaiting_deletion           boolean         NOT NULL DEFAULT 'no',
     deployment_details          jsonb           NOT NULL,
+    constraint_values           jsonb           NOT NULL DEFAULT '{}'::jsonb,
     CHECK ( status != 'none' ),
     CHECK ( status = 'awaiting_computation' OR monitoring_id IS NOT NULL )
 );`
@@ -116,6 +117,31 @@ create index _config_dependencies
     blocking_instance_config_id
 );`
 	queries[idx] = `createIndexConfigurationDependencies`
+	idx++
+
+	queryMap[`createTableDeploymentTransitions`] = `
+create table if not exists soma.deployment_transitions (
+    transition_id               bigserial       PRIMARY KEY,
+    check_instance_config_id    uuid            NOT NULL,
+    check_instance_id           uuid            NOT NULL,
+    previous_status             varchar(32)     NOT NULL,
+    previous_next_status        varchar(32)     NOT NULL,
+    status                      varchar(32)     NOT NULL,
+    next_status                 varchar(32)     NOT NULL,
+    actor                       varchar(256)    NOT NULL,
+    occurred_at                 timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    CONSTRAINT _deployment_transition_timezone_utc CHECK( EXTRACT( TIMEZONE FROM occurred_at ) = '0' )
+);`
+	queries[idx] = `createTableDeploymentTransitions`
+	idx++
+
+	queryMap[`createIndexDeploymentTransitionInstance`] = `
+create index _deployment_transition_instance
+    on soma.deployment_transitions (
+    check_instance_id,
+    transition_id
+);`
+	queries[idx] = `createIndexDeploymentTransitionInstance`
 
 	performDatabaseTask(printOnly, verbose, queries, queryMap)
 }
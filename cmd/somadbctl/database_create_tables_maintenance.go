@@ -0,0 +1,44 @@
+package main
+
+func createTablesMaintenance(printOnly bool, verbose bool) {
+	idx := 0
+	// map for storing the SQL statements by name
+	queryMap := make(map[string]string)
+	// slice storing the required statement order so foreign keys can
+	// resolve successfully
+	queries := make([]string, 5)
+
+	queryMap[`createTableMaintenanceWindows`] = `
+create table if not exists soma.maintenance_windows (
+    window_id                   uuid            PRIMARY KEY,
+    repository_id               uuid            NOT NULL REFERENCES soma.repository ( id ) ON DELETE CASCADE DEFERRABLE,
+    object_type                 varchar(64)     NOT NULL REFERENCES soma.object_types ( object_type ) DEFERRABLE,
+    object_id                   uuid            NOT NULL,
+    starts_at                   timestamptz(3)  NOT NULL,
+    ends_at                     timestamptz(3)  NOT NULL,
+    reason                      text            NOT NULL,
+    created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
+    created_at                  timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    CONSTRAINT _maintenance_window_object_type CHECK ( object_type IN ( 'repository', 'bucket', 'group', 'cluster', 'node' ) ),
+    CONSTRAINT _maintenance_window_ordered CHECK ( ends_at > starts_at ),
+    CONSTRAINT _maintenance_window_timezone_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' )
+);`
+	queries[idx] = `createTableMaintenanceWindows`
+	idx++
+
+	queryMap[`createIndexMaintenanceWindowObject`] = `
+create index _maintenance_window_object
+    on soma.maintenance_windows ( object_id, ends_at );`
+	queries[idx] = `createIndexMaintenanceWindowObject`
+	idx++
+
+	queryMap[`createIndexMaintenanceWindowRepository`] = `
+create index _maintenance_window_repository
+    on soma.maintenance_windows ( repository_id, ends_at );`
+	queries[idx] = `createIndexMaintenanceWindowRepository`
+	idx++
+
+	performDatabaseTask(printOnly, verbose, queries[:idx], queryMap)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
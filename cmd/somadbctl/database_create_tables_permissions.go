@@ -118,6 +118,9 @@ create table if not exists soma.authorizations_global (
     category                    varchar(32)     NOT NULL REFERENCES soma.category (name) DEFERRABLE,
     created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
     created_at                  timestamptz(3)  NOT NULL DEFAULT NOW(),
+    not_before                  timestamptz(3)  NULL,
+    not_after                   timestamptz(3)  NULL,
+    CHECK ( not_before IS NULL OR not_after IS NULL OR not_before < not_after ),
     FOREIGN KEY ( permission_id, category ) REFERENCES soma.permission ( id, category ) DEFERRABLE,
     CHECK (   ( admin_id IS NOT NULL AND user_id IS     NULL AND tool_id IS     NULL AND team_id IS     NULL )
            OR ( admin_id IS     NULL AND user_id IS NOT NULL AND tool_id IS     NULL AND team_id IS     NULL )
@@ -180,6 +183,9 @@ create table if not exists soma.authorizations_repository (
     category                    varchar(32)     NOT NULL REFERENCES soma.category (name) DEFERRABLE,
     created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
     created_at                  timestamptz(3)  NOT NULL DEFAULT NOW(),
+    not_before                  timestamptz(3)  NULL,
+    not_after                   timestamptz(3)  NULL,
+    CHECK ( not_before IS NULL OR not_after IS NULL OR not_before < not_after ),
     FOREIGN KEY ( permission_id, category ) REFERENCES soma.permission (id, category) DEFERRABLE,
     FOREIGN KEY ( bucket_id, repository_id ) REFERENCES soma.buckets ( bucket_id, repository_id ) DEFERRABLE,
     FOREIGN KEY ( bucket_id, group_id ) REFERENCES soma.groups ( bucket_id, group_id ) DEFERRABLE,
@@ -247,6 +253,9 @@ create table if not exists soma.authorizations_monitoring (
     category                    varchar(32)     NOT NULL REFERENCES soma.category (name) DEFERRABLE,
     created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
     created_at                  timestamptz(3)  NOT NULL DEFAULT NOW(),
+    not_before                  timestamptz(3)  NULL,
+    not_after                   timestamptz(3)  NULL,
+    CHECK ( not_before IS NULL OR not_after IS NULL OR not_before < not_after ),
     FOREIGN KEY ( permission_id, category ) REFERENCES soma.permission (id, category) DEFERRABLE,
     CHECK (   ( user_id IS NOT NULL AND tool_id IS     NULL AND team_id IS     NULL )
            OR ( user_id IS     NULL AND tool_id IS NOT NULL AND team_id IS     NULL )
@@ -289,6 +298,9 @@ create table if not exists soma.authorizations_team (
     category                    varchar(32)     NOT NULL REFERENCES soma.category (name) DEFERRABLE,
     created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
     created_at                  timestamptz(3)  NOT NULL DEFAULT NOW(),
+    not_before                  timestamptz(3)  NULL,
+    not_after                   timestamptz(3)  NULL,
+    CHECK ( not_before IS NULL OR not_after IS NULL OR not_before < not_after ),
     FOREIGN KEY ( permission_id, category ) REFERENCES soma.permission (id, category) DEFERRABLE,
     CHECK (   ( user_id IS NOT NULL AND tool_id IS     NULL AND team_id IS     NULL )
            OR ( user_id IS     NULL AND tool_id IS NOT NULL AND team_id IS     NULL )
@@ -320,6 +332,20 @@ create unique index _unique_team_team_authoriz
 	queries[idx] = `createUniqueIndexTeamTeamAuthorizations`
 	idx++
 
+	// the permission subset of API keys lives in the auth schema,
+	// but requires the permissions to exist
+	queryMap[`create__auth.api_key_permissions`] = `
+create table if not exists auth.api_key_permissions (
+    api_key_id                  uuid            NOT NULL,
+    permission_id               uuid            NOT NULL,
+    category                    varchar(32)     NOT NULL,
+    CONSTRAINT _api_key_permission_key_exists FOREIGN KEY (api_key_id) REFERENCES auth.api_keys (id) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_permission_exists FOREIGN KEY (permission_id, category) REFERENCES soma.permission (id, category) ON DELETE CASCADE DEFERRABLE,
+    CONSTRAINT _api_key_permission_unique UNIQUE (api_key_id, permission_id)
+);`
+	queries[idx] = `create__auth.api_key_permissions`
+	idx++
+
 	performDatabaseTask(printOnly, verbose, queries, queryMap)
 }
 
@@ -0,0 +1,130 @@
+package main
+
+func createTablesWebhooks(printOnly bool, verbose bool) {
+	idx := 0
+	// map for storing the SQL statements by name
+	queryMap := make(map[string]string)
+	// slice storing the required statement order so foreign keys can
+	// resolve successfully
+	queries := make([]string, 10)
+
+	queryMap[`createTableWebhooks`] = `
+create table if not exists soma.webhooks (
+    webhook_id                  uuid            PRIMARY KEY,
+    name                        varchar(256)    NOT NULL,
+    url                         text            NOT NULL,
+    secret                      varchar(128)    NOT NULL,
+    repository_id               uuid            NULL REFERENCES soma.repository ( id ) ON DELETE CASCADE DEFERRABLE,
+    monitoring_id               uuid            NULL REFERENCES soma.monitoring_systems ( monitoring_id ) ON DELETE CASCADE DEFERRABLE,
+    states                      varchar(32)[]   NULL,
+    created_by                  uuid            NOT NULL REFERENCES inventory.user ( id ) DEFERRABLE,
+    created_at                  timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    CONSTRAINT _webhook_unique_name UNIQUE ( name ),
+    CONSTRAINT _webhook_timezone_utc CHECK( EXTRACT( TIMEZONE FROM created_at ) = '0' )
+);`
+	queries[idx] = `createTableWebhooks`
+	idx++
+
+	queryMap[`createTableDeploymentEvents`] = `
+create table if not exists soma.deployment_events (
+    event_id                    bigserial       PRIMARY KEY,
+    check_instance_config_id    uuid            NOT NULL,
+    check_instance_id           uuid            NOT NULL,
+    check_id                    uuid            NULL,
+    repository_id               uuid            NULL,
+    monitoring_id               uuid            NULL,
+    version                     integer         NOT NULL,
+    previous_status             varchar(32)     NULL,
+    status                      varchar(32)     NOT NULL,
+    next_status                 varchar(32)     NOT NULL,
+    occurred_at                 timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    dispatched                  boolean         NOT NULL DEFAULT 'no'
+);`
+	queries[idx] = `createTableDeploymentEvents`
+	idx++
+
+	queryMap[`createIndexDeploymentEventsPending`] = `
+create index _deployment_events_pending
+    on soma.deployment_events ( event_id )
+    where not dispatched;`
+	queries[idx] = `createIndexDeploymentEventsPending`
+	idx++
+
+	queryMap[`createTableWebhookOutbox`] = `
+create table if not exists soma.webhook_outbox (
+    delivery_id                 uuid            NOT NULL DEFAULT public.gen_random_uuid(),
+    webhook_id                  uuid            NOT NULL REFERENCES soma.webhooks ( webhook_id ) ON DELETE CASCADE DEFERRABLE,
+    event_id                    bigint          NOT NULL REFERENCES soma.deployment_events ( event_id ) ON DELETE CASCADE DEFERRABLE,
+    attempts                    integer         NOT NULL DEFAULT 0,
+    next_attempt_at             timestamptz(3)  NOT NULL DEFAULT NOW()::timestamptz(3),
+    last_error                  text            NULL,
+    delivered_at                timestamptz(3)  NULL,
+    failed_at                   timestamptz(3)  NULL,
+    CONSTRAINT _webhook_outbox_primary_key PRIMARY KEY ( delivery_id ),
+    CONSTRAINT _webhook_outbox_unique_event UNIQUE ( webhook_id, event_id )
+);`
+	queries[idx] = `createTableWebhookOutbox`
+	idx++
+
+	queryMap[`createIndexWebhookOutboxDue`] = `
+create index _webhook_outbox_due
+    on soma.webhook_outbox ( next_attempt_at )
+    where delivered_at IS NULL AND failed_at IS NULL;`
+	queries[idx] = `createIndexWebhookOutboxDue`
+	idx++
+
+	// every status change of a check instance configuration is
+	// recorded by the database, since status updates are issued from
+	// many places, including bulk updates
+	queryMap[`createFunctionRecordDeploymentEvent`] = `
+create or replace function soma.record_deployment_event() returns trigger as $$
+declare
+    chk_id  uuid;
+    repo_id uuid;
+begin
+    if TG_OP = 'UPDATE' and NEW.status IS NOT DISTINCT FROM OLD.status then
+        return NEW;
+    end if;
+    select sc.check_id, sc.repository_id
+    into   chk_id, repo_id
+    from   soma.check_instances sci
+    join   soma.checks sc
+      on   sci.check_id = sc.check_id
+    where  sci.check_instance_id = NEW.check_instance_id;
+    insert into soma.deployment_events (
+                check_instance_config_id,
+                check_instance_id,
+                check_id,
+                repository_id,
+                monitoring_id,
+                version,
+                previous_status,
+                status,
+                next_status)
+    values (    NEW.check_instance_config_id,
+                NEW.check_instance_id,
+                chk_id,
+                repo_id,
+                NEW.monitoring_id,
+                NEW.version,
+                case when TG_OP = 'UPDATE' then OLD.status end,
+                NEW.status,
+                NEW.next_status);
+    return NEW;
+end;
+$$ language plpgsql;`
+	queries[idx] = `createFunctionRecordDeploymentEvent`
+	idx++
+
+	queryMap[`createTriggerDeploymentEvent`] = `
+create trigger _deployment_event
+    after insert or update of status
+    on soma.check_instance_configurations
+    for each row execute procedure soma.record_deployment_event();`
+	queries[idx] = `createTriggerDeploymentEvent`
+	idx++
+
+	performDatabaseTask(printOnly, verbose, queries[:idx], queryMap)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -196,7 +196,7 @@ INSERT INTO public.schema_versions (
             description
 ) VALUES (
             'auth',
-            201811150001,
+            202610180001,
             'Initial create - somadbctl %s'
 );`, version)
 	queryMap["insertAuthSchemaVersion"] = authString
@@ -210,7 +210,7 @@ INSERT INTO public.schema_versions (
             description
 ) VALUES (
             'soma',
-            201905130001,
+            202610180006,
             'Initial create - somadbctl %s'
 );`, version)
 	queryMap["insertSomaSchemaVersion"] = somaString
@@ -1,6 +1,8 @@
 package adm
 
 import (
+	"time"
+
 	"github.com/mjolnir42/soma/internal/db"
 
 	"gopkg.in/resty.v1"
@@ -9,9 +11,11 @@ import (
 var (
 	client        *resty.Client
 	cache         *db.DB
+	cacheTTL      time.Duration
 	async         bool
 	jobSave       bool
 	postProcessor string
+	outputFormat  string
 )
 
 func ConfigureClient(c *resty.Client) {
@@ -208,9 +208,23 @@ argloop:
 					errors = append(errors, err.Error())
 					goto abort
 				}
-				*constraints = append(*constraints, constr)
 				skip = true
 				skipcount = 3
+				// the constraint specification can be followed by
+				// optional operator and group modifiers
+			modloop:
+				for rest := args[pos+4:]; len(rest) >= 2; rest = rest[2:] {
+					switch rest[0] {
+					case `operator`:
+						constr.Operator = rest[1]
+					case `group`:
+						constr.Group = rest[1]
+					default:
+						break modloop
+					}
+					skipcount += 2
+				}
+				*constraints = append(*constraints, constr)
 				continue argloop
 
 			case `on`:
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 
@@ -26,6 +27,16 @@ func Perform(rqType, path, tmpl string, body interface{}, c *cli.Context) error
 		goto noattachment
 	}
 
+	// dry runs return the planned changes instead of a job
+	if c != nil && c.Bool(`dry-run`) {
+		if strings.Contains(path, `?`) {
+			path = path + `&dryrun=true`
+		} else {
+			path = path + `?dryrun=true`
+		}
+		tmpl = `plan`
+	}
+
 	switch rqType {
 	case `get`:
 		resp, err = GetReq(path)
@@ -113,6 +124,11 @@ func handleRequestOptions(resp *resty.Response, err error) (*resty.Response, err
 		return nil, err
 	}
 
+	if resp.StatusCode() == http.StatusNotFound {
+		// the request may have been built from stale cached IDs
+		invalidateCachedIDs(resp.Request.URL)
+	}
+
 	if resp.StatusCode() >= 300 {
 		return resp, fmt.Errorf("Request error: %s, %s", resp.Status(), resp.String())
 	}
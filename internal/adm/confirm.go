@@ -0,0 +1,43 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"io"
+	"strings"
+
+	"github.com/peterh/liner"
+)
+
+// Confirm asks the user to answer question with yes or no until a
+// valid answer is given. Aborting the prompt or the end of the input
+// count as no.
+func Confirm(question string) (bool, error) {
+	line := liner.NewLiner()
+	defer line.Close()
+	line.SetCtrlCAborts(true)
+
+	for {
+		choice, err := line.Prompt(question + ` (y/n): `)
+		switch {
+		case err == liner.ErrPromptAborted, err == io.EOF:
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+
+		switch strings.ToLower(strings.TrimSpace(choice)) {
+		case `y`, `yes`:
+			return true, nil
+		case `n`, `no`:
+			return false, nil
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
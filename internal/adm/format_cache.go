@@ -0,0 +1,50 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// cacheEntry is an entry of the local ID cache as it is rendered by
+// soma cache show
+type cacheEntry struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	ID     string `json:"id"`
+	Expire string `json:"expire"`
+}
+
+// printCache renders the local ID cache entries in data. They are
+// not a proto.Result and printed with one entry per row.
+func printCache(data []byte) error {
+	switch outputFormat {
+	case `table`, `csv`:
+	case `yaml`:
+		return printYAML(data)
+	default:
+		return printJSON(data)
+	}
+
+	entries := []cacheEntry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	objects := make([]reflect.Value, len(entries))
+	for i := range entries {
+		objects[i] = reflect.ValueOf(entries[i])
+	}
+
+	if outputFormat == `csv` {
+		return printCSVObjects(objects, false)
+	}
+	return printListTable(``, objects)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
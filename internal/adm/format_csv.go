@@ -0,0 +1,82 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"reflect"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// printCSV renders the objects in data as CSV with a header record.
+// List results contain the scalar attributes of the objects, all
+// other results their flattened nested attributes as well.
+func printCSV(data []byte, cmd string) error {
+	var res proto.Result
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	if printStatus(&res) {
+		return nil
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	if cmd == `tree` && res.Tree != nil {
+		w.Write([]string{`type`, `id`, `name`, `parentId`})
+		for _, e := range treeEntries(res.Tree) {
+			w.Write([]string{e.kind, e.id, e.name, e.parentID})
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	kind, objects := resultObjects(&res)
+	if kind == `` {
+		return nil
+	}
+	return printCSVObjects(objects, !isListCommand(cmd))
+}
+
+// printCSVObjects renders objects as CSV with a header record. If
+// deep is true, nested attributes are flattened as well.
+func printCSVObjects(objects []reflect.Value, deep bool) error {
+	w := csv.NewWriter(os.Stdout)
+
+	// objects can have different nested attributes, the header is
+	// the union of all of them
+	rows := make([]map[string]string, len(objects))
+	header := []string{}
+	seen := map[string]bool{}
+	for i, obj := range objects {
+		rows[i] = map[string]string{}
+		for _, f := range flatten(``, obj, deep) {
+			rows[i][f.key] = f.value
+			if !seen[f.key] {
+				seen[f.key] = true
+				header = append(header, f.key)
+			}
+		}
+	}
+
+	w.Write(header)
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for j, key := range header {
+			record[j] = row[key]
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,140 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// printExplanation renders the explanation of a check configuration
+// on a tree object
+func printExplanation(data []byte) error {
+	var res proto.Result
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	// errors are printed as they are
+	if res.StatusCode != 200 || res.CheckExplanations == nil {
+		return printJSON(data)
+	}
+
+	for _, x := range *res.CheckExplanations {
+		verdict := `applies`
+		if !x.Applies {
+			verdict = `does not apply`
+		}
+		fmt.Printf("Check %s %s to %s %s",
+			x.CheckConfigID, verdict, x.ObjectType,
+			planName(x.ObjectName, x.ObjectID))
+		if x.Reason != `` {
+			fmt.Printf(": %s", x.Reason)
+		}
+		fmt.Println()
+
+		fmt.Println("\nInheritance:")
+		for _, p := range x.Inheritance {
+			line := []string{p.ObjectType, planName(p.ObjectName, p.ObjectID)}
+			switch {
+			case !p.HasCheck:
+				line = append(line, `(no check)`)
+			case p.IsSource:
+				line = append(line, `(source)`)
+			default:
+				line = append(line, `(inherited)`)
+			}
+			if p.Inheritance {
+				line = append(line, `inheritance`)
+			}
+			if p.ChildrenOnly {
+				line = append(line, `children-only`)
+			}
+			fmt.Printf("  %s\n", strings.Join(line, ` `))
+		}
+
+		if len(x.Disabled) > 0 {
+			fmt.Println("\nDisable properties:")
+			for _, p := range x.Disabled {
+				line := []string{fmt.Sprintf("%s=%s", p.Name, p.Value),
+					`view`, p.View}
+				if p.Inherited {
+					line = append(line, `(inherited)`)
+				}
+				if p.Effective {
+					line = append(line, `EFFECTIVE`)
+				}
+				fmt.Printf("  %s\n", strings.Join(line, ` `))
+			}
+		}
+
+		if len(x.Constraints) > 0 {
+			fmt.Println("\nConstraints:")
+			for _, cc := range x.Constraints {
+				fmt.Printf("  %s\n", explainConstraintLine(cc))
+			}
+		}
+
+		if len(x.Instances) > 0 {
+			fmt.Println("\nInstances:")
+			for _, i := range x.Instances {
+				fmt.Printf("  %s\n", explainInstanceLine(i))
+			}
+		}
+	}
+	return nil
+}
+
+// explainConstraintLine returns the description of a single
+// evaluated constraint
+func explainConstraintLine(cc proto.CheckExplanationConstraint) string {
+	mark := `[ok]  `
+	if !cc.Match {
+		mark = `[fail]`
+	}
+	op := cc.Operator
+	if op == `` {
+		op = proto.ConstraintOpEqual
+	}
+	line := []string{mark, cc.ConstraintType, cc.Key, op, cc.Expected}
+	if cc.Group != `` {
+		line = append(line, `group`, cc.Group)
+	}
+	actual := `(none)`
+	if len(cc.Actual) > 0 {
+		actual = strings.Join(cc.Actual, `, `)
+	}
+	return strings.Join(append(line, `actual:`, actual), ` `)
+}
+
+// explainInstanceLine returns the description of a single resulting
+// check instance
+func explainInstanceLine(i proto.CheckExplanationInstance) string {
+	line := []string{`new`}
+	if i.InstanceID != `` {
+		line = []string{i.InstanceID}
+	}
+	if i.ServiceName != `` {
+		line = append(line, `service`, i.ServiceName)
+	}
+	keys := make([]string, 0, len(i.ServiceConfig))
+	for k := range i.ServiceConfig {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		line = append(line, fmt.Sprintf("%s=%s", k, i.ServiceConfig[k]))
+	}
+	return strings.Join(line, ` `)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -20,14 +20,51 @@ func FormatOut(c *cli.Context, data []byte, cmd string) error {
 		return printJSON(data)
 	}
 
+	// plans and explanations are rendered as text unless one of the
+	// structured formats yaml or csv is selected
 	switch cmd {
-	// TODO
+	case `plan`, `explain`:
+		switch outputFormat {
+		case `yaml`:
+			return printYAML(data)
+		case `csv`:
+			return printCSV(data, cmd)
+		default:
+			if cmd == `plan` {
+				return printPlan(data)
+			}
+			return printExplanation(data)
+		}
+	case `cache`:
+		return printCache(data)
+	}
+
+	switch outputFormat {
+	case `table`:
+		return printTable(data, cmd)
+	case `csv`:
+		return printCSV(data, cmd)
+	case `yaml`:
+		return printYAML(data)
 	default:
-		// hardwire JSON output for now
 		return printJSON(data)
 	}
 }
 
+// ConfigureOutputFormat sets the format used by FormatOut. Supported
+// formats are json, table, csv and yaml. An empty format selects json.
+func ConfigureOutputFormat(f string) error {
+	switch f {
+	case ``:
+		outputFormat = `json`
+	case `json`, `table`, `csv`, `yaml`:
+		outputFormat = f
+	default:
+		return fmt.Errorf("Unsupported output format: %s", f)
+	}
+	return nil
+}
+
 func printJSON(data []byte) error {
 	var outputDevice io.WriteCloser
 	var proc *exec.Cmd
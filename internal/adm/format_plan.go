@@ -0,0 +1,103 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// printPlan renders the result of a dry run as list of changes
+func printPlan(data []byte) error {
+	var (
+		res                       proto.Result
+		created, updated, deleted int
+	)
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	// errors are printed as they are
+	if res.StatusCode != 200 || res.Plan == nil {
+		return printJSON(data)
+	}
+
+	if len(*res.Plan) == 0 {
+		fmt.Println(`No changes.`)
+		return nil
+	}
+
+	for _, p := range *res.Plan {
+		sym := planSymbol(p.Action)
+		switch sym {
+		case `+`:
+			created++
+		case `-`:
+			deleted++
+		default:
+			updated++
+		}
+		fmt.Printf("%s %s\n", sym, planLine(p))
+	}
+	fmt.Printf("\nPlan: %d to add, %d to change, %d to remove.\n",
+		created, updated, deleted)
+	return nil
+}
+
+// planSymbol returns the symbol that marks a change as addition,
+// removal or modification
+func planSymbol(action string) string {
+	switch action {
+	case `create`, `member_new`, `node_assignment`,
+		`property_new`, `check_new`, `check_instance_create`:
+		return `+`
+	case `delete`, `member_removed`,
+		`property_delete`, `check_removed`, `check_instance_delete`:
+		return `-`
+	}
+	return `~`
+}
+
+// planLine returns the description of a single planned change
+func planLine(p proto.PlanAction) string {
+	line := []string{p.Action, p.ObjectType, planName(p.ObjectName, p.ObjectID)}
+
+	if p.ChildType != `` {
+		line = append(line, `child`, p.ChildType,
+			planName(p.ChildName, p.ChildID))
+	}
+	if p.PropertyType != `` {
+		line = append(line, `property`,
+			fmt.Sprintf("%s:%s", p.PropertyType, p.PropertyName))
+		if p.PropertyView != `` {
+			line = append(line, `view`, p.PropertyView)
+		}
+	}
+	if p.CheckInstanceID != `` {
+		line = append(line, `instance`, p.CheckInstanceID)
+	} else if p.CheckID != `` {
+		line = append(line, `check`, p.CheckID)
+	}
+	if p.MonitoringName != `` {
+		line = append(line, `monitoring`, p.MonitoringName)
+	}
+	return strings.Join(line, ` `)
+}
+
+// planName returns name if it is set, otherwise id
+func planName(name, id string) string {
+	if name != `` {
+		return name
+	}
+	return id
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,289 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// resultMetaFields are the fields of proto.Result that do not carry
+// the returned objects
+var resultMetaFields = map[string]bool{
+	`StatusCode`: true,
+	`StatusText`: true,
+	`RequestID`:  true,
+	`Errors`:     true,
+	`JobID`:      true,
+	`JobType`:    true,
+	`Layout`:     true,
+	`Plan`:       true,
+	`Tree`:       true,
+}
+
+// listColumns limits the columns of the list tables of commands
+// whose objects have too many attributes to be readable. The key is
+// the command name passed to Perform, so that commands returning the
+// same object type can show different columns. Commands without entry
+// show all scalar attributes.
+var listColumns = map[string][]string{
+	`apikey::list`: {`id`, `name`, `userName`, `teamID`, `expiresAt`,
+		`revokedAt`},
+	`check-config::list`: {`ID`, `name`, `interval`, `objectType`,
+		`objectID`, `isActive`, `isEnabled`},
+	`instance::list`: {`id`, `checkId`, `objectType`, `objectId`,
+		`currentStatus`, `nextStatus`},
+	`job::list`: {`id`, `type`, `status`, `result`, `queued`,
+		`finished`},
+	`node::list`: {`id`, `assetID`, `name`, `teamID`, `serverID`,
+		`state`, `isOnline`},
+	`repository::audit`: {`jobId`, `jobType`, `status`, `result`,
+		`userName`, `objectType`, `objectName`, `queued`},
+	`user::list`: {`id`, `userName`, `firstName`, `lastName`, `teamId`,
+		`isActive`},
+	`user::sync`: {`userName`, `firstName`, `lastName`,
+		`employeeNumber`, `mailAddress`, `isDeleted`},
+}
+
+// isListCommand returns true if the result of command cmd is
+// rendered with one object per row
+func isListCommand(cmd string) bool {
+	if _, ok := listColumns[cmd]; ok {
+		return true
+	}
+	return cmd == `list` || strings.HasSuffix(cmd, `::list`)
+}
+
+// listFields returns the fields of obj that are shown in the list
+// table of command cmd
+func listFields(cmd string, obj reflect.Value) []field {
+	fields := flatten(``, obj, false)
+	if columns, ok := listColumns[cmd]; ok {
+		fields = selectFields(fields, columns)
+	}
+	return fields
+}
+
+// field is a single rendered attribute of an object
+type field struct {
+	key   string
+	value string
+}
+
+// resultObjects returns the name of the populated object list of res
+// and its elements
+func resultObjects(res *proto.Result) (string, []reflect.Value) {
+	v := reflect.ValueOf(res).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		if resultMetaFields[t.Field(i).Name] {
+			continue
+		}
+		f := v.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() {
+			continue
+		}
+		if f.Elem().Kind() != reflect.Slice {
+			continue
+		}
+		objects := make([]reflect.Value, f.Elem().Len())
+		for j := range objects {
+			objects[j] = f.Elem().Index(j)
+		}
+		return t.Field(i).Name, objects
+	}
+	return ``, nil
+}
+
+// flatten renders the attributes of object v. If deep is false,
+// only the scalar attributes are rendered, otherwise nested objects
+// are flattened into dotted keys and lists are summarized.
+func flatten(prefix string, v reflect.Value, deep bool) []field {
+	fields := []field{}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fields
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		// lists of plain values, ie. deploymentsList
+		return append(fields, field{key: `value`, value: scalar(v)})
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == `` {
+			continue
+		}
+		key := prefix + name
+
+		ft := t.Field(i).Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch ft.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Array,
+			reflect.Map, reflect.Interface:
+			if !deep {
+				continue
+			}
+		default:
+			// scalar attributes are always rendered, so that all
+			// objects of a type have the same columns
+			fields = append(fields, field{key: key, value: scalar(v.Field(i))})
+			continue
+		}
+
+		f := v.Field(i)
+		for f.Kind() == reflect.Ptr || f.Kind() == reflect.Interface {
+			if f.IsNil() {
+				break
+			}
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.Struct:
+			fields = append(fields, flatten(key+`.`, f, deep)...)
+		case reflect.Slice, reflect.Array:
+			if f.Len() > 0 {
+				fields = append(fields, field{key: key, value: summary(f)})
+			}
+		case reflect.Map:
+			keys := f.MapKeys()
+			sort.Slice(keys, func(a, b int) bool {
+				return scalar(keys[a]) < scalar(keys[b])
+			})
+			for _, k := range keys {
+				fields = append(fields, field{
+					key:   key + `.` + scalar(k),
+					value: scalar(f.MapIndex(k)),
+				})
+			}
+		}
+	}
+	return fields
+}
+
+// summary renders a list attribute as a single value. Lists of plain
+// values are joined, lists of objects are represented by the names of
+// the objects or by their number if they have no name.
+func summary(v reflect.Value) string {
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		for e.Kind() == reflect.Ptr || e.Kind() == reflect.Interface {
+			if e.IsNil() {
+				break
+			}
+			e = e.Elem()
+		}
+		if e.Kind() != reflect.Struct {
+			items = append(items, scalar(e))
+			continue
+		}
+		name := e.FieldByName(`Name`)
+		if !name.IsValid() || name.Kind() != reflect.String {
+			return fmt.Sprintf("(%d entries)", v.Len())
+		}
+		items = append(items, name.String())
+	}
+	return strings.Join(items, `, `)
+}
+
+// scalar renders a plain value
+func scalar(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ``
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// jsonName returns the name of struct field f inside the JSON
+// encoding, or the empty string if it is not encoded
+func jsonName(f reflect.StructField) string {
+	if f.PkgPath != `` {
+		// unexported
+		return ``
+	}
+	name := strings.Split(f.Tag.Get(`json`), `,`)[0]
+	switch name {
+	case `-`:
+		return ``
+	case ``:
+		return f.Name
+	}
+	return name
+}
+
+// selectFields returns the fields named in columns, in that order
+func selectFields(fields []field, columns []string) []field {
+	byKey := make(map[string]string, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f.value
+	}
+	selected := make([]field, 0, len(columns))
+	for _, c := range columns {
+		selected = append(selected, field{key: c, value: byKey[c]})
+	}
+	return selected
+}
+
+// columnHeader converts a JSON attribute name into a table column
+// header, ie. repositoryID becomes REPOSITORY ID
+func columnHeader(name string) string {
+	var b strings.Builder
+	prev := ' '
+	for _, r := range name {
+		if r == '.' {
+			b.WriteRune(' ')
+		} else {
+			if unicode.IsUpper(r) && unicode.IsLower(prev) {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// printStatus reports errors and asynchronous jobs contained in res.
+// It returns true if res is an error result.
+func printStatus(res *proto.Result) bool {
+	failed := res.StatusCode >= 300
+	if failed {
+		fmt.Fprintf(os.Stderr, "Error: %d - %s\n",
+			res.StatusCode, res.StatusText)
+	}
+	if res.Errors != nil {
+		for _, e := range *res.Errors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e)
+		}
+	}
+	if res.JobID != `` {
+		fmt.Fprintf(os.Stderr, "Job %s (%s): %s\n",
+			res.JobID, res.JobType, res.StatusText)
+	}
+	return failed
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,76 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestListFields(t *testing.T) {
+	user := reflect.ValueOf(proto.User{
+		ID:             `f6a4c4a0-0000-4000-8000-000000000001`,
+		UserName:       `jdoe`,
+		FirstName:      `John`,
+		LastName:       `Doe`,
+		EmployeeNumber: `4711`,
+		MailAddress:    `jdoe@example.org`,
+		IsActive:       true,
+		TeamID:         `f6a4c4a0-0000-4000-8000-000000000002`,
+	})
+
+	tests := []struct {
+		cmd     string
+		columns string
+	}{
+		{`user::list`, `id,userName,firstName,lastName,teamId,isActive`},
+		{`user::sync`,
+			`userName,firstName,lastName,employeeNumber,mailAddress,isDeleted`},
+		// commands without column set show all scalar attributes
+		{`list`,
+			`id,userName,firstName,lastName,employeeNumber,mailAddress,isActive,isSystem,isDeleted,teamId`},
+	}
+
+	for _, test := range tests {
+		keys := []string{}
+		for _, f := range listFields(test.cmd, user) {
+			keys = append(keys, f.key)
+		}
+		if got := strings.Join(keys, `,`); got != test.columns {
+			t.Errorf("%s: expected columns %s, got %s", test.cmd,
+				test.columns, got)
+		}
+	}
+}
+
+func TestIsListCommand(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		list bool
+	}{
+		{`list`, true},
+		{`bucket::list`, true},
+		{`repository::audit`, true},
+		{`user::sync`, true},
+		{`show`, false},
+		{`check-config::show`, false},
+		{`command`, false},
+		{`tree`, false},
+	}
+
+	for _, test := range tests {
+		if list := isListCommand(test.cmd); list != test.list {
+			t.Errorf("%s: expected %t, got %t", test.cmd, test.list, list)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
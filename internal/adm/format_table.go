@@ -0,0 +1,96 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// printTable renders the objects in data as human readable table.
+// List results are printed with one object per row, all other
+// results with one attribute per row.
+func printTable(data []byte, cmd string) error {
+	var res proto.Result
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	if printStatus(&res) {
+		return nil
+	}
+
+	if cmd == `tree` && res.Tree != nil {
+		return printTree(res.Tree)
+	}
+
+	kind, objects := resultObjects(&res)
+	if kind == `` {
+		if res.JobID == `` {
+			fmt.Println(res.StatusText)
+		}
+		return nil
+	}
+
+	if isListCommand(cmd) {
+		return printListTable(cmd, objects)
+	}
+	return printShowTable(objects)
+}
+
+// printListTable prints the objects returned by command cmd with one
+// object per row
+func printListTable(cmd string, objects []reflect.Value) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+
+	for i, obj := range objects {
+		fields := listFields(cmd, obj)
+
+		if i == 0 {
+			headers := make([]string, len(fields))
+			for j := range fields {
+				headers[j] = columnHeader(fields[j].key)
+			}
+			fmt.Fprintln(w, strings.Join(headers, "\t"))
+		}
+
+		values := make([]string, len(fields))
+		for j := range fields {
+			values[j] = fields[j].value
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+// printShowTable prints objects with one attribute per row. Empty
+// attributes are omitted.
+func printShowTable(objects []reflect.Value) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+
+	for i, obj := range objects {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		for _, f := range flatten(``, obj, true) {
+			if f.value == `` {
+				continue
+			}
+			fmt.Fprintf(w, "%s:\t%s\n", f.key, f.value)
+		}
+	}
+	return w.Flush()
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
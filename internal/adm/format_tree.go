@@ -0,0 +1,135 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// treeEntry is a single object inside a repository tree
+type treeEntry struct {
+	depth    int
+	kind     string
+	id       string
+	name     string
+	parentID string
+}
+
+// treeEntries returns the objects of tree in depth-first order
+func treeEntries(tree *proto.Tree) []treeEntry {
+	entries := []treeEntry{}
+
+	switch {
+	case tree.Repository != nil:
+		entries = append(entries, treeEntry{
+			kind: `repository`,
+			id:   tree.Repository.ID,
+			name: tree.Repository.Name,
+		})
+		if tree.Repository.Members != nil {
+			for i := range *tree.Repository.Members {
+				entries = appendBucketEntries(entries, 1,
+					tree.Repository.ID, &(*tree.Repository.Members)[i])
+			}
+		}
+	case tree.Bucket != nil:
+		entries = appendBucketEntries(entries, 0, ``, tree.Bucket)
+	case tree.Group != nil:
+		entries = appendGroupEntries(entries, 0, ``, tree.Group)
+	case tree.Cluster != nil:
+		entries = appendClusterEntries(entries, 0, ``, tree.Cluster)
+	case tree.Node != nil:
+		entries = append(entries, treeEntry{
+			kind: `node`,
+			id:   tree.Node.ID,
+			name: tree.Node.Name,
+		})
+	}
+	return entries
+}
+
+func appendBucketEntries(entries []treeEntry, depth int, parentID string,
+	b *proto.Bucket) []treeEntry {
+	entries = append(entries, treeEntry{
+		depth:    depth,
+		kind:     `bucket`,
+		id:       b.ID,
+		name:     b.Name,
+		parentID: parentID,
+	})
+	return appendMemberEntries(entries, depth+1, b.ID,
+		b.MemberGroups, b.MemberClusters, b.MemberNodes)
+}
+
+func appendGroupEntries(entries []treeEntry, depth int, parentID string,
+	g *proto.Group) []treeEntry {
+	entries = append(entries, treeEntry{
+		depth:    depth,
+		kind:     `group`,
+		id:       g.ID,
+		name:     g.Name,
+		parentID: parentID,
+	})
+	return appendMemberEntries(entries, depth+1, g.ID,
+		g.MemberGroups, g.MemberClusters, g.MemberNodes)
+}
+
+func appendClusterEntries(entries []treeEntry, depth int, parentID string,
+	c *proto.Cluster) []treeEntry {
+	entries = append(entries, treeEntry{
+		depth:    depth,
+		kind:     `cluster`,
+		id:       c.ID,
+		name:     c.Name,
+		parentID: parentID,
+	})
+	return appendMemberEntries(entries, depth+1, c.ID, nil, nil, c.Members)
+}
+
+func appendMemberEntries(entries []treeEntry, depth int, parentID string,
+	groups *[]proto.Group, clusters *[]proto.Cluster,
+	nodes *[]proto.Node) []treeEntry {
+	if groups != nil {
+		for i := range *groups {
+			entries = appendGroupEntries(entries, depth, parentID,
+				&(*groups)[i])
+		}
+	}
+	if clusters != nil {
+		for i := range *clusters {
+			entries = appendClusterEntries(entries, depth, parentID,
+				&(*clusters)[i])
+		}
+	}
+	if nodes != nil {
+		for _, n := range *nodes {
+			entries = append(entries, treeEntry{
+				depth:    depth,
+				kind:     `node`,
+				id:       n.ID,
+				name:     n.Name,
+				parentID: parentID,
+			})
+		}
+	}
+	return entries
+}
+
+// printTree prints tree as indented list of its objects
+func printTree(tree *proto.Tree) error {
+	for _, e := range treeEntries(tree) {
+		fmt.Printf("%s%s %s (%s)\n", strings.Repeat(`  `, e.depth),
+			e.kind, e.name, e.id)
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,61 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// printYAML renders the result in data as YAML document
+func printYAML(data []byte) error {
+	var doc interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(yamlNumbers(doc))
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+// yamlNumbers replaces the json.Number values inside doc with
+// integers or floats, since YAML would otherwise render them as
+// quoted strings
+func yamlNumbers(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = yamlNumbers(v[key])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = yamlNumbers(v[i])
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+	return doc
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
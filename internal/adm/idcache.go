@@ -0,0 +1,59 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"regexp"
+	"time"
+)
+
+// reCachedID matches the UUIDs in a request path
+var reCachedID = regexp.MustCompile(
+	`[[:xdigit:]]{8}-[[:xdigit:]]{4}-[[:xdigit:]]{4}-[[:xdigit:]]{4}-[[:xdigit:]]{12}`,
+)
+
+// cachedLookup returns the ID of the object of type kind identified
+// by key from the local ID cache. If it is not cached, the ID is
+// looked up via lookup and recorded in the cache.
+func cachedLookup(kind, key string, lookup func() (string, error)) (string, error) {
+	if cache != nil && cacheTTL > 0 {
+		if id, err := cache.CachedID(kind, key); err == nil {
+			return id, nil
+		}
+	}
+
+	id, err := lookup()
+	if err != nil {
+		return ``, err
+	}
+	if cache != nil && cacheTTL > 0 {
+		// a failure to cache the ID does not fail the lookup
+		cache.CacheID(kind, key, id, cacheTTL)
+	}
+	return id, nil
+}
+
+// invalidateCachedIDs removes all IDs contained in s from the local
+// ID cache. It is used for the paths of requests that returned
+// 404/NotFound, since they may have been built with stale IDs.
+func invalidateCachedIDs(s string) {
+	if cache == nil || cacheTTL == 0 {
+		return
+	}
+	for _, id := range reCachedID.FindAllString(s, -1) {
+		cache.InvalidateID(id)
+	}
+}
+
+// ConfigureIDCache sets the lifetime of entries in the local ID
+// cache. A ttl of 0 disables the ID cache.
+func ConfigureIDCache(ttl time.Duration) {
+	cacheTTL = ttl
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
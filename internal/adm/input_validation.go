@@ -15,6 +15,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mjolnir42/soma/lib/proto"
@@ -339,6 +340,11 @@ func ValidateCheckConstraints(repoID, teamID string,
 	valid := []proto.CheckConfigConstraint{}
 
 	for _, prop := range constraints {
+		if !sliceContainsString(prop.GetOperator(), proto.ConstraintOperators) {
+			return nil, fmt.Errorf("Unknown constraint operator: %s",
+				prop.Operator)
+		}
+
 		switch prop.ConstraintType {
 		case `native`:
 			if _, err := fetchObjList(
@@ -383,6 +389,8 @@ func ValidateCheckConstraints(repoID, teamID string,
 			}
 			valid = append(valid, proto.CheckConfigConstraint{
 				ConstraintType: prop.ConstraintType,
+				Operator:       prop.Operator,
+				Group:          prop.Group,
 				Oncall:         &oncall,
 			})
 
@@ -397,6 +405,8 @@ func ValidateCheckConstraints(repoID, teamID string,
 			service.TeamID = teamID
 			valid = append(valid, proto.CheckConfigConstraint{
 				ConstraintType: prop.ConstraintType,
+				Operator:       prop.Operator,
+				Group:          prop.Group,
 				Service:        &service,
 			})
 
@@ -411,6 +421,8 @@ func ValidateCheckConstraints(repoID, teamID string,
 			custom.Value = prop.Custom.Value
 			valid = append(valid, proto.CheckConfigConstraint{
 				ConstraintType: prop.ConstraintType,
+				Operator:       prop.Operator,
+				Group:          prop.Group,
 				Custom:         &custom,
 			})
 		}
@@ -494,6 +506,21 @@ func ValidateNotUUID(s string) error {
 	return nil
 }
 
+// ParseTimestamp parses s as either RFC3339 timestamp or as duration
+// relative to the current time, ie. 8h. It returns the timestamp in
+// RFC3339 format.
+func ParseTimestamp(s string) (string, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return ``, fmt.Errorf("%s is neither an RFC3339 timestamp"+
+			" nor a duration", s)
+	}
+	return time.Now().Add(d).UTC().Format(time.RFC3339), nil
+}
+
 // fetchObjList is a helper for ValidateUnit and ValidateProvider
 func fetchObjList(path string) (*proto.Result, error) {
 	var (
@@ -507,6 +534,10 @@ func fetchObjList(path string) (*proto.Result, error) {
 	if err = decodeResponse(resp, res); err != nil {
 		return nil, err
 	}
+	if res.StatusCode == 404 {
+		// the path may have been built from stale cached IDs
+		invalidateCachedIDs(path)
+	}
 	if err = checkApplicationError(res); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,119 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// WatchJob prints the events of job jobID while the server streams
+// them. With --json every event is printed as one line of JSON. The
+// returned error is set if the job failed or the stream ended before
+// the job finished.
+func WatchJob(c *cli.Context, jobID string) error {
+	resp, err := client.R().
+		SetDoNotParseResponse(true).
+		SetHeader(`Accept`, `text/event-stream`).
+		Get(fmt.Sprintf("/job/byID/%s/_events", jobID))
+	if err != nil {
+		return err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.StatusCode() >= 300 {
+		detail, _ := ioutil.ReadAll(body)
+		return fmt.Errorf("Request error: %s, %s", resp.Status(), string(detail))
+	}
+
+	var (
+		evType, data string
+		sequence     uint64
+		finished     *proto.JobEvent
+	)
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, `:`):
+			// comments keep the connection open
+		case strings.HasPrefix(line, `event:`):
+			evType = strings.TrimSpace(strings.TrimPrefix(line, `event:`))
+		case strings.HasPrefix(line, `data:`):
+			data += strings.TrimSpace(strings.TrimPrefix(line, `data:`))
+		case line == ``:
+			// an empty line dispatches the event
+			if data == `` {
+				continue
+			}
+			if evType == `error` {
+				return fmt.Errorf("Watching job %s aborted: %s", jobID, data)
+			}
+			ev := proto.JobEvent{}
+			if err = json.Unmarshal([]byte(data), &ev); err != nil {
+				return err
+			}
+			if c.GlobalBool(`json`) {
+				fmt.Println(data)
+			} else {
+				if ev.Sequence > sequence+1 {
+					fmt.Printf("%s ... %d events not retained by the server\n",
+						strings.Repeat(` `, len(ev.OccurredAt)),
+						ev.Sequence-sequence-1)
+				}
+				printJobEvent(ev)
+			}
+			sequence = ev.Sequence
+			if ev.Type == proto.JobEventFinished {
+				finished = &ev
+			}
+			evType, data = ``, ``
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	switch {
+	case finished == nil:
+		return fmt.Errorf("Event stream for job %s ended before the job finished", jobID)
+	case finished.Result != `success`:
+		return fmt.Errorf("Job %s failed", jobID)
+	}
+	return nil
+}
+
+// printJobEvent prints a single job event as progress line. Actions
+// are shown like the changes of a dry run.
+func printJobEvent(ev proto.JobEvent) {
+	switch ev.Type {
+	case proto.JobEventAction:
+		if ev.Action == nil {
+			return
+		}
+		fmt.Printf("%s   %s %s\n", ev.OccurredAt, planSymbol(ev.Action.Action),
+			planLine(*ev.Action))
+	case proto.JobEventFinished:
+		fmt.Printf("%s %s: %s\n", ev.OccurredAt, ev.Type, ev.Result)
+		for _, e := range ev.Errors {
+			fmt.Printf("%s   error: %s\n", ev.OccurredAt, e)
+		}
+	default:
+		fmt.Printf("%s %s\n", ev.OccurredAt, ev.Type)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,105 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/mjolnir42/soma/lib/proto"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// FetchLayout returns the exported layout of repository
+// repositoryID, including the IDs of all objects
+func FetchLayout(repositoryID string) (*proto.Layout, error) {
+	res, err := fetchObjList(fmt.Sprintf("/repository/%s/export",
+		url.QueryEscape(repositoryID)))
+	if err != nil {
+		return nil, err
+	}
+	if res.Layout == nil {
+		return nil, fmt.Errorf(`Server reply contained no layout`)
+	}
+	res.Layout.Sort()
+	return res.Layout, nil
+}
+
+// ReadLayout reads a layout from the YAML or JSON document at path.
+// IDs contained in the document are discarded.
+func ReadLayout(path string) (*proto.Layout, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &proto.Layout{}
+	// JSON documents are valid YAML
+	if err = yaml.UnmarshalStrict(data, layout); err != nil {
+		return nil, fmt.Errorf("Invalid layout file %s: %s",
+			path, err.Error())
+	}
+	if layout.Repository == `` {
+		return nil, fmt.Errorf("Layout file %s names no repository",
+			path)
+	}
+	layout.ClearIDs()
+	for i := range layout.CheckConfigs {
+		for j := range layout.CheckConfigs[i].Constraints {
+			constr := &layout.CheckConfigs[i].Constraints[j]
+			// exported layouts omit the default operator
+			if constr.Operator == proto.ConstraintOpEqual {
+				constr.Operator = ``
+			}
+		}
+	}
+	layout.Sort()
+	return layout, nil
+}
+
+// FormatLayout serializes the layout as YAML document, or as JSON
+// document if asJSON is set
+func FormatLayout(layout *proto.Layout, asJSON bool) ([]byte, error) {
+	if asJSON {
+		data, err := json.MarshalIndent(layout, ``, `  `)
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	return yaml.Marshal(layout)
+}
+
+// WaitJob blocks until job jobID has been processed and returns an
+// error if the job did not succeed
+func WaitJob(jobID string) error {
+	if _, err := GetReq(fmt.Sprintf("/job/byID/%s/_processed",
+		url.QueryEscape(jobID))); err != nil && err != io.EOF {
+		return err
+	}
+
+	res, err := fetchObjList(fmt.Sprintf("/job/byID/%s",
+		url.QueryEscape(jobID)))
+	if err != nil {
+		return err
+	}
+	if res.Jobs == nil || len(*res.Jobs) == 0 {
+		return fmt.Errorf("Job %s not found", jobID)
+	}
+	job := (*res.Jobs)[0]
+	if job.Result != `success` {
+		return fmt.Errorf("Job %s (%s) %s: %s",
+			jobID, job.Type, job.Result, job.Error)
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
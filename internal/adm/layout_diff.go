@@ -0,0 +1,473 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"fmt"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// LayoutObject is a single tree object of a flattened layout
+type LayoutObject struct {
+	Type        string
+	ID          string
+	Name        string
+	Bucket      string
+	Environment string
+	ParentType  string
+	Parent      string
+	Properties  []proto.LayoutProperty
+}
+
+// Key returns the identifier of the object within a repository.
+// Groups and clusters are only unique within their bucket.
+func (o *LayoutObject) Key() string {
+	return layoutKey(o.Type, o.Name, o.Bucket)
+}
+
+// String returns the description of the object
+func (o *LayoutObject) String() string {
+	switch o.Type {
+	case proto.EntityGroup, proto.EntityCluster, proto.EntityNode:
+		return fmt.Sprintf("%s %s in bucket %s", o.Type, o.Name, o.Bucket)
+	}
+	return fmt.Sprintf("%s %s", o.Type, o.Name)
+}
+
+// FlatLayout is a layout as list of its objects, parents are listed
+// before their children
+type FlatLayout struct {
+	Objects []*LayoutObject
+	index   map[string]*LayoutObject
+}
+
+// Lookup returns the object of type objectType with name. Bucket is
+// only used for groups and clusters. Returns nil if the layout has no
+// such object.
+func (f *FlatLayout) Lookup(objectType, name, bucket string) *LayoutObject {
+	return f.index[layoutKey(objectType, name, bucket)]
+}
+
+// LayoutPropertyChange is a property change on an object
+type LayoutPropertyChange struct {
+	Object   *LayoutObject
+	Property proto.LayoutProperty
+}
+
+// String returns the description of the property change
+func (p LayoutPropertyChange) String() string {
+	s := fmt.Sprintf("property %s:%s view %s", p.Property.Type,
+		p.Property.Name, p.Property.View)
+	if p.Property.Value != `` {
+		s += fmt.Sprintf(" value %s", p.Property.Value)
+	}
+	return fmt.Sprintf("%s on %s", s, p.Object.String())
+}
+
+// LayoutDiff is the list of changes required to turn a repository
+// into the desired layout. Objects and properties that are not yet
+// created carry no IDs.
+type LayoutDiff struct {
+	Create          []*LayoutObject
+	Move            []*LayoutObject
+	Remove          []*LayoutObject
+	PropertyCreate  []LayoutPropertyChange
+	PropertyUpdate  []LayoutPropertyChange
+	PropertyDestroy []LayoutPropertyChange
+	CheckCreate     []proto.LayoutCheckConfig
+	CheckUpdate     []proto.LayoutCheckConfig
+	CheckDestroy    []proto.LayoutCheckConfig
+}
+
+// FlattenLayout converts a layout into a FlatLayout. It returns an
+// error if an object is contained multiple times.
+func FlattenLayout(l *proto.Layout) (*FlatLayout, error) {
+	f := &FlatLayout{
+		Objects: []*LayoutObject{},
+		index:   map[string]*LayoutObject{},
+	}
+
+	if err := f.add(&LayoutObject{
+		Type:       proto.EntityRepository,
+		ID:         l.ID,
+		Name:       l.Repository,
+		Properties: l.Properties,
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, b := range l.Buckets {
+		if err := f.add(&LayoutObject{
+			Type:        proto.EntityBucket,
+			ID:          b.ID,
+			Name:        b.Name,
+			Environment: b.Environment,
+			ParentType:  proto.EntityRepository,
+			Parent:      l.Repository,
+			Properties:  b.Properties,
+		}); err != nil {
+			return nil, err
+		}
+		if err := f.addMembers(b.Name, proto.EntityBucket, b.Name,
+			b.Groups, b.Clusters, b.Nodes); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// add appends o to the FlatLayout
+func (f *FlatLayout) add(o *LayoutObject) error {
+	if _, ok := f.index[o.Key()]; ok {
+		return fmt.Errorf("Layout contains %s multiple times", o.String())
+	}
+	f.index[o.Key()] = o
+	f.Objects = append(f.Objects, o)
+	return nil
+}
+
+// addMembers appends the member objects of a bucket or group
+func (f *FlatLayout) addMembers(bucket, parentType, parent string,
+	groups []proto.LayoutGroup, clusters []proto.LayoutCluster,
+	nodes []proto.LayoutNode) error {
+	for _, g := range groups {
+		if err := f.add(&LayoutObject{
+			Type:       proto.EntityGroup,
+			ID:         g.ID,
+			Name:       g.Name,
+			Bucket:     bucket,
+			ParentType: parentType,
+			Parent:     parent,
+			Properties: g.Properties,
+		}); err != nil {
+			return err
+		}
+		if err := f.addMembers(bucket, proto.EntityGroup, g.Name,
+			g.Groups, g.Clusters, g.Nodes); err != nil {
+			return err
+		}
+	}
+	for _, c := range clusters {
+		if err := f.add(&LayoutObject{
+			Type:       proto.EntityCluster,
+			ID:         c.ID,
+			Name:       c.Name,
+			Bucket:     bucket,
+			ParentType: parentType,
+			Parent:     parent,
+			Properties: c.Properties,
+		}); err != nil {
+			return err
+		}
+		if err := f.addMembers(bucket, proto.EntityCluster, c.Name,
+			nil, nil, c.Nodes); err != nil {
+			return err
+		}
+	}
+	for _, n := range nodes {
+		if err := f.add(&LayoutObject{
+			Type:       proto.EntityNode,
+			ID:         n.ID,
+			Name:       n.Name,
+			Bucket:     bucket,
+			ParentType: parentType,
+			Parent:     parent,
+			Properties: n.Properties,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffLayout computes the changes required to turn the live layout of
+// a repository into the desired layout
+func DiffLayout(live, desired *proto.Layout) (*LayoutDiff, error) {
+	var (
+		err                    error
+		liveFlat, desiredFlat  *FlatLayout
+		liveChecks, wantChecks map[string]proto.LayoutCheckConfig
+	)
+	if live.Repository != desired.Repository {
+		return nil, fmt.Errorf(
+			"Layout is for repository %s, not repository %s",
+			desired.Repository, live.Repository)
+	}
+	if liveFlat, err = FlattenLayout(live); err != nil {
+		return nil, err
+	}
+	if desiredFlat, err = FlattenLayout(desired); err != nil {
+		return nil, err
+	}
+
+	d := &LayoutDiff{}
+	for _, o := range desiredFlat.Objects {
+		current, ok := liveFlat.index[o.Key()]
+		if !ok {
+			d.Create = append(d.Create, o)
+			for _, prop := range o.Properties {
+				d.PropertyCreate = append(d.PropertyCreate,
+					LayoutPropertyChange{Object: o, Property: prop})
+			}
+			continue
+		}
+		o.ID = current.ID
+
+		switch {
+		case o.Type == proto.EntityBucket &&
+			o.Environment != current.Environment:
+			return nil, fmt.Errorf(
+				"Bucket %s is in environment %s, environments of"+
+					" existing buckets can not be changed",
+				o.Name, current.Environment)
+		case o.Type == proto.EntityNode && o.Bucket != current.Bucket:
+			return nil, fmt.Errorf(
+				"Node %s is assigned to bucket %s and has to be"+
+					" unassigned before it can be assigned to bucket %s",
+				o.Name, current.Bucket, o.Bucket)
+		case o.ParentType != current.ParentType || o.Parent != current.Parent:
+			d.Move = append(d.Move, o)
+		}
+		d.diffProperties(current, o)
+	}
+
+	// walk the live layout backwards to remove children before their
+	// parents
+	for i := len(liveFlat.Objects) - 1; i >= 0; i-- {
+		o := liveFlat.Objects[i]
+		if _, ok := desiredFlat.index[o.Key()]; !ok {
+			d.Remove = append(d.Remove, o)
+		}
+	}
+
+	liveChecks = make(map[string]proto.LayoutCheckConfig)
+	for _, c := range live.CheckConfigs {
+		liveChecks[c.Name] = c
+	}
+	wantChecks = make(map[string]proto.LayoutCheckConfig)
+	for _, c := range desired.CheckConfigs {
+		if _, ok := wantChecks[c.Name]; ok {
+			return nil, fmt.Errorf(
+				"Layout contains check configuration %s multiple times",
+				c.Name)
+		}
+		if desiredFlat.Lookup(c.ObjectType, c.Object, c.Bucket) == nil {
+			return nil, fmt.Errorf(
+				"Check configuration %s references %s %s, which is"+
+					" not part of the layout",
+				c.Name, c.ObjectType, c.Object)
+		}
+		wantChecks[c.Name] = c
+
+		current, ok := liveChecks[c.Name]
+		switch {
+		case !ok:
+			d.CheckCreate = append(d.CheckCreate, c)
+		case !checkConfigSameTarget(current, c):
+			// capability, object and external ID of a check
+			// configuration can not be updated
+			d.CheckDestroy = append(d.CheckDestroy, current)
+			d.CheckCreate = append(d.CheckCreate, c)
+		case !checkConfigEqual(current, c):
+			c.ID = current.ID
+			d.CheckUpdate = append(d.CheckUpdate, c)
+		}
+	}
+	for _, c := range live.CheckConfigs {
+		if _, ok := wantChecks[c.Name]; !ok {
+			d.CheckDestroy = append(d.CheckDestroy, c)
+		}
+	}
+	return d, nil
+}
+
+// diffProperties records the property changes between the live and
+// the desired version of an object
+func (d *LayoutDiff) diffProperties(current, desired *LayoutObject) {
+	have := make(map[string]proto.LayoutProperty)
+	for _, prop := range current.Properties {
+		have[propertyKey(prop)] = prop
+	}
+	want := make(map[string]struct{})
+
+	for _, prop := range desired.Properties {
+		want[propertyKey(prop)] = struct{}{}
+		old, ok := have[propertyKey(prop)]
+		switch {
+		case !ok:
+			d.PropertyCreate = append(d.PropertyCreate,
+				LayoutPropertyChange{Object: desired, Property: prop})
+		case old.Value != prop.Value ||
+			old.Inheritance != prop.Inheritance ||
+			old.ChildrenOnly != prop.ChildrenOnly:
+			prop.SourceInstanceID = old.SourceInstanceID
+			d.PropertyUpdate = append(d.PropertyUpdate,
+				LayoutPropertyChange{Object: desired, Property: prop})
+		}
+	}
+	for _, prop := range current.Properties {
+		if _, ok := want[propertyKey(prop)]; !ok {
+			d.PropertyDestroy = append(d.PropertyDestroy,
+				LayoutPropertyChange{Object: current, Property: prop})
+		}
+	}
+}
+
+// Empty returns true if the diff contains no changes
+func (d *LayoutDiff) Empty() bool {
+	return len(d.Create)+len(d.Move)+len(d.Remove)+
+		len(d.PropertyCreate)+len(d.PropertyUpdate)+
+		len(d.PropertyDestroy)+len(d.CheckCreate)+
+		len(d.CheckUpdate)+len(d.CheckDestroy) == 0
+}
+
+// Print writes the list of changes in the same format that is used
+// for dry runs
+func (d *LayoutDiff) Print() {
+	if d.Empty() {
+		fmt.Println(`No changes.`)
+		return
+	}
+
+	for _, o := range d.Create {
+		switch o.Type {
+		case proto.EntityBucket:
+			fmt.Printf("+ create %s environment %s\n", o.String(),
+				o.Environment)
+		case proto.EntityNode:
+			fmt.Printf("+ assign %s%s\n", o.String(), layoutParent(o))
+		default:
+			fmt.Printf("+ create %s%s\n", o.String(), layoutParent(o))
+		}
+	}
+	for _, o := range d.Move {
+		fmt.Printf("~ move %s to %s %s\n", o.String(), o.ParentType,
+			o.Parent)
+	}
+	for _, p := range d.PropertyDestroy {
+		fmt.Printf("- %s\n", p.String())
+	}
+	for _, p := range d.PropertyCreate {
+		fmt.Printf("+ %s\n", p.String())
+	}
+	for _, p := range d.PropertyUpdate {
+		fmt.Printf("~ %s\n", p.String())
+	}
+	for _, c := range d.CheckDestroy {
+		fmt.Printf("- check %s on %s %s\n", c.Name, c.ObjectType,
+			c.Object)
+	}
+	for _, c := range d.CheckCreate {
+		fmt.Printf("+ check %s on %s %s\n", c.Name, c.ObjectType,
+			c.Object)
+	}
+	for _, c := range d.CheckUpdate {
+		fmt.Printf("~ check %s on %s %s\n", c.Name, c.ObjectType,
+			c.Object)
+	}
+	for _, o := range d.Remove {
+		switch o.Type {
+		case proto.EntityNode:
+			fmt.Printf("- unassign %s\n", o.String())
+		default:
+			fmt.Printf("- destroy %s\n", o.String())
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to add, %d to change, %d to remove.\n",
+		len(d.Create)+len(d.PropertyCreate)+len(d.CheckCreate),
+		len(d.Move)+len(d.PropertyUpdate)+len(d.CheckUpdate),
+		len(d.Remove)+len(d.PropertyDestroy)+len(d.CheckDestroy),
+	)
+}
+
+// layoutKey returns the key of an object within a FlatLayout
+func layoutKey(objectType, name, bucket string) string {
+	switch objectType {
+	case proto.EntityGroup, proto.EntityCluster:
+		return fmt.Sprintf("%s/%s/%s", objectType, bucket, name)
+	}
+	return fmt.Sprintf("%s/%s", objectType, name)
+}
+
+// layoutParent returns the description of the parent of o, if it is
+// not the bucket
+func layoutParent(o *LayoutObject) string {
+	if o.ParentType == proto.EntityBucket {
+		return ``
+	}
+	return fmt.Sprintf(", member of %s %s", o.ParentType, o.Parent)
+}
+
+// propertyKey returns the identifier of a property on an object
+func propertyKey(p proto.LayoutProperty) string {
+	return fmt.Sprintf("%s/%s/%s", p.Type, p.Name, p.View)
+}
+
+// checkConfigSameTarget returns true if a and b are the same
+// capability with the same external ID on the same object
+func checkConfigSameTarget(a, b proto.LayoutCheckConfig) bool {
+	return a.Capability == b.Capability &&
+		a.ObjectType == b.ObjectType &&
+		a.Object == b.Object &&
+		a.Bucket == b.Bucket &&
+		a.ExternalID == b.ExternalID
+}
+
+// checkConfigEqual returns true if a and b are equal, ignoring their
+// IDs. Thresholds and constraints are compared regardless of their
+// order, since the exported layout lists them in database order.
+func checkConfigEqual(a, b proto.LayoutCheckConfig) bool {
+	return checkConfigSameTarget(a, b) &&
+		a.Interval == b.Interval &&
+		a.Inheritance == b.Inheritance &&
+		a.ChildrenOnly == b.ChildrenOnly &&
+		thresholdsEqual(a.Thresholds, b.Thresholds) &&
+		constraintsEqual(a.Constraints, b.Constraints)
+}
+
+// thresholdsEqual returns true if a and b contain the same
+// thresholds in any order
+func thresholdsEqual(a, b []proto.LayoutThreshold) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[proto.LayoutThreshold]int, len(a))
+	for _, t := range a {
+		count[t]++
+	}
+	for _, t := range b {
+		if count[t] == 0 {
+			return false
+		}
+		count[t]--
+	}
+	return true
+}
+
+// constraintsEqual returns true if a and b contain the same
+// constraints in any order
+func constraintsEqual(a, b []proto.LayoutConstraint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[proto.LayoutConstraint]int, len(a))
+	for _, c := range a {
+		count[c]++
+	}
+	for _, c := range b {
+		if count[c] == 0 {
+			return false
+		}
+		count[c]--
+	}
+	return true
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
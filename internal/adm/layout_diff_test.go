@@ -0,0 +1,342 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"testing"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestFlattenLayout(t *testing.T) {
+	f, err := FlattenLayout(testLayout())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		key, parentType, parent string
+	}{
+		{`repository/example`, ``, ``},
+		{`bucket/example_live`, proto.EntityRepository, `example`},
+		{`group/example_live/web`, proto.EntityBucket, `example_live`},
+		{`group/example_live/frontend`, proto.EntityGroup, `web`},
+		{`node/web01`, proto.EntityGroup, `frontend`},
+		{`cluster/example_live/db`, proto.EntityGroup, `web`},
+		{`node/db01`, proto.EntityCluster, `db`},
+		{`node/mail01`, proto.EntityBucket, `example_live`},
+		{`bucket/example_test`, proto.EntityRepository, `example`},
+		{`group/example_test/web`, proto.EntityBucket, `example_test`},
+	}
+	if len(f.Objects) != len(expected) {
+		t.Fatalf("Expected %d objects, got %d", len(expected),
+			len(f.Objects))
+	}
+	for i, e := range expected {
+		o := f.Objects[i]
+		if o.Key() != e.key || o.ParentType != e.parentType ||
+			o.Parent != e.parent {
+			t.Errorf("Object %d: expected %s in %s %s, got %s in %s %s",
+				i, e.key, e.parentType, e.parent,
+				o.Key(), o.ParentType, o.Parent)
+		}
+	}
+
+	if o := f.Lookup(proto.EntityGroup, `web`, `example_test`); o == nil ||
+		o.Bucket != `example_test` {
+		t.Errorf(`Lookup did not find group web in bucket example_test`)
+	}
+	if o := f.Lookup(proto.EntityNode, `web01`, ``); o == nil ||
+		o.Bucket != `example_live` {
+		t.Errorf(`Lookup did not find node web01`)
+	}
+	if f.Lookup(proto.EntityCluster, `db`, `example_test`) != nil {
+		t.Errorf(`Lookup found cluster db in the wrong bucket`)
+	}
+}
+
+func TestFlattenLayoutDuplicate(t *testing.T) {
+	l := testLayout()
+	l.Buckets[1].Nodes = []proto.LayoutNode{{Name: `web01`}}
+
+	if _, err := FlattenLayout(l); err == nil {
+		t.Errorf(`Accepted layout with node web01 in two buckets`)
+	}
+}
+
+func TestDiffLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(l *proto.Layout)
+		// expected number of Create, Move, Remove, PropertyCreate,
+		// PropertyUpdate, PropertyDestroy, CheckCreate, CheckUpdate
+		// and CheckDestroy entries
+		counts [9]int
+	}{
+		{
+			name:   `unchanged`,
+			modify: func(l *proto.Layout) {},
+		},
+		{
+			name: `reordered thresholds and constraints`,
+			modify: func(l *proto.Layout) {
+				c := &l.CheckConfigs[0]
+				c.Thresholds[0], c.Thresholds[1] =
+					c.Thresholds[1], c.Thresholds[0]
+				c.Constraints[0], c.Constraints[1] =
+					c.Constraints[1], c.Constraints[0]
+			},
+		},
+		{
+			name: `changed threshold`,
+			modify: func(l *proto.Layout) {
+				l.CheckConfigs[0].Thresholds[1].Value = 95
+			},
+			counts: [9]int{0, 0, 0, 0, 0, 0, 0, 1, 0},
+		},
+		{
+			name: `duplicated constraint`,
+			modify: func(l *proto.Layout) {
+				c := &l.CheckConfigs[0]
+				c.Constraints[1] = c.Constraints[0]
+			},
+			counts: [9]int{0, 0, 0, 0, 0, 0, 0, 1, 0},
+		},
+		{
+			name: `changed capability`,
+			modify: func(l *proto.Layout) {
+				l.CheckConfigs[0].Capability = `example.cpu.load`
+			},
+			counts: [9]int{0, 0, 0, 0, 0, 0, 1, 0, 1},
+		},
+		{
+			name: `removed check`,
+			modify: func(l *proto.Layout) {
+				l.CheckConfigs = nil
+			},
+			counts: [9]int{0, 0, 0, 0, 0, 0, 0, 0, 1},
+		},
+		{
+			name: `new group with property`,
+			modify: func(l *proto.Layout) {
+				l.Buckets[1].Groups = append(l.Buckets[1].Groups,
+					proto.LayoutGroup{
+						Name:       `mail`,
+						Properties: []proto.LayoutProperty{testProperty()},
+					})
+			},
+			counts: [9]int{1, 0, 0, 1, 0, 0, 0, 0, 0},
+		},
+		{
+			name: `moved node`,
+			modify: func(l *proto.Layout) {
+				b := &l.Buckets[0]
+				b.Groups[0].Nodes = append(b.Groups[0].Nodes, b.Nodes[0])
+				b.Nodes = nil
+			},
+			counts: [9]int{0, 1, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: `removed cluster`,
+			modify: func(l *proto.Layout) {
+				l.Buckets[0].Groups[0].Clusters = nil
+			},
+			counts: [9]int{0, 0, 2, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: `changed properties`,
+			modify: func(l *proto.Layout) {
+				l.Properties[0].Value = `false`
+				l.Buckets[0].Properties = nil
+				l.Buckets[1].Properties = []proto.LayoutProperty{
+					testProperty(),
+				}
+			},
+			counts: [9]int{0, 0, 0, 1, 1, 1, 0, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		desired := testLayout()
+		test.modify(desired)
+		d, err := DiffLayout(testLayout(), desired)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		counts := [9]int{len(d.Create), len(d.Move), len(d.Remove),
+			len(d.PropertyCreate), len(d.PropertyUpdate),
+			len(d.PropertyDestroy), len(d.CheckCreate),
+			len(d.CheckUpdate), len(d.CheckDestroy)}
+		if counts != test.counts {
+			t.Errorf("%s: expected changes %v, got %v",
+				test.name, test.counts, counts)
+		}
+		if d.Empty() != (test.counts == [9]int{}) {
+			t.Errorf("%s: Empty() returned %t", test.name, d.Empty())
+		}
+	}
+}
+
+func TestDiffLayoutIDs(t *testing.T) {
+	live := testLayout()
+	desired := testLayout()
+	desired.CheckConfigs[0].ID = ``
+	desired.CheckConfigs[0].Interval = 120
+	desired.Buckets[0].Groups[0].Clusters[0].Nodes = nil
+	desired.Buckets[0].Groups[0].Nodes = []proto.LayoutNode{{Name: `db01`}}
+
+	d, err := DiffLayout(live, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.CheckUpdate) != 1 || d.CheckUpdate[0].ID != `check-1` {
+		t.Errorf(`Check update does not carry the live check ID`)
+	}
+	if len(d.Move) != 1 || d.Move[0].ID != `node-db01` ||
+		d.Move[0].ParentType != proto.EntityGroup {
+		t.Errorf(`Move does not carry the live node ID and new parent`)
+	}
+}
+
+func TestDiffLayoutRemoveOrder(t *testing.T) {
+	desired := testLayout()
+	desired.Buckets[0].Groups = nil
+	desired.CheckConfigs = nil
+
+	d, err := DiffLayout(testLayout(), desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// children are removed before their parents
+	seen := map[string]bool{}
+	for _, o := range d.Remove {
+		if o.ParentType == proto.EntityGroup ||
+			o.ParentType == proto.EntityCluster {
+			if seen[o.ParentType+`/`+o.Parent] {
+				t.Errorf("%s removed after its parent", o.String())
+			}
+		}
+		seen[o.Type+`/`+o.Name] = true
+	}
+	if len(d.Remove) != 5 {
+		t.Errorf("Expected 5 removals, got %d", len(d.Remove))
+	}
+}
+
+func TestDiffLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(l *proto.Layout)
+	}{
+		{`other repository`, func(l *proto.Layout) {
+			l.Repository = `other`
+		}},
+		{`changed environment`, func(l *proto.Layout) {
+			l.Buckets[0].Environment = `qa`
+		}},
+		{`node in other bucket`, func(l *proto.Layout) {
+			l.Buckets[1].Nodes = l.Buckets[0].Nodes
+			l.Buckets[0].Nodes = nil
+		}},
+		{`duplicate check`, func(l *proto.Layout) {
+			l.CheckConfigs = append(l.CheckConfigs, l.CheckConfigs[0])
+		}},
+		{`check on unknown object`, func(l *proto.Layout) {
+			l.CheckConfigs[0].Object = `unknown`
+		}},
+		{`duplicate object`, func(l *proto.Layout) {
+			l.Buckets[0].Groups = append(l.Buckets[0].Groups,
+				proto.LayoutGroup{Name: `web`})
+		}},
+	}
+
+	for _, test := range tests {
+		desired := testLayout()
+		test.modify(desired)
+		if _, err := DiffLayout(testLayout(), desired); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
+
+// testLayout returns a layout with nested groups, a cluster and a
+// check configuration
+func testLayout() *proto.Layout {
+	return &proto.Layout{
+		ID:         `repo-1`,
+		Repository: `example`,
+		Properties: []proto.LayoutProperty{{
+			Type:        `system`,
+			Name:        `disable_all_monitoring`,
+			Value:       `true`,
+			View:        `any`,
+			Inheritance: true,
+		}},
+		Buckets: []proto.LayoutBucket{
+			{
+				ID:          `bucket-1`,
+				Name:        `example_live`,
+				Environment: `live`,
+				Properties:  []proto.LayoutProperty{testProperty()},
+				Groups: []proto.LayoutGroup{{
+					ID:   `group-web`,
+					Name: `web`,
+					Groups: []proto.LayoutGroup{{
+						ID:    `group-frontend`,
+						Name:  `frontend`,
+						Nodes: []proto.LayoutNode{{ID: `node-web01`, Name: `web01`}},
+					}},
+					Clusters: []proto.LayoutCluster{{
+						ID:    `cluster-db`,
+						Name:  `db`,
+						Nodes: []proto.LayoutNode{{ID: `node-db01`, Name: `db01`}},
+					}},
+				}},
+				Nodes: []proto.LayoutNode{{ID: `node-mail01`, Name: `mail01`}},
+			},
+			{
+				ID:          `bucket-2`,
+				Name:        `example_test`,
+				Environment: `testing`,
+				Groups:      []proto.LayoutGroup{{ID: `group-web-2`, Name: `web`}},
+			},
+		},
+		CheckConfigs: []proto.LayoutCheckConfig{{
+			ID:          `check-1`,
+			Name:        `cpu`,
+			Capability:  `example.cpu.usage`,
+			ObjectType:  proto.EntityGroup,
+			Object:      `web`,
+			Bucket:      `example_live`,
+			Interval:    60,
+			Inheritance: true,
+			Thresholds: []proto.LayoutThreshold{
+				{Predicate: `>=`, Level: `warning`, Value: 80},
+				{Predicate: `>=`, Level: `critical`, Value: 90},
+			},
+			Constraints: []proto.LayoutConstraint{
+				{Type: `system`, Name: `fqdn`, Operator: `glob`,
+					Value: `web*`},
+				{Type: `native`, Name: `environment`, Value: `live`},
+			},
+		}},
+	}
+}
+
+// testProperty returns a custom property
+func testProperty() proto.LayoutProperty {
+	return proto.LayoutProperty{
+		Type:  `custom`,
+		Name:  `owner`,
+		Value: `ops`,
+		View:  `internal`,
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
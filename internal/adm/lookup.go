@@ -11,7 +11,6 @@ package adm
 import (
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/mjolnir42/soma/lib/proto"
@@ -26,7 +25,9 @@ func LookupOncallID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return oncallIDByName(s)
+	return cachedLookup(`oncall`, s, func() (string, error) {
+		return oncallIDByName(s)
+	})
 }
 
 // LookupOncallDetails looks up the details for oncall duty s.
@@ -52,7 +53,9 @@ func LookupUserID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return userIDByUserName(s)
+	return cachedLookup(`user`, s, func() (string, error) {
+		return userIDByUserName(s)
+	})
 }
 
 // LookupAdminID looks up the UUID for an admin account of a
@@ -61,15 +64,18 @@ func LookupUserID(s string) (string, error) {
 // If s is already a UUID, then it is considered the user's
 // userID.
 func LookupAdminID(s string) (string, error) {
-	if IsUUID(s) {
-		return lookupAdminIDByUserID(s)
-	}
-	s = strings.TrimPrefix(s, `admin_`)
-	userID, err := userIDByUserName(s)
-	if err != nil {
-		return ``, err
+	userID := s
+	if !IsUUID(s) {
+		var err error
+		if userID, err = LookupUserID(
+			strings.TrimPrefix(s, `admin_`),
+		); err != nil {
+			return ``, err
+		}
 	}
-	return lookupAdminIDByUserID(userID)
+	return cachedLookup(`admin`, userID, func() (string, error) {
+		return lookupAdminIDByUserID(userID)
+	})
 }
 
 // LookupTeamID looks up the UUID for a team on the server
@@ -82,7 +88,16 @@ func LookupTeamID(s string, r *string) error {
 		*r = s
 		return nil
 	}
-	return teamIDByName(s, r)
+	id, err := cachedLookup(`team`, s, func() (string, error) {
+		var teamID string
+		err := teamIDByName(s, &teamID)
+		return teamID, err
+	})
+	if err != nil {
+		return err
+	}
+	*r = id
+	return nil
 }
 
 // LookupTeamByRepo looks up the UUID for the team that is the
@@ -135,7 +150,7 @@ func LookupTeamByNode(s string) (string, error) {
 	)
 
 	if !IsUUID(s) {
-		if nID, err = nodeIDByName(s); err != nil {
+		if nID, err = LookupNodeID(s); err != nil {
 			return ``, err
 		}
 	} else {
@@ -153,7 +168,9 @@ func LookupRepoID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return repoIDByName(s)
+	return cachedLookup(`repository`, s, func() (string, error) {
+		return repoIDByName(s)
+	})
 }
 
 //  LookupRepoName looks up the name for a repository on the server
@@ -185,7 +202,9 @@ func LookupRepoByBucket(s string) (string, error) {
 		bID = s
 	}
 
-	return repoIDByBucketID(bID)
+	return cachedLookup(`bucket-repository`, bID, func() (string, error) {
+		return repoIDByBucketID(bID)
+	})
 }
 
 // LookupBucketID looks up the UUID for a bucket on the server
@@ -196,7 +215,9 @@ func LookupBucketID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return bucketIDByName(s)
+	return cachedLookup(`bucket`, s, func() (string, error) {
+		return bucketIDByName(s)
+	})
 }
 
 // LookupGroupID looks up the UUID for group group in bucket
@@ -218,7 +239,9 @@ func LookupGroupID(group, bucket string) (string, error) {
 		bID = bucket
 	}
 
-	return groupIDByName(group, bID)
+	return cachedLookup(`group`, bID+`/`+group, func() (string, error) {
+		return groupIDByName(group, bID)
+	})
 }
 
 // LookupClusterID looks up the UUID for cluster cluster in
@@ -240,7 +263,9 @@ func LookupClusterID(cluster, bucket string) (string, error) {
 		bID = bucket
 	}
 
-	return clusterIDByName(cluster, bID)
+	return cachedLookup(`cluster`, bID+`/`+cluster, func() (string, error) {
+		return clusterIDByName(cluster, bID)
+	})
 }
 
 // LookupServerID looks up the UUID for a server either in the
@@ -254,9 +279,13 @@ func LookupServerID(s string) (string, error) {
 		return s, nil
 	}
 	if ok, num := isUint64(s); ok {
-		return serverIDByAsset(s, num)
+		return cachedLookup(`serverasset`, s, func() (string, error) {
+			return serverIDByAsset(num)
+		})
 	}
-	return serverIDByName(s)
+	return cachedLookup(`servername`, s, func() (string, error) {
+		return serverIDByName(s)
+	})
 }
 
 // LookupPermIDRef looks up the UUID for a permission from
@@ -284,7 +313,9 @@ func LookupMonitoringID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return monitoringIDByName(s)
+	return cachedLookup(`monitoring`, s, func() (string, error) {
+		return monitoringIDByName(s)
+	})
 }
 
 // LookupNodeID looks up the UUID of the repository the bucket
@@ -294,7 +325,9 @@ func LookupNodeID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return nodeIDByName(s)
+	return cachedLookup(`node`, s, func() (string, error) {
+		return nodeIDByName(s)
+	})
 }
 
 // LookupCapabilityID looks up the UUID of the capability with the
@@ -303,7 +336,9 @@ func LookupCapabilityID(s string) (string, error) {
 	if IsUUID(s) {
 		return s, nil
 	}
-	return capabilityIDByName(s)
+	return cachedLookup(`capability`, s, func() (string, error) {
+		return capabilityIDByName(s)
+	})
 }
 
 // LookupSectionID looks up the UUID of the section with the name
@@ -503,6 +538,7 @@ func nodeByID(nodeID string) (proto.Node, error) {
 	}
 
 	if nodeID != (*res.Nodes)[0].ID {
+		invalidateCachedIDs(nodeID)
 		err = fmt.Errorf("Id mismatch: %s vs %s",
 			nodeID, (*res.Nodes)[0].ID)
 		goto abort
@@ -556,6 +592,7 @@ func oncallDetailsByID(oncall string) (string, string, error) {
 	}
 
 	if oncall != (*res.Oncalls)[0].ID {
+		invalidateCachedIDs(oncall)
 		err = fmt.Errorf("OncallId mismatch: %s vs %s",
 			oncall, (*res.Oncalls)[0].ID)
 		goto abort
@@ -642,6 +679,7 @@ func teamIDByRepoID(repoID string, team *string) error {
 
 	// check the received record against the input
 	if repoID != (*res.Repositories)[0].ID {
+		invalidateCachedIDs(repoID)
 		err = fmt.Errorf("RepositoryID mismatch: %s vs %s",
 			repoID, (*res.Repositories)[0].ID)
 		goto abort
@@ -657,7 +695,7 @@ abort:
 // teamIDByBucketID implements the actual serverside lookup of
 // a bucket's TeamID
 func teamIDByBucketID(bucketID string) (string, error) {
-	repoID, err := repoIDByBucketID(bucketID)
+	repoID, err := LookupRepoByBucket(bucketID)
 	if err != nil {
 		return ``, err
 	}
@@ -673,6 +711,7 @@ func teamIDByBucketID(bucketID string) (string, error) {
 
 	// check the received record against the input
 	if bucketID != (*res.Buckets)[0].ID {
+		invalidateCachedIDs(bucketID)
 		err = fmt.Errorf("BucketID mismatch: %s vs %s",
 			bucketID, (*res.Buckets)[0].ID)
 		goto abort
@@ -699,6 +738,7 @@ func lookupAdminIDByUserID(userID string) (string, error) {
 
 	// check the received record against the input
 	if userID != (*res.Admins)[0].UserID {
+		invalidateCachedIDs(userID)
 		err = fmt.Errorf("UserID mismatch: %s vs %s",
 			userID, (*res.Admins)[0].UserID)
 		goto abort
@@ -725,6 +765,7 @@ func teamIDByNodeID(node string) (string, error) {
 
 	// check the received record against the input
 	if node != (*res.Nodes)[0].ID {
+		invalidateCachedIDs(node)
 		err = fmt.Errorf("NodeId mismatch: %s vs %s",
 			node, (*res.Nodes)[0].ID)
 		goto abort
@@ -807,6 +848,7 @@ func repoIDByBucketID(bucketID string) (string, error) {
 	}
 
 	if bucketID != (*res.Buckets)[0].ID {
+		invalidateCachedIDs(bucketID)
 		err = fmt.Errorf("BucketID mismatch: %s vs %s",
 			bucketID, (*res.Buckets)[0].ID)
 		goto abort
@@ -865,7 +907,7 @@ func groupIDByName(group, bucketID string) (string, error) {
 	req.Filter.Group.Name = group
 	req.Filter.Group.BucketID = bucketID
 
-	repositoryID, err = repoIDByBucketID(bucketID)
+	repositoryID, err = LookupRepoByBucket(bucketID)
 	if err != nil {
 		goto abort
 	}
@@ -939,9 +981,6 @@ abort:
 // serverIDByName implements the actual lookup of the server UUID
 // by name
 func serverIDByName(s string) (string, error) {
-	if m, err := cache.ServerByName(s); err == nil {
-		return m[`id`], nil
-	}
 	req := proto.NewServerFilter()
 	req.Filter.Server.Name = s
 
@@ -960,12 +999,6 @@ func serverIDByName(s string) (string, error) {
 			s, (*res.Servers)[0].Name)
 		goto abort
 	}
-	// save server in cacheDB
-	cache.Server(
-		(*res.Servers)[0].Name,
-		(*res.Servers)[0].ID,
-		strconv.Itoa(int((*res.Servers)[0].AssetID)),
-	)
 	return (*res.Servers)[0].ID, nil
 
 abort:
@@ -975,10 +1008,7 @@ abort:
 
 // serverIDByAsset implements the actual lookup of the server UUID
 // by numeric AssetID
-func serverIDByAsset(s string, aid uint64) (string, error) {
-	if m, err := cache.ServerByAsset(s); err == nil {
-		return m[`id`], nil
-	}
+func serverIDByAsset(aid uint64) (string, error) {
 	req := proto.NewServerFilter()
 	req.Filter.Server.AssetID = aid
 
@@ -997,12 +1027,6 @@ func serverIDByAsset(s string, aid uint64) (string, error) {
 			aid, (*res.Servers)[0].AssetID)
 		goto abort
 	}
-	// save server in cacheDB
-	cache.Server(
-		(*res.Servers)[0].Name,
-		(*res.Servers)[0].ID,
-		strconv.Itoa(int((*res.Servers)[0].AssetID)),
-	)
 	return (*res.Servers)[0].ID, nil
 
 abort:
@@ -1235,6 +1259,7 @@ func nodeConfigByID(node string) (*proto.NodeConfig, error) {
 
 	// check the received record against the input
 	if node != (*res.Nodes)[0].ID {
+		invalidateCachedIDs(node)
 		err = fmt.Errorf("NodeId mismatch: %s vs %s",
 			node, (*res.Nodes)[0].ID)
 		goto abort
@@ -1469,6 +1494,11 @@ func fetchFilter(req proto.Request, path string) (*proto.Result, error) {
 		return nil, err
 	}
 
+	if res.StatusCode == 404 {
+		// the path may have been built from stale cached IDs
+		invalidateCachedIDs(path)
+	}
+
 	if err = checkApplicationError(res); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package adm
+
+import (
+	"fmt"
+	"net/url"
+
+	"gopkg.in/resty.v1"
+)
+
+// RequestMailToken asks the server to send a mail token for account
+// activation or password reset to the mail address of user
+func RequestMailToken(c *resty.Client, user string) error {
+	var (
+		err  error
+		resp *resty.Response
+	)
+
+	if resp, err = c.R().Put(fmt.Sprintf(
+		"/accounts/mailtoken/%s", url.PathEscape(user))); err != nil {
+		return err
+	} else if resp.StatusCode() != 200 {
+		return fmt.Errorf("Mail token request failed with status code: %d", resp.StatusCode())
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
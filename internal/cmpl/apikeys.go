@@ -0,0 +1,9 @@
+package cmpl
+
+import "github.com/codegangsta/cli"
+
+func APIKeyCreate(c *cli.Context) {
+	GenericMulti(c, []string{`team`, `expires`}, []string{`permission`, `network`})
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -77,7 +77,19 @@ func ValidityAdd(c *cli.Context) {
 	Generic(c, []string{`on`, `direct`, `inherited`})
 }
 
+func WorkflowRetry(c *cli.Context) {
+	if c.Bool(`filter`) {
+		GenericDirect(c, []string{`state`, `monitoring`, `repository`,
+			`capability`, `age`})
+	}
+}
+
 func WorkflowSet(c *cli.Context) {
+	if c.Bool(`filter`) {
+		GenericDirect(c, []string{`status`, `next`, `state`,
+			`monitoring`, `repository`, `capability`, `age`})
+		return
+	}
 	Generic(c, []string{`status`, `next`})
 }
 
@@ -89,6 +101,15 @@ func RepositoryConfigSearch(c *cli.Context) {
 	GenericDirect(c, []string{`id`, `name`, `team`, `deleted`, `active`})
 }
 
+func RepositoryAudit(c *cli.Context) {
+	Generic(c, []string{`from`, `bucket`, `group`, `cluster`, `node`,
+		`check`, `user`, `jobtype`, `since`, `until`})
+}
+
+func RepositoryBatch(c *cli.Context) {
+	Generic(c, []string{`file`})
+}
+
 func CheckConfigList(c *cli.Context) {
 	GenericDirectTriple(c, []string{`in`})
 }
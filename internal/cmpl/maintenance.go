@@ -0,0 +1,43 @@
+package cmpl
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+)
+
+func MaintenanceAdd(c *cli.Context) {
+	switch {
+	case c.NArg() == 0:
+		for _, t := range []string{`repository`, `bucket`, `group`, `cluster`, `node`} {
+			fmt.Println(t)
+		}
+		return
+	case c.NArg() == 1:
+		return
+	}
+
+	skip := 0
+	match := make(map[string]bool)
+
+	// the first two arguments are object type and name
+	for _, t := range c.Args()[2:] {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		skip = 1
+		match[t] = true
+	}
+	// do not complete in positions where arguments are expected
+	if skip > 0 {
+		return
+	}
+	for _, t := range []string{`in`, `from`, `until`, `reason`} {
+		if !match[t] {
+			fmt.Println(t)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,9 @@
+package cmpl
+
+import "github.com/codegangsta/cli"
+
+func WebhookAdd(c *cli.Context) {
+	GenericMulti(c, []string{`url`, `repository`, `monitoring`}, []string{`state`})
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -13,7 +13,9 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/url"
+	"os"
 	"path/filepath"
+	"strconv"
 
 	"golang.org/x/sys/unix"
 
@@ -33,17 +35,24 @@ type Config struct {
 	Observer      bool       `json:"observer,string"`
 	ObserverRepo  string     `json:"-"`
 	NoPoke        bool       `json:"no.poke,string"`
+	WebhookTick   uint64     `json:"webhook.tick.seconds,string"`
+	WebhookTime   uint64     `json:"webhook.timeout.ms,string"`
+	WebhookTries  uint64     `json:"webhook.max.attempts,string"`
 	PrintChannels bool       `json:"startup.print.channel.errors,string"`
 	ShutdownDelay uint64     `json:"shutdown.delay.seconds,string"`
+	RetryAfter    uint64     `json:"shutdown.retry.after.seconds,string"`
 	InstanceName  string     `json:"instance.name"`
 	LogLevel      string     `json:"log.level"`
 	LogPath       string     `json:"log.path"`
 	QueueLen      int        `json:"handler.queue.length,string"`
+	Metrics       bool       `json:"metrics.endpoint,string"`
 	Version       string     `json:"version"`
 	Database      DbConfig   `json:"database"`
 	Daemon        Daemon     `json:"daemon"`
+	AdminSocket   Socket     `json:"admin.socket"`
 	Auth          AuthConfig `json:"authentication"`
 	Ldap          LdapConfig `json:"ldap"`
+	Mail          MailConfig `json:"mail"`
 }
 
 // DbConfig provides the database credentials for SOMA
@@ -67,12 +76,27 @@ type Daemon struct {
 	Key    string   `json:"key.file"`
 }
 
+// Socket represents the configuration of the restricted admin
+// endpoint on a UNIX domain socket. Mode is the octal file mode of
+// the socket, Group its group owner. Besides root and the daemon's
+// own user, only UIDs listed in AllowedUIDs may connect.
+type Socket struct {
+	Path        string      `json:"path"`
+	Mode        string      `json:"mode"`
+	Group       string      `json:"group"`
+	AllowedUIDs []string    `json:"allowed.uids"`
+	FileMode    os.FileMode `json:"-"`
+	UIDs        []uint32    `json:"-"`
+}
+
 // AuthConfig stores authentication settings for SOMA
 type AuthConfig struct {
 	KexExpirySeconds     uint64 `json:"kex.expiry,string"`
 	TokenExpirySeconds   uint64 `json:"token.expiry,string"`
 	CredentialExpiryDays uint64 `json:"credential.expiry,string"`
 	Activation           string `json:"activation.mode"`
+	MailTokenExpiryMin   uint64 `json:"mailtoken.expiry,string"`
+	MailTokenCooldownMin uint64 `json:"mailtoken.cooldown,string"`
 	// dd if=/dev/random bs=1M count=1 2>/dev/null | sha512
 	TokenSeed string `json:"token.seed"`
 	TokenKey  string `json:"token.key"`
@@ -90,6 +114,18 @@ type LdapConfig struct {
 	SkipVerify bool   `json:"insecure,string"`
 }
 
+// MailConfig stores the settings for sending mails. Sender selects
+// the mail sender: smtp, file or exec
+type MailConfig struct {
+	Sender   string `json:"sender"`
+	From     string `json:"from"`
+	Address  string `json:"smtp.address"`
+	User     string `json:"smtp.user"`
+	Password string `json:"smtp.password"`
+	File     string `json:"file.path"`
+	Command  string `json:"exec.command"`
+}
+
 // ReadConfigFile assembles soma.Config from a file
 func (c *Config) ReadConfigFile(fname string) error {
 	file, err := ioutil.ReadFile(fname)
@@ -168,15 +204,73 @@ func (c *Config) ReadConfigFile(fname string) error {
 			c.PokePath)
 	}
 
+	if c.WebhookTick == 0 {
+		log.Println(`Setting default value for webhook.tick.seconds: 10`)
+		c.WebhookTick = 10
+	}
+
+	if c.WebhookTime == 0 {
+		log.Println(`Setting default value for webhook.timeout.ms: 5000`)
+		c.WebhookTime = 5000
+	}
+
+	if c.WebhookTries == 0 {
+		log.Println(`Setting default value for webhook.max.attempts: 12`)
+		c.WebhookTries = 12
+	}
+
 	if c.Auth.Activation == `ldap` && !c.Ldap.TLS {
 		log.Println(`Account activation via LDAP configured, but LDAP/TLS disabled!`)
 	}
 
+	if c.Auth.Activation == `token` {
+		if c.Auth.MailTokenExpiryMin == 0 {
+			log.Println(`Setting default value for mailtoken.expiry: 30`)
+			c.Auth.MailTokenExpiryMin = 30
+		}
+		if c.Auth.MailTokenCooldownMin == 0 {
+			log.Println(`Setting default value for mailtoken.cooldown: 5`)
+			c.Auth.MailTokenCooldownMin = 5
+		}
+		switch c.Mail.Sender {
+		case `smtp`, `file`, `exec`:
+		default:
+			log.Fatal(`Account activation via mail token configured, `,
+				`but no valid mail.sender: `, c.Mail.Sender,
+				`. Valid senders are: smtp, file, exec`)
+		}
+	}
+
 	if c.ShutdownDelay == 0 {
 		log.Println(`Setting default value for shutdown.delay.seconds: 5`)
 		c.ShutdownDelay = 5
 	}
 
+	if c.RetryAfter == 0 {
+		log.Println(`Setting default value for shutdown.retry.after.seconds: 30`)
+		c.RetryAfter = 30
+	}
+
+	if c.AdminSocket.Path != `` {
+		if c.AdminSocket.Mode == `` {
+			log.Println(`Setting default value for admin.socket.mode: 0600`)
+			c.AdminSocket.Mode = `0600`
+		}
+		mode, err := strconv.ParseUint(c.AdminSocket.Mode, 8, 32)
+		if err != nil {
+			log.Fatal(`Invalid admin.socket.mode specified: `, err)
+		}
+		c.AdminSocket.FileMode = os.FileMode(mode)
+
+		for _, u := range c.AdminSocket.AllowedUIDs {
+			uid, err := strconv.ParseUint(u, 10, 32)
+			if err != nil {
+				log.Fatal(`Invalid admin.socket.allowed.uids entry: `, err)
+			}
+			c.AdminSocket.UIDs = append(c.AdminSocket.UIDs, uint32(uid))
+		}
+	}
+
 	switch c.LogLevel {
 	case `debug`, `info`, `warn`, `error`, `fatal`, `panic`:
 	default:
@@ -89,12 +89,6 @@ func (d *DB) EnsureBuckets() error {
 				if _, err := b.CreateBucketIfNotExists([]byte(`team`)); err != nil {
 					return fmt.Errorf("Failed to create DB bucket: %s", err)
 				}
-				if _, err := b.CreateBucketIfNotExists([]byte(`servername`)); err != nil {
-					return fmt.Errorf("Failed to create DB bucket: %s", err)
-				}
-				if _, err := b.CreateBucketIfNotExists([]byte(`serverasset`)); err != nil {
-					return fmt.Errorf("Failed to create DB bucket: %s", err)
-				}
 			}
 			return nil
 		})
@@ -0,0 +1,198 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package db
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// IDCacheEntry is a cached mapping of a name to an ID
+type IDCacheEntry struct {
+	Kind   string    `json:"kind"`
+	Key    string    `json:"key"`
+	ID     string    `json:"id"`
+	Expire time.Time `json:"expire"`
+}
+
+// CacheID records id as ID of the object of type kind identified by
+// key. The entry expires after ttl.
+func (d *DB) CacheID(kind, key, id string, ttl time.Duration) error {
+	if err := d.Open(); err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(`idcache`))
+		if err != nil {
+			return err
+		}
+		k, err := b.CreateBucketIfNotExists([]byte(kind))
+		if err != nil {
+			return err
+		}
+		mapdata := map[string]string{
+			`id`:     id,
+			`expire`: time.Now().UTC().Add(ttl).Format(time.RFC3339),
+		}
+		data, _ := json.Marshal(&mapdata)
+		return k.Put([]byte(key), data)
+	})
+}
+
+// CachedID returns the cached ID of the object of type kind
+// identified by key. It returns bolt.ErrBucketNotFound if there is
+// no such entry or it has expired.
+func (d *DB) CachedID(kind, key string) (string, error) {
+	if err := d.Open(); err != nil {
+		return ``, err
+	}
+	defer d.Close()
+
+	var id string
+	if err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(`idcache`))
+		if b == nil {
+			return bolt.ErrBucketNotFound
+		}
+		if b = b.Bucket([]byte(kind)); b == nil {
+			return bolt.ErrBucketNotFound
+		}
+
+		data := b.Get([]byte(key))
+		if data == nil {
+			return bolt.ErrBucketNotFound
+		}
+		mapdata := map[string]string{}
+		if err := json.Unmarshal(data, &mapdata); err != nil {
+			return bolt.ErrBucketNotFound
+		}
+		expire, _ := time.Parse(time.RFC3339, mapdata[`expire`])
+		if time.Now().UTC().After(expire.UTC()) {
+			return bolt.ErrBucketNotFound
+		}
+		id = mapdata[`id`]
+		return nil
+	}); err != nil {
+		return ``, err
+	}
+	if id == `` {
+		return ``, bolt.ErrBucketNotFound
+	}
+	return id, nil
+}
+
+// InvalidateID removes all entries from the ID cache that map to id
+func (d *DB) InvalidateID(id string) error {
+	if err := d.Open(); err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(`idcache`))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(kind, v []byte) error {
+			// only nested buckets have a nil value
+			if v != nil {
+				return nil
+			}
+			k := b.Bucket(kind)
+			stale := [][]byte{}
+			if err := k.ForEach(func(key, data []byte) error {
+				mapdata := map[string]string{}
+				if json.Unmarshal(data, &mapdata) == nil &&
+					mapdata[`id`] == id {
+					stale = append(stale, key)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			// keys can not be deleted while iterating
+			for _, key := range stale {
+				if err := k.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// ClearIDCache removes all entries from the ID cache
+func (d *DB) ClearIDCache() error {
+	if err := d.Open(); err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(`idcache`)) != nil {
+			if err := tx.DeleteBucket([]byte(`idcache`)); err != nil {
+				return err
+			}
+		}
+		_, err := tx.CreateBucket([]byte(`idcache`))
+		return err
+	})
+}
+
+// IDCacheEntries returns all entries in the ID cache, including
+// expired ones, sorted by kind and key
+func (d *DB) IDCacheEntries() ([]IDCacheEntry, error) {
+	if err := d.Open(); err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	entries := []IDCacheEntry{}
+	if err := d.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(`idcache`))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(kind, v []byte) error {
+			if v != nil {
+				return nil
+			}
+			return b.Bucket(kind).ForEach(func(key, data []byte) error {
+				mapdata := map[string]string{}
+				if err := json.Unmarshal(data, &mapdata); err != nil {
+					return nil
+				}
+				expire, _ := time.Parse(time.RFC3339, mapdata[`expire`])
+				entries = append(entries, IDCacheEntry{
+					Kind:   string(kind),
+					Key:    string(key),
+					ID:     mapdata[`id`],
+					Expire: expire,
+				})
+				return nil
+			})
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,167 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package db
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestCachedIDExpiry(t *testing.T) {
+	d, cleanup := testDB(t)
+	defer cleanup()
+
+	if err := d.CacheID(`team`, `ops`, `team-1`, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CacheID(`team`, `dev`, `team-2`, -time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if id, err := d.CachedID(`team`, `ops`); err != nil || id != `team-1` {
+		t.Errorf("Expected cached ID team-1, got %q (%v)", id, err)
+	}
+	if _, err := d.CachedID(`team`, `dev`); err != bolt.ErrBucketNotFound {
+		t.Errorf("Expected expired entry to miss, got %v", err)
+	}
+	if _, err := d.CachedID(`team`, `unknown`); err != bolt.ErrBucketNotFound {
+		t.Errorf("Expected unknown key to miss, got %v", err)
+	}
+	if _, err := d.CachedID(`oncall`, `ops`); err != bolt.ErrBucketNotFound {
+		t.Errorf("Expected unknown kind to miss, got %v", err)
+	}
+
+	// expired entries are still listed
+	entries, err := d.IDCacheEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Key != `dev` ||
+		entries[1].Key != `ops` {
+		t.Fatalf("Unexpected cache entries: %v", entries)
+	}
+	if !entries[0].Expire.Before(time.Now()) ||
+		!entries[1].Expire.After(time.Now()) {
+		t.Errorf("Unexpected expiry times: %v", entries)
+	}
+
+	// a new entry replaces the expired one
+	if err := d.CacheID(`team`, `dev`, `team-3`, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := d.CachedID(`team`, `dev`); err != nil || id != `team-3` {
+		t.Errorf("Expected cached ID team-3, got %q (%v)", id, err)
+	}
+}
+
+func TestInvalidateID(t *testing.T) {
+	d, cleanup := testDB(t)
+	defer cleanup()
+
+	for _, e := range []IDCacheEntry{
+		{Kind: `servername`, Key: `db01`, ID: `server-1`},
+		{Kind: `serverasset`, Key: `4711`, ID: `server-1`},
+		{Kind: `servername`, Key: `db02`, ID: `server-2`},
+		{Kind: `team`, Key: `ops`, ID: `team-1`},
+	} {
+		if err := d.CacheID(e.Kind, e.Key, e.ID, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := d.InvalidateID(`server-1`); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range [][2]string{
+		{`servername`, `db01`},
+		{`serverasset`, `4711`},
+	} {
+		if _, err := d.CachedID(key[0], key[1]); err != bolt.ErrBucketNotFound {
+			t.Errorf("%s/%s: expected invalidated entry to miss, got %v",
+				key[0], key[1], err)
+		}
+	}
+	if id, err := d.CachedID(`servername`, `db02`); err != nil ||
+		id != `server-2` {
+		t.Errorf("Expected cached ID server-2, got %q (%v)", id, err)
+	}
+	if id, err := d.CachedID(`team`, `ops`); err != nil || id != `team-1` {
+		t.Errorf("Expected cached ID team-1, got %q (%v)", id, err)
+	}
+
+	// invalidating an unknown ID is not an error
+	if err := d.InvalidateID(`server-3`); err != nil {
+		t.Error(err)
+	}
+	if entries, err := d.IDCacheEntries(); err != nil || len(entries) != 2 {
+		t.Errorf("Expected 2 cache entries, got %v (%v)", entries, err)
+	}
+}
+
+func TestClearIDCache(t *testing.T) {
+	d, cleanup := testDB(t)
+	defer cleanup()
+
+	// clearing an empty cache is not an error
+	if err := d.ClearIDCache(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CacheID(`team`, `ops`, `team-1`, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CacheID(`servername`, `db01`, `server-1`,
+		time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.ClearIDCache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.CachedID(`team`, `ops`); err != bolt.ErrBucketNotFound {
+		t.Errorf("Expected cleared entry to miss, got %v", err)
+	}
+	if entries, err := d.IDCacheEntries(); err != nil || len(entries) != 0 {
+		t.Errorf("Expected empty cache, got %v (%v)", entries, err)
+	}
+
+	// the cache is usable after it was cleared
+	if err := d.CacheID(`team`, `ops`, `team-2`, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := d.CachedID(`team`, `ops`); err != nil || id != `team-2` {
+		t.Errorf("Expected cached ID team-2, got %q (%v)", id, err)
+	}
+}
+
+// testDB returns a DB configured with a bolt file in a temporary
+// directory and a function that removes the directory
+func testDB(t *testing.T) (*DB, func()) {
+	dir, err := ioutil.TempDir(``, `somadb`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &DB{}
+	d.Configure(
+		filepath.Join(dir, `soma.db`),
+		os.FileMode(0600),
+		&bolt.Options{Timeout: time.Second},
+	)
+	if err := d.EnsureBuckets(); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return d, func() { os.RemoveAll(dir) }
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
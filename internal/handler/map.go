@@ -113,4 +113,21 @@ func (h *Map) Run(n string) {
 	go h.hmap[n].Run()
 }
 
+// QueueDepth returns the number of requests waiting in the input
+// channels of each handler
+func (h *Map) QueueDepth() map[string]int {
+	h.RLock()
+	defer h.RUnlock()
+	depth := make(map[string]int, len(h.hmap))
+	for name, hdl := range h.hmap {
+		input, priority := hdl.Intake(), hdl.PriorityIntake()
+		depth[name] = len(input)
+		// most handlers alias both intakes to the same channel
+		if priority != input {
+			depth[name] += len(priority)
+		}
+	}
+	return depth
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,81 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+// Package mail implements the pluggable mail senders used by SOMA
+// to deliver mails to users, for example activation and password
+// reset tokens.
+package mail // import "github.com/mjolnir42/soma/internal/mail"
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/config"
+)
+
+// Sender delivers a mail to a single recipient. Implementations
+// reject recipients that fail ValidAddress.
+type Sender interface {
+	Send(to, subject, body string) error
+}
+
+// New returns the Sender selected by the configuration
+func New(c config.MailConfig) (Sender, error) {
+	if c.From == `` {
+		return nil, fmt.Errorf(`mail: from address is not configured`)
+	}
+
+	switch c.Sender {
+	case `smtp`:
+		return newSMTPSender(c)
+	case `file`:
+		return newFileSender(c)
+	case `exec`:
+		return newExecSender(c)
+	}
+	return nil, fmt.Errorf("mail: unknown sender: %s", c.Sender)
+}
+
+// ValidAddress returns an error if addr is not a plain mail address
+// without display name. Addresses starting with a - are rejected as
+// well, since command line mailers would parse them as option.
+func ValidAddress(addr string) error {
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return fmt.Errorf("mail: invalid address %q: %s", addr, err)
+	}
+	if parsed.Address != addr {
+		return fmt.Errorf("mail: invalid address %q: not a plain address",
+			addr)
+	}
+	if strings.HasPrefix(addr, `-`) {
+		return fmt.Errorf("mail: invalid address %q: leading -", addr)
+	}
+	return nil
+}
+
+// message assembles a plain text mail
+func message(from, to, subject, body string) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", to)
+	fmt.Fprintf(buf, "Subject: %s\r\n",
+		mime.QEncoding.Encode(`utf-8`, subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprint(buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprint(buf, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprint(buf, "Content-Transfer-Encoding: 8bit\r\n")
+	fmt.Fprint(buf, "\r\n")
+	fmt.Fprint(buf, body)
+	return buf.Bytes()
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,64 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package mail // import "github.com/mjolnir42/soma/internal/mail"
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/mjolnir42/soma/internal/config"
+)
+
+// execSender pipes the mail into an external command, for example
+// sendmail. The recipient address is appended as last argument,
+// after a -- that ends the option parsing of the command.
+type execSender struct {
+	from string
+	args []string
+}
+
+func newExecSender(c config.MailConfig) (*execSender, error) {
+	args, err := shellwords.Parse(c.Command)
+	if err != nil {
+		return nil, fmt.Errorf("mail: invalid exec.command: %s", err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf(`mail: exec.command is not configured`)
+	}
+	return &execSender{
+		from: c.From,
+		args: args,
+	}, nil
+}
+
+// command returns the command that delivers a mail to recipient to
+func (s *execSender) command(to string) *exec.Cmd {
+	args := append(append([]string{}, s.args[1:]...), `--`, to)
+	return exec.Command(s.args[0], args...)
+}
+
+// Send implements Sender
+func (s *execSender) Send(to, subject, body string) error {
+	var stderr bytes.Buffer
+
+	if err := ValidAddress(to); err != nil {
+		return err
+	}
+	cmd := s.command(to)
+	cmd.Stdin = bytes.NewReader(message(s.from, to, subject, body))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("mail: %s: %s %s", s.args[0], err,
+			stderr.String())
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,63 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package mail // import "github.com/mjolnir42/soma/internal/mail"
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/config"
+)
+
+// fileSender appends all mails to a file in mbox format instead of
+// delivering them. It is intended for testing setups.
+type fileSender struct {
+	from string
+	path string
+	lock sync.Mutex
+}
+
+func newFileSender(c config.MailConfig) (*fileSender, error) {
+	if c.File == `` {
+		return nil, fmt.Errorf(`mail: file.path is not configured`)
+	}
+	return &fileSender{
+		from: c.From,
+		path: c.File,
+	}, nil
+}
+
+// Send implements Sender
+func (s *fileSender) Send(to, subject, body string) error {
+	if err := ValidAddress(to); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fh, err := os.OpenFile(s.path,
+		os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(fh, "From %s %s\n%s\n\n",
+		s.from,
+		time.Now().UTC().Format(time.ANSIC),
+		message(s.from, to, subject, body),
+	); err != nil {
+		fh.Close()
+		return err
+	}
+	return fh.Close()
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,56 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package mail // import "github.com/mjolnir42/soma/internal/mail"
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+
+	"github.com/mjolnir42/soma/internal/config"
+)
+
+// smtpSender delivers mails via an SMTP relay. STARTTLS is used if
+// the relay offers it.
+type smtpSender struct {
+	from    string
+	address string
+	auth    smtp.Auth
+}
+
+func newSMTPSender(c config.MailConfig) (*smtpSender, error) {
+	host, _, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("mail: invalid smtp.address: %s", err)
+	}
+
+	s := &smtpSender{
+		from:    c.From,
+		address: c.Address,
+	}
+	if c.User != `` {
+		s.auth = smtp.PlainAuth(``, c.User, c.Password, host)
+	}
+	return s, nil
+}
+
+// Send implements Sender
+func (s *smtpSender) Send(to, subject, body string) error {
+	if err := ValidAddress(to); err != nil {
+		return err
+	}
+	return smtp.SendMail(
+		s.address,
+		s.auth,
+		s.from,
+		[]string{to},
+		message(s.from, to, subject, body),
+	)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
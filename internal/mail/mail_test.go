@@ -0,0 +1,141 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package mail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/config"
+)
+
+func TestMessage(t *testing.T) {
+	raw := string(message(`soma@example.org`, `user@example.org`,
+		`Your token`, "line 1\nline 2\n"))
+
+	parts := strings.SplitN(raw, "\r\n\r\n", 2)
+	if len(parts) != 2 {
+		t.Fatalf(`Message has no header/body separator`)
+	}
+	if parts[1] != "line 1\nline 2\n" {
+		t.Errorf("Incorrect body: %q", parts[1])
+	}
+
+	headers := map[string]string{}
+	for _, line := range strings.Split(parts[0], "\r\n") {
+		kv := strings.SplitN(line, `: `, 2)
+		if len(kv) != 2 {
+			t.Fatalf("Malformed header line: %q", line)
+		}
+		headers[kv[0]] = kv[1]
+	}
+	for key, value := range map[string]string{
+		`From`:         `soma@example.org`,
+		`To`:           `user@example.org`,
+		`Subject`:      `Your token`,
+		`MIME-Version`: `1.0`,
+		`Content-Type`: `text/plain; charset=utf-8`,
+	} {
+		if headers[key] != value {
+			t.Errorf("Header %s: expected %q, got %q",
+				key, value, headers[key])
+		}
+	}
+	if headers[`Date`] == `` {
+		t.Errorf(`Message has no Date header`)
+	}
+}
+
+func TestMessageEncodesSubject(t *testing.T) {
+	raw := string(message(`soma@example.org`, `user@example.org`,
+		"Token\r\nBcc: evil@example.org", ``))
+
+	if strings.Contains(raw, "\r\nBcc:") {
+		t.Errorf(`Subject injected a header`)
+	}
+}
+
+func TestValidAddress(t *testing.T) {
+	tests := []struct {
+		addr  string
+		valid bool
+	}{
+		{`user@example.org`, true},
+		{`first.last+tag@mail.example.org`, true},
+		{``, false},
+		{`user`, false},
+		{`User <user@example.org>`, false},
+		{`-C/tmp/x.cf`, false},
+		{`-oQ/tmp@example.org`, false},
+		{"user@example.org\r\nBcc: evil@example.org", false},
+		{`a@example.org, b@example.org`, false},
+	}
+
+	for _, test := range tests {
+		err := ValidAddress(test.addr)
+		if test.valid && err != nil {
+			t.Errorf("%q: expected valid address, got %s", test.addr, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%q: expected invalid address", test.addr)
+		}
+	}
+}
+
+func TestExecSenderCommand(t *testing.T) {
+	s, err := newExecSender(config.MailConfig{
+		From:    `soma@example.org`,
+		Command: `/usr/sbin/sendmail -i -f 'soma@example.org'`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := s.command(`user@example.org`)
+	expected := []string{`/usr/sbin/sendmail`, `-i`, `-f`,
+		`soma@example.org`, `--`, `user@example.org`}
+	if len(cmd.Args) != len(expected) {
+		t.Fatalf("Expected argv %v, got %v", expected, cmd.Args)
+	}
+	for i := range expected {
+		if cmd.Args[i] != expected[i] {
+			t.Errorf("Expected argv %v, got %v", expected, cmd.Args)
+			break
+		}
+	}
+
+	// the configured arguments are not modified between mails
+	s.command(`other@example.org`)
+	if len(s.args) != 4 {
+		t.Errorf("Configured arguments modified: %v", s.args)
+	}
+}
+
+func TestExecSenderRejectsOption(t *testing.T) {
+	s, err := newExecSender(config.MailConfig{
+		From:    `soma@example.org`,
+		Command: `/bin/true`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Send(`-C/tmp/x.cf`, `subject`, `body`); err == nil {
+		t.Errorf(`Sent mail to an option instead of an address`)
+	}
+}
+
+func TestNewExecSenderEmptyCommand(t *testing.T) {
+	if _, err := New(config.MailConfig{
+		From:   `soma@example.org`,
+		Sender: `exec`,
+	}); err == nil {
+		t.Errorf(`Accepted exec sender without command`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -59,6 +59,7 @@ const (
 	SectionUnit             = `unit`
 	SectionValidity         = `validity`
 	SectionView             = `view`
+	SectionWebhook          = `webhook`
 )
 
 // Sections in category Identity are special global sections for actions
@@ -73,10 +74,11 @@ const (
 // Sections in category self are for actions with a per-user
 // scope
 const (
-	CategorySelf = `self`
-	SectionJob   = `job`
-	SectionTeam  = `team`
-	SectionUser  = `user`
+	CategorySelf  = `self`
+	SectionAPIKey = `apikey`
+	SectionJob    = `job`
+	SectionTeam   = `team`
+	SectionUser   = `user`
 )
 
 // Sections in category operation are special global sections
@@ -107,6 +109,7 @@ const (
 	SectionCluster          = `cluster`
 	SectionGroup            = `group`
 	SectionInstance         = `instance`
+	SectionMaintenance      = `maintenance`
 	SectionNodeConfig       = `node-config`
 	SectionPropertyCustom   = `property-custom`
 	SectionRepositoryConfig = `repository-config`
@@ -137,14 +140,20 @@ const (
 	ActionAssemble        = `assemble`
 	ActionAssign          = `assign`
 	ActionAudit           = `audit`
+	ActionBatch           = `batch`
+	ActionBulkRetry       = `bulk-retry`
+	ActionBulkSet         = `bulk-set`
 	ActionCreate          = `create`
 	ActionDeclare         = `declare`
 	ActionDelete          = `delete`
 	ActionDestroy         = `destroy`
+	ActionExplain         = `explain`
+	ActionExport          = `export`
 	ActionFailed          = `failed`
 	ActionFilter          = `filter`
 	ActionGet             = `get`
 	ActionGrant           = `grant`
+	ActionHistory         = `history`
 	ActionInsertNullID    = `insert-null`
 	ActionList            = `list`
 	ActionMap             = `map`
@@ -154,6 +163,7 @@ const (
 	ActionPending         = `pending`
 	ActionPropertyCreate  = `property-create`
 	ActionPropertyDestroy = `property-destroy`
+	ActionPropertyReplace = `property-replace`
 	ActionPropertyUpdate  = `property-update`
 	ActionPurge           = `purge`
 	ActionRemove          = `remove`
@@ -182,6 +192,7 @@ const (
 	ActionUse             = `use`
 	ActionVersions        = `versions`
 	ActionWait            = `wait`
+	ActionWatch           = `watch`
 )
 
 // Section supervisor handles AAA requests outside the permission
@@ -197,11 +208,13 @@ const (
 	ActionKex             = `kex`
 	ActionPassword        = `password`
 	ActionToken           = `token`
+	TaskAPIKey            = `api-key`
 	TaskBasicAuth         = `basic-auth`
 	TaskChange            = `change`
 	TaskInvalidate        = `invalidate`
 	TaskInvalidateAccount = `invalidate-account`
 	TaskInvalidateGlobal  = `invalidate-global`
+	TaskMailToken         = `mail-token`
 	TaskNone              = `none`
 	TaskRequest           = `request`
 	TaskReset             = `reset`
@@ -209,6 +222,10 @@ const (
 	TaskUser              = `user`
 )
 
+// APIKeyUserPrefix is prepended to the ID of an API key to form the
+// BasicAuth user name used to authenticate with the key
+const APIKeyUserPrefix = `apikey_`
+
 // Entity types
 const (
 	EntityRepository = `repository`
@@ -216,6 +233,7 @@ const (
 	EntityGroup      = `group`
 	EntityCluster    = `cluster`
 	EntityNode       = `node`
+	EntityCheck      = `check`
 	EntityMonitoring = `monitoring`
 	EntityTeam       = `team`
 	InvalidObjectID  = `ffffffff-ffff-3fff-ffff-ffffffffffff`
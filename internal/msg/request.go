@@ -23,6 +23,7 @@ type Request struct {
 	TargetEntity  string
 	RemoteAddr    string
 	AuthUser      string
+	AuthAPIKey    string
 	RequestURI    string
 	Reply         chan Result `json:"-"`
 	JobID         uuid.UUID
@@ -33,7 +34,9 @@ type Request struct {
 
 	Super *Supervisor
 	Cache *Request
+	Batch []Request
 
+	APIKey      proto.APIKey
 	ActionObj   proto.Action
 	Admin       proto.Admin
 	Attribute   proto.Attribute
@@ -54,6 +57,7 @@ type Request struct {
 	JobStatus   proto.JobStatus
 	JobType     proto.JobType
 	Level       proto.Level
+	Maintenance proto.Maintenance
 	Metric      proto.Metric
 	Mode        proto.Mode
 	Monitoring  proto.Monitoring
@@ -75,6 +79,7 @@ type Request struct {
 	User        proto.User
 	Validity    proto.Validity
 	View        proto.View
+	Webhook     proto.Webhook
 	Workflow    proto.Workflow
 }
 
@@ -86,6 +91,7 @@ func New(r *http.Request, params httprouter.Params) Request {
 		RequestURI: requestURI(params),
 		RemoteAddr: remoteAddr(r),
 		AuthUser:   authUser(params),
+		AuthAPIKey: authAPIKey(params),
 		Reply:      returnChannel,
 	}
 }
@@ -93,8 +99,10 @@ func New(r *http.Request, params httprouter.Params) Request {
 type Filter struct {
 	IsDetailed bool
 	ActionObj  proto.Action
+	Audit      proto.AuditFilter
 	Bucket     proto.BucketFilter
 	Cluster    proto.Cluster
+	Deployment proto.DeploymentFilter
 	Grant      proto.Grant
 	Group      proto.Group
 	Job        proto.JobFilter
@@ -112,6 +120,7 @@ type Filter struct {
 	Server     proto.Server
 	Team       proto.Team
 	User       proto.User
+	Workflow   proto.WorkflowFilter
 }
 
 type UpdateData struct {
@@ -135,6 +144,8 @@ type Flags struct {
 	Unscoped     bool
 	Rebuild      bool
 	RebuildLevel string
+	Recursive    bool
+	DryRun       bool
 }
 
 func CacheUpdateFromRequest(rq *Request) Request {
@@ -26,49 +26,57 @@ type Result struct {
 
 	Super Supervisor
 
-	ActionObj      []proto.Action
-	Admin          []proto.Admin
-	Attribute      []proto.Attribute
-	Bucket         []proto.Bucket
-	Capability     []proto.Capability
-	Category       []proto.Category
-	CheckConfig    []proto.CheckConfig
-	Cluster        []proto.Cluster
-	Datacenter     []proto.Datacenter
-	Deployment     []proto.Deployment
-	Entity         []proto.Entity
-	Environment    []proto.Environment
-	Grant          []proto.Grant
-	Group          []proto.Group
-	HostDeployment []proto.HostDeployment
-	Instance       []proto.Instance
-	Job            []proto.Job
-	JobResult      []proto.JobResult
-	JobStatus      []proto.JobStatus
-	JobType        []proto.JobType
-	Level          []proto.Level
-	Metric         []proto.Metric
-	Mode           []proto.Mode
-	Monitoring     []proto.Monitoring
-	Node           []proto.Node
-	Oncall         []proto.Oncall
-	Permission     []proto.Permission
-	Predicate      []proto.Predicate
-	Property       []proto.Property
-	Provider       []proto.Provider
-	Repository     []proto.Repository
-	SectionObj     []proto.Section
-	Server         []proto.Server
-	State          []proto.State
-	Status         []proto.Status
-	System         []proto.System
-	Team           []proto.Team
-	Tree           proto.Tree
-	Unit           []proto.Unit
-	User           []proto.User
-	Validity       []proto.Validity
-	View           []proto.View
-	Workflow       []proto.Workflow
+	APIKey           []proto.APIKey
+	ActionObj        []proto.Action
+	Admin            []proto.Admin
+	Attribute        []proto.Attribute
+	Audit            []proto.Audit
+	Bucket           []proto.Bucket
+	Capability       []proto.Capability
+	Category         []proto.Category
+	CheckConfig      []proto.CheckConfig
+	CheckExplanation []proto.CheckExplanation
+	Cluster          []proto.Cluster
+	Datacenter       []proto.Datacenter
+	Deployment       []proto.Deployment
+	Entity           []proto.Entity
+	Environment      []proto.Environment
+	Grant            []proto.Grant
+	Group            []proto.Group
+	HostDeployment   []proto.HostDeployment
+	Instance         []proto.Instance
+	Job              []proto.Job
+	JobEvent         []proto.JobEvent
+	JobResult        []proto.JobResult
+	JobStatus        []proto.JobStatus
+	JobType          []proto.JobType
+	Layout           proto.Layout
+	Level            []proto.Level
+	Maintenance      []proto.Maintenance
+	Metric           []proto.Metric
+	Mode             []proto.Mode
+	Monitoring       []proto.Monitoring
+	Node             []proto.Node
+	Oncall           []proto.Oncall
+	Permission       []proto.Permission
+	Plan             []proto.PlanAction
+	Predicate        []proto.Predicate
+	Property         []proto.Property
+	Provider         []proto.Provider
+	Repository       []proto.Repository
+	SectionObj       []proto.Section
+	Server           []proto.Server
+	State            []proto.State
+	Status           []proto.Status
+	System           []proto.System
+	Team             []proto.Team
+	Tree             proto.Tree
+	Unit             []proto.Unit
+	User             []proto.User
+	Validity         []proto.Validity
+	View             []proto.View
+	Webhook          []proto.Webhook
+	Workflow         []proto.Workflow
 }
 
 func FromRequest(rq *Request) Result {
@@ -148,6 +156,8 @@ func (r *Result) Clear(s string) {
 		r.ActionObj = []proto.Action{}
 	case `admin`:
 		r.Admin = []proto.Admin{}
+	case SectionAPIKey:
+		r.APIKey = []proto.APIKey{}
 	case `attribute`:
 		r.Attribute = []proto.Attribute{}
 	case `bucket`:
@@ -179,8 +189,11 @@ func (r *Result) Clear(s string) {
 		r.Instance = []proto.Instance{}
 	case `job`:
 		r.Job = []proto.Job{}
+		r.JobEvent = []proto.JobEvent{}
 	case `level`:
 		r.Level = []proto.Level{}
+	case SectionMaintenance:
+		r.Maintenance = []proto.Maintenance{}
 	case `metric`:
 		r.Metric = []proto.Metric{}
 	case `mode`:
@@ -201,6 +214,7 @@ func (r *Result) Clear(s string) {
 		r.Provider = []proto.Provider{}
 	case `repository`:
 		r.Repository = []proto.Repository{}
+		r.Audit = []proto.Audit{}
 	case `section`:
 		r.SectionObj = []proto.Section{}
 	case `server`:
@@ -223,6 +237,8 @@ func (r *Result) Clear(s string) {
 		r.Validity = []proto.Validity{}
 	case `view`:
 		r.View = []proto.View{}
+	case SectionWebhook:
+		r.Webhook = []proto.Webhook{}
 	case `workflow`:
 		r.Workflow = []proto.Workflow{}
 	}
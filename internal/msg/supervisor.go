@@ -39,6 +39,8 @@ type Supervisor struct {
 	// User for whom should be revoked
 	RevokeForName string
 	RevokeForID   string
+	// User to whom a mail token should be sent
+	MailTokenFor string
 	// XXX Everything below is deprecated
 	// Fields for map update notifications
 	Object string
@@ -28,6 +28,12 @@ func authUser(params httprouter.Params) string {
 	return params.ByName(`AuthenticatedUser`)
 }
 
+// authAPIKey extracts the AuthenticatedAPIKey set by Basic
+// Authentication if the request was authenticated with an API key
+func authAPIKey(params httprouter.Params) string {
+	return params.ByName(`AuthenticatedAPIKey`)
+}
+
 // remoteAddr extracts the IP address part of the IP:port string
 // set as net/http.Request.RemoteAddr. It handles IPv4 cases like
 // 192.0.2.1:48467 and IPv6 cases like [2001:db8::1%lo0]:48467
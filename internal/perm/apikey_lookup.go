@@ -0,0 +1,72 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package perm
+
+// apiKeyLookup is the cache data structure for API keys, tracking
+// the subject a key is bound to and the subset of permissions the
+// key may use
+type apiKeyLookup struct {
+	// keyID -> apiKeyEntry
+	byID map[string]*apiKeyEntry
+}
+
+// apiKeyEntry is the cache entry for a single API key
+type apiKeyEntry struct {
+	// user or team
+	subjectType string
+	subjectID   string
+	// permissionID -> struct{}
+	permissions map[string]struct{}
+}
+
+// newAPIKeyLookup returns an initialized apiKeyLookup
+func newAPIKeyLookup() *apiKeyLookup {
+	m := apiKeyLookup{}
+	m.byID = map[string]*apiKeyEntry{}
+	return &m
+}
+
+// add inserts an API key into the cache, replacing an already
+// existing entry for the same key
+func (m *apiKeyLookup) add(keyID, subjectType, subjectID string,
+	permIDs []string) {
+	e := apiKeyEntry{
+		subjectType: subjectType,
+		subjectID:   subjectID,
+		permissions: map[string]struct{}{},
+	}
+	for _, permID := range permIDs {
+		e.permissions[permID] = struct{}{}
+	}
+	m.byID[keyID] = &e
+}
+
+// rm removes an API key from the cache
+func (m *apiKeyLookup) rm(keyID string) {
+	delete(m.byID, keyID)
+}
+
+// get returns the cache entry for keyID or nil if the key is not
+// found
+func (m *apiKeyLookup) get(keyID string) *apiKeyEntry {
+	return m.byID[keyID]
+}
+
+// filter returns the subset of permIDs that may be used with the
+// API key
+func (e *apiKeyEntry) filter(permIDs []string) []string {
+	r := []string{}
+	for _, permID := range permIDs {
+		if _, ok := e.permissions[permID]; ok {
+			r = append(r, permID)
+		}
+	}
+	return r
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
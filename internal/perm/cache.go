@@ -34,6 +34,7 @@ type Cache struct {
 	action  *actionLookup
 	user    *userLookup
 	team    *teamLookup
+	apiKey  *apiKeyLookup
 
 	// semi-flat repository object lookup map
 	object *objectLookup
@@ -56,6 +57,7 @@ func New() *Cache {
 	c.action = newActionLookup()
 	c.user = newUserLookup()
 	c.team = newTeamLookup()
+	c.apiKey = newAPIKeyLookup()
 	c.object = newObjectLookup()
 	c.pmap = newPermissionMapping()
 	c.grantGlobal = newUnscopedGrantMap()
@@ -69,6 +71,8 @@ func New() *Cache {
 func (c *Cache) Perform(q *msg.Request) {
 	// delegate the request to per-section methods
 	switch q.Cache.Section {
+	case msg.SectionAPIKey:
+		c.performAPIKey(q.Cache)
 	case msg.SectionAction:
 		c.performAction(q.Cache)
 	case msg.SectionAdminMgmt:
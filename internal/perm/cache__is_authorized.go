@@ -26,7 +26,8 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 		WithField(`permCache::error`, `none`)
 
 	var user *proto.User
-	var subjType, category, actionID, sectionID string
+	var key *apiKeyEntry
+	var subjType, category, actionID, sectionID, teamID string
 	var sectionPermIDs, actionPermIDs, mergedPermIDs []string
 	var any bool
 
@@ -53,9 +54,26 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 			WithField(`permCache::error`, `UserNotFound`)
 		goto dispatch
 	}
+	teamID = user.TeamID
 
-	// check if the subject has omnipotence
-	if c.checkOmnipotence(subjType, user.ID, &result) {
+	// requests authenticated via API key are restricted to the
+	// permissions of the key
+	if q.Super.Authorize.AuthAPIKey != `` {
+		result.Super.Audit = result.Super.Audit.WithField(`permCache::apiKey`, q.Super.Authorize.AuthAPIKey)
+		if key = c.apiKey.get(q.Super.Authorize.AuthAPIKey); key == nil {
+			result.Super.Audit = result.Super.Audit.WithField(`permCache::status`, `processing`).
+				WithField(`permCache::result`, `InternalError`).
+				WithField(`permCache::error`, `APIKeyNotFound`)
+			goto dispatch
+		}
+		if key.subjectType == `team` {
+			teamID = key.subjectID
+		}
+	}
+
+	// check if the subject has omnipotence, which can not be used
+	// via API key
+	if key == nil && c.checkOmnipotence(subjType, user.ID, &result) {
 		result.Super.Audit = result.Super.Audit.
 			WithField(`permCache::status`, `evaluated`).
 			WithField(`permCache::result`, `omnipotent`)
@@ -92,20 +110,23 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 		actionID = action.ID
 	}
 
-	// check if the user has the correct system permission
-	if ok, invalid := c.checkSystem(category, subjType,
-		user.ID, &result); invalid {
-		result.Super.Audit = result.Super.Audit.
-			WithField(`permCache::status`, `processing`).
-			WithField(`permCache::result`, `InternalError`).
-			WithField(`permCache::error`, `InvalidMissingSystemPermission`)
-		goto dispatch
-	} else if ok {
-		result.Super.Audit = result.Super.Audit.
-			WithField(`permCache::status`, `evaluated`).
-			WithField(`permCache::result`, `systempermission`)
-		result.Super.Verdict = 200
-		goto dispatch
+	// check if the user has the correct system permission, which
+	// can not be used via API key
+	if key == nil {
+		if ok, invalid := c.checkSystem(category, subjType,
+			user.ID, &result); invalid {
+			result.Super.Audit = result.Super.Audit.
+				WithField(`permCache::status`, `processing`).
+				WithField(`permCache::result`, `InternalError`).
+				WithField(`permCache::error`, `InvalidMissingSystemPermission`)
+			goto dispatch
+		} else if ok {
+			result.Super.Audit = result.Super.Audit.
+				WithField(`permCache::status`, `evaluated`).
+				WithField(`permCache::result`, `systempermission`)
+			result.Super.Verdict = 200
+			goto dispatch
+		}
 	}
 	result.Super.Audit = result.Super.Audit.WithField(`permCache::system`, `false`)
 
@@ -127,6 +148,9 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 	sectionPermIDs = c.pmap.getSectionPermissionID(sectionID)
 	actionPermIDs = c.pmap.getActionPermissionID(sectionID, actionID)
 	mergedPermIDs = append(sectionPermIDs, actionPermIDs...)
+	if key != nil {
+		mergedPermIDs = key.filter(mergedPermIDs)
+	}
 
 	// check if we care about the specific object
 	switch q.Super.Authorize.Action {
@@ -135,8 +159,9 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 	}
 
 	// check if the user has one the permissions that map the
-	// requested action
-	if c.checkPermission(mergedPermIDs, any, q.Super.Authorize, subjType, user.ID,
+	// requested action. API keys bound to a team only act with the
+	// team's permissions
+	if (key == nil || key.subjectType == `user`) && c.checkPermission(mergedPermIDs, any, q.Super.Authorize, subjType, user.ID,
 		category, &result) {
 		result.Super.Audit = result.Super.Audit.
 			WithField(`permCache::status`, `evaluated`).
@@ -156,7 +181,7 @@ func (c *Cache) isAuthorized(q *msg.Request) msg.Result {
 	}
 
 	// check if the user's team has a specific grant for the action
-	if c.checkPermission(mergedPermIDs, any, q.Super.Authorize, `team`, user.TeamID,
+	if c.checkPermission(mergedPermIDs, any, q.Super.Authorize, `team`, teamID,
 		category, &result) {
 		result.Super.Audit = result.Super.Audit.
 			WithField(`permCache::status`, `evaluated`).
@@ -229,7 +254,7 @@ permloop:
 				objID = q.Repository.TeamID
 			// per-repository scope
 			case msg.SectionInstance, msg.SectionNodeConfig, msg.SectionPropertyCustom,
-				msg.SectionRepositoryConfig:
+				msg.SectionRepositoryConfig, msg.SectionMaintenance:
 				objID = q.Repository.ID
 			case msg.SectionBucket, msg.SectionCluster, msg.SectionCheckConfig,
 				msg.SectionGroup:
@@ -250,7 +275,8 @@ permloop:
 			}
 		case msg.SectionBucket, msg.SectionCheckConfig, msg.SectionCluster,
 			msg.SectionGroup, msg.SectionInstance, msg.SectionNodeConfig,
-			msg.SectionPropertyCustom, msg.SectionRepositoryConfig:
+			msg.SectionPropertyCustom, msg.SectionRepositoryConfig,
+			msg.SectionMaintenance:
 			// per-repository sections
 			if c.grantRepository.assess(subjectType, subjectID,
 				category, objID, permID, any, result) {
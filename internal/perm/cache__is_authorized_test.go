@@ -0,0 +1,101 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package perm
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAPIKeyGlobalPermissions(t *testing.T) {
+	const (
+		userID    = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a001`
+		teamID    = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a002`
+		sectionID = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a003`
+		actionID  = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a004`
+		systemID  = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a005`
+		globalID  = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a006`
+		keyID     = `7f0c5a3c-5f0e-4a4b-9a52-1c1fd1a0a007`
+		omniID    = `00000000-0000-0000-0000-000000000000`
+	)
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+
+	tests := []struct {
+		name       string
+		omnipotent bool
+		system     bool
+		global     bool
+		apiKey     bool
+		keyPerms   []string
+		verdict    uint16
+	}{
+		{`omnipotence`, true, false, false, false, nil, 200},
+		{`omnipotence via key`, true, false, false, true,
+			[]string{globalID}, 403},
+		{`system`, false, true, false, false, nil, 200},
+		{`system via key`, false, true, false, true,
+			[]string{globalID, systemID}, 403},
+		{`permission`, false, false, true, false, nil, 200},
+		{`permission via key`, false, false, true, true,
+			[]string{globalID}, 200},
+		{`permission not on key`, false, false, true, true,
+			[]string{}, 403},
+	}
+
+	for _, test := range tests {
+		c := New()
+		c.user.add(userID, `alice`, teamID)
+		c.section.add(sectionID, msg.SectionServer, `global`)
+		c.action.add(sectionID, msg.SectionServer, actionID, `show`,
+			`global`)
+		c.pmap.addPermission(systemID, `global`, `system`)
+		c.pmap.addPermission(globalID, `server`, `global`)
+		c.pmap.mapSection(sectionID, globalID)
+
+		if test.omnipotent {
+			c.grantGlobal.grant(`user`, userID, `omnipotence`, omniID,
+				`grant-omnipotence`, grantValidity{})
+		}
+		if test.system {
+			c.grantGlobal.grant(`user`, userID, `system`, systemID,
+				`grant-system`, grantValidity{})
+		}
+		if test.global {
+			c.grantGlobal.grant(`user`, userID, `global`, globalID,
+				`grant-global`, grantValidity{})
+		}
+
+		q := &msg.Request{
+			Section: msg.SectionSupervisor,
+			Super: &msg.Supervisor{
+				Audit: logrus.NewEntry(logger),
+				Authorize: &msg.Request{
+					Section:  msg.SectionServer,
+					Action:   `show`,
+					AuthUser: `alice`,
+				},
+			},
+		}
+		if test.apiKey {
+			c.apiKey.add(keyID, `user`, userID, test.keyPerms)
+			q.Super.Authorize.AuthAPIKey = keyID
+		}
+
+		if res := c.IsAuthorized(q); res.Super.Verdict != test.verdict {
+			t.Errorf("%s: expected verdict %d, got %d", test.name,
+				test.verdict, res.Super.Verdict)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
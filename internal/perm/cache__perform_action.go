@@ -13,6 +13,30 @@ import (
 
 // These are the per-Action methods used in Cache.Perform
 
+// performAPIKeyCreate registers an API key with the permissions it
+// is restricted to
+func (c *Cache) performAPIKeyCreate(q *msg.Request) {
+	subjectType, subjectID := `user`, q.APIKey.UserID
+	if q.APIKey.TeamID != `` {
+		subjectType, subjectID = `team`, q.APIKey.TeamID
+	}
+	permIDs := make([]string, 0, len(q.APIKey.Permissions))
+	for _, permission := range q.APIKey.Permissions {
+		permIDs = append(permIDs, permission.ID)
+	}
+
+	c.lock.Lock()
+	c.apiKey.add(q.APIKey.ID, subjectType, subjectID, permIDs)
+	c.lock.Unlock()
+}
+
+// performAPIKeyRevoke removes a revoked API key
+func (c *Cache) performAPIKeyRevoke(q *msg.Request) {
+	c.lock.Lock()
+	c.apiKey.rm(q.APIKey.ID)
+	c.lock.Unlock()
+}
+
 // performActionAdd registers an action
 func (c *Cache) performActionAdd(q *msg.Request) {
 	c.lock.Lock()
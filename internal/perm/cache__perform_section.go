@@ -11,6 +11,15 @@ import "github.com/mjolnir42/soma/internal/msg"
 
 // These are the per-Section methods used in Cache.Perform
 
+func (c *Cache) performAPIKey(q *msg.Request) {
+	switch q.Action {
+	case msg.ActionCreate:
+		c.performAPIKeyCreate(q)
+	case msg.ActionRevoke:
+		c.performAPIKeyRevoke(q)
+	}
+}
+
 func (c *Cache) performAction(q *msg.Request) {
 	switch q.Action {
 	case msg.ActionAdd:
@@ -35,6 +44,12 @@ func (c *Cache) performBucket(q *msg.Request) {
 		c.performBucketCreate(q)
 	case msg.ActionDestroy:
 		c.performBucketDestroy(q)
+	case msg.ActionMemberUnassign:
+		// unassigning a node from its bucket removes it from
+		// the repository
+		if q.TargetEntity == msg.EntityNode {
+			c.performNodeUnassign(q)
+		}
 	}
 }
 
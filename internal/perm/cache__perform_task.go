@@ -142,6 +142,7 @@ func (c *Cache) performRightGrantScopeMonitoring(q *msg.Request) {
 			q.Grant.ObjectID,
 			q.Grant.PermissionID,
 			q.Grant.ID,
+			newGrantValidity(q.Grant),
 		)
 	}
 }
@@ -157,6 +158,7 @@ func (c *Cache) performRightGrantScopeRepository(q *msg.Request) {
 			q.Grant.ObjectID,
 			q.Grant.PermissionID,
 			q.Grant.ID,
+			newGrantValidity(q.Grant),
 		)
 	}
 }
@@ -172,6 +174,7 @@ func (c *Cache) performRightGrantScopeTeam(q *msg.Request) {
 			q.Grant.ObjectID,
 			q.Grant.PermissionID,
 			q.Grant.ID,
+			newGrantValidity(q.Grant),
 		)
 	}
 }
@@ -184,6 +187,7 @@ func (c *Cache) performRightGrantUnscoped(q *msg.Request) {
 		q.Grant.Category,
 		q.Grant.PermissionID,
 		q.Grant.ID,
+		newGrantValidity(q.Grant),
 	)
 }
 
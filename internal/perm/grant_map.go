@@ -9,6 +9,7 @@ package perm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mjolnir42/soma/internal/msg"
 )
@@ -26,6 +27,8 @@ type unscopedGrantMap struct {
 	grants map[string]map[string]map[string]string
 	// grantID -> subject|category|permissionID
 	byGrant map[string]map[string]string
+	// grantID -> validity period
+	validity map[string]grantValidity
 }
 
 // newUnscopedGrantMap returns an initialized unscopedGrantMap
@@ -33,12 +36,13 @@ func newUnscopedGrantMap() *unscopedGrantMap {
 	u := unscopedGrantMap{}
 	u.grants = map[string]map[string]map[string]string{}
 	u.byGrant = map[string]map[string]string{}
+	u.validity = map[string]grantValidity{}
 	return &u
 }
 
 // grant records a grant of a permission to a subject into the cache
 func (m *unscopedGrantMap) grant(subjType, subjID, category,
-	permissionID, grantID string) {
+	permissionID, grantID string, validity grantValidity) {
 	// only accept these four types
 	switch subjType {
 	case `user`, `admin`, `tool`, `team`:
@@ -61,6 +65,7 @@ func (m *unscopedGrantMap) grant(subjType, subjID, category,
 		`category`:     category,
 		`permissionID`: permissionID,
 	}
+	m.validity[grantID] = validity
 }
 
 // revoke removes a grant of a permission from the cache
@@ -72,6 +77,7 @@ func (m *unscopedGrantMap) revoke(grantID string) {
 	subject := fmt.Sprintf("%s:%s", g[`subjType`], g[`subjID`])
 	delete(m.grants[subject][g[`category`]], g[`permissionID`])
 	delete(m.byGrant, grantID)
+	delete(m.validity, grantID)
 }
 
 // getPermissionGrantID returns all grantIDs for a permissionID
@@ -137,6 +143,13 @@ func (m *unscopedGrantMap) assess(subjType, subjID, category,
 	}
 	if grantID, ok := m.grants[subject][category][permissionID]; ok {
 		if grantID != `` {
+			// grant is outside of its validity period
+			if reason := m.validity[grantID].check(time.Now()); reason != `` {
+				result.Super.Audit = result.Super.Audit.
+					WithField(prefix, reason).
+					WithField(prefix+`-grantID`, grantID)
+				return false
+			}
 			// subject has been granted the requested permission
 			result.Super.Audit = result.Super.Audit.
 				WithField(prefix, `SuccessFindingGrant`)
@@ -161,6 +174,8 @@ type scopedGrantMap struct {
 	grants map[string]map[string]map[string]map[string]string
 	// grantID -> subject|category|permissionID|objectID
 	byGrant map[string]map[string]string
+	// grantID -> validity period
+	validity map[string]grantValidity
 }
 
 // newScopedGrantMap return ans initialized scopedGrantMap
@@ -169,13 +184,14 @@ func newScopedGrantMap(mapscope string) *scopedGrantMap {
 	s.scope = mapscope
 	s.grants = map[string]map[string]map[string]map[string]string{}
 	s.byGrant = map[string]map[string]string{}
+	s.validity = map[string]grantValidity{}
 	return &s
 }
 
 // grant records a grant of a permission on an object to a subject
 // into the cache
 func (m *scopedGrantMap) grant(subjType, subjID, category, objID,
-	permissionID, grantID string) {
+	permissionID, grantID string, validity grantValidity) {
 	// only accept these four types
 	switch subjType {
 	case `user`, `admin`, `tool`, `team`:
@@ -202,6 +218,7 @@ func (m *scopedGrantMap) grant(subjType, subjID, category, objID,
 		`objID`:        objID,
 		`permissionID`: permissionID,
 	}
+	m.validity[grantID] = validity
 }
 
 // revoke removes a grant of a permission from the cache
@@ -214,6 +231,7 @@ func (m *scopedGrantMap) revoke(grantID string) {
 	delete(m.grants[subject][g[`category`]][g[`permissionID`]],
 		g[`objID`])
 	delete(m.byGrant, grantID)
+	delete(m.validity, grantID)
 }
 
 // getPermissionGrantID returns all grantIDs for a permissionID
@@ -299,15 +317,24 @@ func (m *scopedGrantMap) assess(subjType, subjID, category,
 	// object the permission was granted, only check that is what granted
 	// on some objects
 	if any {
-		if len(m.grants[subject][category][permissionID]) > 0 {
-			result.Super.Audit = result.Super.Audit.
-				WithField(prefix, `SuccessFindingAnyGrant`)
-			return true
+		for _, grantID := range m.grants[subject][category][permissionID] {
+			if m.validity[grantID].check(time.Now()) == `` {
+				result.Super.Audit = result.Super.Audit.
+					WithField(prefix, `SuccessFindingAnyGrant`)
+				return true
+			}
 		}
 	}
 
 	if grantID, ok := m.grants[subject][category][permissionID][objID]; ok {
 		if grantID != `` {
+			// grant is outside of its validity period
+			if reason := m.validity[grantID].check(time.Now()); reason != `` {
+				result.Super.Audit = result.Super.Audit.
+					WithField(prefix, reason).
+					WithField(prefix+`-grantID`, grantID)
+				return false
+			}
 			// subject has been granted the requested permission
 			// on the indicated object
 			result.Super.Audit = result.Super.Audit.
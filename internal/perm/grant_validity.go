@@ -0,0 +1,54 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package perm
+
+import (
+	"time"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// grantValidity is the optional validity period of a permission
+// grant. A zero value leaves that side of the period open.
+type grantValidity struct {
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// newGrantValidity returns the validity period of grant g. Since
+// grants fail closed, a timestamp that can not be parsed results in
+// a grant that is never valid.
+func newGrantValidity(g proto.Grant) grantValidity {
+	v := grantValidity{}
+	var errBefore, errAfter error
+
+	if g.NotBefore != `` {
+		v.notBefore, errBefore = time.Parse(time.RFC3339, g.NotBefore)
+	}
+	if g.NotAfter != `` {
+		v.notAfter, errAfter = time.Parse(time.RFC3339, g.NotAfter)
+	}
+	if errBefore != nil || errAfter != nil {
+		v.notAfter = time.Unix(0, 0)
+	}
+	return v
+}
+
+// check returns the reason why the grant is not valid at time t, or
+// the empty string if it is valid
+func (v grantValidity) check(t time.Time) string {
+	switch {
+	case !v.notAfter.IsZero() && !t.Before(v.notAfter):
+		return `GrantExpired`
+	case !v.notBefore.IsZero() && t.Before(v.notBefore):
+		return `GrantNotYetValid`
+	}
+	return ``
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,76 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package perm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestGrantValidity(t *testing.T) {
+	const (
+		start = `2026-10-01T00:00:00Z`
+		end   = `2026-10-31T00:00:00Z`
+	)
+	at := func(ts string) time.Time {
+		tm, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name      string
+		notBefore string
+		notAfter  string
+		now       string
+		reason    string
+	}{
+		{`unset`, ``, ``, `2026-10-15T00:00:00Z`, ``},
+		{`unset far past`, ``, ``, `1970-01-01T00:00:00Z`, ``},
+		{`within period`, start, end, `2026-10-15T12:00:00Z`, ``},
+		{`not yet valid`, start, end, `2026-09-30T23:59:59Z`,
+			`GrantNotYetValid`},
+		{`just before start`, start, ``, `2026-09-30T23:59:59.999Z`,
+			`GrantNotYetValid`},
+		{`exactly at start`, start, end, start, ``},
+		{`just before end`, start, end, `2026-10-30T23:59:59.999Z`, ``},
+		{`exactly at end`, start, end, end, `GrantExpired`},
+		{`expired`, start, end, `2026-11-01T00:00:00Z`, `GrantExpired`},
+		{`open start`, ``, end, `2000-01-01T00:00:00Z`, ``},
+		{`open start expired`, ``, end, end, `GrantExpired`},
+		{`open end`, start, ``, `2100-01-01T00:00:00Z`, ``},
+		{`other timezone`, `2026-10-01T02:00:00+02:00`, ``,
+			`2026-09-30T23:59:59Z`, `GrantNotYetValid`},
+		{`fractional seconds`, `2026-10-01T00:00:00.500Z`, ``,
+			`2026-10-01T00:00:00.250Z`, `GrantNotYetValid`},
+		// unparseable timestamps fail closed
+		{`invalid start`, `yesterday`, ``, `2026-10-15T00:00:00Z`,
+			`GrantExpired`},
+		{`invalid end`, ``, `2026-10-31`, `2026-10-15T00:00:00Z`,
+			`GrantExpired`},
+		{`invalid end valid start`, start, `never`,
+			`2026-10-15T00:00:00Z`, `GrantExpired`},
+	}
+
+	for _, test := range tests {
+		v := newGrantValidity(proto.Grant{
+			NotBefore: test.notBefore,
+			NotAfter:  test.notAfter,
+		})
+		if reason := v.check(at(test.now)); reason != test.reason {
+			t.Errorf("%s: expected %q, got %q", test.name, test.reason,
+				reason)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
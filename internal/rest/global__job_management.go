@@ -32,8 +32,7 @@ func (x *Rest) JobMgmtWait(w http.ResponseWriter, r *http.Request,
 	}
 
 	x.handlerMap.MustLookup(&request).Intake() <- request
-	<-request.Reply
-	x.replyNoContent(&w)
+	x.replyJobWait(&w, &request)
 }
 
 // JobMgmtList function
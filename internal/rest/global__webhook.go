@@ -0,0 +1,191 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// WebhookList function
+func (x *Rest) WebhookList(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWebhook
+	request.Action = msg.ActionList
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// WebhookShow function
+func (x *Rest) WebhookShow(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWebhook
+	request.Action = msg.ActionShow
+	request.Webhook = proto.Webhook{
+		ID: params.ByName(`webhookID`),
+	}
+
+	if err := checkStringIsUUID(request.Webhook.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// WebhookAdd function
+func (x *Rest) WebhookAdd(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWebhook
+	request.Action = msg.ActionAdd
+
+	cReq := proto.NewWebhookRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if err := validateWebhook(cReq.Webhook); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Webhook = proto.Webhook{
+		Name:         cReq.Webhook.Name,
+		URL:          cReq.Webhook.URL,
+		RepositoryID: cReq.Webhook.RepositoryID,
+		MonitoringID: cReq.Webhook.MonitoringID,
+		States:       cReq.Webhook.States,
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// WebhookRemove function
+func (x *Rest) WebhookRemove(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWebhook
+	request.Action = msg.ActionRemove
+	request.Webhook = proto.Webhook{
+		ID: params.ByName(`webhookID`),
+	}
+
+	if err := checkStringIsUUID(request.Webhook.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// validateWebhook checks a webhook subscription submitted by a client
+func validateWebhook(wh *proto.Webhook) error {
+	if wh == nil {
+		return fmt.Errorf(`Request contains no webhook`)
+	}
+	if wh.Name == `` {
+		return fmt.Errorf(`Webhook name must not be empty`)
+	}
+	u, err := url.Parse(wh.URL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != `http` && u.Scheme != `https`) || u.Hostname() == `` {
+		return fmt.Errorf("Invalid webhook URL: %s", wh.URL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("Webhook URL must not contain credentials: %s",
+			wh.URL)
+	}
+	// deliveries must not be sent to somad's own host
+	if strings.EqualFold(strings.TrimSuffix(u.Hostname(), `.`),
+		`localhost`) {
+		return fmt.Errorf("Webhook URL points to a local address: %s",
+			wh.URL)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil &&
+		(ip.IsLoopback() || ip.IsUnspecified() ||
+			ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()) {
+		return fmt.Errorf("Webhook URL points to a local address: %s",
+			wh.URL)
+	}
+	for _, id := range []string{wh.RepositoryID, wh.MonitoringID} {
+		if id == `` {
+			continue
+		}
+		if err := checkStringIsUUID(id); err != nil {
+			return err
+		}
+	}
+	for _, state := range wh.States {
+		switch state {
+		case proto.DeploymentAwaitingComputation,
+			proto.DeploymentComputed,
+			proto.DeploymentAwaitingRollout,
+			proto.DeploymentRolloutInProgress,
+			proto.DeploymentActive,
+			proto.DeploymentRolloutFailed,
+			proto.DeploymentAwaitingDeprovision,
+			proto.DeploymentDeprovisionInProgress,
+			proto.DeploymentDeprovisionFailed,
+			proto.DeploymentDeprovisioned,
+			proto.DeploymentAwaitingDeletion,
+			proto.DeploymentBlocked:
+		default:
+			return fmt.Errorf("Invalid deployment state filter: %s", state)
+		}
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
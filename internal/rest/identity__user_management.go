@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/mjolnir42/soma/internal/mail"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/lib/proto"
 )
@@ -125,6 +126,12 @@ func (x *Rest) UserMgmtAdd(w http.ResponseWriter, r *http.Request,
 			`Invalid username containing : character`))
 		return
 	}
+	if cReq.User.MailAddress != `` {
+		if err := mail.ValidAddress(cReq.User.MailAddress); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
 	request.User.UserName = cReq.User.UserName
 	request.User.FirstName = cReq.User.FirstName
 	request.User.LastName = cReq.User.LastName
@@ -164,6 +171,12 @@ func (x *Rest) UserMgmtUpdate(w http.ResponseWriter, r *http.Request,
 			`Invalid username containing : character`))
 		return
 	}
+	if cReq.User.MailAddress != `` {
+		if err := mail.ValidAddress(cReq.User.MailAddress); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
 	if params.ByName(`userID`) != cReq.User.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			`Mismatching user UUIDs in body and URL`))
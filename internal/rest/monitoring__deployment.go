@@ -11,9 +11,12 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
 )
 
 // DeploymentShow function
@@ -135,12 +138,78 @@ func (x *Rest) DeploymentPending(w http.ResponseWriter, r *http.Request,
 // DeploymentFilter function
 func (x *Rest) DeploymentFilter(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
+	defer panicCatcher(w)
 
 	request := msg.New(r, params)
 	request.Section = msg.SectionDeployment
 	request.Action = msg.ActionFilter
 
-	x.replyNotImplemented(&w, &request, nil)
+	if err := checkStringIsUUID(params.ByName(`monitoringID`)); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Monitoring.ID = params.ByName(`monitoringID`)
+
+	switch params.ByName(`state`) {
+	case proto.DeploymentAwaitingRollout,
+		proto.DeploymentRolloutInProgress,
+		proto.DeploymentActive,
+		proto.DeploymentRolloutFailed,
+		proto.DeploymentAwaitingDeprovision,
+		proto.DeploymentDeprovisionInProgress,
+		proto.DeploymentDeprovisionFailed:
+		request.Search.Deployment.State = params.ByName(`state`)
+	default:
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Invalid deployment state: %s", params.ByName(`state`)))
+		return
+	}
+
+	// all further filter conditions are optional query parameters
+	query := r.URL.Query()
+	switch query.Get(`task`) {
+	case ``, proto.TaskRollout, proto.TaskDeprovision, proto.TaskDelete:
+		request.Search.Deployment.Task = query.Get(`task`)
+	default:
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Invalid deployment task: %s", query.Get(`task`)))
+		return
+	}
+	if query.Get(`capability`) != `` {
+		if err := checkStringIsUUID(query.Get(`capability`)); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+	if query.Get(`since`) != `` {
+		if _, err := time.Parse(time.RFC3339, query.Get(`since`)); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+	if query.Get(`detailed`) != `` {
+		detailed, err := strconv.ParseBool(query.Get(`detailed`))
+		if err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		request.Search.IsDetailed = detailed
+	}
+	request.Search.Deployment.Repository = query.Get(`repository`)
+	request.Search.Deployment.Bucket = query.Get(`bucket`)
+	request.Search.Deployment.View = query.Get(`view`)
+	request.Search.Deployment.CapabilityID = query.Get(`capability`)
+	request.Search.Deployment.Datacenter = query.Get(`datacenter`)
+	request.Search.Deployment.ChangedSince = query.Get(`since`)
+
+	// BUG	if !x.isAuthorized(&request) {
+	// BUG		x.replyForbidden(&w, &request)
+	// BUG		return
+	// BUG	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -11,6 +11,7 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mjolnir42/soma/internal/msg"
@@ -89,6 +90,34 @@ func (x *Rest) WorkflowSearch(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// WorkflowHistory returns the recorded deployment state transitions
+// of a check instance
+func (x *Rest) WorkflowHistory(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWorkflow
+	request.Action = msg.ActionHistory
+	request.Workflow = proto.Workflow{
+		InstanceID: params.ByName(`instanceID`),
+	}
+
+	if err := checkStringIsUUID(request.Workflow.InstanceID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
 // WorkflowRetry function
 func (x *Rest) WorkflowRetry(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
@@ -150,6 +179,20 @@ func (x *Rest) WorkflowSet(w http.ResponseWriter, r *http.Request,
 	default:
 	}
 
+	// the next status is determined by the deployment workflow
+	if next, ok := proto.DeploymentNextStatus(
+		cReq.Workflow.Status,
+	); !ok {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Unknown deployment state: %s", cReq.Workflow.Status))
+		return
+	} else if next != cReq.Workflow.NextStatus {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Deployment state %s requires next status %s",
+			cReq.Workflow.Status, next))
+		return
+	}
+
 	// It's dangerous out there, take this -f
 	if !cReq.Flags.Forced {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
@@ -172,4 +215,155 @@ func (x *Rest) WorkflowSet(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// WorkflowBulkRetry reschedules all check instances in a failed
+// state that match the request filter
+func (x *Rest) WorkflowBulkRetry(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWorkflow
+	request.Action = msg.ActionBulkRetry
+
+	cReq := proto.NewWorkflowBulkRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if err := validateWorkflowFilter(cReq.Filter); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	for _, state := range cReq.Filter.Workflow.States {
+		switch state {
+		case proto.DeploymentRolloutFailed,
+			proto.DeploymentDeprovisionFailed:
+		default:
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Retry requires a failed deployment state, not %s",
+				state))
+			return
+		}
+	}
+	request.Search.Workflow = *cReq.Filter.Workflow
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// WorkflowBulkSet moves all check instances that match the request
+// filter into the requested workflow state
+func (x *Rest) WorkflowBulkSet(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionWorkflow
+	request.Action = msg.ActionBulkSet
+
+	cReq := proto.NewWorkflowBulkRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if err := validateWorkflowFilter(cReq.Filter); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if cReq.Workflow == nil || cReq.Workflow.Status == `` {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			`No target workflow status specified`))
+		return
+	}
+
+	// set requests select the states they move instances out of
+	// explicitly, every one must allow the requested transition
+	if len(cReq.Filter.Workflow.States) == 0 {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			`No workflow states to select instances by specified`))
+		return
+	}
+	for _, state := range cReq.Filter.Workflow.States {
+		if err := proto.ValidateDeploymentTransition(
+			state, cReq.Workflow.Status,
+		); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+	if next, _ := proto.DeploymentNextStatus(
+		cReq.Workflow.Status,
+	); cReq.Workflow.NextStatus != `` && cReq.Workflow.NextStatus != next {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Deployment state %s requires next status %s",
+			cReq.Workflow.Status, next))
+		return
+	}
+
+	// It's dangerous out there, take this -f
+	if cReq.Flags == nil || !cReq.Flags.Forced {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			`WorkflowBulkSet request declined, force required.`))
+		return
+	}
+	request.Search.Workflow = *cReq.Filter.Workflow
+	request.Workflow = proto.Workflow{
+		Status: cReq.Workflow.Status,
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// validateWorkflowFilter checks the filter of a bulk workflow request
+func validateWorkflowFilter(f *proto.Filter) error {
+	if f == nil || f.Workflow == nil {
+		return fmt.Errorf(`Request contains no workflow filter`)
+	}
+	if f.Workflow.Status != `` {
+		f.Workflow.States = append(f.Workflow.States, f.Workflow.Status)
+		f.Workflow.Status = ``
+	}
+	for _, state := range f.Workflow.States {
+		if _, ok := proto.DeploymentNextStatus(state); !ok {
+			return fmt.Errorf("Unknown deployment state: %s", state)
+		}
+	}
+	for _, id := range []string{
+		f.Workflow.MonitoringID,
+		f.Workflow.RepositoryID,
+		f.Workflow.CapabilityID,
+	} {
+		if id == `` {
+			continue
+		}
+		if err := checkStringIsUUID(id); err != nil {
+			return err
+		}
+	}
+	if f.Workflow.MinAge != `` {
+		age, err := time.ParseDuration(f.Workflow.MinAge)
+		if err != nil {
+			return err
+		}
+		if age < 0 {
+			return fmt.Errorf("Negative minimum age: %s",
+				f.Workflow.MinAge)
+		}
+	}
+	return nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,397 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// RepositoryBatch function processes an ordered list of operations on
+// a repository as a single job. Every operation is authorized as if
+// it was submitted to its own endpoint.
+func (x *Rest) RepositoryBatch(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionRepository
+	request.Action = msg.ActionBatch
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewBatchRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Repository.ID = params.ByName(`repositoryID`)
+
+	if cReq.Batch == nil || len(cReq.Batch.Operations) == 0 {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			`Batch request without operations`))
+		return
+	}
+
+	request.Batch = make([]msg.Request, 0, len(cReq.Batch.Operations))
+	for i := range cReq.Batch.Operations {
+		sub, err := x.batchOperation(&request, &cReq.Batch.Operations[i])
+		if err != nil {
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Batch operation %d: %s", i, err.Error()))
+			return
+		}
+
+		if !x.isAuthorizedBatch(&sub) {
+			x.replyForbidden(&w, &request)
+			return
+		}
+		request.Batch = append(request.Batch, sub)
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// batchOperation converts a single operation of a batch request into
+// the request it would be as standalone request
+func (x *Rest) batchOperation(q *msg.Request,
+	op *proto.BatchOperation) (msg.Request, error) {
+	var err error
+
+	sub := msg.Request{
+		ID:         q.ID,
+		Section:    op.Section,
+		Action:     op.Action,
+		RemoteAddr: q.RemoteAddr,
+		AuthUser:   q.AuthUser,
+		RequestURI: q.RequestURI,
+		Reply:      make(chan msg.Result, 1),
+		Flag:       q.Flag,
+	}
+	sub.Repository.ID = q.Repository.ID
+	if op.Request.Property != nil {
+		sub.Property.Type = op.Request.Property.Type
+	}
+
+	switch op.Section {
+	case msg.SectionRepositoryConfig:
+		err = batchRepositoryConfig(&sub, &op.Request)
+	case msg.SectionBucket:
+		err = batchBucket(&sub, &op.Request)
+	case msg.SectionGroup:
+		err = batchGroup(&sub, &op.Request)
+	case msg.SectionCluster:
+		err = batchCluster(&sub, &op.Request)
+	case msg.SectionNodeConfig:
+		err = batchNodeConfig(&sub, &op.Request)
+	case msg.SectionCheckConfig:
+		err = x.batchCheckConfig(&sub, &op.Request)
+	default:
+		err = batchUnsupported(&sub)
+	}
+	return sub, err
+}
+
+// isAuthorizedBatch checks the authorization of a single operation of
+// a batch request
+func (x *Rest) isAuthorizedBatch(q *msg.Request) bool {
+	if q.Section == msg.SectionCheckConfig {
+		// check configurations also require permission to use the
+		// monitoring system
+		use := *q
+		use.Section = msg.SectionMonitoring
+		use.Action = msg.ActionUse
+		if !x.isAuthorized(&use) {
+			return false
+		}
+	}
+	return x.isAuthorized(q)
+}
+
+// batchRepositoryConfig builds repository property operations
+func batchRepositoryConfig(q *msg.Request, cReq *proto.Request) error {
+	switch {
+	case cReq.Repository == nil:
+		return fmt.Errorf(`Repository data missing`)
+	case cReq.Repository.ID != q.Repository.ID:
+		return fmt.Errorf("Mismatched repository ids: %s, %s",
+			q.Repository.ID, cReq.Repository.ID)
+	}
+	q.Repository = cReq.Repository.Clone()
+	q.TargetEntity = msg.EntityRepository
+
+	switch q.Action {
+	case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+		msg.ActionPropertyDestroy, msg.ActionPropertyReplace:
+		if err := batchProperty(q, q.Repository.Properties); err != nil {
+			return err
+		}
+		if q.Repository.Properties != nil {
+			for i := range *q.Repository.Properties {
+				(*q.Repository.Properties)[i].RepositoryID = q.Repository.ID
+			}
+		}
+		return nil
+	}
+	return batchUnsupported(q)
+}
+
+// batchBucket builds bucket membership and property operations
+func batchBucket(q *msg.Request, cReq *proto.Request) error {
+	if cReq.Bucket == nil || cReq.Bucket.ID == `` {
+		return fmt.Errorf(`Bucket data missing`)
+	}
+	q.Bucket = cReq.Bucket.Clone()
+	q.Bucket.RepositoryID = q.Repository.ID
+
+	switch q.Action {
+	case msg.ActionMemberAssign:
+		entity, err := batchMemberEntity(q.Bucket.MemberGroups,
+			q.Bucket.MemberClusters, q.Bucket.MemberNodes)
+		if err != nil {
+			return err
+		}
+		q.TargetEntity = entity
+	case msg.ActionMemberUnassign:
+		// groups and clusters can not exist outside of a bucket,
+		// they have to be destroyed instead
+		if q.Bucket.MemberNodes == nil || len(*q.Bucket.MemberNodes) != 1 ||
+			q.Bucket.MemberGroups != nil || q.Bucket.MemberClusters != nil {
+			return fmt.Errorf(
+				`Bucket member unassignment requires exactly one node`)
+		}
+		q.TargetEntity = msg.EntityNode
+		q.Node.ID = (*q.Bucket.MemberNodes)[0].ID
+	case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+		msg.ActionPropertyDestroy, msg.ActionPropertyReplace:
+		if err := batchProperty(q, q.Bucket.Properties); err != nil {
+			return err
+		}
+		q.TargetEntity = msg.EntityBucket
+		if q.Bucket.Properties != nil {
+			for i := range *q.Bucket.Properties {
+				(*q.Bucket.Properties)[i].BucketID = q.Bucket.ID
+			}
+		}
+	default:
+		return batchUnsupported(q)
+	}
+	return nil
+}
+
+// batchGroup builds group membership and property operations
+func batchGroup(q *msg.Request, cReq *proto.Request) error {
+	if cReq.Group == nil || cReq.Group.ID == `` || cReq.Group.BucketID == `` {
+		return fmt.Errorf(`Group data missing`)
+	}
+	q.Group = cReq.Group.Clone()
+	q.Group.RepositoryID = q.Repository.ID
+	q.Bucket.ID = q.Group.BucketID
+
+	switch q.Action {
+	case msg.ActionMemberAssign, msg.ActionMemberUnassign:
+		entity, err := batchMemberEntity(q.Group.MemberGroups,
+			q.Group.MemberClusters, q.Group.MemberNodes)
+		if err != nil {
+			return err
+		}
+		q.TargetEntity = entity
+		if entity == msg.EntityCluster {
+			q.Cluster.BucketID = q.Group.BucketID
+		}
+	case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+		msg.ActionPropertyDestroy, msg.ActionPropertyReplace:
+		if err := batchProperty(q, q.Group.Properties); err != nil {
+			return err
+		}
+		q.TargetEntity = msg.EntityGroup
+		if q.Group.Properties != nil {
+			for i := range *q.Group.Properties {
+				(*q.Group.Properties)[i].RepositoryID = q.Repository.ID
+				(*q.Group.Properties)[i].BucketID = q.Group.BucketID
+			}
+		}
+	default:
+		return batchUnsupported(q)
+	}
+	return nil
+}
+
+// batchCluster builds cluster membership and property operations
+func batchCluster(q *msg.Request, cReq *proto.Request) error {
+	if cReq.Cluster == nil || cReq.Cluster.ID == `` || cReq.Cluster.BucketID == `` {
+		return fmt.Errorf(`Cluster data missing`)
+	}
+	q.Cluster = cReq.Cluster.Clone()
+	q.Cluster.RepositoryID = q.Repository.ID
+	q.Bucket.ID = q.Cluster.BucketID
+
+	switch q.Action {
+	case msg.ActionMemberAssign, msg.ActionMemberUnassign:
+		if q.Cluster.Members == nil || len(*q.Cluster.Members) != 1 {
+			return fmt.Errorf(
+				`Cluster membership changes require exactly one node`)
+		}
+		q.TargetEntity = msg.EntityNode
+	case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+		msg.ActionPropertyDestroy, msg.ActionPropertyReplace:
+		if err := batchProperty(q, q.Cluster.Properties); err != nil {
+			return err
+		}
+		q.TargetEntity = msg.EntityCluster
+		if q.Cluster.Properties != nil {
+			for i := range *q.Cluster.Properties {
+				(*q.Cluster.Properties)[i].BucketID = q.Cluster.BucketID
+			}
+		}
+	default:
+		return batchUnsupported(q)
+	}
+	return nil
+}
+
+// batchNodeConfig builds node property operations
+func batchNodeConfig(q *msg.Request, cReq *proto.Request) error {
+	switch {
+	case cReq.Node == nil || cReq.Node.ID == ``:
+		return fmt.Errorf(`Node data missing`)
+	case cReq.Node.Config == nil:
+		return fmt.Errorf(`Node configuration data missing`)
+	case cReq.Node.Config.RepositoryID != q.Repository.ID:
+		return fmt.Errorf("Mismatched repository ids: %s, %s",
+			q.Repository.ID, cReq.Node.Config.RepositoryID)
+	case cReq.Node.Config.BucketID == ``:
+		return fmt.Errorf(`Node configuration data incomplete`)
+	}
+	q.Node = cReq.Node.Clone()
+	q.Bucket.ID = q.Node.Config.BucketID
+
+	switch q.Action {
+	case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+		msg.ActionPropertyDestroy, msg.ActionPropertyReplace:
+		if err := batchProperty(q, q.Node.Properties); err != nil {
+			return err
+		}
+		q.TargetEntity = msg.EntityNode
+		if q.Node.Properties != nil {
+			for i := range *q.Node.Properties {
+				(*q.Node.Properties)[i].RepositoryID = q.Repository.ID
+				(*q.Node.Properties)[i].BucketID = q.Bucket.ID
+			}
+		}
+	default:
+		return batchUnsupported(q)
+	}
+	return nil
+}
+
+// batchCheckConfig builds check configuration operations, including
+// the lookup of the monitoring system the check is deployed to
+func (x *Rest) batchCheckConfig(q *msg.Request, cReq *proto.Request) error {
+	switch {
+	case cReq.CheckConfig == nil:
+		return fmt.Errorf(`Check configuration data missing`)
+	case cReq.CheckConfig.RepositoryID != q.Repository.ID:
+		return fmt.Errorf("Mismatched repository ids: %s, %s",
+			q.Repository.ID, cReq.CheckConfig.RepositoryID)
+	}
+
+	switch q.Action {
+	case msg.ActionCreate:
+		q.CheckConfig = cReq.CheckConfig.Clone()
+	case msg.ActionUpdate:
+		if cReq.CheckConfig.ID == `` {
+			return fmt.Errorf(`Check configuration ID missing`)
+		}
+		q.CheckConfig = cReq.CheckConfig.Clone()
+	case msg.ActionDestroy:
+		if cReq.CheckConfig.ID == `` {
+			return fmt.Errorf(`Check configuration ID missing`)
+		}
+		q.CheckConfig = proto.CheckConfig{
+			ID:           cReq.CheckConfig.ID,
+			RepositoryID: cReq.CheckConfig.RepositoryID,
+		}
+		result := x.lookupSync(q, msg.SectionCheckConfig, msg.ActionShow)
+		if len(result.CheckConfig) == 0 {
+			return fmt.Errorf("Unknown check configuration %s",
+				q.CheckConfig.ID)
+		}
+		q.CheckConfig.CapabilityID = result.CheckConfig[0].CapabilityID
+	default:
+		return batchUnsupported(q)
+	}
+
+	// get the correct monitoringID based on the capability
+	q.Capability.ID = q.CheckConfig.CapabilityID
+	result := x.lookupSync(q, msg.SectionCapability, msg.ActionShow)
+	if len(result.Capability) == 0 {
+		return fmt.Errorf("Unknown capability %s", q.Capability.ID)
+	}
+	q.Monitoring.ID = result.Capability[0].MonitoringID
+	return nil
+}
+
+// batchProperty validates the properties of a batch property
+// operation
+func batchProperty(q *msg.Request, properties *[]proto.Property) error {
+	propertyType, err := validateProperties(q.Action, q.Property.Type,
+		properties)
+	if err != nil {
+		return err
+	}
+	q.Property.Type = propertyType
+	return nil
+}
+
+// batchMemberEntity returns the entity type of the single member of a
+// membership operation
+func batchMemberEntity(groups *[]proto.Group, clusters *[]proto.Cluster,
+	nodes *[]proto.Node) (string, error) {
+	var groupCount, clusterCount, nodeCount int
+	if groups != nil {
+		groupCount = len(*groups)
+	}
+	if clusters != nil {
+		clusterCount = len(*clusters)
+	}
+	if nodes != nil {
+		nodeCount = len(*nodes)
+	}
+
+	switch {
+	case groupCount == 1 && clusterCount == 0 && nodeCount == 0:
+		return msg.EntityGroup, nil
+	case groupCount == 0 && clusterCount == 1 && nodeCount == 0:
+		return msg.EntityCluster, nil
+	case groupCount == 0 && clusterCount == 0 && nodeCount == 1:
+		return msg.EntityNode, nil
+	}
+	return ``, fmt.Errorf(
+		"Membership changes require exactly one member,"+
+			" got %d groups, %d clusters and %d nodes",
+		groupCount, clusterCount, nodeCount,
+	)
+}
+
+// batchUnsupported returns the error for operations that can not be
+// part of a batch request
+func batchUnsupported(q *msg.Request) error {
+	return fmt.Errorf("Unsupported batch operation %s::%s",
+		q.Section, q.Action)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,213 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestBatchOperation(t *testing.T) {
+	const (
+		repoID   = `repoID`
+		bucketID = `bucketID`
+	)
+	nodes := func(n int) *[]proto.Node {
+		s := make([]proto.Node, n)
+		for i := range s {
+			s[i].ID = `nodeID`
+		}
+		return &s
+	}
+	system := &[]proto.Property{{
+		Type:   msg.PropertySystem,
+		System: &proto.PropertySystem{Name: `fqdn`, Value: `a.example.com`},
+	}}
+
+	tests := []struct {
+		name    string
+		op      proto.BatchOperation
+		err     bool
+		entity  string
+		propTyp string
+	}{
+		{name: `unsupported section`, op: proto.BatchOperation{
+			Section: msg.SectionMonitoring,
+			Action:  msg.ActionShow,
+		}, err: true},
+		{name: `repository mismatch`, op: proto.BatchOperation{
+			Section: msg.SectionRepositoryConfig,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Repository: &proto.Repository{
+				ID:         `otherRepoID`,
+				Properties: system,
+			}},
+		}, err: true},
+		{name: `repository property`, op: proto.BatchOperation{
+			Section: msg.SectionRepositoryConfig,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Repository: &proto.Repository{
+				ID:         repoID,
+				Properties: system,
+			}},
+		}, entity: msg.EntityRepository, propTyp: msg.PropertySystem},
+		{name: `bucket data missing`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionMemberAssign,
+		}, err: true},
+		{name: `bucket node assign`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Bucket: &proto.Bucket{
+				ID:          bucketID,
+				MemberNodes: nodes(1),
+			}},
+		}, entity: msg.EntityNode},
+		{name: `bucket assign two members`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Bucket: &proto.Bucket{
+				ID:           bucketID,
+				MemberNodes:  nodes(1),
+				MemberGroups: &[]proto.Group{{ID: `groupID`}},
+			}},
+		}, err: true},
+		{name: `bucket group unassign`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionMemberUnassign,
+			Request: proto.Request{Bucket: &proto.Bucket{
+				ID:           bucketID,
+				MemberGroups: &[]proto.Group{{ID: `groupID`}},
+			}},
+		}, err: true},
+		{name: `bucket destroy`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionDestroy,
+			Request: proto.Request{Bucket: &proto.Bucket{ID: bucketID}},
+		}, err: true},
+		{name: `bucket property without properties`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Bucket: &proto.Bucket{ID: bucketID}},
+		}, err: true},
+		{name: `bucket property`, op: proto.BatchOperation{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Bucket: &proto.Bucket{
+				ID:         bucketID,
+				Properties: system,
+			}},
+		}, entity: msg.EntityBucket, propTyp: msg.PropertySystem},
+		{name: `group without bucket`, op: proto.BatchOperation{
+			Section: msg.SectionGroup,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Group: &proto.Group{ID: `groupID`}},
+		}, err: true},
+		{name: `group cluster assign`, op: proto.BatchOperation{
+			Section: msg.SectionGroup,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Group: &proto.Group{
+				ID:             `groupID`,
+				BucketID:       bucketID,
+				MemberClusters: &[]proto.Cluster{{ID: `clusterID`}},
+			}},
+		}, entity: msg.EntityCluster},
+		{name: `cluster two nodes`, op: proto.BatchOperation{
+			Section: msg.SectionCluster,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Cluster: &proto.Cluster{
+				ID:       `clusterID`,
+				BucketID: bucketID,
+				Members:  nodes(2),
+			}},
+		}, err: true},
+		{name: `cluster node assign`, op: proto.BatchOperation{
+			Section: msg.SectionCluster,
+			Action:  msg.ActionMemberAssign,
+			Request: proto.Request{Cluster: &proto.Cluster{
+				ID:       `clusterID`,
+				BucketID: bucketID,
+				Members:  nodes(1),
+			}},
+		}, entity: msg.EntityNode},
+		{name: `node config mismatch`, op: proto.BatchOperation{
+			Section: msg.SectionNodeConfig,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Node: &proto.Node{
+				ID: `nodeID`,
+				Config: &proto.NodeConfig{
+					RepositoryID: `otherRepoID`,
+					BucketID:     bucketID,
+				},
+				Properties: system,
+			}},
+		}, err: true},
+		{name: `node property`, op: proto.BatchOperation{
+			Section: msg.SectionNodeConfig,
+			Action:  msg.ActionPropertyCreate,
+			Request: proto.Request{Node: &proto.Node{
+				ID: `nodeID`,
+				Config: &proto.NodeConfig{
+					RepositoryID: repoID,
+					BucketID:     bucketID,
+				},
+				Properties: system,
+			}},
+		}, entity: msg.EntityNode, propTyp: msg.PropertySystem},
+		{name: `check configuration missing`, op: proto.BatchOperation{
+			Section: msg.SectionCheckConfig,
+			Action:  msg.ActionCreate,
+		}, err: true},
+		{name: `check configuration mismatch`, op: proto.BatchOperation{
+			Section: msg.SectionCheckConfig,
+			Action:  msg.ActionCreate,
+			Request: proto.Request{CheckConfig: &proto.CheckConfig{
+				RepositoryID: `otherRepoID`,
+			}},
+		}, err: true},
+	}
+
+	x := &Rest{}
+	for _, test := range tests {
+		q := &msg.Request{
+			Section:  msg.SectionRepository,
+			Action:   msg.ActionBatch,
+			AuthUser: `alice`,
+		}
+		q.Repository.ID = repoID
+
+		sub, err := x.batchOperation(q, &test.op)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error state: %v", test.name, err)
+			continue
+		}
+		if test.err {
+			continue
+		}
+		switch {
+		case sub.Section != test.op.Section || sub.Action != test.op.Action:
+			t.Errorf("%s: request type changed to %s::%s", test.name,
+				sub.Section, sub.Action)
+		case sub.AuthUser != q.AuthUser:
+			t.Errorf("%s: lost user %s", test.name, q.AuthUser)
+		case sub.Repository.ID != repoID:
+			t.Errorf("%s: routed to repository %s", test.name,
+				sub.Repository.ID)
+		case sub.TargetEntity != test.entity:
+			t.Errorf("%s: expected entity %s, got %s", test.name,
+				test.entity, sub.TargetEntity)
+		case sub.Property.Type != test.propTyp:
+			t.Errorf("%s: expected property type %q, got %q", test.name,
+				test.propTyp, sub.Property.Type)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -132,6 +132,10 @@ func (x *Rest) BucketCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionBucket
 	request.Action = msg.ActionCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewBucketRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -172,6 +176,10 @@ func (x *Rest) BucketDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionBucket
 	request.Action = msg.ActionDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Bucket.RepositoryID = params.ByName(`repositoryID`)
 	request.Repository.ID = params.ByName(`repositoryID`)
@@ -217,19 +225,135 @@ func (x *Rest) BucketRename(w http.ResponseWriter, r *http.Request,
 // BucketMemberList function
 func (x *Rest) BucketMemberList(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
-	// TODO
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionBucket
+	request.Action = msg.ActionMemberList
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Bucket.RepositoryID = params.ByName(`repositoryID`)
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
 }
 
 // BucketMemberAssign function
 func (x *Rest) BucketMemberAssign(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
-	// TODO
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionBucket
+	request.Action = msg.ActionMemberAssign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewBucketRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket = cReq.Bucket.Clone()
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Bucket.RepositoryID = params.ByName(`repositoryID`)
+
+	// the request must contain exactly one member object
+	var groups, clusters, nodes int
+	if request.Bucket.MemberGroups != nil {
+		groups = len(*request.Bucket.MemberGroups)
+	}
+	if request.Bucket.MemberClusters != nil {
+		clusters = len(*request.Bucket.MemberClusters)
+	}
+	if request.Bucket.MemberNodes != nil {
+		nodes = len(*request.Bucket.MemberNodes)
+	}
+	switch {
+	case groups == 1 && clusters == 0 && nodes == 0:
+		request.TargetEntity = msg.EntityGroup
+		request.Bucket.MemberClusters = nil
+		request.Bucket.MemberNodes = nil
+	case groups == 0 && clusters == 1 && nodes == 0:
+		request.TargetEntity = msg.EntityCluster
+		request.Bucket.MemberGroups = nil
+		request.Bucket.MemberNodes = nil
+	case groups == 0 && clusters == 0 && nodes == 1:
+		request.TargetEntity = msg.EntityNode
+		request.Bucket.MemberGroups = nil
+		request.Bucket.MemberClusters = nil
+	default:
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Bucket member assignment requires exactly one member,"+
+				" got %d groups, %d clusters and %d nodes",
+			groups, clusters, nodes,
+		))
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
 }
 
 // BucketMemberUnassign function
 func (x *Rest) BucketMemberUnassign(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
-	// TODO
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionBucket
+	request.Action = msg.ActionMemberUnassign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Bucket.RepositoryID = params.ByName(`repositoryID`)
+
+	switch params.ByName(`memberType`) {
+	case msg.EntityNode:
+		request.TargetEntity = msg.EntityNode
+		request.Node.ID = params.ByName(`memberID`)
+		request.Bucket.MemberNodes = &[]proto.Node{
+			proto.Node{ID: params.ByName(`memberID`)},
+		}
+	case msg.EntityGroup, msg.EntityCluster:
+		// groups and clusters can not exist outside of a bucket,
+		// they have to be destroyed instead
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"A %s can not be unassigned from its bucket, use destroy",
+			params.ByName(`memberType`),
+		))
+		return
+	default:
+		x.replyBadRequest(&w, &request, nil)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
 }
 
 // BucketPropertyCreate function
@@ -240,6 +364,10 @@ func (x *Rest) BucketPropertyCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionBucket
 	request.Action = msg.ActionPropertyCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewBucketRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -247,26 +375,22 @@ func (x *Rest) BucketPropertyCreate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`bucketID`) != cReq.Bucket.ID:
+	if params.ByName(`bucketID`) != cReq.Bucket.ID {
 		x.replyBadRequest(&w, &request,
 			fmt.Errorf("Mismatched bucket ids: %s, %s",
 				params.ByName(`bucket`),
 				cReq.Bucket.ID))
 		return
-	case len(*cReq.Bucket.Properties) != 1:
-		x.replyBadRequest(&w, &request,
-			fmt.Errorf("Expected property count 1, actual count: %d",
-				len(*cReq.Bucket.Properties)))
-		return
-	case (*cReq.Bucket.Properties)[0].Type == `service` && (*cReq.Bucket.Properties)[0].Service.Name == ``:
-		x.replyBadRequest(&w, &request,
-			fmt.Errorf(`Empty service name is invalid`))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Bucket.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
 	}
 	request.TargetEntity = msg.EntityBucket
 	request.Bucket = cReq.Bucket.Clone()
-	request.Property.Type = (*cReq.Bucket.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -286,6 +410,10 @@ func (x *Rest) BucketPropertyDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionBucket
 	request.Action = msg.ActionPropertyDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	request.TargetEntity = msg.EntityBucket
 	request.Repository.ID = params.ByName(`repositoryID`)
@@ -302,6 +430,77 @@ func (x *Rest) BucketPropertyDestroy(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if params.ByName(`sourceID`) == `` {
+		// without source instance in the path, the properties to
+		// destroy are listed in the request body
+		cReq := proto.NewBucketRequest()
+		if err := decodeJSONBody(r, &cReq); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		if params.ByName(`bucketID`) != cReq.Bucket.ID {
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Mismatched bucket ids: %s, %s",
+				params.ByName(`bucketID`),
+				cReq.Bucket.ID))
+			return
+		}
+		request.Bucket.Properties = cReq.Bucket.Properties
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), request.Bucket.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Property.Type = propertyType
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// BucketPropertyReplace function
+func (x *Rest) BucketPropertyReplace(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionBucket
+	request.Action = msg.ActionPropertyReplace
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewBucketRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if params.ByName(`bucketID`) != cReq.Bucket.ID {
+		x.replyBadRequest(&w, &request,
+			fmt.Errorf("Mismatched bucket ids: %s, %s",
+				params.ByName(`bucket`),
+				cReq.Bucket.ID))
+		return
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), cReq.Bucket.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.TargetEntity = msg.EntityBucket
+	request.Bucket = cReq.Bucket.Clone()
+	request.Property.Type = propertyType
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -320,6 +519,10 @@ func (x *Rest) BucketPropertyUpdate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionBucket
 	request.Action = msg.ActionPropertyUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewBucketRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -327,26 +530,22 @@ func (x *Rest) BucketPropertyUpdate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`bucketID`) != cReq.Bucket.ID:
+	if params.ByName(`bucketID`) != cReq.Bucket.ID {
 		x.replyBadRequest(&w, &request,
 			fmt.Errorf("Mismatched bucket ids: %s, %s",
 				params.ByName(`bucket`),
 				cReq.Bucket.ID))
 		return
-	case len(*cReq.Bucket.Properties) != 1:
-		x.replyBadRequest(&w, &request,
-			fmt.Errorf("Expected property count 1, actual count: %d",
-				len(*cReq.Bucket.Properties)))
-		return
-	case (*cReq.Bucket.Properties)[0].Type == `service` && (*cReq.Bucket.Properties)[0].Service.Name == ``:
-		x.replyBadRequest(&w, &request,
-			fmt.Errorf(`Empty service name is invalid`))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Bucket.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
 	}
 	request.TargetEntity = msg.EntityBucket
 	request.Bucket = cReq.Bucket.Clone()
-	request.Property.Type = (*cReq.Bucket.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -103,6 +103,40 @@ func (x *Rest) CheckConfigShow(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// CheckConfigExplain function
+func (x *Rest) CheckConfigExplain(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionCheckConfig
+	request.Action = msg.ActionExplain
+
+	switch params.ByName(`entity`) {
+	case msg.EntityGroup, msg.EntityCluster, msg.EntityNode:
+	default:
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Invalid entity to explain check configuration on: %s",
+			params.ByName(`entity`)))
+		return
+	}
+	request.CheckConfig = proto.CheckConfig{
+		ID:           params.ByName(`checkID`),
+		RepositoryID: params.ByName(`repositoryID`),
+		ObjectID:     params.ByName(`objectID`),
+		ObjectType:   params.ByName(`entity`),
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
 // CheckConfigCreate function
 func (x *Rest) CheckConfigCreate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
@@ -111,6 +145,10 @@ func (x *Rest) CheckConfigCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionMonitoring
 	request.Action = msg.ActionUse
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewCheckConfigRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -150,6 +188,68 @@ func (x *Rest) CheckConfigCreate(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// CheckConfigUpdate function
+func (x *Rest) CheckConfigUpdate(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionCheckConfig
+	request.Action = msg.ActionUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewCheckConfigRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if cReq.CheckConfig.ID != params.ByName(`checkID`) ||
+		cReq.CheckConfig.RepositoryID != params.ByName(`repositoryID`) {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Mismatched check configuration IDs: %s/%s, %s/%s",
+			params.ByName(`repositoryID`), params.ByName(`checkID`),
+			cReq.CheckConfig.RepositoryID, cReq.CheckConfig.ID,
+		))
+		return
+	}
+	request.CheckConfig = cReq.CheckConfig.Clone()
+
+	//Get the correct monitoringID based on the provided capability
+	request.Section = msg.SectionCapability
+	request.Action = msg.ActionShow
+	request.Capability = proto.Capability{
+		ID: request.CheckConfig.CapabilityID,
+	}
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	if len(result.Capability) == 0 {
+		x.send(&w, &result)
+		return
+	}
+	request.Monitoring.ID = result.Capability[0].MonitoringID
+	request.Section = msg.SectionMonitoring
+	request.Action = msg.ActionUse
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	request.Section = msg.SectionCheckConfig
+	request.Action = msg.ActionUpdate
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result = <-request.Reply
+	x.send(&w, &result)
+}
+
 // CheckConfigDestroy function
 func (x *Rest) CheckConfigDestroy(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
@@ -162,6 +262,10 @@ func (x *Rest) CheckConfigDestroy(w http.ResponseWriter, r *http.Request,
 	}
 	request.Section = msg.SectionCheckConfig
 	request.Action = msg.ActionShow
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	x.handlerMap.MustLookup(&request).Intake() <- request
 	result := <-request.Reply
 	if len(result.CheckConfig) == 0 {
@@ -135,6 +135,10 @@ func (x *Rest) ClusterCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewClusterRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -170,6 +174,10 @@ func (x *Rest) ClusterDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Cluster.ID = params.ByName(`clusterID`)
@@ -216,6 +224,10 @@ func (x *Rest) ClusterMemberAssign(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionMemberAssign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewClusterRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -246,6 +258,10 @@ func (x *Rest) ClusterMemberUnassign(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionMemberUnassign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Cluster.ID = params.ByName(`clusterID`)
@@ -280,6 +296,10 @@ func (x *Rest) ClusterPropertyCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionPropertyCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewClusterRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -287,24 +307,18 @@ func (x *Rest) ClusterPropertyCreate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`clusterID`) != cReq.Cluster.ID:
+	if params.ByName(`clusterID`) != cReq.Cluster.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched cluster ids: %s, %s",
 			params.ByName(`clusterID`),
 			cReq.Cluster.ID,
 		))
 		return
-	case len(*cReq.Cluster.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Cluster.Properties),
-		))
-		return
-	case (*cReq.Cluster.Properties)[0].Type == `service` && (*cReq.Cluster.Properties)[0].Service.Name == ``:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			`Invalid empty service name`,
-		))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Cluster.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
 	}
 	request.TargetEntity = msg.EntityCluster
@@ -312,7 +326,7 @@ func (x *Rest) ClusterPropertyCreate(w http.ResponseWriter, r *http.Request,
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Cluster = cReq.Cluster.Clone()
 	request.Cluster.ID = params.ByName(`clusterID`)
-	request.Property.Type = (*cReq.Cluster.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -332,6 +346,10 @@ func (x *Rest) ClusterPropertyDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
 	request.Action = msg.ActionPropertyDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	request.TargetEntity = msg.EntityCluster
 	request.Repository.ID = params.ByName(`repositoryID`)
@@ -350,6 +368,31 @@ func (x *Rest) ClusterPropertyDestroy(w http.ResponseWriter, r *http.Request,
 		},
 	}
 
+	if params.ByName(`sourceID`) == `` {
+		// without source instance in the path, the properties to
+		// destroy are listed in the request body
+		cReq := proto.NewClusterRequest()
+		if err := decodeJSONBody(r, &cReq); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		if params.ByName(`clusterID`) != cReq.Cluster.ID {
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Mismatched cluster ids: %s, %s",
+				params.ByName(`clusterID`),
+				cReq.Cluster.ID))
+			return
+		}
+		request.Cluster.Properties = cReq.Cluster.Properties
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), request.Cluster.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Property.Type = propertyType
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -360,14 +403,18 @@ func (x *Rest) ClusterPropertyDestroy(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
-// ClusterPropertyUpdate function
-func (x *Rest) ClusterPropertyUpdate(w http.ResponseWriter, r *http.Request,
+// ClusterPropertyReplace function
+func (x *Rest) ClusterPropertyReplace(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer panicCatcher(w)
 
 	request := msg.New(r, params)
 	request.Section = msg.SectionCluster
-	request.Action = msg.ActionPropertyUpdate
+	request.Action = msg.ActionPropertyReplace
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewClusterRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -375,32 +422,76 @@ func (x *Rest) ClusterPropertyUpdate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`clusterID`) != cReq.Cluster.ID:
+	if params.ByName(`clusterID`) != cReq.Cluster.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched cluster ids: %s, %s",
 			params.ByName(`clusterID`),
 			cReq.Cluster.ID,
 		))
 		return
-	case len(*cReq.Cluster.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Cluster.Properties),
-		))
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), cReq.Cluster.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
-	case (*cReq.Cluster.Properties)[0].Type == `service` && (*cReq.Cluster.Properties)[0].Service.Name == ``:
+	}
+	request.TargetEntity = msg.EntityCluster
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Cluster = cReq.Cluster.Clone()
+	request.Cluster.ID = params.ByName(`clusterID`)
+	request.Property.Type = propertyType
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// ClusterPropertyUpdate function
+func (x *Rest) ClusterPropertyUpdate(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionCluster
+	request.Action = msg.ActionPropertyUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewClusterRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if params.ByName(`clusterID`) != cReq.Cluster.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
-			`Invalid empty service name`,
+			"Mismatched cluster ids: %s, %s",
+			params.ByName(`clusterID`),
+			cReq.Cluster.ID,
 		))
 		return
 	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Cluster.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.TargetEntity = msg.EntityCluster
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Cluster = cReq.Cluster.Clone()
 	request.Cluster.ID = params.ByName(`clusterID`)
-	request.Property.Type = (*cReq.Cluster.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
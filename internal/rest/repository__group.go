@@ -139,6 +139,10 @@ func (x *Rest) GroupCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewGroupRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -173,6 +177,10 @@ func (x *Rest) GroupDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Group.ID = params.ByName(`groupID`)
@@ -219,6 +227,10 @@ func (x *Rest) GroupMemberAssign(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionMemberAssign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewGroupRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -275,6 +287,10 @@ func (x *Rest) GroupMemberUnassign(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionMemberUnassign
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Group.ID = params.ByName(`groupID`)
@@ -322,6 +338,10 @@ func (x *Rest) GroupPropertyCreate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionPropertyCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewGroupRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -329,28 +349,24 @@ func (x *Rest) GroupPropertyCreate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`groupID`) != cReq.Group.ID:
+	if params.ByName(`groupID`) != cReq.Group.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched group ids: %s, %s",
 			params.ByName(`groupID`),
 			cReq.Group.ID))
 		return
-	case len(*cReq.Group.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Group.Properties)))
-		return
-	case (*cReq.Group.Properties)[0].Type == `service` && (*cReq.Group.Properties)[0].Service.Name == ``:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			`Empty service name is invalid`))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Group.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
 	}
 	request.TargetEntity = msg.EntityGroup
 	request.Group = cReq.Group.Clone()
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
-	request.Property.Type = (*cReq.Group.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -370,6 +386,10 @@ func (x *Rest) GroupPropertyDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionPropertyDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	request.TargetEntity = msg.EntityGroup
 	request.Repository.ID = params.ByName(`repositoryID`)
@@ -385,6 +405,79 @@ func (x *Rest) GroupPropertyDestroy(w http.ResponseWriter, r *http.Request,
 		SourceInstanceID: params.ByName(`sourceID`),
 	}}
 
+	if params.ByName(`sourceID`) == `` {
+		// without source instance in the path, the properties to
+		// destroy are listed in the request body
+		cReq := proto.NewGroupRequest()
+		if err := decodeJSONBody(r, &cReq); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		if params.ByName(`groupID`) != cReq.Group.ID {
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Mismatched group ids: %s, %s",
+				params.ByName(`groupID`),
+				cReq.Group.ID))
+			return
+		}
+		request.Group.Properties = cReq.Group.Properties
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), request.Group.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Property.Type = propertyType
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// GroupPropertyReplace function
+func (x *Rest) GroupPropertyReplace(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionGroup
+	request.Action = msg.ActionPropertyReplace
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewGroupRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if params.ByName(`groupID`) != cReq.Group.ID {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Mismatched group ids: %s, %s",
+			params.ByName(`groupID`),
+			cReq.Group.ID))
+		return
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), cReq.Group.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.TargetEntity = msg.EntityGroup
+	request.Group = cReq.Group.Clone()
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Property.Type = propertyType
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -403,6 +496,10 @@ func (x *Rest) GroupPropertyUpdate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionGroup
 	request.Action = msg.ActionPropertyUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewGroupRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -410,28 +507,24 @@ func (x *Rest) GroupPropertyUpdate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`groupID`) != cReq.Group.ID:
+	if params.ByName(`groupID`) != cReq.Group.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched group ids: %s, %s",
 			params.ByName(`groupID`),
 			cReq.Group.ID))
 		return
-	case len(*cReq.Group.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Group.Properties)))
-		return
-	case (*cReq.Group.Properties)[0].Type == `service` && (*cReq.Group.Properties)[0].Service.Name == ``:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			`Empty service name is invalid`))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Group.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
 	}
 	request.TargetEntity = msg.EntityGroup
 	request.Group = cReq.Group.Clone()
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
-	request.Property.Type = (*cReq.Group.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
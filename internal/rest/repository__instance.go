@@ -10,6 +10,7 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/mjolnir42/soma/internal/msg"
@@ -20,13 +21,16 @@ func (x *Rest) InstanceShow(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer panicCatcher(w)
 
-	// BUG needs scope switch repository|bucket|group|cluster|node
-
 	request := msg.New(r, params)
 	request.Section = msg.SectionInstance
 	request.Action = msg.ActionShow
 	request.Instance.ID = params.ByName(`instanceID`)
 
+	if err := instanceScope(&request, r, params); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -43,13 +47,16 @@ func (x *Rest) InstanceVersions(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer panicCatcher(w)
 
-	// BUG needs scope switch repository|bucket|group|cluster|node
-
 	request := msg.New(r, params)
 	request.Section = msg.SectionInstance
 	request.Action = msg.ActionVersions
 	request.Instance.ID = params.ByName(`instanceID`)
 
+	if err := instanceScope(&request, r, params); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -60,9 +67,9 @@ func (x *Rest) InstanceVersions(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
-// InstanceList returns the list of instances in the subtree
-// below the queried object.
-// Currently only supports repositories and buckets as target.
+// InstanceList returns the list of instances of the queried object.
+// Repositories and buckets always include their full subtree, groups
+// and clusters only if the query parameter recursive is set.
 func (x *Rest) InstanceList(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer panicCatcher(w)
@@ -71,22 +78,12 @@ func (x *Rest) InstanceList(w http.ResponseWriter, r *http.Request,
 	request.Section = msg.SectionInstance
 	request.Action = msg.ActionList
 
-	switch {
-	case params.ByName(`repository`) != ``:
-		request.Instance.ObjectType = `repository`
-		request.Instance.ObjectID = params.ByName(`repository`)
-		request.Instance.RepositoryID = params.ByName(`repository`)
-	case params.ByName(`bucket`) != ``:
-		request.Instance.ObjectType = `bucket`
-		request.Instance.ObjectID = params.ByName(`bucket`)
-		request.Instance.BucketID = params.ByName(`bucket`)
-	case params.ByName(`group`) != ``:
-		fallthrough
-	case params.ByName(`cluster`) != ``:
-		fallthrough
-	case params.ByName(`node`) != ``:
-		fallthrough
-	default:
+	if err := instanceScope(&request, r, params); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if request.Instance.ObjectType == `` {
 		x.replyNotImplemented(&w, &request, nil)
 		return
 	}
@@ -101,4 +98,42 @@ func (x *Rest) InstanceList(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// instanceScope sets the object an instance request is scoped to,
+// using the most specific object found in the route parameters. An
+// unscoped request leaves the object type empty.
+func instanceScope(request *msg.Request, r *http.Request,
+	params httprouter.Params) error {
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Instance.RepositoryID = params.ByName(`repositoryID`)
+	request.Instance.BucketID = params.ByName(`bucketID`)
+
+	switch {
+	case params.ByName(`nodeID`) != ``:
+		request.Instance.ObjectType = msg.EntityNode
+		request.Instance.ObjectID = params.ByName(`nodeID`)
+	case params.ByName(`clusterID`) != ``:
+		request.Instance.ObjectType = msg.EntityCluster
+		request.Instance.ObjectID = params.ByName(`clusterID`)
+	case params.ByName(`groupID`) != ``:
+		request.Instance.ObjectType = msg.EntityGroup
+		request.Instance.ObjectID = params.ByName(`groupID`)
+	case params.ByName(`bucketID`) != ``:
+		request.Instance.ObjectType = msg.EntityBucket
+		request.Instance.ObjectID = params.ByName(`bucketID`)
+	case params.ByName(`repositoryID`) != ``:
+		request.Instance.ObjectType = msg.EntityRepository
+		request.Instance.ObjectID = params.ByName(`repositoryID`)
+	}
+
+	if recursive := r.URL.Query().Get(`recursive`); recursive != `` {
+		var err error
+		if request.Flag.Recursive, err = strconv.ParseBool(
+			recursive,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,207 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// MaintenanceList function
+func (x *Rest) MaintenanceList(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionMaintenance
+	request.Action = msg.ActionList
+	request.Repository.ID = params.ByName(`repositoryID`)
+
+	if err := checkStringIsUUID(request.Repository.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// MaintenanceShow function
+func (x *Rest) MaintenanceShow(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionMaintenance
+	request.Action = msg.ActionShow
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Maintenance = proto.Maintenance{
+		ID: params.ByName(`maintenanceID`),
+	}
+
+	for _, id := range []string{
+		request.Repository.ID,
+		request.Maintenance.ID,
+	} {
+		if err := checkStringIsUUID(id); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// MaintenanceAdd function
+func (x *Rest) MaintenanceAdd(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionMaintenance
+	request.Action = msg.ActionAdd
+	request.Repository.ID = params.ByName(`repositoryID`)
+
+	if err := checkStringIsUUID(request.Repository.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewMaintenanceRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	if err := validateMaintenance(cReq.Maintenance,
+		request.Repository.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Maintenance = proto.Maintenance{
+		RepositoryID: request.Repository.ID,
+		ObjectType:   cReq.Maintenance.ObjectType,
+		ObjectID:     cReq.Maintenance.ObjectID,
+		StartsAt:     cReq.Maintenance.StartsAt,
+		EndsAt:       cReq.Maintenance.EndsAt,
+		Reason:       cReq.Maintenance.Reason,
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// MaintenanceRemove function
+func (x *Rest) MaintenanceRemove(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionMaintenance
+	request.Action = msg.ActionRemove
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Maintenance = proto.Maintenance{
+		ID: params.ByName(`maintenanceID`),
+	}
+
+	for _, id := range []string{
+		request.Repository.ID,
+		request.Maintenance.ID,
+	} {
+		if err := checkStringIsUUID(id); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// validateMaintenance checks a maintenance window submitted by a
+// client for repository repositoryID. A missing start time is set to
+// the current time, both times are normalized to UTC.
+func validateMaintenance(m *proto.Maintenance, repositoryID string) error {
+	var (
+		startsAt, endsAt time.Time
+		err              error
+	)
+
+	if m == nil {
+		return fmt.Errorf(`Request contains no maintenance window`)
+	}
+	switch m.ObjectType {
+	case `repository`:
+		if m.ObjectID == `` {
+			m.ObjectID = repositoryID
+		}
+		if m.ObjectID != repositoryID {
+			return fmt.Errorf("Maintenance window for repository %s"+
+				" submitted to repository %s", m.ObjectID, repositoryID)
+		}
+	case `bucket`, `group`, `cluster`, `node`:
+	default:
+		return fmt.Errorf("Invalid maintenance object type: %s",
+			m.ObjectType)
+	}
+	if err = checkStringIsUUID(m.ObjectID); err != nil {
+		return err
+	}
+	if m.Reason == `` {
+		return fmt.Errorf(`Maintenance reason must not be empty`)
+	}
+
+	if m.StartsAt == `` {
+		startsAt = time.Now().UTC()
+	} else if startsAt, err = time.Parse(time.RFC3339, m.StartsAt); err != nil {
+		return err
+	}
+	if endsAt, err = time.Parse(time.RFC3339, m.EndsAt); err != nil {
+		return err
+	}
+	if !endsAt.After(startsAt) {
+		return fmt.Errorf(`Maintenance window must end after it starts`)
+	}
+	if !endsAt.After(time.Now()) {
+		return fmt.Errorf(`Maintenance window ends in the past`)
+	}
+	m.StartsAt = startsAt.UTC().Format(msg.RFC3339Milli)
+	m.EndsAt = endsAt.UTC().Format(msg.RFC3339Milli)
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -24,6 +24,10 @@ func (x *Rest) NodeConfigAssign(w http.ResponseWriter,
 	request := msg.New(r, params)
 	request.Section = msg.SectionNode
 	request.Action = msg.ActionShow
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewNodeRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -84,6 +88,10 @@ func (x *Rest) NodeConfigUnassign(w http.ResponseWriter,
 	}
 	request.Section = msg.SectionNode
 	request.Action = msg.ActionShow
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	x.handlerMap.MustLookup(&request).Intake() <- request
 	result := <-request.Reply
 	// if there is no result we do not need to authorize it
@@ -123,6 +131,10 @@ func (x *Rest) NodeConfigPropertyCreate(w http.ResponseWriter,
 	request := msg.New(r, params)
 	request.Section = msg.SectionNodeConfig
 	request.Action = msg.ActionPropertyCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewNodeRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -130,31 +142,25 @@ func (x *Rest) NodeConfigPropertyCreate(w http.ResponseWriter,
 		return
 	}
 
-	switch {
-	case params.ByName(`nodeID`) != cReq.Node.ID:
+	if params.ByName(`nodeID`) != cReq.Node.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched node ids: %s, %s",
 			params.ByName(`nodeID`),
 			cReq.Node.ID))
 		return
-	case len(*cReq.Node.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Node.Properties)))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Node.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
-	case (*cReq.Node.Properties)[0].Type == "service":
-		if (*cReq.Node.Properties)[0].Service.Name == `` {
-			x.replyBadRequest(&w, &request, fmt.Errorf(
-				"Empty service name is invalid"))
-			return
-		}
 	}
 	request.TargetEntity = msg.EntityNode
 	request.Node = cReq.Node.Clone()
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Node.ID = params.ByName(`nodeID`)
-	request.Property.Type = (*cReq.Node.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -174,6 +180,10 @@ func (x *Rest) NodeConfigPropertyDestroy(w http.ResponseWriter,
 	request := msg.New(r, params)
 	request.Section = msg.SectionNodeConfig
 	request.Action = msg.ActionPropertyDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewNodeRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -216,6 +226,68 @@ func (x *Rest) NodeConfigPropertyDestroy(w http.ResponseWriter,
 		SourceInstanceID: params.ByName(`sourceID`),
 	}}
 
+	if params.ByName(`sourceID`) == `` {
+		// without source instance in the path, the properties to
+		// destroy are listed in the request body
+		request.Node.Properties = cReq.Node.Properties
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), request.Node.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Property.Type = propertyType
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// NodeConfigPropertyReplace function
+func (x *Rest) NodeConfigPropertyReplace(w http.ResponseWriter,
+	r *http.Request, params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionNodeConfig
+	request.Action = msg.ActionPropertyReplace
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewNodeRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if params.ByName(`nodeID`) != cReq.Node.ID {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Mismatched node ids: %s, %s",
+			params.ByName(`nodeID`),
+			cReq.Node.ID))
+		return
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), cReq.Node.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.TargetEntity = msg.EntityNode
+	request.Node = cReq.Node.Clone()
+	request.Repository.ID = params.ByName(`repositoryID`)
+	request.Bucket.ID = params.ByName(`bucketID`)
+	request.Node.ID = params.ByName(`nodeID`)
+	request.Property.Type = propertyType
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -234,6 +306,10 @@ func (x *Rest) NodeConfigPropertyUpdate(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionNodeConfig
 	request.Action = msg.ActionPropertyUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewNodeRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -241,31 +317,25 @@ func (x *Rest) NodeConfigPropertyUpdate(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	switch {
-	case params.ByName(`nodeID`) != cReq.Node.ID:
+	if params.ByName(`nodeID`) != cReq.Node.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf(
 			"Mismatched node ids: %s, %s",
 			params.ByName(`nodeID`),
 			cReq.Node.ID))
 		return
-	case len(*cReq.Node.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf(
-			"Expected property count 1, actual count: %d",
-			len(*cReq.Node.Properties)))
+	}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Node.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
 		return
-	case (*cReq.Node.Properties)[0].Type == "service":
-		if (*cReq.Node.Properties)[0].Service.Name == `` {
-			x.replyBadRequest(&w, &request, fmt.Errorf(
-				"Empty service name is invalid"))
-			return
-		}
 	}
 	request.TargetEntity = msg.EntityNode
 	request.Node = cReq.Node.Clone()
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Bucket.ID = params.ByName(`bucketID`)
 	request.Node.ID = params.ByName(`nodeID`)
-	request.Property.Type = (*cReq.Node.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -123,6 +123,26 @@ func (x *Rest) RepositoryConfigTree(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
+// RepositoryConfigExport function
+func (x *Rest) RepositoryConfigExport(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionRepositoryConfig
+	request.Action = msg.ActionExport
+	request.Repository.ID = params.ByName(`repositoryID`)
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
 // RepositoryConfigPropertyCreate function
 func (x *Rest) RepositoryConfigPropertyCreate(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
@@ -131,6 +151,10 @@ func (x *Rest) RepositoryConfigPropertyCreate(w http.ResponseWriter, r *http.Req
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepositoryConfig
 	request.Action = msg.ActionPropertyCreate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewRepositoryRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -138,26 +162,20 @@ func (x *Rest) RepositoryConfigPropertyCreate(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	switch {
-	case params.ByName(`repositoryID`) != cReq.Repository.ID:
+	if params.ByName(`repositoryID`) != cReq.Repository.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf("Mismatched repository ids: %s, %s",
 			params.ByName(`repositoryID`), cReq.Repository.ID))
 		return
-	case len(*cReq.Repository.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf("Expected property count 1, actual count: %d",
-			len(*cReq.Repository.Properties)))
-		return
 	}
-	switch (*cReq.Repository.Properties)[0].Type {
-	case `service`:
-		if (*cReq.Repository.Properties)[0].Service.Name == `` {
-			x.replyBadRequest(&w, &request, fmt.Errorf(`Invalid service name: empty string`))
-			return
-		}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Repository.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
 	}
 	request.Repository = cReq.Repository.Clone()
 	request.TargetEntity = msg.EntityRepository
-	request.Property.Type = (*cReq.Repository.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
@@ -177,6 +195,10 @@ func (x *Rest) RepositoryConfigPropertyDestroy(w http.ResponseWriter, r *http.Re
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepositoryConfig
 	request.Action = msg.ActionPropertyDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.TargetEntity = msg.EntityRepository
 	request.Property.Type = params.ByName(`propertyType`)
 	request.Repository.ID = params.ByName(`repositoryID`)
@@ -188,6 +210,75 @@ func (x *Rest) RepositoryConfigPropertyDestroy(w http.ResponseWriter, r *http.Re
 		},
 	}
 
+	if params.ByName(`sourceID`) == `` {
+		// without source instance in the path, the properties to
+		// destroy are listed in the request body
+		cReq := proto.NewRepositoryRequest()
+		if err := decodeJSONBody(r, &cReq); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		if params.ByName(`repositoryID`) != cReq.Repository.ID {
+			x.replyBadRequest(&w, &request, fmt.Errorf(
+				"Mismatched repository ids: %s, %s",
+				params.ByName(`repositoryID`),
+				cReq.Repository.ID))
+			return
+		}
+		request.Repository.Properties = cReq.Repository.Properties
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), request.Repository.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Property.Type = propertyType
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// RepositoryConfigPropertyReplace function
+func (x *Rest) RepositoryConfigPropertyReplace(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionRepositoryConfig
+	request.Action = msg.ActionPropertyReplace
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	cReq := proto.NewRepositoryRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if params.ByName(`repositoryID`) != cReq.Repository.ID {
+		x.replyBadRequest(&w, &request, fmt.Errorf("Mismatched repository ids: %s, %s",
+			params.ByName(`repositoryID`), cReq.Repository.ID))
+		return
+	}
+	propertyType, err := validateProperties(request.Action,
+		params.ByName(`propertyType`), cReq.Repository.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+	request.Repository = cReq.Repository.Clone()
+	request.TargetEntity = msg.EntityRepository
+	request.Property.Type = propertyType
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -206,6 +297,10 @@ func (x *Rest) RepositoryConfigPropertyUpdate(w http.ResponseWriter, r *http.Req
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepositoryConfig
 	request.Action = msg.ActionPropertyUpdate
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewRepositoryRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -213,26 +308,20 @@ func (x *Rest) RepositoryConfigPropertyUpdate(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	switch {
-	case params.ByName(`repositoryID`) != cReq.Repository.ID:
+	if params.ByName(`repositoryID`) != cReq.Repository.ID {
 		x.replyBadRequest(&w, &request, fmt.Errorf("Mismatched repository ids: %s, %s",
 			params.ByName(`repositoryID`), cReq.Repository.ID))
 		return
-	case len(*cReq.Repository.Properties) != 1:
-		x.replyBadRequest(&w, &request, fmt.Errorf("Expected property count 1, actual count: %d",
-			len(*cReq.Repository.Properties)))
-		return
 	}
-	switch (*cReq.Repository.Properties)[0].Type {
-	case `service`:
-		if (*cReq.Repository.Properties)[0].Service.Name == `` {
-			x.replyBadRequest(&w, &request, fmt.Errorf(`Invalid service name: empty string`))
-			return
-		}
+	propertyType, err := validateProperties(request.Action, ``,
+		cReq.Repository.Properties)
+	if err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
 	}
 	request.Repository = cReq.Repository.Clone()
 	request.TargetEntity = msg.EntityRepository
-	request.Property.Type = (*cReq.Repository.Properties)[0].Type
+	request.Property.Type = propertyType
 
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
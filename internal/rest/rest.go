@@ -9,8 +9,12 @@
 package rest // import "github.com/mjolnir42/soma/internal/rest"
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/mjolnir42/soma/internal/config"
@@ -19,9 +23,6 @@ import (
 	metrics "github.com/rcrowley/go-metrics"
 )
 
-// ShutdownInProgress indicates a pending service shutdown
-var ShutdownInProgress bool
-
 // Metrics is the map of runtime metric registries
 var Metrics = make(map[string]metrics.Registry)
 
@@ -33,6 +34,11 @@ type Rest struct {
 	reqLog       *logrus.Logger
 	errLog       *logrus.Logger
 	restricted   bool
+	server       *http.Server
+	// draining is set to 1 once the service shutdown started
+	draining int32
+	// inFlight is the number of requests currently being processed
+	inFlight int64
 }
 
 // New returns a new REST interface
@@ -49,22 +55,78 @@ func New(
 	x.reqLog = reqLog
 	x.errLog = errLog
 	x.conf = conf
+	x.server = &http.Server{Addr: conf.Daemon.URL.Host}
 	return &x
 }
 
+// NewRestricted returns a new REST interface that serves the
+// restricted admin endpoint on the configured UNIX socket
+func NewRestricted(
+	authorizationFunction func(*msg.Request) bool,
+	appHandlerMap *handler.Map,
+	conf *config.Config,
+	reqLog, errLog *logrus.Logger,
+) *Rest {
+	x := New(authorizationFunction, appHandlerMap, conf, reqLog, errLog)
+	x.restricted = true
+	x.server = &http.Server{}
+	return x
+}
+
 // Run is the event server for Rest
 func (x *Rest) Run() {
-	router := x.setupRouter()
+	var err error
 
-	// TODO switch to new abortable interface
-	if x.conf.Daemon.TLS {
-		server := &http.Server{Addr: x.conf.Daemon.URL.Host, Handler: router}
-		server.TLSConfig = &tls.Config{MaxVersion: tls.VersionTLS13, MinVersion: tls.VersionTLS10}
-		err := server.ListenAndServeTLS(x.conf.Daemon.Cert, x.conf.Daemon.Key)
-		x.errLog.Fatal(err)
+	x.server.Handler = x.setupRouter()
+	if x.restricted {
+		err = x.serveSocket()
+	} else if x.conf.Daemon.TLS {
+		x.server.TLSConfig = &tls.Config{MaxVersion: tls.VersionTLS13, MinVersion: tls.VersionTLS10}
+		err = x.server.ListenAndServeTLS(x.conf.Daemon.Cert, x.conf.Daemon.Key)
 	} else {
-		x.errLog.Fatal(http.ListenAndServe(x.conf.Daemon.URL.Host, router))
+		err = x.server.ListenAndServe()
+	}
+
+	// ErrServerClosed is the result of Stop during shutdown
+	if err != http.ErrServerClosed {
+		x.errLog.Fatal(err)
 	}
 }
 
+// Drain marks the service as shutting down. New requests are refused
+// with 503/ServiceUnavailable, while requests that are already being
+// processed are given up to timeout to finish.
+func (x *Rest) Drain(timeout time.Duration) {
+	atomic.StoreInt32(&x.draining, 1)
+
+	deadline := time.Now().Add(timeout)
+	for atomic.LoadInt64(&x.inFlight) > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Stop closes the listener and waits up to timeout for the replies
+// to the remaining requests to be written, after which their
+// connections are closed.
+func (x *Rest) Stop(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := x.server.Shutdown(ctx); err != nil {
+		x.errLog.Errorln(`REST shutdown:`, err)
+		x.server.Close()
+	}
+}
+
+// shutdownInProgress returns true if the service is shutting down
+func (x *Rest) shutdownInProgress() bool {
+	return atomic.LoadInt32(&x.draining) == 1
+}
+
+// retryAfter returns the value of the Retry-After header sent with
+// requests refused during shutdown
+func (x *Rest) retryAfter() string {
+	return strconv.FormatUint(x.conf.RetryAfter, 10)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -86,7 +86,7 @@ func (x *Rest) basicAuth(h httprouter.Handle) httprouter.Handle {
 					request.Section = msg.SectionSupervisor
 					request.Action = msg.ActionAuthenticate
 					request.Super = &msg.Supervisor{
-						RestrictedEndpoint: false,
+						RestrictedEndpoint: x.restricted,
 						Task:               msg.TaskBasicAuth,
 						BasicAuth: struct {
 							User  string
@@ -96,6 +96,10 @@ func (x *Rest) basicAuth(h httprouter.Handle) httprouter.Handle {
 							Token: string(pair[1]),
 						},
 					}
+					// API keys authenticate as apikey_<keyID>
+					if strings.HasPrefix(string(pair[0]), msg.APIKeyUserPrefix) {
+						request.Super.Task = msg.TaskAPIKey
+					}
 					supervisor.Intake() <- request
 					result := <-request.Reply
 					if result.Error != nil {
@@ -104,7 +108,27 @@ func (x *Rest) basicAuth(h httprouter.Handle) httprouter.Handle {
 							WithField(`AuthenticationError`, result.Error.Error())
 						goto unauthorized
 					}
-					if result.Super.Verdict == 200 {
+					if result.Super.Verdict == 200 && request.Super.Task == msg.TaskAPIKey {
+						// requests via API key are performed as the
+						// account that created the key
+						ps = append(ps, httprouter.Param{
+							Key:   `AuthenticatedUser`,
+							Value: result.Super.BasicAuth.User,
+						})
+						// record the used key for the permission cache
+						ps = append(ps, httprouter.Param{
+							Key:   `AuthenticatedAPIKey`,
+							Value: strings.TrimPrefix(string(pair[0]), msg.APIKeyUserPrefix),
+						})
+
+						logEntry.WithField(`AuthenticationUser`, result.Super.BasicAuth.User).
+							WithField(`AuthenticationAPIKey`, string(pair[0])).
+							WithField(`Code`, int(result.Super.Verdict)).
+							Debug(http.StatusText(int(result.Super.Verdict)))
+
+						h(w, r, ps)
+						return
+					} else if result.Super.Verdict == 200 {
 						// record the authenticated user
 						ps = append(ps, httprouter.Param{
 							Key:   `AuthenticatedUser`,
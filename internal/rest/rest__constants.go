@@ -10,13 +10,18 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 const (
 	rtRepository                 = `/repository/`
 	rtRepositoryID               = `/repository/:repositoryID`
+	rtRepositoryBatch            = `/repository/:repositoryID/batch/`
+	rtRepositoryExport           = `/repository/:repositoryID/export`
 	rtRepositoryInstance         = `/repository/:repositoryID/instance/`
 	rtRepositoryInstanceID       = `/repository/:repositoryID/instance/:instanceID`
 	rtRepositoryInstanceVersions = `/repository/:repositoryID/instance/:instanceID/versions`
+	rtRepositoryMaintenance      = `/repository/:repositoryID/maintenance/`
+	rtRepositoryMaintenanceID    = `/repository/:repositoryID/maintenance/:maintenanceID`
 	rtRepositoryMember           = `/repository/:repositoryID/member/`
 	rtRepositoryMemberID         = `/repository/:repositoryID/member/:memberType/:memberID`
 	rtRepositoryProperty         = `/repository/:repositoryID/property/`
 	rtRepositoryPropertyID       = `/repository/:repositoryID/property/:propertyType/:sourceID`
+	rtRepositoryPropertyType     = `/repository/:repositoryID/property/:propertyType/`
 	rtRepositoryPropertyMgmt     = `/repository/:repositoryID/property-mgmt/:propertyType/`
 	rtRepositoryPropertyMgmtID   = `/repository/:repositoryID/property-mgmt/:propertyType/:propertyID`
 	rtRepositoryTree             = `/repository/:repositoryID/tree`
@@ -30,6 +35,7 @@ const (
 	rtBucketMemberID             = `/repository/:repositoryID/bucket/:bucketID/member/:memberType/:memberID`
 	rtBucketProperty             = `/repository/:repositoryID/bucket/:bucketID/property/`
 	rtBucketPropertyID           = `/repository/:repositoryID/bucket/:bucketID/property/:propertyType/:sourceID`
+	rtBucketPropertyType         = `/repository/:repositoryID/bucket/:bucketID/property/:propertyType/`
 	rtBucketTree                 = `/repository/:repositoryID/bucket/:bucketID/tree`
 	rtCluster                    = `/repository/:repositoryID/bucket/:bucketID/cluster/`
 	rtClusterID                  = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID`
@@ -40,6 +46,7 @@ const (
 	rtClusterMemberID            = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID/member/:memberType/:memberID`
 	rtClusterProperty            = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID/property/`
 	rtClusterPropertyID          = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID/property/:propertyType/:sourceID`
+	rtClusterPropertyType        = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID/property/:propertyType/`
 	rtClusterTree                = `/repository/:repositoryID/bucket/:bucketID/cluster/:clusterID/tree`
 	rtGroup                      = `/repository/:repositoryID/bucket/:bucketID/group/`
 	rtGroupID                    = `/repository/:repositoryID/bucket/:bucketID/group/:groupID`
@@ -51,6 +58,7 @@ const (
 	rtGroupMemberID              = `/repository/:repositoryID/bucket/:bucketID/group/:groupID/member/:memberType/:memberID`
 	rtGroupProperty              = `/repository/:repositoryID/bucket/:bucketID/group/:groupID/property/`
 	rtGroupPropertyID            = `/repository/:repositoryID/bucket/:bucketID/group/:groupID/property/:propertyType/:sourceID`
+	rtGroupPropertyType          = `/repository/:repositoryID/bucket/:bucketID/group/:groupID/property/:propertyType/`
 	rtGroupTree                  = `/repository/:repositoryID/bucket/:bucketID/group/:groupID/tree`
 	rtNode                       = `/node/`
 	rtNodeID                     = `/node/:nodeID`
@@ -61,6 +69,7 @@ const (
 	rtNodeInstanceVersions       = `/repository/:repositoryID/bucket/:bucketID/node/:nodeID/instance/:instanceID/versions`
 	rtNodeProperty               = `/repository/:repositoryID/bucket/:bucketID/node/:nodeID/property/`
 	rtNodePropertyID             = `/repository/:repositoryID/bucket/:bucketID/node/:nodeID/property/:propertyType/:sourceID`
+	rtNodePropertyType           = `/repository/:repositoryID/bucket/:bucketID/node/:nodeID/property/:propertyType/`
 	rtNodeTree                   = `/repository/:repositoryID/bucket/:bucketID/node/:nodeID/tree`
 	rtPermission                 = `/category/:category/permission/`
 	rtPermissionID               = `/category/:category/permission/:permissionID`
@@ -105,6 +114,7 @@ const (
 	rtJobEntry                   = `/job/byID/`
 	rtJobEntryID                 = `/job/byID/:jobID`
 	rtJobEntryWaitID             = `/job/byID/:jobID/_processed`
+	rtJobEntryWatchID            = `/job/byID/:jobID/_events`
 	rtJobTypeMgmt                = `/job/type-mgmt/`
 	rtJobTypeMgmtID              = `/job/type-mgmt/:typeID`
 	rtJobStatusMgmt              = `/job/status-mgmt/`
@@ -0,0 +1,229 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// summaryQuantiles are the quantiles exported for timers and
+// histograms
+var summaryQuantiles = []float64{0.5, 0.9, 0.99}
+
+// metricFamily is a set of samples that share a metric name
+type metricFamily struct {
+	name    string
+	kind    string
+	samples []metricSample
+}
+
+// metricSample is a single exported value. The quantile of summary
+// samples is not part of labels, so that all samples of a series are
+// written together.
+type metricSample struct {
+	suffix   string
+	labels   []string
+	quantile string
+	value    float64
+}
+
+// privateLabels are the labels of metrics that are only exported on
+// the restricted admin endpoint, since they reveal object names
+var privateLabels = []string{`repository`}
+
+// MetricsScrape function exports the runtime metric registries and
+// the handler queue depths in the Prometheus text exposition format
+func (x *Rest) MetricsScrape(w http.ResponseWriter, _ *http.Request,
+	_ httprouter.Params) {
+	defer panicCatcher(w)
+
+	families := make(map[string]*metricFamily)
+	for _, registry := range Metrics {
+		registry.Each(func(name string, metric interface{}) {
+			if !x.restricted && isPrivateMetric(name) {
+				return
+			}
+			addMetric(families, name, metric)
+		})
+	}
+	for name, depth := range x.handlerMap.QueueDepth() {
+		addSample(families, `soma_handler_queue_depth`, `gauge`,
+			metricSample{
+				labels: []string{fmt.Sprintf("handler=%q", name)},
+				value:  float64(depth),
+			})
+	}
+
+	buf := &bytes.Buffer{}
+	writeFamilies(buf, families)
+
+	w.Header().Set(`Content-Type`, `text/plain; version=0.0.4; charset=utf-8`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// isPrivateMetric returns true if the registry name carries one of
+// the privateLabels
+func isPrivateMetric(registryName string) bool {
+	_, labels := splitMetricName(registryName)
+	for _, l := range labels {
+		for _, pair := range strings.Split(l, `,`) {
+			for _, private := range privateLabels {
+				if strings.HasPrefix(pair, private+`=`) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// addMetric converts a go-metrics metric into exported samples.
+// Counters can be decremented and are exported as gauges, meters
+// only count up and are exported as counters.
+func addMetric(families map[string]*metricFamily, registryName string,
+	metric interface{}) {
+	name, labels := splitMetricName(registryName)
+
+	switch m := metric.(type) {
+	case metrics.Counter:
+		addSample(families, name, `gauge`, metricSample{
+			labels: labels,
+			value:  float64(m.Count()),
+		})
+	case metrics.Gauge:
+		addSample(families, name, `gauge`, metricSample{
+			labels: labels,
+			value:  float64(m.Value()),
+		})
+	case metrics.GaugeFloat64:
+		addSample(families, name, `gauge`, metricSample{
+			labels: labels,
+			value:  m.Value(),
+		})
+	case metrics.Meter:
+		addSample(families, name+`_total`, `counter`, metricSample{
+			labels: labels,
+			value:  float64(m.Count()),
+		})
+	case metrics.Timer:
+		t := m.Snapshot()
+		addSummary(families, name+`_seconds`, labels, t.Count(),
+			float64(t.Sum())/float64(time.Second),
+			t.Percentiles(summaryQuantiles), float64(time.Second))
+	case metrics.Histogram:
+		h := m.Snapshot()
+		addSummary(families, name, labels, h.Count(), float64(h.Sum()),
+			h.Percentiles(summaryQuantiles), 1)
+	}
+}
+
+// addSummary adds the samples of a summary. Quantiles are divided by
+// scale.
+func addSummary(families map[string]*metricFamily, name string,
+	labels []string, count int64, sum float64, quantiles []float64,
+	scale float64) {
+	for i, q := range summaryQuantiles {
+		addSample(families, name, `summary`, metricSample{
+			labels:   labels,
+			quantile: strconv.FormatFloat(q, 'g', -1, 64),
+			value:    quantiles[i] / scale,
+		})
+	}
+	addSample(families, name, `summary`, metricSample{
+		suffix: `_sum`,
+		labels: labels,
+		value:  sum,
+	})
+	addSample(families, name, `summary`, metricSample{
+		suffix: `_count`,
+		labels: labels,
+		value:  float64(count),
+	})
+}
+
+// addSample adds a sample to the family name, creating the family if
+// required
+func addSample(families map[string]*metricFamily, name, kind string,
+	sample metricSample) {
+	if _, ok := families[name]; !ok {
+		families[name] = &metricFamily{name: name, kind: kind}
+	}
+	families[name].samples = append(families[name].samples, sample)
+}
+
+// splitMetricName converts a registry name into a valid metric name
+// and its labels. Labels are part of the registry name in the
+// Prometheus syntax, see soma.MetricName.
+func splitMetricName(registryName string) (string, []string) {
+	name, labels := registryName, []string{}
+	if i := strings.Index(registryName, `{`); i >= 0 &&
+		strings.HasSuffix(registryName, `}`) {
+		name = registryName[:i]
+		if l := registryName[i+1 : len(registryName)-1]; l != `` {
+			labels = []string{l}
+		}
+	}
+
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z',
+			r >= '0' && r <= '9', r == '_', r == ':':
+			return r
+		}
+		return '_'
+	}, name)
+	return name, labels
+}
+
+// writeFamilies writes all families sorted by name
+func writeFamilies(buf *bytes.Buffer, families map[string]*metricFamily) {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		writeFamily(buf, families[name])
+	}
+}
+
+// writeFamily writes all samples of family f
+func writeFamily(buf *bytes.Buffer, f *metricFamily) {
+	sort.SliceStable(f.samples, func(i, j int) bool {
+		return strings.Join(f.samples[i].labels, `,`) <
+			strings.Join(f.samples[j].labels, `,`)
+	})
+
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.name, f.kind)
+	for _, s := range f.samples {
+		pairs := s.labels
+		if s.quantile != `` {
+			pairs = append(append([]string{}, pairs...),
+				fmt.Sprintf("quantile=%q", s.quantile))
+		}
+		labels := ``
+		if len(pairs) > 0 {
+			labels = `{` + strings.Join(pairs, `,`) + `}`
+		}
+		fmt.Fprintf(buf, "%s%s%s %s\n", f.name, s.suffix, labels,
+			strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,139 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/soma"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+func TestMetricsRender(t *testing.T) {
+	registry := metrics.NewRegistry()
+
+	metrics.GetOrRegisterCounter(`.treekeeper.count`, registry).Inc(3)
+	metrics.GetOrRegisterMeter(`.requests`, registry).Mark(2)
+	metrics.GetOrRegisterGauge(soma.MetricName(`.jobs.backlog`,
+		`repository`, `example`), registry).Update(5)
+	metrics.GetOrRegisterGauge(soma.MetricName(`.jobs.backlog`,
+		`repository`, `test`), registry).Update(1)
+	metrics.GetOrRegisterGauge(`.jobs.backlog.total`, registry).Update(6)
+	metrics.GetOrRegisterGaugeFloat64(soma.MetricName(`.deployments`,
+		`state`, `active`, `monitoring`, `mon`), registry).Update(1.5)
+	timer := metrics.NewTimer()
+	registry.Register(`.requests.latency`, timer)
+	timer.Update(2 * time.Second)
+	timer.Update(2 * time.Second)
+	histogram := metrics.NewHistogram(metrics.NewUniformSample(16))
+	registry.Register(`.job.size`, histogram)
+	histogram.Update(10)
+	// samples of labelled summaries are grouped by series
+	for section, size := range map[string]int64{`bucket`: 4, `node`: 8} {
+		h := metrics.NewHistogram(metrics.NewUniformSample(16))
+		registry.Register(soma.MetricName(`.job.items`, `section`,
+			section), h)
+		h.Update(size)
+	}
+
+	families := make(map[string]*metricFamily)
+	registry.Each(func(name string, metric interface{}) {
+		addMetric(families, name, metric)
+	})
+	buf := &bytes.Buffer{}
+	writeFamilies(buf, families)
+
+	expected := `# TYPE _deployments gauge
+_deployments{monitoring="mon",state="active"} 1.5
+# TYPE _job_items summary
+_job_items{section="bucket",quantile="0.5"} 4
+_job_items{section="bucket",quantile="0.9"} 4
+_job_items{section="bucket",quantile="0.99"} 4
+_job_items_sum{section="bucket"} 4
+_job_items_count{section="bucket"} 1
+_job_items{section="node",quantile="0.5"} 8
+_job_items{section="node",quantile="0.9"} 8
+_job_items{section="node",quantile="0.99"} 8
+_job_items_sum{section="node"} 8
+_job_items_count{section="node"} 1
+# TYPE _job_size summary
+_job_size{quantile="0.5"} 10
+_job_size{quantile="0.9"} 10
+_job_size{quantile="0.99"} 10
+_job_size_sum 10
+_job_size_count 1
+# TYPE _jobs_backlog gauge
+_jobs_backlog{repository="example"} 5
+_jobs_backlog{repository="test"} 1
+# TYPE _jobs_backlog_total gauge
+_jobs_backlog_total 6
+# TYPE _requests_latency_seconds summary
+_requests_latency_seconds{quantile="0.5"} 2
+_requests_latency_seconds{quantile="0.9"} 2
+_requests_latency_seconds{quantile="0.99"} 2
+_requests_latency_seconds_sum 4
+_requests_latency_seconds_count 2
+# TYPE _requests_total counter
+_requests_total 2
+# TYPE _treekeeper_count gauge
+_treekeeper_count 3
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   []string
+		registry string
+		metric   string
+		split    []string
+		private  bool
+	}{
+		{`.requests`, nil, `.requests`, `_requests`, nil, false},
+		{`.jobs.backlog`, []string{`repository`, `example`},
+			`.jobs.backlog{repository="example"}`, `_jobs_backlog`,
+			[]string{`repository="example"`}, true},
+		{`.deployments`, []string{`state`, `active`, `monitoring`, `a"b`},
+			`.deployments{monitoring="a\"b",state="active"}`,
+			`_deployments`,
+			[]string{`monitoring="a\"b",state="active"`}, false},
+		// labels without value are dropped
+		{`.lifecycle.poke`, []string{`result`},
+			`.lifecycle.poke`, `_lifecycle_poke`, nil, false},
+	}
+
+	for _, test := range tests {
+		registry := soma.MetricName(test.name, test.labels...)
+		if registry != test.registry {
+			t.Errorf("%s: expected registry name %s, got %s", test.name,
+				test.registry, registry)
+			continue
+		}
+		metric, labels := splitMetricName(registry)
+		if metric != test.metric {
+			t.Errorf("%s: expected metric name %s, got %s", test.name,
+				test.metric, metric)
+		}
+		if len(labels) != len(test.split) ||
+			(len(labels) > 0 && labels[0] != test.split[0]) {
+			t.Errorf("%s: expected labels %v, got %v", test.name,
+				test.split, labels)
+		}
+		if private := isPrivateMetric(registry); private != test.private {
+			t.Errorf("%s: expected private %t, got %t", test.name,
+				test.private, private)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
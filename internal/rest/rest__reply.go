@@ -10,11 +10,22 @@
 package rest // import "github.com/mjolnir42/soma/internal/rest"
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mjolnir42/soma/internal/msg"
 )
 
+const (
+	// reply buffer of job event streams
+	jobStreamBuffer = 64
+	// interval between comments sent to keep idle job event streams
+	// open through proxies
+	jobStreamKeepalive = 30 * time.Second
+)
+
 // replyNoContent returns a 204 HTTP statuscode reply with no content
 func (x *Rest) replyNoContent(w *http.ResponseWriter) {
 	(*w).WriteHeader(http.StatusNoContent)
@@ -64,6 +75,83 @@ func (x *Rest) hardConflict(w *http.ResponseWriter, err error) {
 	http.Error(*w, http.StatusText(http.StatusConflict), http.StatusConflict)
 }
 
+// hardUnavailable returns a 503 HTTP error with a Retry-After header
+// and no application data body. It is used to refuse requests during
+// service shutdown.
+func (x *Rest) hardUnavailable(w *http.ResponseWriter) {
+	(*w).Header().Set(`Retry-After`, x.retryAfter())
+	http.Error(*w, `Shutdown in progress`,
+		http.StatusServiceUnavailable)
+}
+
+// replyJobWait answers a job wait request once the job_block handler
+// released it. Waits for finished jobs are answered with
+// 204/NoContent, aborted waits with the HTTP error they were aborted
+// with.
+func (x *Rest) replyJobWait(w *http.ResponseWriter, q *msg.Request) {
+	result, ok := <-q.Reply
+	switch {
+	case !ok, result.Code == 200:
+		x.replyNoContent(w)
+	case result.Code == 503:
+		x.hardUnavailable(w)
+	default:
+		http.Error(*w, http.StatusText(int(result.Code)),
+			int(result.Code))
+	}
+}
+
+// replyJobStream streams the events of a job to the client as
+// server-sent events, until the job_stream handler ends the stream or
+// the client disconnects. Watches aborted by the handler end with an
+// error event.
+func (x *Rest) replyJobStream(w *http.ResponseWriter, r *http.Request,
+	q *msg.Request) {
+	flusher, ok := (*w).(http.Flusher)
+	if !ok {
+		x.hardServerError(w)
+		return
+	}
+
+	(*w).Header().Set(`Content-Type`, `text/event-stream`)
+	(*w).Header().Set(`Cache-Control`, `no-cache`)
+	(*w).Header().Set(`X-Accel-Buffering`, `no`)
+	(*w).WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(jobStreamKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprint(*w, ": keepalive\n\n")
+		case result, ok := <-q.Reply:
+			if !ok {
+				return
+			}
+			if result.Error != nil {
+				fmt.Fprintf(*w, "event: error\ndata: %s\n\n",
+					result.Error.Error())
+				flusher.Flush()
+				return
+			}
+			for _, ev := range result.JobEvent {
+				data, err := json.Marshal(ev)
+				if err != nil {
+					x.errLog.Println(`replyJobStream:`, err)
+					return
+				}
+				fmt.Fprintf(*w, "id: %d\nevent: %s\ndata: %s\n\n",
+					ev.Sequence, ev.Type, data)
+			}
+		}
+		flusher.Flush()
+	}
+}
+
 // hardServerError returns a 500 HTTP error with no application data
 // body. This function is intended to be used only if normal response
 // generation itself fails
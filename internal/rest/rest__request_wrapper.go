@@ -9,6 +9,7 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
@@ -48,8 +49,13 @@ func (x *Rest) checkShutdown(h httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request,
 		ps httprouter.Params) {
 
-		if !ShutdownInProgress {
-			metrics.GetOrRegisterCounter(`.requests`, Metrics[`soma`]).Inc(1)
+		// the request is counted before the check, so that Drain can
+		// not miss it
+		atomic.AddInt64(&x.inFlight, 1)
+		defer atomic.AddInt64(&x.inFlight, -1)
+
+		if !x.shutdownInProgress() {
+			metrics.GetOrRegisterMeter(`.requests`, Metrics[`soma`]).Mark(1)
 			start := time.Now()
 
 			h(w, r, ps)
@@ -59,8 +65,7 @@ func (x *Rest) checkShutdown(h httprouter.Handle) httprouter.Handle {
 			return
 		}
 
-		http.Error(w, `Shutdown in progress`,
-			http.StatusServiceUnavailable)
+		x.hardUnavailable(&w)
 	}
 }
 
@@ -17,7 +17,12 @@ func (x *Rest) setupRouter() *httprouter.Router {
 	router := httprouter.New()
 
 	router.HEAD(`/`, x.Unauthenticated(x.Ping))
+	if x.conf.Metrics {
+		router.GET(`/metrics`, x.Unauthenticated(x.MetricsScrape))
+	}
 
+	router.GET(`/accounts/apikeys/:apikeyID`, x.Authenticated(x.APIKeyShow))
+	router.GET(`/accounts/apikeys/`, x.Authenticated(x.APIKeyList))
 	router.GET(`/attribute/:attribute`, x.Authenticated(x.AttributeShow))
 	router.GET(`/attribute/`, x.Authenticated(x.AttributeList))
 	router.GET(`/capability/:capabilityID`, x.Authenticated(x.CapabilityShow))
@@ -75,7 +80,10 @@ func (x *Rest) setupRouter() *httprouter.Router {
 	router.GET(`/validity/`, x.Authenticated(x.ValidityList))
 	router.GET(`/view/:view`, x.Authenticated(x.ViewShow))
 	router.GET(`/view/`, x.Authenticated(x.ViewList))
+	router.GET(`/webhook/:webhookID`, x.Authenticated(x.WebhookShow))
+	router.GET(`/webhook/`, x.Authenticated(x.WebhookList))
 	router.GET(`/workflow/`, x.Authenticated(x.WorkflowList))
+	router.GET(`/workflow/history/:instanceID`, x.Authenticated(x.WorkflowHistory))
 	router.GET(`/workflow/summary`, x.Authenticated(x.WorkflowSummary))
 	router.GET(rtBucket, x.Authenticated(x.BucketList))
 	router.GET(rtBucketID, x.Authenticated(x.BucketShow))
@@ -120,10 +128,13 @@ func (x *Rest) setupRouter() *httprouter.Router {
 	router.GET(rtPropertyMgmt, x.Authenticated(x.PropertyMgmtList))
 	router.GET(rtPropertyMgmtID, x.Authenticated(x.PropertyMgmtShow))
 	router.GET(rtRepository, x.Authenticated(x.RepositoryConfigList))
+	router.GET(rtRepositoryExport, x.Authenticated(x.RepositoryConfigExport))
 	router.GET(rtTeamRepositoryID, x.Authenticated(x.ScopeSelectRepositoryShow))
 	router.GET(rtRepositoryInstance, x.Authenticated(x.InstanceList))
 	router.GET(rtRepositoryInstanceID, x.Authenticated(x.InstanceShow))
 	router.GET(rtRepositoryInstanceVersions, x.Authenticated(x.InstanceVersions))
+	router.GET(rtRepositoryMaintenance, x.Authenticated(x.MaintenanceList))
+	router.GET(rtRepositoryMaintenanceID, x.Authenticated(x.MaintenanceShow))
 	router.GET(rtRepositoryPropertyMgmt, x.Authenticated(x.PropertyMgmtList))
 	router.GET(rtRepositoryPropertyMgmtID, x.Authenticated(x.PropertyMgmtShow))
 	router.GET(rtRepositoryTree, x.Authenticated(x.RepositoryConfigTree))
@@ -162,7 +173,10 @@ func (x *Rest) setupRouter() *httprouter.Router {
 	router.POST(rtSearchServiceProperty, x.Authenticated(x.PropertyMgmtSearch))
 
 	if !x.conf.ReadOnly {
+		router.GET(`/checkconfig/:repositoryID/:checkID/explain/:entity/:objectID`, x.Authenticated(x.CheckConfigExplain))
+
 		if !x.conf.Observer {
+			router.DELETE(`/accounts/apikeys/:apikeyID`, x.Authenticated(x.APIKeyRevoke))
 			router.DELETE(`/accounts/tokens/:account`, x.Authenticated(x.SupervisorTokenInvalidateAccount))
 			router.DELETE(`/attribute/:attribute`, x.Authenticated(x.AttributeRemove))
 			router.DELETE(`/capability/:capabilityID`, x.Authenticated(x.CapabilityRevoke))
@@ -192,25 +206,32 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.DELETE(`/user/:userID`, x.Authenticated(x.UserMgmtRemove))
 			router.DELETE(`/validity/:property`, x.Authenticated(x.ValidityRemove))
 			router.DELETE(`/view/:view`, x.Authenticated(x.ViewRemove))
+			router.DELETE(`/webhook/:webhookID`, x.Authenticated(x.WebhookRemove))
 			router.DELETE(rtBucketID, x.Authenticated(x.BucketDestroy))
 			router.DELETE(rtBucketMemberID, x.Authenticated(x.BucketMemberUnassign))
+			router.DELETE(rtBucketProperty, x.Authenticated(x.BucketPropertyDestroy))
 			router.DELETE(rtBucketPropertyID, x.Authenticated(x.BucketPropertyDestroy))
 			router.DELETE(rtClusterID, x.Authenticated(x.ClusterDestroy))
 			router.DELETE(rtClusterMemberID, x.Authenticated(x.ClusterMemberUnassign))
+			router.DELETE(rtClusterProperty, x.Authenticated(x.ClusterPropertyDestroy))
 			router.DELETE(rtClusterPropertyID, x.Authenticated(x.ClusterPropertyDestroy))
 			router.DELETE(rtGroupID, x.Authenticated(x.GroupDestroy))
 			router.DELETE(rtGroupMemberID, x.Authenticated(x.GroupMemberUnassign))
+			router.DELETE(rtGroupProperty, x.Authenticated(x.GroupPropertyDestroy))
 			router.DELETE(rtGroupPropertyID, x.Authenticated(x.GroupPropertyDestroy))
 			router.DELETE(rtJobResultMgmtID, x.Authenticated(x.JobResultMgmtRemove))
 			router.DELETE(rtJobStatusMgmtID, x.Authenticated(x.JobStatusMgmtRemove))
 			router.DELETE(rtJobTypeMgmtID, x.Authenticated(x.JobTypeMgmtRemove))
 			router.DELETE(rtNode, x.Authenticated(x.NodeMgmtRemove))
 			router.DELETE(rtNodeID, x.Authenticated(x.NodeMgmtRemove))
+			router.DELETE(rtNodeProperty, x.Authenticated(x.NodeConfigPropertyDestroy))
 			router.DELETE(rtNodePropertyID, x.Authenticated(x.NodeConfigPropertyDestroy))
 			router.DELETE(rtNodeUnassign, x.Authenticated(x.NodeConfigUnassign))
 			router.DELETE(rtOncallMemberID, x.Authenticated(x.OncallMemberUnassign))
 			router.DELETE(rtPermissionID, x.Authenticated(x.PermissionRemove))
 			router.DELETE(rtPropertyMgmtID, x.Authenticated(x.PropertyMgmtRemove))
+			router.DELETE(rtRepositoryMaintenanceID, x.Authenticated(x.MaintenanceRemove))
+			router.DELETE(rtRepositoryProperty, x.Authenticated(x.RepositoryConfigPropertyDestroy))
 			router.DELETE(rtRepositoryPropertyID, x.Authenticated(x.RepositoryConfigPropertyDestroy))
 			router.DELETE(rtRepositoryPropertyMgmtID, x.Authenticated(x.PropertyMgmtCustomRemove))
 			router.DELETE(rtRightID, x.Authenticated(x.RightRevoke))
@@ -223,9 +244,12 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.GET(rtDeploymentState, x.Unauthenticated(x.DeploymentPending))
 			router.GET(rtDeploymentStateID, x.Unauthenticated(x.DeploymentFilter))
 			router.GET(rtJobEntryWaitID, x.Authenticated(x.ScopeSelectJobWait))
+			router.GET(rtJobEntryWatchID, x.Authenticated(x.JobWatch))
 			router.GET(rtTeamRepositoryIDAudit, x.Authenticated(x.RepositoryAudit))
 			router.PATCH(`/accounts/password/:kexID`, x.Unauthenticated(x.SupervisorPasswordChange))
 			router.PATCH(`/oncall/:oncallID`, x.Authenticated(x.OncallUpdate))
+			router.PATCH(`/workflow/bulk/retry`, x.Authenticated(x.WorkflowBulkRetry))
+			router.PATCH(`/workflow/bulk/set`, x.Authenticated(x.WorkflowBulkSet))
 			router.PATCH(`/workflow/retry`, x.Authenticated(x.WorkflowRetry))
 			router.PATCH(`/workflow/set/:instanceconfigID`, x.Authenticated(x.WorkflowSet))
 			router.PATCH(rtAliasDeploymentIDAction, x.Unauthenticated(x.DeploymentUpdate))
@@ -236,6 +260,7 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.PATCH(rtPermissionID, x.Authenticated(x.PermissionEdit))
 			router.PATCH(rtTeamRepositoryIDName, x.Authenticated(x.RepositoryRename))
 			router.PATCH(rtTeamRepositoryIDOwner, x.Authenticated(x.RepositoryRepossess))
+			router.POST(`/accounts/apikeys/`, x.Authenticated(x.APIKeyCreate))
 			router.POST(`/attribute/`, x.Authenticated(x.AttributeAdd))
 			router.POST(`/capability/`, x.Authenticated(x.CapabilityDeclare))
 			router.POST(`/category/:category/section/:sectionID/action/`, x.Authenticated(x.ActionAdd))
@@ -263,6 +288,7 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.POST(`/user/`, x.Authenticated(x.UserMgmtAdd))
 			router.POST(`/validity/`, x.Authenticated(x.ValidityAdd))
 			router.POST(`/view/`, x.Authenticated(x.ViewAdd))
+			router.POST(`/webhook/`, x.Authenticated(x.WebhookAdd))
 			router.POST(rtBucket, x.Authenticated(x.BucketCreate))
 			router.POST(rtBucketMember, x.Authenticated(x.BucketMemberAssign))
 			router.POST(rtBucketProperty, x.Authenticated(x.BucketPropertyCreate))
@@ -280,6 +306,8 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.POST(rtPermission, x.Authenticated(x.PermissionAdd))
 			router.POST(rtPropertyMgmt, x.Authenticated(x.PropertyMgmtAdd))
 			router.POST(rtRepository, x.Authenticated(x.RepositoryMgmtCreate))
+			router.POST(rtRepositoryBatch, x.Authenticated(x.RepositoryBatch))
+			router.POST(rtRepositoryMaintenance, x.Authenticated(x.MaintenanceAdd))
 			router.POST(rtRepositoryProperty, x.Authenticated(x.RepositoryConfigPropertyCreate))
 			router.POST(rtRepositoryPropertyMgmt, x.Authenticated(x.PropertyMgmtCustomAdd))
 			router.POST(rtRight, x.Authenticated(x.RightGrant))
@@ -287,7 +315,9 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.PUT(`/accounts/activate/root/:kexID`, x.Unauthenticated(x.SupervisorActivateRoot))
 			router.PUT(`/accounts/activate/user/:kexID`, x.Unauthenticated(x.SupervisorActivateUser))
 			router.PUT(`/accounts/activate/admin/:kexID`, x.Unauthenticated(x.SupervisorActivateAdmin))
+			router.PUT(`/accounts/mailtoken/:account`, x.Unauthenticated(x.SupervisorTokenMail))
 			router.PUT(`/accounts/password/:kexID`, x.Unauthenticated(x.SupervisorPasswordReset))
+			router.PUT(`/checkconfig/:repositoryID/:checkID`, x.Authenticated(x.CheckConfigUpdate))
 			router.PUT(`/datacenter/:datacenter`, x.Authenticated(x.DatacenterRename))
 			router.PUT(`/entity/:entity`, x.Authenticated(x.EntityRename))
 			router.PUT(`/environment/:environment`, x.Authenticated(x.EnvironmentRename))
@@ -299,12 +329,17 @@ func (x *Rest) setupRouter() *httprouter.Router {
 			router.PUT(`/user/:userID`, x.Authenticated(x.UserMgmtUpdate))
 			router.PUT(`/view/:view`, x.Authenticated(x.ViewRename))
 			router.PUT(rtBucketPropertyID, x.Authenticated(x.BucketPropertyUpdate))
+			router.PUT(rtBucketPropertyType, x.Authenticated(x.BucketPropertyReplace))
 			router.PUT(rtClusterPropertyID, x.Authenticated(x.ClusterPropertyUpdate))
+			router.PUT(rtClusterPropertyType, x.Authenticated(x.ClusterPropertyReplace))
 			router.PUT(rtGroupPropertyID, x.Authenticated(x.GroupPropertyUpdate))
+			router.PUT(rtGroupPropertyType, x.Authenticated(x.GroupPropertyReplace))
 			router.PUT(rtNodeConfig, x.Authenticated(x.NodeConfigAssign))
 			router.PUT(rtNodeID, x.Authenticated(x.NodeMgmtUpdate))
 			router.PUT(rtNodePropertyID, x.Authenticated(x.NodeConfigPropertyUpdate))
+			router.PUT(rtNodePropertyType, x.Authenticated(x.NodeConfigPropertyReplace))
 			router.PUT(rtRepositoryPropertyID, x.Authenticated(x.RepositoryConfigPropertyUpdate))
+			router.PUT(rtRepositoryPropertyType, x.Authenticated(x.RepositoryConfigPropertyReplace))
 			router.PUT(rtTeamPropertyMgmtID, x.Authenticated(x.PropertyMgmtServiceUpdate))
 
 		}
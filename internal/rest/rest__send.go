@@ -56,6 +56,9 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 	switch r.Section {
 	// simple result data with straight forward copying
 
+	case msg.SectionAPIKey:
+		result = proto.NewAPIKeyResult()
+		*result.APIKeys = append(*result.APIKeys, r.APIKey...)
 	case msg.SectionAction:
 		result = proto.NewActionResult()
 		*result.Actions = append(*result.Actions, r.ActionObj...)
@@ -69,8 +72,15 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 		result = proto.NewCategoryResult()
 		*result.Categories = append(*result.Categories, r.Category...)
 	case msg.SectionCheckConfig:
-		result = proto.NewCheckConfigResult()
-		*result.CheckConfigs = append(*result.CheckConfigs, r.CheckConfig...)
+		switch r.Action {
+		case msg.ActionExplain:
+			result = proto.NewCheckExplanationResult()
+			*result.CheckExplanations = append(*result.CheckExplanations,
+				r.CheckExplanation...)
+		default:
+			result = proto.NewCheckConfigResult()
+			*result.CheckConfigs = append(*result.CheckConfigs, r.CheckConfig...)
+		}
 	case msg.SectionDatacenter:
 		result = proto.NewDatacenterResult()
 		*result.Datacenters = append(*result.Datacenters, r.Datacenter...)
@@ -99,6 +109,9 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 	case msg.SectionLevel:
 		result = proto.NewLevelResult()
 		*result.Levels = append(*result.Levels, r.Level...)
+	case msg.SectionMaintenance:
+		result = proto.NewMaintenanceResult()
+		*result.Maintenances = append(*result.Maintenances, r.Maintenance...)
 	case msg.SectionMetric:
 		result = proto.NewMetricResult()
 		*result.Metrics = append(*result.Metrics, r.Metric...)
@@ -141,6 +154,9 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 	case msg.SectionView:
 		result = proto.NewViewResult()
 		*result.Views = append(*result.Views, r.View...)
+	case msg.SectionWebhook:
+		result = proto.NewWebhookResult()
+		*result.Webhooks = append(*result.Webhooks, r.Webhook...)
 	case msg.SectionWorkflow:
 		result = proto.NewWorkflowResult()
 		*result.Workflows = append(*result.Workflows, r.Workflow...)
@@ -208,6 +224,12 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 		case msg.ActionTree:
 			result = proto.NewTreeResult()
 			*result.Tree = r.Tree
+		case msg.ActionAudit:
+			result = proto.NewAuditResult()
+			*result.Audits = append(*result.Audits, r.Audit...)
+		case msg.ActionExport:
+			result = proto.NewLayoutResult()
+			*result.Layout = r.Layout
 		default:
 			result = proto.NewRepositoryResult()
 			*result.Repositories = append(*result.Repositories, r.Repository...)
@@ -331,6 +353,23 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 				result.Forbidden(nil)
 				goto buildJSON
 
+			// mail token request
+			case msg.TaskMailToken:
+				// check supervisor verdict
+				if r.Code == 200 && r.Super.Verdict == 200 {
+					result.OK()
+					logEntry.WithField(`Code`, r.Code).Info(`OK`)
+					goto buildJSON
+				}
+
+				// mask as 403/Forbidden
+				logEntry.WithField(`Code`, r.Code).
+					WithField(`Masked`, 403).
+					WithField(`Task`, r.Super.Task).
+					Warnf(`Forbidden`)
+				result.Forbidden(nil)
+				goto buildJSON
+
 			// token generation request - encrypted payload
 			case msg.TaskRequest:
 				// check supervisor verdict
@@ -463,6 +502,10 @@ func (x *Rest) send(w *http.ResponseWriter, r *msg.Result) {
 		result.DataClean()
 		goto buildJSON
 	}
+	if r.Plan != nil {
+		result.Plan = &[]proto.PlanAction{}
+		*result.Plan = append(*result.Plan, r.Plan...)
+	}
 	result.RequestID = r.ID.String()
 
 	logEntry = logEntry.WithField(`Code`, r.Code)
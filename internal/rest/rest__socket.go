@@ -0,0 +1,131 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// socketAddr is the address requests received via the admin socket
+// are attributed to. Kex and token handling require the remote
+// address of a request to be an IP address.
+var socketAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}
+
+// serveSocket serves the REST interface on the admin UNIX socket.
+// All requests received on it are from a restricted endpoint.
+func (x *Rest) serveSocket() error {
+	var (
+		err  error
+		l    net.Listener
+		grp  *user.Group
+		gid  int
+		path = x.conf.AdminSocket.Path
+	)
+
+	// remove a stale socket left behind by an unclean shutdown
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if l, err = net.Listen(`unix`, path); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(path, x.conf.AdminSocket.FileMode); err != nil {
+		l.Close()
+		return err
+	}
+
+	if x.conf.AdminSocket.Group != `` {
+		if grp, err = user.LookupGroup(x.conf.AdminSocket.Group); err != nil {
+			l.Close()
+			return err
+		}
+		if gid, err = strconv.Atoi(grp.Gid); err != nil {
+			l.Close()
+			return err
+		}
+		if err = os.Chown(path, -1, gid); err != nil {
+			l.Close()
+			return err
+		}
+	}
+
+	return x.server.Serve(&socketListener{
+		UnixListener: l.(*net.UnixListener),
+		allowed:      x.conf.AdminSocket.UIDs,
+		reqLog:       x.reqLog,
+	})
+}
+
+// socketListener only accepts connections from processes running
+// as root, as the user of the daemon or as one of the allowed UIDs
+type socketListener struct {
+	*net.UnixListener
+	allowed []uint32
+	reqLog  *logrus.Logger
+}
+
+// Accept implements net.Listener. Connections from peers that are
+// not permitted are closed immediately.
+func (l *socketListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			return nil, err
+		}
+
+		uid, pid, err := peerCredentials(conn)
+		logEntry := l.reqLog.WithField(`Phase`, `admin-socket`).
+			WithField(`PeerUID`, uid).
+			WithField(`PeerPID`, pid)
+		switch {
+		case err != nil:
+			logEntry.WithField(`Error`, err.Error()).
+				Warnln(`Rejected admin socket connection`)
+			conn.Close()
+			continue
+		case !l.permitted(uid):
+			logEntry.Warnln(`Rejected admin socket connection`)
+			conn.Close()
+			continue
+		}
+		logEntry.Debugln(`Accepted admin socket connection`)
+		return &socketConn{UnixConn: conn}, nil
+	}
+}
+
+// permitted returns true if uid may use the admin socket
+func (l *socketListener) permitted(uid uint32) bool {
+	if uid == 0 || uid == uint32(os.Geteuid()) {
+		return true
+	}
+	for _, allowed := range l.allowed {
+		if uid == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// socketConn is a connection accepted on the admin socket
+type socketConn struct {
+	*net.UnixConn
+}
+
+// RemoteAddr implements net.Conn and returns socketAddr
+func (c *socketConn) RemoteAddr() net.Addr {
+	return socketAddr
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,40 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the UID and PID of the process connected
+// to conn via SO_PEERCRED
+func peerCredentials(conn *net.UnixConn) (uint32, int32, error) {
+	var (
+		cred    *unix.Ucred
+		credErr error
+	)
+
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return 0, 0, err
+	}
+	if err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd),
+			unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return 0, 0, err
+	}
+	if credErr != nil {
+		return 0, 0, credErr
+	}
+	return cred.Uid, cred.Pid, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
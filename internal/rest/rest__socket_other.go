@@ -0,0 +1,25 @@
+//go:build !linux
+// +build !linux
+
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+	"net"
+)
+
+// peerCredentials is only implemented via SO_PEERCRED on Linux, all
+// connections are rejected on other platforms
+func peerCredentials(conn *net.UnixConn) (uint32, int32, error) {
+	return 0, 0, fmt.Errorf(`Peer credentials are not supported` +
+		` on this platform`)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
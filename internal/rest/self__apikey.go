@@ -0,0 +1,121 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+	uuid "github.com/satori/go.uuid"
+)
+
+// APIKeyList function
+func (x *Rest) APIKeyList(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionAPIKey
+	request.Action = msg.ActionList
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// APIKeyShow function
+func (x *Rest) APIKeyShow(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionAPIKey
+	request.Action = msg.ActionShow
+	request.APIKey.ID = params.ByName(`apikeyID`)
+
+	if _, err := uuid.FromString(request.APIKey.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// APIKeyCreate function
+func (x *Rest) APIKeyCreate(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionAPIKey
+	request.Action = msg.ActionCreate
+
+	cReq := proto.NewAPIKeyRequest()
+	if err := decodeJSONBody(r, &cReq); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if cReq.APIKey.Name == `` {
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			`APIKeyCreate request missing APIKey.Name`))
+		return
+	}
+	request.APIKey = cReq.APIKey.Clone()
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// APIKeyRevoke function
+func (x *Rest) APIKeyRevoke(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionAPIKey
+	request.Action = msg.ActionRevoke
+	request.APIKey.ID = params.ByName(`apikeyID`)
+
+	if _, err := uuid.FromString(request.APIKey.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -94,14 +94,65 @@ func (x *Rest) JobWait(w http.ResponseWriter, r *http.Request,
 	request.Action = msg.ActionWait
 	request.Job.ID = params.ByName(`jobID`)
 
-	if !x.isAuthorized(&request) {
+	if err := checkStringIsUUID(request.Job.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) || !x.isJobVisible(&request) {
+		x.replyForbidden(&w, &request)
+		return
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	x.replyJobWait(&w, &request)
+}
+
+// JobWatch function
+func (x *Rest) JobWatch(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionJob
+	request.Action = msg.ActionWatch
+	request.Job.ID = params.ByName(`jobID`)
+
+	if err := checkStringIsUUID(request.Job.ID); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
+
+	if !x.isAuthorized(&request) || !x.isJobVisible(&request) {
 		x.replyForbidden(&w, &request)
 		return
 	}
 
+	// the job_stream handler replies with a result per event, a
+	// larger buffer allows it to pass bursts of events without
+	// dropping the client
+	request.Reply = make(chan msg.Result, jobStreamBuffer)
 	x.handlerMap.MustLookup(&request).Intake() <- request
-	<-request.Reply
-	x.replyNoContent(&w)
+	x.replyJobStream(&w, r, &request)
+}
+
+// isJobVisible checks that the user may see the repository the job
+// in q was created for. The job is looked up and stored in q. Unknown
+// jobs are treated as not visible, so that job IDs can not be probed.
+func (x *Rest) isJobVisible(q *msg.Request) bool {
+	result := x.lookupSync(q, msg.SectionJob, msg.ActionShow)
+	if len(result.Job) == 0 {
+		return false
+	}
+	q.Job = result.Job[0]
+
+	repo := *q
+	repo.Section = msg.SectionRepositoryConfig
+	repo.Action = msg.ActionShow
+	repo.Repository = proto.Repository{
+		ID: q.Job.RepositoryID,
+	}
+	return x.isAuthorized(&repo)
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
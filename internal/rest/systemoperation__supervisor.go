@@ -104,6 +104,26 @@ func (x *Rest) SupervisorTokenRequest(w http.ResponseWriter, r *http.Request,
 	x.SupervisorEncryptedData(&w, r, &params, `token/request`)
 }
 
+// SupervisorTokenMail is the endpoint used to request a mail token
+// for account activation or password reset. The reply does not
+// disclose whether the account exists.
+func (x *Rest) SupervisorTokenMail(w http.ResponseWriter, r *http.Request,
+	params httprouter.Params) {
+	defer panicCatcher(w)
+
+	request := msg.New(r, params)
+	request.Section = msg.SectionSupervisor
+	request.Action = msg.ActionToken
+	request.Super = &msg.Supervisor{
+		Task:         msg.TaskMailToken,
+		MailTokenFor: params.ByName(`account`),
+	}
+
+	x.handlerMap.MustLookup(&request).Intake() <- request
+	result := <-request.Reply
+	x.send(&w, &result)
+}
+
 // SupervisorActivateUser is the encrypted endpoint used to
 // activate a user account using external ownership verification
 func (x *Rest) SupervisorActivateUser(w http.ResponseWriter, r *http.Request,
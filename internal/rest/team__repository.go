@@ -10,6 +10,8 @@ package rest // import "github.com/mjolnir42/soma/internal/rest"
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 	"unicode/utf8"
 
 	"github.com/julienschmidt/httprouter"
@@ -25,6 +27,10 @@ func (x *Rest) RepositoryDestroy(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepository
 	request.Action = msg.ActionDestroy
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Repository.TeamID = params.ByName(`teamID`)
 
@@ -38,7 +44,9 @@ func (x *Rest) RepositoryDestroy(w http.ResponseWriter, r *http.Request,
 	x.send(&w, &result)
 }
 
-// RepositoryAudit function
+// RepositoryAudit function returns the change history of a
+// repository. The history can be filtered via the optional query
+// parameters entity, object, user, jobtype, since and until.
 func (x *Rest) RepositoryAudit(w http.ResponseWriter, r *http.Request,
 	params httprouter.Params) {
 	defer panicCatcher(w)
@@ -49,6 +57,44 @@ func (x *Rest) RepositoryAudit(w http.ResponseWriter, r *http.Request,
 	request.Repository.ID = params.ByName(`repositoryID`)
 	request.Repository.TeamID = params.ByName(`teamID`)
 
+	query := r.URL.Query()
+	switch query.Get(`entity`) {
+	case ``, msg.EntityRepository, msg.EntityBucket, msg.EntityGroup,
+		msg.EntityCluster, msg.EntityNode, msg.EntityCheck:
+		request.Search.Audit.ObjectType = query.Get(`entity`)
+	default:
+		x.replyBadRequest(&w, &request, fmt.Errorf(
+			"Invalid audit entity: %s", query.Get(`entity`)))
+		return
+	}
+	if query.Get(`object`) != `` {
+		if err := checkStringIsUUID(query.Get(`object`)); err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+	}
+	for _, ts := range []string{`since`, `until`} {
+		if query.Get(ts) != `` {
+			if _, err := time.Parse(time.RFC3339, query.Get(ts)); err != nil {
+				x.replyBadRequest(&w, &request, err)
+				return
+			}
+		}
+	}
+	if query.Get(`detailed`) != `` {
+		detailed, err := strconv.ParseBool(query.Get(`detailed`))
+		if err != nil {
+			x.replyBadRequest(&w, &request, err)
+			return
+		}
+		request.Search.IsDetailed = detailed
+	}
+	request.Search.Audit.ObjectID = query.Get(`object`)
+	request.Search.Audit.UserName = query.Get(`user`)
+	request.Search.Audit.JobType = query.Get(`jobtype`)
+	request.Search.Audit.Since = query.Get(`since`)
+	request.Search.Audit.Until = query.Get(`until`)
+
 	if !x.isAuthorized(&request) {
 		x.replyForbidden(&w, &request)
 		return
@@ -67,6 +113,10 @@ func (x *Rest) RepositoryRename(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepository
 	request.Action = msg.ActionRename
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewRepositoryRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
@@ -101,6 +151,10 @@ func (x *Rest) RepositoryRepossess(w http.ResponseWriter, r *http.Request,
 	request := msg.New(r, params)
 	request.Section = msg.SectionRepository
 	request.Action = msg.ActionRepossess
+	if err := requestDryRun(r, &request); err != nil {
+		x.replyBadRequest(&w, &request, err)
+		return
+	}
 
 	cReq := proto.NewRepositoryRequest()
 	if err := decodeJSONBody(r, &cReq); err != nil {
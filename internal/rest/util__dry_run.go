@@ -0,0 +1,31 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mjolnir42/soma/internal/msg"
+)
+
+// requestDryRun sets the DryRun flag of q if the request asked for
+// a dry run via the dryrun query parameter. Dry runs are only
+// supported by requests that are processed by TreeKeeper.
+func requestDryRun(r *http.Request, q *msg.Request) error {
+	var err error
+
+	if v := r.URL.Query().Get(`dryrun`); v != `` {
+		if q.Flag.DryRun, err = strconv.ParseBool(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
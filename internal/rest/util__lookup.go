@@ -0,0 +1,24 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"github.com/mjolnir42/soma/internal/msg"
+)
+
+// lookupSync sends a copy of q as read request section::action to
+// its handler and waits for the result
+func (x *Rest) lookupSync(q *msg.Request, section, action string) msg.Result {
+	lookup := *q
+	lookup.Section = section
+	lookup.Action = action
+	x.handlerMap.MustLookup(&lookup).Intake() <- lookup
+	return <-lookup.Reply
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,68 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package rest // import "github.com/mjolnir42/soma/internal/rest"
+
+import (
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// validateProperties validates the property list of a property
+// request for action and returns the property type. All properties
+// of a request must be of the same type. If propertyType is set, it
+// is the expected type of all properties. Only property-replace
+// requests may contain no properties at all, which removes all
+// properties of propertyType.
+func validateProperties(action, propertyType string,
+	properties *[]proto.Property) (string, error) {
+	count := 0
+	if properties != nil {
+		count = len(*properties)
+	}
+	if count == 0 && action != msg.ActionPropertyReplace {
+		return ``, fmt.Errorf(
+			`Expected at least one property, actual count: 0`)
+	}
+
+	for i := 0; i < count; i++ {
+		property := (*properties)[i]
+		if propertyType == `` {
+			propertyType = property.Type
+		}
+		if property.Type != propertyType {
+			return ``, fmt.Errorf(
+				"Mixed property types in request: %s, %s",
+				propertyType, property.Type)
+		}
+
+		switch action {
+		case msg.ActionPropertyCreate, msg.ActionPropertyUpdate,
+			msg.ActionPropertyReplace:
+			if property.Type == msg.PropertyService &&
+				(property.Service == nil || property.Service.Name == ``) {
+				return ``, fmt.Errorf(`Empty service name is invalid`)
+			}
+		case msg.ActionPropertyDestroy:
+			if property.SourceInstanceID == `` {
+				return ``, fmt.Errorf(`Property source instance missing`)
+			}
+		}
+	}
+
+	switch propertyType {
+	case msg.PropertyCustom, msg.PropertyOncall, msg.PropertyService,
+		msg.PropertySystem:
+	default:
+		return ``, fmt.Errorf("Invalid property type: %s", propertyType)
+	}
+	return propertyType, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -21,20 +21,23 @@ import (
 
 // BucketRead handles read requests for buckets
 type BucketRead struct {
-	Input           chan msg.Request
-	Shutdown        chan struct{}
-	handlerName     string
-	conn            *sql.DB
-	stmtList        *sql.Stmt
-	stmtShow        *sql.Stmt
-	stmtSearch      *sql.Stmt
-	stmtPropOncall  *sql.Stmt
-	stmtPropService *sql.Stmt
-	stmtPropSystem  *sql.Stmt
-	stmtPropCustom  *sql.Stmt
-	appLog          *logrus.Logger
-	reqLog          *logrus.Logger
-	errLog          *logrus.Logger
+	Input                 chan msg.Request
+	Shutdown              chan struct{}
+	handlerName           string
+	conn                  *sql.DB
+	stmtList              *sql.Stmt
+	stmtShow              *sql.Stmt
+	stmtSearch            *sql.Stmt
+	stmtPropOncall        *sql.Stmt
+	stmtPropService       *sql.Stmt
+	stmtPropSystem        *sql.Stmt
+	stmtPropCustom        *sql.Stmt
+	stmtMemberListGroup   *sql.Stmt
+	stmtMemberListCluster *sql.Stmt
+	stmtMemberListNode    *sql.Stmt
+	appLog                *logrus.Logger
+	reqLog                *logrus.Logger
+	errLog                *logrus.Logger
 }
 
 // newBucketRead returns a new BucketRead handler with input
@@ -62,6 +65,7 @@ func (r *BucketRead) RegisterRequests(hmap *handler.Map) {
 		msg.ActionList,
 		msg.ActionShow,
 		msg.ActionSearch,
+		msg.ActionMemberList,
 	} {
 		hmap.Request(msg.SectionBucket, action, r.handlerName)
 	}
@@ -82,13 +86,16 @@ func (r *BucketRead) Run() {
 	var err error
 
 	for statement, prepStmt := range map[string]**sql.Stmt{
-		stmt.AuthorizedBucketList:   &r.stmtList,
-		stmt.BucketShow:             &r.stmtShow,
-		stmt.AuthorizedBucketSearch: &r.stmtSearch,
-		stmt.BucketOncProps:         &r.stmtPropOncall,
-		stmt.BucketSvcProps:         &r.stmtPropService,
-		stmt.BucketSysProps:         &r.stmtPropSystem,
-		stmt.BucketCstProps:         &r.stmtPropCustom,
+		stmt.AuthorizedBucketList:    &r.stmtList,
+		stmt.BucketShow:              &r.stmtShow,
+		stmt.AuthorizedBucketSearch:  &r.stmtSearch,
+		stmt.BucketOncProps:          &r.stmtPropOncall,
+		stmt.BucketSvcProps:          &r.stmtPropService,
+		stmt.BucketSysProps:          &r.stmtPropSystem,
+		stmt.BucketCstProps:          &r.stmtPropCustom,
+		stmt.BucketMemberGroupList:   &r.stmtMemberListGroup,
+		stmt.BucketMemberClusterList: &r.stmtMemberListCluster,
+		stmt.BucketMemberNodeList:    &r.stmtMemberListNode,
 	} {
 		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
 			r.errLog.Fatal(`bucket`, err, stmt.Name(statement))
@@ -121,6 +128,8 @@ func (r *BucketRead) process(q *msg.Request) {
 		r.show(q, &result)
 	case msg.ActionSearch:
 		r.search(q, &result)
+	case msg.ActionMemberList:
+		r.memberList(q, &result)
 	default:
 		result.UnknownRequest(q)
 	}
@@ -309,6 +318,142 @@ func (r *BucketRead) search(q *msg.Request, mr *msg.Result) {
 	mr.OK()
 }
 
+// memberList returns the direct children of a bucket
+func (r *BucketRead) memberList(q *msg.Request, mr *msg.Result) {
+	var (
+		bucket                             proto.Bucket
+		bucketName                         string
+		memberGroupID, memberGroupName     string
+		memberClusterID, memberClusterName string
+		memberNodeID, memberNodeName       string
+		err                                error
+		rows                               *sql.Rows
+	)
+
+	bucket.ID = q.Bucket.ID
+	bucket.RepositoryID = q.Bucket.RepositoryID
+	bucket.MemberGroups = &[]proto.Group{}
+	bucket.MemberClusters = &[]proto.Cluster{}
+	bucket.MemberNodes = &[]proto.Node{}
+
+	// fetch member groups
+	if rows, err = r.stmtMemberListGroup.Query(
+		q.Bucket.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&memberGroupID,
+			&memberGroupName,
+			&bucketName,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		bucket.Name = bucketName
+		*bucket.MemberGroups = append(*bucket.MemberGroups, proto.Group{
+			ID:       memberGroupID,
+			Name:     memberGroupName,
+			BucketID: q.Bucket.ID,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	// fetch member clusters
+	if rows, err = r.stmtMemberListCluster.Query(
+		q.Bucket.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&memberClusterID,
+			&memberClusterName,
+			&bucketName,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		bucket.Name = bucketName
+		*bucket.MemberClusters = append(*bucket.MemberClusters,
+			proto.Cluster{
+				ID:       memberClusterID,
+				Name:     memberClusterName,
+				BucketID: q.Bucket.ID,
+			})
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	// fetch member nodes
+	if rows, err = r.stmtMemberListNode.Query(
+		q.Bucket.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&memberNodeID,
+			&memberNodeName,
+			&bucketName,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		bucket.Name = bucketName
+		*bucket.MemberNodes = append(*bucket.MemberNodes,
+			proto.Node{
+				ID:   memberNodeID,
+				Name: memberNodeName,
+			})
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	appendBucketMembers(mr, bucket)
+	mr.OK()
+}
+
+// appendBucketMembers adds the member listing bucket to the result.
+// Empty member lists are omitted from the JSON export. A bucket without
+// any members is not added, since its name is only known from the
+// member rows.
+func appendBucketMembers(mr *msg.Result, bucket proto.Bucket) {
+	if len(*bucket.MemberGroups) == 0 {
+		// trigger ,omitempty in JSON export
+		bucket.MemberGroups = nil
+	}
+	if len(*bucket.MemberClusters) == 0 {
+		// trigger ,omitempty in JSON export
+		bucket.MemberClusters = nil
+	}
+	if len(*bucket.MemberNodes) == 0 {
+		// trigger ,omitempty in JSON export
+		bucket.MemberNodes = nil
+	}
+	if bucket.MemberGroups == nil && bucket.MemberClusters == nil &&
+		bucket.MemberNodes == nil {
+		return
+	}
+	mr.Bucket = append(mr.Bucket, bucket)
+}
+
 // ShutdownNow signals the handler to shut down
 func (r *BucketRead) ShutdownNow() {
 	close(r.Shutdown)
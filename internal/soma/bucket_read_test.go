@@ -0,0 +1,77 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestAppendBucketMembers(t *testing.T) {
+	bucket := func(groups, clusters, nodes int) proto.Bucket {
+		b := proto.Bucket{
+			ID:             `bucketID`,
+			MemberGroups:   &[]proto.Group{},
+			MemberClusters: &[]proto.Cluster{},
+			MemberNodes:    &[]proto.Node{},
+		}
+		if groups+clusters+nodes > 0 {
+			b.Name = `example_test`
+		}
+		for i := 0; i < groups; i++ {
+			*b.MemberGroups = append(*b.MemberGroups, proto.Group{
+				Name: `group`,
+			})
+		}
+		for i := 0; i < clusters; i++ {
+			*b.MemberClusters = append(*b.MemberClusters,
+				proto.Cluster{Name: `cluster`})
+		}
+		for i := 0; i < nodes; i++ {
+			*b.MemberNodes = append(*b.MemberNodes, proto.Node{
+				Name: `node`,
+			})
+		}
+		return b
+	}
+
+	tests := []struct {
+		name   string
+		bucket proto.Bucket
+		json   string
+	}{
+		{`no members`, bucket(0, 0, 0), `[]`},
+		{`groups`, bucket(1, 0, 0),
+			`[{"ID":"bucketID","name":"example_test","memberGroups":[{"name":"group"}]}]`},
+		{`clusters`, bucket(0, 2, 0),
+			`[{"ID":"bucketID","name":"example_test","memberClusters":[{"name":"cluster"},{"name":"cluster"}]}]`},
+		{`nodes`, bucket(0, 0, 1),
+			`[{"ID":"bucketID","name":"example_test","memberNodes":[{"name":"node"}]}]`},
+	}
+
+	for _, test := range tests {
+		mr := msg.Result{}
+		appendBucketMembers(&mr, test.bucket)
+
+		result := proto.NewBucketResult()
+		*result.Buckets = append(*result.Buckets, mr.Bucket...)
+		raw, err := json.Marshal(result.Buckets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(raw) != test.json {
+			t.Errorf("%s: expected %s, got %s", test.name, test.json,
+				raw)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -107,6 +107,96 @@ func exportCheckConfigObjectTX(tx *sql.Tx, objectID string) (
 	return &checkconfigs, nil
 }
 
+// exportCheckConfigRepositoryTX exports all check configurations of
+// a repository, including thresholds and constraints
+func exportCheckConfigRepositoryTX(tx *sql.Tx, repositoryID string) (
+	*[]proto.CheckConfig, error) {
+	var (
+		err           error
+		checkconfigs  []proto.CheckConfig
+		checkConfigID string
+		checkConfigs  []string
+		checkConfig   *proto.CheckConfig
+		txMap         map[string]*sql.Stmt
+		rows          *sql.Rows
+	)
+
+	// declare this tx as deferrable read-only
+	if _, err = tx.Exec(stmt.ReadOnlyTransaction); err != nil {
+		return nil, err
+	}
+
+	txMap = make(map[string]*sql.Stmt)
+	checkconfigs = make([]proto.CheckConfig, 0)
+
+	for name, statement := range map[string]string{
+		`configs`:       stmt.RepositoryExportCheckConfigs,
+		`base`:          stmt.CheckConfigShowBase,
+		`threshold`:     stmt.CheckConfigShowThreshold,
+		`cstrCustom`:    stmt.CheckConfigShowConstrCustom,
+		`cstrSystem`:    stmt.CheckConfigShowConstrSystem,
+		`cstrNative`:    stmt.CheckConfigShowConstrNative,
+		`cstrService`:   stmt.CheckConfigShowConstrService,
+		`cstrAttribute`: stmt.CheckConfigShowConstrAttribute,
+		`cstrOncall`:    stmt.CheckConfigShowConstrOncall,
+	} {
+		if txMap[name], err = tx.Prepare(statement); err != nil {
+			return nil, err
+		}
+	}
+
+	// read all IDs first, queries within the same transaction can
+	// not be issued while the rows are open
+	// https://github.com/lib/pq/issues/81
+	if rows, err = txMap[`configs`].Query(repositoryID); err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		if err = rows.Scan(
+			&checkConfigID,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		checkConfigs = append(checkConfigs, checkConfigID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, checkConfigID = range checkConfigs {
+		if checkConfig, err = exportCheckConfig(
+			txMap[`base`],
+			checkConfigID,
+		); err != nil {
+			return nil, err
+		} else if checkConfig == nil {
+			continue
+		}
+		if checkConfig.Thresholds, err = exportCheckConfigThresholds(
+			txMap[`threshold`],
+			checkConfigID,
+		); err != nil {
+			return nil, err
+		}
+		if checkConfig.Constraints, err = exportCheckConfigConstraints(
+			txMap[`cstrCustom`],
+			txMap[`cstrSystem`],
+			txMap[`cstrNative`],
+			txMap[`cstrService`],
+			txMap[`cstrAttribute`],
+			txMap[`cstrOncall`],
+			checkConfigID,
+		); err != nil {
+			return nil, err
+		}
+		checkconfigs = append(checkconfigs, *checkConfig)
+	}
+	tx.Commit()
+
+	return &checkconfigs, nil
+}
+
 // expects stmt.CheckConfigShowBase as prepared statement
 func exportCheckConfig(prepStmt *sql.Stmt, queryID string) (
 	*proto.CheckConfig, error) {
@@ -267,6 +357,7 @@ func exportCheckConfigCustomConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group                         string
 		checkConfigID, propertyID, repositoryID string
 		name, value                             string
 		rows                                    *sql.Rows
@@ -285,12 +376,16 @@ func exportCheckConfigCustomConstraints(prepStmt *sql.Stmt,
 			&repositoryID,
 			&value,
 			&name,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `custom`,
+			Operator:       operator,
+			Group:          group,
 			Custom: &proto.PropertyCustom{
 				ID:           propertyID,
 				RepositoryID: repositoryID,
@@ -312,6 +407,7 @@ func exportCheckConfigSystemConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group            string
 		checkConfigID, name, value string
 		rows                       *sql.Rows
 		err                        error
@@ -327,12 +423,16 @@ func exportCheckConfigSystemConstraints(prepStmt *sql.Stmt,
 			&checkConfigID,
 			&name,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `system`,
+			Operator:       operator,
+			Group:          group,
 			System: &proto.PropertySystem{
 				Name:  name,
 				Value: value,
@@ -352,6 +452,7 @@ func exportCheckConfigNativeConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group            string
 		checkConfigID, name, value string
 		rows                       *sql.Rows
 		err                        error
@@ -367,12 +468,16 @@ func exportCheckConfigNativeConstraints(prepStmt *sql.Stmt,
 			&checkConfigID,
 			&name,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `native`,
+			Operator:       operator,
+			Group:          group,
 			Native: &proto.PropertyNative{
 				Name:  name,
 				Value: value,
@@ -392,6 +497,7 @@ func exportCheckConfigServiceConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group             string
 		checkConfigID, name, teamID string
 		rows                        *sql.Rows
 		err                         error
@@ -407,12 +513,16 @@ func exportCheckConfigServiceConstraints(prepStmt *sql.Stmt,
 			&checkConfigID,
 			&teamID,
 			&name,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `service`,
+			Operator:       operator,
+			Group:          group,
 			Service: &proto.PropertyService{
 				Name:   name,
 				TeamID: teamID,
@@ -432,6 +542,7 @@ func exportCheckConfigAttributeConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group            string
 		checkConfigID, name, value string
 		rows                       *sql.Rows
 		err                        error
@@ -447,12 +558,16 @@ func exportCheckConfigAttributeConstraints(prepStmt *sql.Stmt,
 			&checkConfigID,
 			&name,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `attribute`,
+			Operator:       operator,
+			Group:          group,
 			Attribute: &proto.ServiceAttribute{
 				Name:  name,
 				Value: value,
@@ -472,6 +587,7 @@ func exportCheckConfigOncallConstraints(prepStmt *sql.Stmt,
 	queryID string) ([]proto.CheckConfigConstraint, error) {
 
 	var (
+		operator, group                       string
 		checkConfigID, oncallID, name, number string
 		rows                                  *sql.Rows
 		err                                   error
@@ -488,12 +604,16 @@ func exportCheckConfigOncallConstraints(prepStmt *sql.Stmt,
 			&oncallID,
 			&name,
 			&number,
+			&operator,
+			&group,
 		); err != nil {
 			rows.Close()
 			return nil, err
 		}
 		cstr := proto.CheckConfigConstraint{
 			ConstraintType: `oncall`,
+			Operator:       operator,
+			Group:          group,
 			Oncall: &proto.PropertyOncall{
 				ID:     oncallID,
 				Name:   name,
@@ -332,42 +332,47 @@ func (r *CheckConfigurationRead) thresholds(cnf *proto.CheckConfig) error {
 
 // constraints adds constraints to a check configuration
 func (r *CheckConfigurationRead) constraints(cnf *proto.CheckConfig) error {
+	return loadConstraints(cnf, map[string]*sql.Stmt{
+		msg.ConstraintCustom:    r.stmtShowConstraintCustom,
+		msg.ConstraintSystem:    r.stmtShowConstraintSystem,
+		msg.ConstraintNative:    r.stmtShowConstraintNative,
+		msg.ConstraintService:   r.stmtShowConstraintService,
+		msg.ConstraintAttribute: r.stmtShowConstraintAttribute,
+		msg.ConstraintOncall:    r.stmtShowConstraintOncall,
+	})
+}
+
+// loadConstraints adds constraints to a check configuration. stmts
+// maps every constraint type to the prepared statement that loads
+// constraints of that type, stmt.CheckConfigShowConstr*.
+func loadConstraints(cnf *proto.CheckConfig, stmts map[string]*sql.Stmt) error {
 	var err error
 	cnf.Constraints = make([]proto.CheckConfigConstraint, 0)
 
 	for _, typ := range []string{
-		`custom`,
-		`system`,
-		`native`,
-		`service`,
-		`attribute`,
-		`oncall`,
+		msg.ConstraintCustom,
+		msg.ConstraintSystem,
+		msg.ConstraintNative,
+		msg.ConstraintService,
+		msg.ConstraintAttribute,
+		msg.ConstraintOncall,
 	} {
 		switch typ {
-		case `custom`:
-			if err = r.constraintCustom(cnf); err != nil {
-				return err
-			}
-		case `system`:
-			if err = r.constraintSystem(cnf); err != nil {
-				return err
-			}
-		case `native`:
-			if err = r.constraintNative(cnf); err != nil {
-				return err
-			}
-		case `service`:
-			if err = r.constraintService(cnf); err != nil {
-				return err
-			}
-		case `attribute`:
-			if err = r.constraintAttribute(cnf); err != nil {
-				return err
-			}
-		case `oncall`:
-			if err = r.constraintOncall(cnf); err != nil {
-				return err
-			}
+		case msg.ConstraintCustom:
+			err = constraintCustom(stmts[typ], cnf)
+		case msg.ConstraintSystem:
+			err = constraintSystem(stmts[typ], cnf)
+		case msg.ConstraintNative:
+			err = constraintNative(stmts[typ], cnf)
+		case msg.ConstraintService:
+			err = constraintService(stmts[typ], cnf)
+		case msg.ConstraintAttribute:
+			err = constraintAttribute(stmts[typ], cnf)
+		case msg.ConstraintOncall:
+			err = constraintOncall(stmts[typ], cnf)
+		}
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -375,14 +380,15 @@ func (r *CheckConfigurationRead) constraints(cnf *proto.CheckConfig) error {
 
 // constraintCustom adds constraints on custom properties to
 // a check configuration
-func (r *CheckConfigurationRead) constraintCustom(cnf *proto.CheckConfig) error {
+func constraintCustom(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, propertyID, repoID, property, value string
 		rows                                          *sql.Rows
 		err                                           error
+		operator, group                               string
 	)
 
-	if rows, err = r.stmtShowConstraintCustom.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -396,12 +402,16 @@ func (r *CheckConfigurationRead) constraintCustom(cnf *proto.CheckConfig) error
 			&repoID,
 			&value,
 			&property,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `custom`,
+			Operator:       operator,
+			Group:          group,
 			Custom: &proto.PropertyCustom{
 				ID:           propertyID,
 				RepositoryID: repoID,
@@ -419,14 +429,15 @@ func (r *CheckConfigurationRead) constraintCustom(cnf *proto.CheckConfig) error
 
 // constraintSystem adds constraints on system properties to
 // a check configuration
-func (r *CheckConfigurationRead) constraintSystem(cnf *proto.CheckConfig) error {
+func constraintSystem(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, property, value string
 		rows                      *sql.Rows
 		err                       error
+		operator, group           string
 	)
 
-	if rows, err = r.stmtShowConstraintSystem.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -438,12 +449,16 @@ func (r *CheckConfigurationRead) constraintSystem(cnf *proto.CheckConfig) error
 			&configID,
 			&property,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `system`,
+			Operator:       operator,
+			Group:          group,
 			System: &proto.PropertySystem{
 				Name:  property,
 				Value: value,
@@ -459,14 +474,15 @@ func (r *CheckConfigurationRead) constraintSystem(cnf *proto.CheckConfig) error
 
 // constraintNative adds constraints on native properties to
 // a check configuration
-func (r *CheckConfigurationRead) constraintNative(cnf *proto.CheckConfig) error {
+func constraintNative(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, property, value string
 		rows                      *sql.Rows
 		err                       error
+		operator, group           string
 	)
 
-	if rows, err = r.stmtShowConstraintNative.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -478,12 +494,16 @@ func (r *CheckConfigurationRead) constraintNative(cnf *proto.CheckConfig) error
 			&configID,
 			&property,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `native`,
+			Operator:       operator,
+			Group:          group,
 			Native: &proto.PropertyNative{
 				Name:  property,
 				Value: value,
@@ -497,14 +517,15 @@ func (r *CheckConfigurationRead) constraintNative(cnf *proto.CheckConfig) error
 
 // constraintService adds constraints on service properties to
 // a check configuration
-func (r *CheckConfigurationRead) constraintService(cnf *proto.CheckConfig) error {
+func constraintService(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, teamID, svcName string
 		rows                      *sql.Rows
 		err                       error
+		operator, group           string
 	)
 
-	if rows, err = r.stmtShowConstraintService.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -516,12 +537,16 @@ func (r *CheckConfigurationRead) constraintService(cnf *proto.CheckConfig) error
 			&configID,
 			&teamID,
 			&svcName,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `service`,
+			Operator:       operator,
+			Group:          group,
 			Service: &proto.PropertyService{
 				Name:   svcName,
 				TeamID: teamID,
@@ -537,14 +562,15 @@ func (r *CheckConfigurationRead) constraintService(cnf *proto.CheckConfig) error
 
 // constraintAttribute adds constraints on service attributes
 // to a check configuration
-func (r *CheckConfigurationRead) constraintAttribute(cnf *proto.CheckConfig) error {
+func constraintAttribute(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, attribute, value string
 		rows                       *sql.Rows
 		err                        error
+		operator, group            string
 	)
 
-	if rows, err = r.stmtShowConstraintAttribute.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -556,12 +582,16 @@ func (r *CheckConfigurationRead) constraintAttribute(cnf *proto.CheckConfig) err
 			&configID,
 			&attribute,
 			&value,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `attribute`,
+			Operator:       operator,
+			Group:          group,
 			Attribute: &proto.ServiceAttribute{
 				Name:  attribute,
 				Value: value,
@@ -574,14 +604,15 @@ func (r *CheckConfigurationRead) constraintAttribute(cnf *proto.CheckConfig) err
 
 // constraintOncall adds constraints on oncall properties
 // to a check configuration
-func (r *CheckConfigurationRead) constraintOncall(cnf *proto.CheckConfig) error {
+func constraintOncall(s *sql.Stmt, cnf *proto.CheckConfig) error {
 	var (
 		configID, oncallID, oncallName, oncallNumber string
 		rows                                         *sql.Rows
 		err                                          error
+		operator, group                              string
 	)
 
-	if rows, err = r.stmtShowConstraintOncall.Query(
+	if rows, err = s.Query(
 		cnf.ID,
 	); err != nil {
 		return err
@@ -594,12 +625,16 @@ func (r *CheckConfigurationRead) constraintOncall(cnf *proto.CheckConfig) error
 			&oncallID,
 			&oncallName,
 			&oncallNumber,
+			&operator,
+			&group,
 		); err != nil {
 			return err
 		}
 
 		constraint := proto.CheckConfigConstraint{
 			ConstraintType: `oncall`,
+			Operator:       operator,
+			Group:          group,
 			Oncall: &proto.PropertyOncall{
 				ID:     oncallID,
 				Name:   oncallName,
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/lib/pq"
 	"github.com/mjolnir42/soma/internal/handler"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
@@ -28,14 +29,15 @@ type DeploymentWrite struct {
 	handlerName              string
 	conn                     *sql.DB
 	stmtGet                  *sql.Stmt
-	stmtSetStatusUpdate      *sql.Stmt
 	stmtGetStatus            *sql.Stmt
-	stmtActivate             *sql.Stmt
 	stmtList                 *sql.Stmt
 	stmtAll                  *sql.Stmt
 	stmtClearFlag            *sql.Stmt
-	stmtDeprovision          *sql.Stmt
 	stmtDeprovisionForUpdate *sql.Stmt
+	stmtFilter               *sql.Stmt
+	stmtSilenced             *sql.Stmt
+	stmtSilencedList         *sql.Stmt
+	stmtTransition           *sql.Stmt
 	appLog                   *logrus.Logger
 	reqLog                   *logrus.Logger
 	errLog                   *logrus.Logger
@@ -68,6 +70,7 @@ func (w *DeploymentWrite) RegisterRequests(hmap *handler.Map) {
 		msg.ActionFailed,
 		msg.ActionList,
 		msg.ActionPending,
+		msg.ActionFilter,
 	} {
 		hmap.Request(msg.SectionDeployment, action, w.handlerName)
 	}
@@ -88,15 +91,16 @@ func (w *DeploymentWrite) Run() {
 	var err error
 
 	for statement, prepStmt := range map[string]**sql.Stmt{
-		stmt.DeploymentGet:              &w.stmtGet,
-		stmt.DeploymentUpdate:           &w.stmtSetStatusUpdate,
-		stmt.DeploymentStatus:           &w.stmtGetStatus,
-		stmt.DeploymentActivate:         &w.stmtActivate,
-		stmt.DeploymentList:             &w.stmtList,
-		stmt.DeploymentListAll:          &w.stmtAll,
-		stmt.DeploymentClearFlag:        &w.stmtClearFlag,
-		stmt.DeploymentDeprovision:      &w.stmtDeprovision,
-		stmt.DeploymentDeprovisionStyle: &w.stmtDeprovisionForUpdate,
+		stmt.DeploymentGet:                &w.stmtGet,
+		stmt.DeploymentStatus:             &w.stmtGetStatus,
+		stmt.DeploymentList:               &w.stmtList,
+		stmt.DeploymentListAll:            &w.stmtAll,
+		stmt.DeploymentClearFlag:          &w.stmtClearFlag,
+		stmt.DeploymentDeprovisionStyle:   &w.stmtDeprovisionForUpdate,
+		stmt.DeploymentFilter:             &w.stmtFilter,
+		stmt.MaintenanceSilencedUntil:     &w.stmtSilenced,
+		stmt.MaintenanceSilencedInstances: &w.stmtSilencedList,
+		stmt.WorkflowTransition:           &w.stmtTransition,
 	} {
 		if *prepStmt, err = w.conn.Prepare(statement); err != nil {
 			w.errLog.Fatal(`deployment`, err, stmt.Name(statement))
@@ -131,6 +135,8 @@ func (w *DeploymentWrite) process(q *msg.Request) {
 		w.pending(q, &result)
 	case msg.ActionList:
 		w.list(q, &result)
+	case msg.ActionFilter:
+		w.filter(q, &result)
 	default:
 		result.UnknownRequest(q)
 	}
@@ -141,11 +147,10 @@ func (w *DeploymentWrite) process(q *msg.Request) {
 // the stored deployment and advances the deployment workflow as required
 func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 	var (
-		instanceConfigID, status, nextStatus                      string
-		newCurrentStatus, details, newNextStatus, deprovisionTask string
-		statusUpdateRequired, hasUpdate                           bool
-		err                                                       error
-		res                                                       sql.Result
+		instanceConfigID, status, nextStatus       string
+		newCurrentStatus, details, deprovisionTask string
+		statusUpdateRequired, hasUpdate            bool
+		err                                        error
 	)
 
 	if err = w.stmtGet.QueryRow(
@@ -168,6 +173,12 @@ func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 		mr.ServerError(err, q.Section)
 		return
 	}
+	if depl.SilencedUntil, err = silencedUntil(
+		w.stmtSilenced, q.Deployment.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
 
 	// returns true if there is a updated version blocked, ie.
 	// after this deprovisioning a new version will be rolled out
@@ -191,7 +202,6 @@ func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 	switch status {
 	case proto.DeploymentAwaitingRollout:
 		newCurrentStatus = proto.DeploymentRolloutInProgress
-		newNextStatus = proto.DeploymentActive
 		depl.Task = proto.TaskRollout
 		statusUpdateRequired = true
 	case proto.DeploymentRolloutInProgress:
@@ -202,12 +212,10 @@ func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 		statusUpdateRequired = false
 	case proto.DeploymentRolloutFailed:
 		newCurrentStatus = proto.DeploymentRolloutInProgress
-		newNextStatus = proto.DeploymentActive
 		depl.Task = proto.TaskRollout
 		statusUpdateRequired = true
 	case proto.DeploymentAwaitingDeprovision:
 		newCurrentStatus = proto.DeploymentDeprovisionInProgress
-		newNextStatus = proto.DeploymentDeprovisioned
 		depl.Task = deprovisionTask
 		statusUpdateRequired = true
 	case proto.DeploymentDeprovisionInProgress:
@@ -215,7 +223,6 @@ func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 		statusUpdateRequired = false
 	case proto.DeploymentDeprovisionFailed:
 		newCurrentStatus = proto.DeploymentDeprovisionInProgress
-		newNextStatus = proto.DeploymentDeprovisioned
 		depl.Task = deprovisionTask
 		statusUpdateRequired = true
 	default:
@@ -232,21 +239,19 @@ func (w *DeploymentWrite) show(q *msg.Request, mr *msg.Result) {
 	}
 
 	if statusUpdateRequired {
-		if res, err = w.stmtSetStatusUpdate.Exec(
-			newCurrentStatus,
-			newNextStatus,
+		if err = transition(
+			w.stmtTransition,
 			instanceConfigID,
+			status,
+			newCurrentStatus,
+			actorMonitoring,
 		); err != nil {
-			mr.ServerError(err, q.Section)
+			w.transitionError(err, q, mr)
 			return
 		}
-		if mr.RowCnt(res.RowsAffected()) {
-			mr.Deployment = append(mr.Deployment, depl)
-		}
-	} else {
-		mr.Deployment = append(mr.Deployment, depl)
-		mr.OK()
 	}
+	mr.Deployment = append(mr.Deployment, depl)
+	mr.OK()
 }
 
 // success marks a rollout as successfully completed
@@ -254,7 +259,6 @@ func (w *DeploymentWrite) success(q *msg.Request, mr *msg.Result) {
 	var (
 		instanceConfigID, status, next, task string
 		err                                  error
-		res                                  sql.Result
 	)
 
 	if err = w.stmtGetStatus.QueryRow(
@@ -273,13 +277,14 @@ func (w *DeploymentWrite) success(q *msg.Request, mr *msg.Result) {
 
 	switch status {
 	case proto.DeploymentRolloutInProgress:
-		if res, err = w.stmtActivate.Exec(
-			next,
-			proto.DeploymentNone,
-			time.Now().UTC(),
+		if err = transition(
+			w.stmtTransition,
 			instanceConfigID,
+			status,
+			proto.DeploymentActive,
+			actorMonitoring,
 		); err != nil {
-			mr.ServerError(err, q.Section)
+			w.transitionError(err, q, mr)
 			return
 		}
 
@@ -292,13 +297,14 @@ func (w *DeploymentWrite) success(q *msg.Request, mr *msg.Result) {
 			return
 		}
 
-		if res, err = w.stmtDeprovision.Exec(
-			next,
-			proto.DeploymentNone,
-			time.Now().UTC(),
+		if err = transition(
+			w.stmtTransition,
 			instanceConfigID,
+			status,
+			proto.DeploymentDeprovisioned,
+			actorMonitoring,
 		); err != nil {
-			mr.ServerError(err, q.Section)
+			w.transitionError(err, q, mr)
 			return
 		}
 	default:
@@ -313,12 +319,11 @@ func (w *DeploymentWrite) success(q *msg.Request, mr *msg.Result) {
 		return
 	}
 
-	if mr.RowCnt(res.RowsAffected()) {
-		mr.Deployment = append(mr.Deployment, proto.Deployment{
-			ID:   q.Deployment.ID,
-			Task: task,
-		})
-	}
+	mr.Deployment = append(mr.Deployment, proto.Deployment{
+		ID:   q.Deployment.ID,
+		Task: task,
+	})
+	mr.OK()
 }
 
 // failed marks a rollout as failed
@@ -326,7 +331,6 @@ func (w *DeploymentWrite) failed(q *msg.Request, mr *msg.Result) {
 	var (
 		instanceConfigID, status, next, task string
 		err                                  error
-		res                                  sql.Result
 	)
 
 	if err = w.stmtGetStatus.QueryRow(
@@ -345,12 +349,14 @@ func (w *DeploymentWrite) failed(q *msg.Request, mr *msg.Result) {
 
 	switch status {
 	case proto.DeploymentRolloutInProgress:
-		if res, err = w.stmtSetStatusUpdate.Exec(
-			proto.DeploymentRolloutFailed,
-			proto.DeploymentNone,
+		if err = transition(
+			w.stmtTransition,
 			instanceConfigID,
+			status,
+			proto.DeploymentRolloutFailed,
+			actorMonitoring,
 		); err != nil {
-			mr.ServerError(err, q.Section)
+			w.transitionError(err, q, mr)
 			return
 		}
 		task = proto.TaskRollout
@@ -362,12 +368,14 @@ func (w *DeploymentWrite) failed(q *msg.Request, mr *msg.Result) {
 			return
 		}
 
-		if res, err = w.stmtSetStatusUpdate.Exec(
-			proto.DeploymentDeprovisionFailed,
-			proto.DeploymentNone,
+		if err = transition(
+			w.stmtTransition,
 			instanceConfigID,
+			status,
+			proto.DeploymentDeprovisionFailed,
+			actorMonitoring,
 		); err != nil {
-			mr.ServerError(err, q.Section)
+			w.transitionError(err, q, mr)
 			return
 		}
 	default:
@@ -382,12 +390,11 @@ func (w *DeploymentWrite) failed(q *msg.Request, mr *msg.Result) {
 		return
 	}
 
-	if mr.RowCnt(res.RowsAffected()) {
-		mr.Deployment = append(mr.Deployment, proto.Deployment{
-			ID:   q.Deployment.ID,
-			Task: task,
-		})
-	}
+	mr.Deployment = append(mr.Deployment, proto.Deployment{
+		ID:   q.Deployment.ID,
+		Task: task,
+	})
+	mr.OK()
 }
 
 // pending returns all deployment IDs for a monitoring system that have
@@ -467,6 +474,152 @@ func (w *DeploymentWrite) list(q *msg.Request, mr *msg.Result) {
 	mr.OK()
 }
 
+// filter returns the deployments of a monitoring system that match
+// the search conditions. Unlike show, it does not advance the
+// deployment workflow and unlike list and pending, it does not clear
+// the update flag
+func (w *DeploymentWrite) filter(q *msg.Request, mr *msg.Result) {
+	var (
+		instanceID, status, details, task string
+		hasUpdate                         bool
+		err                               error
+		rows                              *sql.Rows
+		nullRepo, nullBucket, nullView    sql.NullString
+		nullCapability, nullDatacenter    sql.NullString
+		changedSince                      pq.NullTime
+		instanceIDs                       []string
+		silenced                          map[string]string
+	)
+
+	if q.Search.Deployment.Repository != `` {
+		nullRepo.String = q.Search.Deployment.Repository
+		nullRepo.Valid = true
+	}
+	if q.Search.Deployment.Bucket != `` {
+		nullBucket.String = q.Search.Deployment.Bucket
+		nullBucket.Valid = true
+	}
+	if q.Search.Deployment.View != `` {
+		nullView.String = q.Search.Deployment.View
+		nullView.Valid = true
+	}
+	if q.Search.Deployment.CapabilityID != `` {
+		nullCapability.String = q.Search.Deployment.CapabilityID
+		nullCapability.Valid = true
+	}
+	if q.Search.Deployment.Datacenter != `` {
+		nullDatacenter.String = q.Search.Deployment.Datacenter
+		nullDatacenter.Valid = true
+	}
+	if q.Search.Deployment.ChangedSince != `` {
+		if changedSince.Time, err = time.Parse(
+			time.RFC3339,
+			q.Search.Deployment.ChangedSince,
+		); err != nil {
+			mr.BadRequest(err, q.Section)
+			return
+		}
+		changedSince.Valid = true
+	}
+
+	if rows, err = w.stmtFilter.Query(
+		q.Monitoring.ID,
+		q.Search.Deployment.State,
+		nullRepo,
+		nullBucket,
+		nullView,
+		nullCapability,
+		nullDatacenter,
+		changedSince,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&instanceID,
+			&status,
+			&details,
+			&hasUpdate,
+		); err != nil {
+			mr.ServerError(err, q.Section)
+			return
+		}
+
+		if task, err = deploymentTask(status, hasUpdate); err != nil {
+			// the REST layer only accepts deployable states
+			mr.ServerError(err, q.Section)
+			return
+		}
+
+		if q.Search.Deployment.Task != `` &&
+			q.Search.Deployment.Task != task {
+			continue
+		}
+
+		if !q.Search.IsDetailed {
+			mr.Deployment = append(mr.Deployment, proto.Deployment{
+				ID:   instanceID,
+				Task: task,
+			})
+			continue
+		}
+
+		depl := proto.Deployment{}
+		if err = json.Unmarshal([]byte(details), &depl); err != nil {
+			mr.ServerError(err, q.Section)
+			return
+		}
+		depl.Task = task
+		mr.Deployment = append(mr.Deployment, depl)
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	// instanceIDs is only filled for detailed results and then has
+	// one entry per deployment
+	if silenced, err = silencedInstances(
+		w.stmtSilencedList, instanceIDs,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	for i := range instanceIDs {
+		mr.Deployment[i].SilencedUntil = silenced[instanceIDs[i]]
+	}
+	mr.OK()
+}
+
+// deploymentTask returns the task of a deployment in state status.
+// Deprovisioning an instance configuration that is blocking an update
+// replaces it, otherwise the check instance is deleted. This is the
+// same distinction as in show.
+func deploymentTask(status string, hasUpdate bool) (string, error) {
+	switch status {
+	case proto.DeploymentAwaitingRollout,
+		proto.DeploymentRolloutInProgress,
+		proto.DeploymentActive,
+		proto.DeploymentRolloutFailed:
+		return proto.TaskRollout, nil
+	case proto.DeploymentAwaitingDeprovision,
+		proto.DeploymentDeprovisionInProgress,
+		proto.DeploymentDeprovisionFailed:
+		if hasUpdate {
+			return proto.TaskDeprovision, nil
+		}
+		return proto.TaskDelete, nil
+	}
+	return ``, fmt.Errorf(
+		"Impossible deployment state %s encountered",
+		status,
+	)
+}
+
 // ShutdownNow signals the handler to shut down
 func (w *DeploymentWrite) ShutdownNow() {
 	close(w.Shutdown)
@@ -42,4 +42,17 @@ func (w *DeploymentWrite) deprovisionForUpdate(q *msg.Request) (string, error) {
 	return task, nil
 }
 
+// transitionError sets the result for a failed deployment state
+// transition. A concurrent state change is reported as conflict, the
+// monitoring system can retry with the new state.
+func (w *DeploymentWrite) transitionError(err error, q *msg.Request,
+	mr *msg.Result) {
+	switch err {
+	case errTransitionConflict:
+		mr.Conflict(err, q.Section)
+	default:
+		mr.ServerError(err, q.Section)
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,53 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"testing"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestDeploymentTask(t *testing.T) {
+	tests := []struct {
+		status    string
+		hasUpdate bool
+		task      string
+		err       bool
+	}{
+		{proto.DeploymentAwaitingRollout, false, proto.TaskRollout, false},
+		{proto.DeploymentRolloutInProgress, false, proto.TaskRollout, false},
+		{proto.DeploymentActive, false, proto.TaskRollout, false},
+		{proto.DeploymentRolloutFailed, true, proto.TaskRollout, false},
+		{proto.DeploymentAwaitingDeprovision, false, proto.TaskDelete, false},
+		{proto.DeploymentAwaitingDeprovision, true, proto.TaskDeprovision, false},
+		{proto.DeploymentDeprovisionInProgress, false, proto.TaskDelete, false},
+		{proto.DeploymentDeprovisionInProgress, true, proto.TaskDeprovision, false},
+		{proto.DeploymentDeprovisionFailed, false, proto.TaskDelete, false},
+		{proto.DeploymentDeprovisionFailed, true, proto.TaskDeprovision, false},
+		{proto.DeploymentAwaitingComputation, false, ``, true},
+		{proto.DeploymentComputed, false, ``, true},
+		{proto.DeploymentBlocked, false, ``, true},
+		{proto.DeploymentDeprovisioned, true, ``, true},
+		{proto.DeploymentAwaitingDeletion, false, ``, true},
+	}
+
+	for _, test := range tests {
+		task, err := deploymentTask(test.status, test.hasUpdate)
+		if (err != nil) != test.err {
+			t.Errorf("%s/%t: unexpected error state: %v", test.status,
+				test.hasUpdate, err)
+		}
+		if task != test.task {
+			t.Errorf("%s/%t: expected task %q, got %q", test.status,
+				test.hasUpdate, test.task, task)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -20,12 +20,6 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// XXX BUG shutdown information must be transported to the
-// REST packages
-
-// ShutdownInProgress signals activated system shutdown
-var ShutdownInProgress bool
-
 // GrimReaper handles requests for controlled shutdown
 type GrimReaper struct {
 	Input    chan msg.Request
@@ -122,7 +116,10 @@ runloop:
 		goto runloop
 	}
 
-	time.Sleep(time.Duration(grim.soma.conf.ShutdownDelay) * time.Second)
+	// deliver the replies of the requests aborted by the shutdown
+	grim.frontends(func(f Frontend) {
+		f.Stop(time.Duration(grim.soma.conf.ShutdownDelay) * time.Second)
+	})
 	grim.appLog.Println("GrimReaper: shutdown complete")
 }
 
@@ -138,15 +135,15 @@ func (grim *GrimReaper) process(q *msg.Request) bool {
 		return false
 	}
 
-	// tell HTTP handlers to start turning people away
-	ShutdownInProgress = true
-
 	// answer shutdown request
 	result.OK()
 	q.Reply <- result
 
-	// give HTTP handlers time to turn people away
-	time.Sleep(time.Duration(grim.soma.conf.ShutdownDelay) * time.Second)
+	// turn new requests away and give the requests in progress time
+	// to finish
+	grim.frontends(func(f Frontend) {
+		f.Drain(time.Duration(grim.soma.conf.ShutdownDelay) * time.Second)
+	})
 
 	// I have awoken.
 	grim.appLog.Println(`GRIM REAPER ACTIVATED. SYSTEM SHUTDOWN INITIATED`)
@@ -182,6 +179,7 @@ func (grim *GrimReaper) process(q *msg.Request) bool {
 		`job_block`,
 		`forest_custodian`,
 		`guidepost`,
+		`job_stream`,
 		`lifecycle`,
 		`deployment`,
 	} {
@@ -209,6 +207,20 @@ func (grim *GrimReaper) process(q *msg.Request) bool {
 	return true
 }
 
+// frontends calls fn concurrently for all registered frontends and
+// waits for all calls to return
+func (grim *GrimReaper) frontends(fn func(Frontend)) {
+	wg := sync.WaitGroup{}
+	for _, f := range grim.soma.frontends {
+		wg.Add(1)
+		go func(f Frontend) {
+			defer wg.Done()
+			fn(f)
+		}(f)
+	}
+	wg.Wait()
+}
+
 // ShutdownNow signals the handler to shut down. In the case of the
 // GrimReaper, this will shut down SOMA
 func (grim *GrimReaper) ShutdownNow() {
@@ -36,6 +36,7 @@ type GuidePost struct {
 	stmtServiceAttributes     *sql.Stmt
 	stmtCapabilityThresholds  *sql.Stmt
 	stmtCheckDetailsForDelete *sql.Stmt
+	stmtCheckDetailsForUpdate *sql.Stmt
 	stmtBucketForNodeID       *sql.Stmt
 	stmtBucketForClusterID    *sql.Stmt
 	stmtBucketForGroupID      *sql.Stmt
@@ -78,6 +79,7 @@ func (g *GuidePost) RegisterRequests(hmap *handler.Map) {
 			msg.ActionDestroy,
 			msg.ActionPropertyCreate,
 			msg.ActionPropertyDestroy,
+			msg.ActionPropertyReplace,
 			msg.ActionPropertyUpdate,
 		} {
 			hmap.Request(section, action, `guidepost`)
@@ -89,23 +91,30 @@ func (g *GuidePost) RegisterRequests(hmap *handler.Map) {
 		Action  string
 	}{
 		{Section: msg.SectionSystem, Action: msg.ActionRepoStop},
+		{Section: msg.SectionRepository, Action: msg.ActionBatch},
 		{Section: msg.SectionRepository, Action: msg.ActionDestroy},
 		{Section: msg.SectionRepository, Action: msg.ActionRename},
 		{Section: msg.SectionRepository, Action: msg.ActionRepossess},
 		{Section: msg.SectionRepositoryConfig, Action: msg.ActionPropertyCreate},
 		{Section: msg.SectionRepositoryConfig, Action: msg.ActionPropertyDestroy},
+		{Section: msg.SectionRepositoryConfig, Action: msg.ActionPropertyReplace},
 		{Section: msg.SectionRepositoryConfig, Action: msg.ActionPropertyUpdate},
 		{Section: msg.SectionNodeConfig, Action: msg.ActionAssign},
 		{Section: msg.SectionNodeConfig, Action: msg.ActionUnassign},
 		{Section: msg.SectionNodeConfig, Action: msg.ActionPropertyCreate},
 		{Section: msg.SectionNodeConfig, Action: msg.ActionPropertyDestroy},
+		{Section: msg.SectionNodeConfig, Action: msg.ActionPropertyReplace},
 		{Section: msg.SectionNodeConfig, Action: msg.ActionPropertyUpdate},
+		{Section: msg.SectionBucket, Action: msg.ActionMemberAssign},
+		{Section: msg.SectionBucket, Action: msg.ActionMemberUnassign},
 		{Section: msg.SectionGroup, Action: msg.ActionMemberAssign},
 		{Section: msg.SectionGroup, Action: msg.ActionMemberUnassign},
 		{Section: msg.SectionCluster, Action: msg.ActionMemberAssign},
 		{Section: msg.SectionCluster, Action: msg.ActionMemberUnassign},
 		{Section: msg.SectionCheckConfig, Action: msg.ActionCreate},
 		{Section: msg.SectionCheckConfig, Action: msg.ActionDestroy},
+		{Section: msg.SectionCheckConfig, Action: msg.ActionExplain},
+		{Section: msg.SectionCheckConfig, Action: msg.ActionUpdate},
 	} {
 		hmap.Request(request.Section, request.Action, `guidepost`)
 	}
@@ -135,6 +144,7 @@ func (g *GuidePost) Run() {
 		stmt.ServiceAttributes:     &g.stmtServiceAttributes,
 		stmt.CapabilityThresholds:  &g.stmtCapabilityThresholds,
 		stmt.CheckDetailsForDelete: &g.stmtCheckDetailsForDelete,
+		stmt.CheckDetailsForUpdate: &g.stmtCheckDetailsForUpdate,
 		stmt.NodeBucketID:          &g.stmtBucketForNodeID,
 		stmt.ClusterBucketID:       &g.stmtBucketForClusterID,
 		stmt.GroupBucketID:         &g.stmtBucketForGroupID,
@@ -209,6 +219,28 @@ func (g *GuidePost) process(q *msg.Request) {
 	keeper = fmt.Sprintf("repository_%s_%s", repoName, repoID)
 	handler = g.soma.handlerMap.Get(keeper).(*TreeKeeper)
 
+	// dry runs are not saved as jobs, the TreeKeeper replies with
+	// the planned changes directly
+	if q.Flag.DryRun {
+		g.appLog.Infof("Forwarding dry run (%s::%s) for %s",
+			q.Section,
+			q.Action,
+			q.AuthUser)
+		handler.Input <- *q
+		return
+	}
+
+	// explaining a check only reads the tree, the TreeKeeper replies
+	// with the explanation directly
+	if q.Section == msg.SectionCheckConfig && q.Action == msg.ActionExplain {
+		g.appLog.Infof("Forwarding explain (%s::%s) for %s",
+			q.Section,
+			q.Action,
+			q.AuthUser)
+		handler.Input <- *q
+		return
+	}
+
 	// store job in database
 	q.JobID = uuid.Must(uuid.NewV4())
 	g.appLog.Infof("Saving job %s (%s::%s) for %s",
@@ -240,6 +272,12 @@ func (g *GuidePost) process(q *msg.Request) {
 		nf = false
 		goto bailout
 	}
+	if js, ok := g.soma.handlerMap.Get(`job_stream`).(*JobStream); ok {
+		js.Publish(newJobEvent(
+			q.JobID.String(),
+			proto.JobEventQueued,
+		))
+	}
 
 	handler.Input <- *q
 	result.JobID = q.JobID.String()
@@ -0,0 +1,80 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+)
+
+// validateBatch validates all requests contained in a batch request.
+// Every contained request must route to the repository of the batch.
+func (g *GuidePost) validateBatch(q *msg.Request) (bool, error) {
+	if len(q.Batch) == 0 {
+		return false, fmt.Errorf(`Batch request without operations`)
+	}
+
+	for i := range q.Batch {
+		sub := &q.Batch[i]
+
+		if !isBatchable(sub) {
+			return false, fmt.Errorf(
+				"Batch operation %d: invalid request type %s::%s",
+				i, sub.Section, sub.Action,
+			)
+		}
+
+		repoID, _, nf, err := g.extractRouting(sub)
+		if err != nil {
+			return nf, fmt.Errorf("Batch operation %d: %s", i, err.Error())
+		}
+		if repoID != q.Repository.ID {
+			return false, fmt.Errorf(
+				"Batch operation %d: request for repository %s"+
+					" in batch for repository %s",
+				i, repoID, q.Repository.ID,
+			)
+		}
+
+		if nf, err = g.validateRequest(sub); err != nil {
+			return nf, fmt.Errorf("Batch operation %d: %s", i, err.Error())
+		}
+	}
+	return false, nil
+}
+
+// isBatchable returns true if q can be part of a batch request
+func isBatchable(q *msg.Request) bool {
+	switch {
+	case q.Section == msg.SectionRepository:
+		// repository requests can not be batched, this includes
+		// nested batch requests
+		return false
+	case q.Action == msg.ActionCreate && q.Section != msg.SectionCheckConfig,
+		q.Action == msg.ActionDestroy && q.Section != msg.SectionCheckConfig,
+		q.Action == msg.ActionAssign,
+		q.Action == msg.ActionUnassign:
+		// objects must exist before the batch is submitted
+		return false
+	}
+	return true
+}
+
+// fillBatch fills in the required data for all requests contained in
+// a batch request
+func (g *GuidePost) fillBatch(q *msg.Request) (bool, error) {
+	for i := range q.Batch {
+		if nf, err := g.fillReqData(&q.Batch[i]); err != nil {
+			return nf, fmt.Errorf("Batch operation %d: %s", i, err.Error())
+		}
+	}
+	return false, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,72 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+)
+
+func TestIsBatchable(t *testing.T) {
+	tests := []struct {
+		section string
+		action  string
+		ok      bool
+	}{
+		{msg.SectionRepository, msg.ActionBatch, false},
+		{msg.SectionRepository, msg.ActionDestroy, false},
+		{msg.SectionBucket, msg.ActionCreate, false},
+		{msg.SectionGroup, msg.ActionDestroy, false},
+		{msg.SectionNodeConfig, msg.ActionAssign, false},
+		{msg.SectionNodeConfig, msg.ActionUnassign, false},
+		{msg.SectionCheckConfig, msg.ActionCreate, true},
+		{msg.SectionCheckConfig, msg.ActionDestroy, true},
+		{msg.SectionBucket, msg.ActionMemberAssign, true},
+		{msg.SectionCluster, msg.ActionMemberUnassign, true},
+		{msg.SectionNodeConfig, msg.ActionPropertyCreate, true},
+		{msg.SectionRepositoryConfig, msg.ActionPropertyReplace, true},
+	}
+
+	for _, test := range tests {
+		q := &msg.Request{Section: test.section, Action: test.action}
+		if ok := isBatchable(q); ok != test.ok {
+			t.Errorf("%s::%s: expected %t, got %t", test.section,
+				test.action, test.ok, ok)
+		}
+	}
+}
+
+func TestValidateBatchRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch []msg.Request
+	}{
+		{`empty`, []msg.Request{}},
+		{`nested batch`, []msg.Request{
+			{Section: msg.SectionRepository, Action: msg.ActionBatch},
+		}},
+		{`object creation`, []msg.Request{
+			{Section: msg.SectionGroup, Action: msg.ActionCreate},
+		}},
+	}
+
+	g := &GuidePost{}
+	for _, test := range tests {
+		q := &msg.Request{
+			Section: msg.SectionRepository,
+			Action:  msg.ActionBatch,
+			Batch:   test.batch,
+		}
+		if _, err := g.validateBatch(q); err == nil {
+			t.Errorf("%s: batch was not rejected", test.name)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -75,6 +75,7 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 		case msg.ActionPropertyCreate:
 		case msg.ActionPropertyUpdate:
 		case msg.ActionPropertyDestroy:
+		case msg.ActionPropertyReplace:
 		default:
 			return ``, ``
 		}
@@ -83,6 +84,8 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 		switch q.Action {
 		case msg.ActionCreate:
 		case msg.ActionDestroy:
+		case msg.ActionExplain:
+		case msg.ActionUpdate:
 		default:
 			return ``, ``
 		}
@@ -97,6 +100,7 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 		case msg.ActionPropertyCreate:
 		case msg.ActionPropertyUpdate:
 		case msg.ActionPropertyDestroy:
+		case msg.ActionPropertyReplace:
 		default:
 			return ``, ``
 		}
@@ -111,6 +115,7 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 		case msg.ActionPropertyCreate:
 		case msg.ActionPropertyUpdate:
 		case msg.ActionPropertyDestroy:
+		case msg.ActionPropertyReplace:
 		default:
 			return ``, ``
 		}
@@ -123,9 +128,12 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 			return q.Bucket.RepositoryID, q.Bucket.ID
 		}
 		switch q.Action {
+		case msg.ActionMemberAssign:
+		case msg.ActionMemberUnassign:
 		case msg.ActionPropertyCreate:
 		case msg.ActionPropertyUpdate:
 		case msg.ActionPropertyDestroy:
+		case msg.ActionPropertyReplace:
 		default:
 			return ``, ``
 		}
@@ -135,12 +143,14 @@ func (g *GuidePost) extractID(q *msg.Request) (string, string) {
 		case msg.ActionPropertyCreate:
 		case msg.ActionPropertyUpdate:
 		case msg.ActionPropertyDestroy:
+		case msg.ActionPropertyReplace:
 		default:
 			return ``, ``
 		}
 		return q.Repository.ID, ``
 	case msg.SectionRepository:
 		switch q.Action {
+		case msg.ActionBatch:
 		case msg.ActionDestroy:
 		case msg.ActionRename:
 		case msg.ActionRepossess:
@@ -21,14 +21,20 @@ import (
 //
 func (g *GuidePost) fillReqData(q *msg.Request) (bool, error) {
 	switch {
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionBatch:
+		return g.fillBatch(q)
 	case q.Action == msg.ActionPropertyCreate && q.Property.Type == `service`:
 		return g.fillServiceAttributes(q)
 	case q.Action == msg.ActionPropertyUpdate && q.Property.Type == `service`:
 		return g.fillServiceAttributes(q)
+	case q.Action == msg.ActionPropertyReplace && q.Property.Type == `service`:
+		return g.fillServiceAttributes(q)
 	case q.Section == msg.SectionNodeConfig && q.Action == msg.ActionAssign:
 		return g.fillNode(q)
 	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionDestroy:
 		return g.fillCheckDeleteInfo(q)
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionUpdate:
+		return g.fillCheckUpdateInfo(q)
 	case q.Section == msg.SectionBucket && q.Action == msg.ActionCreate:
 		return g.fillBucketID(q)
 	case q.Section == msg.SectionGroup && q.Action == msg.ActionCreate:
@@ -100,42 +106,46 @@ func (g *GuidePost) fillNode(q *msg.Request) (bool, error) {
 }
 
 // load authoritative copy of the service attributes from the
-// database. Replaces whatever the client sent in.
+// database for all properties of the request. Replaces whatever the
+// client sent in.
 func (g *GuidePost) fillServiceAttributes(q *msg.Request) (bool, error) {
+	properties := requestProperties(q)
+	if properties == nil {
+		return false, nil
+	}
+
+	// ignore error since it would have been caught by GuidePost
+	repoID, _, _, _ := g.extractRouting(q)
+
+	for i := range *properties {
+		if nf, err := g.fillServiceProperty(
+			repoID, &(*properties)[i],
+		); err != nil {
+			return nf, err
+		}
+	}
+	return false, nil
+}
+
+// load the authoritative copy of the service attributes for service
+// property prop from repository repoID
+func (g *GuidePost) fillServiceProperty(repoID string,
+	prop *proto.Property) (bool, error) {
 	var (
-		serviceID, attr, val, svName, svTeam, repoID string
-		rows                                         *sql.Rows
-		err                                          error
-		nf                                           bool
+		serviceID, attr, val, svName, svTeam string
+		rows                                 *sql.Rows
+		err                                  error
+		nf                                   bool
 	)
 	attrs := []proto.ServiceAttribute{}
 
-	switch q.Section {
-	case msg.SectionRepositoryConfig:
-		// svName may be the ID or the name
-		serviceID = (*q.Repository.Properties)[0].Service.ID
-		svName = (*q.Repository.Properties)[0].Service.Name
-		svTeam = (*q.Repository.Properties)[0].Service.TeamID
-	case msg.SectionBucket:
-		serviceID = (*q.Bucket.Properties)[0].Service.ID
-		svName = (*q.Bucket.Properties)[0].Service.Name
-		svTeam = (*q.Bucket.Properties)[0].Service.TeamID
-	case msg.SectionGroup:
-		serviceID = (*q.Group.Properties)[0].Service.ID
-		svName = (*q.Group.Properties)[0].Service.Name
-		svTeam = (*q.Group.Properties)[0].Service.TeamID
-	case msg.SectionCluster:
-		serviceID = (*q.Cluster.Properties)[0].Service.ID
-		svName = (*q.Cluster.Properties)[0].Service.Name
-		svTeam = (*q.Cluster.Properties)[0].Service.TeamID
-	case msg.SectionNodeConfig:
-		serviceID = (*q.Node.Properties)[0].Service.ID
-		svName = (*q.Node.Properties)[0].Service.Name
-		svTeam = (*q.Node.Properties)[0].Service.TeamID
+	if prop.Service == nil {
+		return false, fmt.Errorf(`Service property specification missing`)
 	}
-
-	// ignore error since it would have been caught by GuidePost
-	repoID, _, _, _ = g.extractRouting(q)
+	// svName may be the ID or the name
+	serviceID = prop.Service.ID
+	svName = prop.Service.Name
+	svTeam = prop.Service.TeamID
 
 	// validate the tuple (repo, team, service) is valid.
 	// also resolve and disambiguate serviceID and serviceName
@@ -175,19 +185,10 @@ abort:
 	if err != nil {
 		return nf, err
 	}
-	// not aborted: set the loaded attributes
-	switch q.Section {
-	case msg.SectionRepositoryConfig:
-		(*q.Repository.Properties)[0].Service.Attributes = attrs
-	case msg.SectionBucket:
-		(*q.Bucket.Properties)[0].Service.Attributes = attrs
-	case msg.SectionGroup:
-		(*q.Group.Properties)[0].Service.Attributes = attrs
-	case msg.SectionCluster:
-		(*q.Cluster.Properties)[0].Service.Attributes = attrs
-	case msg.SectionNodeConfig:
-		(*q.Node.Properties)[0].Service.Attributes = attrs
-	}
+	// not aborted: set the resolved service and the loaded attributes
+	prop.Service.ID = serviceID
+	prop.Service.Name = svName
+	prop.Service.Attributes = attrs
 	return false, nil
 }
 
@@ -217,8 +218,61 @@ func (g *GuidePost) fillCheckDeleteInfo(q *msg.Request) (bool, error) {
 	return false, nil
 }
 
-// if the request is a property deletion, populate required IDs
+// if the request is a check update, populate required IDs from the
+// stored check configuration
+func (g *GuidePost) fillCheckUpdateInfo(q *msg.Request) (bool, error) {
+	var updObjID, updObjTyp, updCapID, updSrcChkID string
+	var err error
+
+	if err = g.stmtCheckDetailsForUpdate.QueryRow(
+		q.CheckConfig.ID,
+		q.CheckConfig.RepositoryID,
+	).Scan(
+		&updObjID,
+		&updObjTyp,
+		&updCapID,
+		&updSrcChkID,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return true, fmt.Errorf(
+				"Failed to find source check for config %s",
+				q.CheckConfig.ID)
+		}
+		return false, err
+	}
+	if q.CheckConfig.CapabilityID != updCapID {
+		return false, fmt.Errorf(
+			"Capability of check configuration %s can not be changed",
+			q.CheckConfig.ID)
+	}
+	q.CheckConfig.ObjectID = updObjID
+	q.CheckConfig.ObjectType = updObjTyp
+	q.CheckConfig.ExternalID = updSrcChkID
+	return false, nil
+}
+
+// if the request is a property deletion, populate required IDs for
+// all properties of the request
 func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
+	properties := requestProperties(q)
+	if properties == nil {
+		return false, nil
+	}
+
+	for i := range *properties {
+		if nf, err := g.fillPropertyDeleteDetails(
+			q.Section, &(*properties)[i],
+		); err != nil {
+			return nf, err
+		}
+	}
+	return false, nil
+}
+
+// populate the property specification and view of property prop,
+// which is to be deleted from an object of section
+func (g *GuidePost) fillPropertyDeleteDetails(section string,
+	prop *proto.Property) (bool, error) {
 	var (
 		err                                             error
 		row                                             *sql.Row
@@ -228,9 +282,9 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 	)
 
 	// select SQL statement
-	switch q.Section {
-	case msg.SectionRepositoryConfig:
-		switch q.Property.Type {
+	switch section {
+	case msg.SectionRepository, msg.SectionRepositoryConfig:
+		switch prop.Type {
 		case msg.PropertySystem:
 			queryStmt = stmt.RepoSystemPropertyForDelete
 		case msg.PropertyCustom:
@@ -241,7 +295,7 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 			queryStmt = stmt.RepoOncallPropertyForDelete
 		}
 	case msg.SectionBucket:
-		switch q.Property.Type {
+		switch prop.Type {
 		case msg.PropertySystem:
 			queryStmt = stmt.BucketSystemPropertyForDelete
 		case msg.PropertyCustom:
@@ -252,7 +306,7 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 			queryStmt = stmt.BucketOncallPropertyForDelete
 		}
 	case msg.SectionGroup:
-		switch q.Property.Type {
+		switch prop.Type {
 		case msg.PropertySystem:
 			queryStmt = stmt.GroupSystemPropertyForDelete
 		case msg.PropertyCustom:
@@ -263,7 +317,7 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 			queryStmt = stmt.GroupOncallPropertyForDelete
 		}
 	case msg.SectionCluster:
-		switch q.Property.Type {
+		switch prop.Type {
 		case msg.PropertySystem:
 			queryStmt = stmt.ClusterSystemPropertyForDelete
 		case msg.PropertyCustom:
@@ -274,7 +328,7 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 			queryStmt = stmt.ClusterOncallPropertyForDelete
 		}
 	case msg.SectionNodeConfig:
-		switch q.Property.Type {
+		switch prop.Type {
 		case msg.PropertySystem:
 			queryStmt = stmt.NodeSystemPropertyForDelete
 		case msg.PropertyCustom:
@@ -285,26 +339,14 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 			queryStmt = stmt.NodeOncallPropertyForDelete
 		}
 	}
+	if queryStmt == `` {
+		return false, fmt.Errorf("Invalid property type %s for %s",
+			prop.Type, section)
+	}
 
 	// execute and scan
-	switch q.Section {
-	case msg.SectionRepository, msg.SectionRepositoryConfig:
-		row = g.conn.QueryRow(queryStmt,
-			(*q.Repository.Properties)[0].SourceInstanceID)
-	case msg.SectionBucket:
-		row = g.conn.QueryRow(queryStmt,
-			(*q.Bucket.Properties)[0].SourceInstanceID)
-	case msg.SectionGroup:
-		row = g.conn.QueryRow(queryStmt,
-			(*q.Group.Properties)[0].SourceInstanceID)
-	case msg.SectionCluster:
-		row = g.conn.QueryRow(queryStmt,
-			(*q.Cluster.Properties)[0].SourceInstanceID)
-	case msg.SectionNodeConfig:
-		row = g.conn.QueryRow(queryStmt,
-			(*q.Node.Properties)[0].SourceInstanceID)
-	}
-	switch q.Property.Type {
+	row = g.conn.QueryRow(queryStmt, prop.SourceInstanceID)
+	switch prop.Type {
 	case msg.PropertySystem:
 		err = row.Scan(&view, &sysProp, &value)
 	case msg.PropertyCustom:
@@ -319,116 +361,55 @@ func (g *GuidePost) fillPropertyDeleteInfo(q *msg.Request) (bool, error) {
 		if err == sql.ErrNoRows {
 			return true, fmt.Errorf(
 				"Failed to find source property for %s",
-				(*q.Repository.Properties)[0].SourceInstanceID)
+				prop.SourceInstanceID)
 		}
 		return false, err
 	}
 
-	// assemble and set results: property specification
-	var (
-		pSys *proto.PropertySystem
-		pCst *proto.PropertyCustom
-		pSvc *proto.PropertyService
-		pOnc *proto.PropertyOncall
-	)
-	switch q.Property.Type {
+	// assemble and set results: view and property specification
+	prop.View = view
+	switch prop.Type {
 	case msg.PropertySystem:
-		pSys = &proto.PropertySystem{
+		prop.System = &proto.PropertySystem{
 			Name:  sysProp,
 			Value: value,
 		}
 	case msg.PropertyCustom:
-		pCst = &proto.PropertyCustom{
+		prop.Custom = &proto.PropertyCustom{
 			ID:    cstID,
 			Name:  cstProp,
 			Value: value,
 		}
 	case msg.PropertyService:
-		pSvc = &proto.PropertyService{
+		prop.Service = &proto.PropertyService{
 			ID: svcID,
 		}
 	case msg.PropertyOncall:
-		num := strconv.Itoa(oncNumber)
-		pOnc = &proto.PropertyOncall{
+		prop.Oncall = &proto.PropertyOncall{
 			ID:     oncID,
 			Name:   oncName,
-			Number: num,
+			Number: strconv.Itoa(oncNumber),
 		}
 	}
+	return false, nil
+}
 
-	// assemble and set results: view
-	switch q.Section {
-	case msg.SectionRepositoryConfig:
-		(*q.Repository.Properties)[0].View = view
-	case msg.SectionBucket:
-		(*q.Bucket.Properties)[0].View = view
-	case msg.SectionGroup:
-		(*q.Group.Properties)[0].View = view
-	case msg.SectionCluster:
-		(*q.Cluster.Properties)[0].View = view
-	case msg.SectionNodeConfig:
-		(*q.Node.Properties)[0].View = view
-	}
-
-	// final assembly step
+// requestProperties returns the property list of the object request
+// q operates on
+func requestProperties(q *msg.Request) *[]proto.Property {
 	switch q.Section {
-	case msg.SectionRepositoryConfig:
-		switch q.Property.Type {
-		case msg.PropertySystem:
-			(*q.Repository.Properties)[0].System = pSys
-		case msg.PropertyCustom:
-			(*q.Repository.Properties)[0].Custom = pCst
-		case msg.PropertyService:
-			(*q.Repository.Properties)[0].Service = pSvc
-		case msg.PropertyOncall:
-			(*q.Repository.Properties)[0].Oncall = pOnc
-		}
+	case msg.SectionRepository, msg.SectionRepositoryConfig:
+		return q.Repository.Properties
 	case msg.SectionBucket:
-		switch q.Property.Type {
-		case msg.PropertySystem:
-			(*q.Bucket.Properties)[0].System = pSys
-		case msg.PropertyCustom:
-			(*q.Bucket.Properties)[0].Custom = pCst
-		case msg.PropertyService:
-			(*q.Bucket.Properties)[0].Service = pSvc
-		case msg.PropertyOncall:
-			(*q.Bucket.Properties)[0].Oncall = pOnc
-		}
+		return q.Bucket.Properties
 	case msg.SectionGroup:
-		switch q.Property.Type {
-		case msg.PropertySystem:
-			(*q.Group.Properties)[0].System = pSys
-		case msg.PropertyCustom:
-			(*q.Group.Properties)[0].Custom = pCst
-		case msg.PropertyService:
-			(*q.Group.Properties)[0].Service = pSvc
-		case msg.PropertyOncall:
-			(*q.Group.Properties)[0].Oncall = pOnc
-		}
+		return q.Group.Properties
 	case msg.SectionCluster:
-		switch q.Property.Type {
-		case msg.PropertySystem:
-			(*q.Cluster.Properties)[0].System = pSys
-		case msg.PropertyCustom:
-			(*q.Cluster.Properties)[0].Custom = pCst
-		case msg.PropertyService:
-			(*q.Cluster.Properties)[0].Service = pSvc
-		case msg.PropertyOncall:
-			(*q.Cluster.Properties)[0].Oncall = pOnc
-		}
+		return q.Cluster.Properties
 	case msg.SectionNodeConfig:
-		switch q.Property.Type {
-		case msg.PropertySystem:
-			(*q.Node.Properties)[0].System = pSys
-		case msg.PropertyCustom:
-			(*q.Node.Properties)[0].Custom = pCst
-		case msg.PropertyService:
-			(*q.Node.Properties)[0].Service = pSvc
-		case msg.PropertyOncall:
-			(*q.Node.Properties)[0].Oncall = pOnc
-		}
+		return q.Node.Properties
 	}
-	return false, err
+	return nil
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
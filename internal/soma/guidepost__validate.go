@@ -10,12 +10,22 @@ package soma
 import (
 	"database/sql"
 	"fmt"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
 )
 
 func (g *GuidePost) validateRequest(q *msg.Request) (bool, error) {
+	// batch requests are valid if all contained requests are
+	if q.Section == msg.SectionRepository && q.Action == msg.ActionBatch {
+		return g.validateBatch(q)
+	}
+
 	switch q.Section {
 	case msg.SectionCheckConfig:
 		switch q.Action {
@@ -61,7 +71,10 @@ func (g *GuidePost) validateRequest(q *msg.Request) (bool, error) {
 	switch q.Section {
 	case msg.SectionCheckConfig:
 		switch q.Action {
-		case msg.ActionCreate:
+		case msg.ActionCreate, msg.ActionUpdate:
+			if err := g.validateCheckConstraints(q); err != nil {
+				return false, err
+			}
 			return g.validateCheckThresholds(q)
 		}
 	case msg.SectionBucket:
@@ -75,7 +88,8 @@ func (g *GuidePost) validateRequest(q *msg.Request) (bool, error) {
 
 	// listed actions are accepted, but require no further validation
 	switch q.Action {
-	case msg.ActionPropertyCreate, msg.ActionPropertyDestroy, msg.ActionPropertyUpdate:
+	case msg.ActionPropertyCreate, msg.ActionPropertyDestroy,
+		msg.ActionPropertyReplace, msg.ActionPropertyUpdate:
 		switch q.Section {
 		case
 			msg.SectionRepositoryConfig,
@@ -104,6 +118,11 @@ func (g *GuidePost) validateRequest(q *msg.Request) (bool, error) {
 			msg.SectionCluster:
 			return false, nil
 		}
+	case msg.ActionExplain:
+		switch q.Section {
+		case msg.SectionCheckConfig:
+			return false, nil
+		}
 	case msg.ActionRename:
 		switch q.Section {
 		case msg.SectionRepository:
@@ -127,6 +146,18 @@ func (g *GuidePost) validateObjectMatch(q *msg.Request) (bool, error) {
 	switch q.Action {
 	case msg.ActionMemberAssign, msg.ActionMemberUnassign:
 		switch q.Section {
+		case msg.SectionBucket:
+			switch {
+			case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityGroup:
+				groupID = (*q.Bucket.MemberGroups)[0].ID
+			case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityCluster:
+				clusterID = (*q.Bucket.MemberClusters)[0].ID
+			case q.TargetEntity == msg.EntityNode:
+				nodeID = (*q.Bucket.MemberNodes)[0].ID
+			default:
+				return false, fmt.Errorf("Incorrect validation attempted for %s::%s(%s)",
+					q.Section, q.Action, q.TargetEntity)
+			}
 		case msg.SectionCluster:
 			switch q.TargetEntity {
 			case msg.EntityNode:
@@ -209,6 +240,23 @@ func (g *GuidePost) validateObjectMatch(q *msg.Request) (bool, error) {
 			)
 		}
 	}
+	if q.Section == msg.SectionBucket {
+		var objID, objBucketID string
+		switch q.TargetEntity {
+		case msg.EntityNode:
+			objID, objBucketID = nodeID, valNodeBId
+		case msg.EntityCluster:
+			objID, objBucketID = clusterID, valClusterBId
+		case msg.EntityGroup:
+			objID, objBucketID = groupID, valGroupBId
+		}
+		if objBucketID != q.Bucket.ID {
+			return false, fmt.Errorf(
+				"Object %s(%s) is in a different bucket (%s/%s)",
+				q.TargetEntity, objID, objBucketID, q.Bucket.ID,
+			)
+		}
+	}
 	if q.Section == msg.SectionGroup && q.Action == msg.ActionMemberAssign {
 		switch q.TargetEntity {
 		case msg.EntityNode:
@@ -441,6 +489,98 @@ func (g *GuidePost) validateCheckThresholds(q *msg.Request) (bool, error) {
 	return false, nil
 }
 
+// check the operators and OR groups of the check constraints to be
+// supported by the constraint types and their values to be valid
+// operands
+func (g *GuidePost) validateCheckConstraints(q *msg.Request) error {
+	for _, constr := range q.CheckConfig.Constraints {
+		var value string
+		op := constr.GetOperator()
+
+		// match_group is a varchar(64) column
+		if utf8.RuneCountInString(constr.Group) > 64 {
+			return fmt.Errorf(
+				"OR group name exceeds 64 characters: %s",
+				constr.Group)
+		}
+
+		// the property matching the constraint type must be set
+		missing := false
+		switch constr.ConstraintType {
+		case msg.ConstraintNative:
+			missing = constr.Native == nil
+		case msg.ConstraintSystem:
+			missing = constr.System == nil
+		case msg.ConstraintCustom:
+			missing = constr.Custom == nil
+		case msg.ConstraintAttribute:
+			missing = constr.Attribute == nil
+		case msg.ConstraintOncall:
+			missing = constr.Oncall == nil
+		case msg.ConstraintService:
+			missing = constr.Service == nil
+		}
+		if missing {
+			return fmt.Errorf(
+				"Constraint of type %s is missing its %s property",
+				constr.ConstraintType, constr.ConstraintType)
+		}
+
+		switch constr.ConstraintType {
+		case msg.ConstraintNative:
+			value = constr.Native.Value
+		case msg.ConstraintSystem:
+			value = constr.System.Value
+		case msg.ConstraintCustom:
+			value = constr.Custom.Value
+		case msg.ConstraintAttribute:
+			value = constr.Attribute.Value
+			switch {
+			case constr.Group != ``:
+				return fmt.Errorf(
+					"Attribute constraints can not be part of OR group %s",
+					constr.Group)
+			case op == proto.ConstraintOpNotEqual,
+				op == proto.ConstraintOpNotMatch,
+				op == proto.ConstraintOpNotGlob:
+				return fmt.Errorf(
+					"Attribute constraints do not support operator %s",
+					op)
+			}
+		case msg.ConstraintOncall, msg.ConstraintService:
+			if op != proto.ConstraintOpEqual && op != proto.ConstraintOpNotEqual {
+				return fmt.Errorf(
+					"Constraints of type %s do not support operator %s",
+					constr.ConstraintType, op)
+			}
+		}
+
+		switch op {
+		case proto.ConstraintOpEqual, proto.ConstraintOpNotEqual:
+		case proto.ConstraintOpMatch, proto.ConstraintOpNotMatch:
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("Invalid regular expression %s: %s",
+					value, err.Error())
+			}
+		case proto.ConstraintOpGlob, proto.ConstraintOpNotGlob:
+			if _, err := path.Match(value, ``); err != nil {
+				return fmt.Errorf("Invalid glob pattern %s: %s",
+					value, err.Error())
+			}
+		case proto.ConstraintOpLess, proto.ConstraintOpLessEqual,
+			proto.ConstraintOpGreater, proto.ConstraintOpGreaterEqual:
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf(
+					"Operator %s requires a numeric value, got: %s",
+					op, value)
+			}
+		default:
+			return fmt.Errorf("Unknown constraint operator: %s", op)
+		}
+	}
+	return nil
+}
+
 // check the naming schema for the bucket (global unique object)
 func (g *GuidePost) validateBucketName(q *msg.Request) (bool, error) {
 	_, repoName, _, _ := g.extractRouting(q)
@@ -0,0 +1,80 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestValidateCheckConstraints(t *testing.T) {
+	tests := []struct {
+		name   string
+		constr proto.CheckConfigConstraint
+		valid  bool
+	}{
+		{`native`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintNative,
+			Native:         &proto.PropertyNative{Name: `object_type`, Value: `node`},
+		}, true},
+		{`native missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintNative,
+		}, false},
+		{`system missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintSystem,
+			Operator:       proto.ConstraintOpMatch,
+		}, false},
+		{`custom missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintCustom,
+			System:         &proto.PropertySystem{Name: `fqdn`, Value: `a`},
+		}, false},
+		{`attribute missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintAttribute,
+		}, false},
+		{`oncall missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintOncall,
+		}, false},
+		{`service missing`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintService,
+		}, false},
+		{`invalid regular expression`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintSystem,
+			Operator:       proto.ConstraintOpMatch,
+			System:         &proto.PropertySystem{Name: `fqdn`, Value: `(`},
+		}, false},
+		{`numeric comparison`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintSystem,
+			Operator:       proto.ConstraintOpGreater,
+			System:         &proto.PropertySystem{Name: `cpu_count`, Value: `4`},
+		}, true},
+		{`group name too long`, proto.CheckConfigConstraint{
+			ConstraintType: msg.ConstraintNative,
+			Group:          strings.Repeat(`g`, 65),
+			Native:         &proto.PropertyNative{Name: `object_type`, Value: `node`},
+		}, false},
+	}
+
+	g := &GuidePost{}
+	for _, test := range tests {
+		q := &msg.Request{
+			CheckConfig: proto.CheckConfig{
+				Constraints: []proto.CheckConfigConstraint{test.constr},
+			},
+		}
+		err := g.validateCheckConstraints(q)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("%s: expected valid %t, got error %v", test.name,
+				test.valid, err)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
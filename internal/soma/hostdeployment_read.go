@@ -28,6 +28,7 @@ type HostDeploymentRead struct {
 	conn                    *sql.DB
 	stmtInstancesForNode    *sql.Stmt
 	stmtLastInstanceVersion *sql.Stmt
+	stmtSilenced            *sql.Stmt
 	appLog                  *logrus.Logger
 	reqLog                  *logrus.Logger
 	errLog                  *logrus.Logger
@@ -79,6 +80,7 @@ func (r *HostDeploymentRead) Run() {
 	for statement, prepStmt := range map[string]**sql.Stmt{
 		stmt.DeploymentInstancesForNode:    &r.stmtInstancesForNode,
 		stmt.DeploymentLastInstanceVersion: &r.stmtLastInstanceVersion,
+		stmt.MaintenanceSilencedInstances:  &r.stmtSilenced,
 	} {
 		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
 			r.errLog.Fatal(`hostdeployment`, err, stmt.Name(statement))
@@ -119,6 +121,7 @@ func (r *HostDeploymentRead) process(q *msg.Request) {
 func (r *HostDeploymentRead) get(q *msg.Request, mr *msg.Result) {
 	var (
 		checkInstanceID, deploymentDetails, status string
+		instanceIDs                                []string
 		idList                                     *sql.Rows
 		err                                        error
 	)
@@ -191,11 +194,16 @@ func (r *HostDeploymentRead) get(q *msg.Request, mr *msg.Result) {
 		}
 
 		mr.Deployment = append(mr.Deployment, depl)
+		instanceIDs = append(instanceIDs, checkInstanceID)
 	}
 	if err = idList.Err(); err != nil {
 		mr.ServerError(err, q.Section)
 		return
 	}
+	if err = r.setSilenced(mr, instanceIDs); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
 	mr.OK()
 }
 
@@ -203,6 +211,7 @@ func (r *HostDeploymentRead) get(q *msg.Request, mr *msg.Result) {
 func (r *HostDeploymentRead) assemble(q *msg.Request, mr *msg.Result) {
 	var (
 		checkInstanceID, deploymentDetails, status string
+		instanceIDs                                []string
 		idList                                     *sql.Rows
 		err                                        error
 	)
@@ -280,11 +289,16 @@ assembleloop:
 		}
 
 		mr.Deployment = append(mr.Deployment, depl)
+		instanceIDs = append(instanceIDs, checkInstanceID)
 	}
 	if err = idList.Err(); err != nil {
 		mr.ServerError(err, q.Section)
 		return
 	}
+	if err = r.setSilenced(mr, instanceIDs); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
 
 	// assemble delete list
 	for _, delID := range q.DeploymentIDs {
@@ -303,6 +317,19 @@ assembleloop:
 	mr.OK()
 }
 
+// setSilenced sets the end of the maintenance on the deployments of
+// mr, which are ordered like instanceIDs
+func (r *HostDeploymentRead) setSilenced(mr *msg.Result, instanceIDs []string) error {
+	silenced, err := silencedInstances(r.stmtSilenced, instanceIDs)
+	if err != nil {
+		return err
+	}
+	for i := range instanceIDs {
+		mr.Deployment[i].SilencedUntil = silenced[instanceIDs[i]]
+	}
+	return nil
+}
+
 // ShutdownNow signals the handler to shut down
 func (r *HostDeploymentRead) ShutdownNow() {
 	close(r.Shutdown)
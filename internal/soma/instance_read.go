@@ -23,16 +23,19 @@ import (
 
 // InstanceRead handles read requests for check instances
 type InstanceRead struct {
-	Input        chan msg.Request
-	Shutdown     chan struct{}
-	handlerName  string
-	conn         *sql.DB
-	stmtList     *sql.Stmt
-	stmtShow     *sql.Stmt
-	stmtVersions *sql.Stmt
-	appLog       *logrus.Logger
-	reqLog       *logrus.Logger
-	errLog       *logrus.Logger
+	Input          chan msg.Request
+	Shutdown       chan struct{}
+	handlerName    string
+	conn           *sql.DB
+	stmtList       *sql.Stmt
+	stmtObjectList *sql.Stmt
+	stmtObject     *sql.Stmt
+	stmtInSubtree  *sql.Stmt
+	stmtShow       *sql.Stmt
+	stmtVersions   *sql.Stmt
+	appLog         *logrus.Logger
+	reqLog         *logrus.Logger
+	errLog         *logrus.Logger
 }
 
 // newInstanceRead return a new InstanceRead handler with
@@ -82,6 +85,9 @@ func (r *InstanceRead) Run() {
 
 	for statement, prepStmt := range map[string]**sql.Stmt{
 		stmt.InstanceScopedList: &r.stmtList,
+		stmt.InstanceObjectList: &r.stmtObjectList,
+		stmt.InstanceObject:     &r.stmtObject,
+		stmt.InstanceInSubtree:  &r.stmtInSubtree,
 		stmt.InstanceShow:       &r.stmtShow,
 		stmt.InstanceVersions:   &r.stmtVersions,
 	} {
@@ -157,6 +163,17 @@ func (r *InstanceRead) show(q *msg.Request, mr *msg.Result) {
 		return
 	}
 
+	if inScope, err := r.inScope(q, repositoryID, bucketID, objectID,
+		objectType); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	} else if !inScope {
+		mr.NotFound(fmt.Errorf("Instance %s not found below %s %s",
+			q.Instance.ID, q.Instance.ObjectType, q.Instance.ObjectID,
+		), q.Section)
+		return
+	}
+
 	// unmarhal JSONB deployment details
 	depl := proto.Deployment{}
 	if err = json.Unmarshal([]byte(details), &depl); err != nil {
@@ -182,15 +199,17 @@ func (r *InstanceRead) show(q *msg.Request, mr *msg.Result) {
 	mr.OK()
 }
 
-// list returns all instances either globally, within a repository
-// or within a bucket
+// list returns all instances either globally, within a repository,
+// within a bucket or on a group, cluster or node. Repositories and
+// buckets always include all instances of their subtree, groups and
+// clusters only if the recursive flag is set
 func (r *InstanceRead) list(q *msg.Request, mr *msg.Result) {
 	var (
 		err                                      error
 		version                                  int64
 		isInherited                              bool
 		rows                                     *sql.Rows
-		nullRepositoryID, nullBucketID           *sql.NullString
+		nullRepositoryID, nullBucketID           sql.NullString
 		instanceID, checkID, configID            string
 		objectID, objectType, status, nextStatus string
 		repositoryID, bucketID, instanceConfigID string
@@ -203,6 +222,11 @@ func (r *InstanceRead) list(q *msg.Request, mr *msg.Result) {
 	case msg.EntityBucket:
 		nullBucketID.String = q.Instance.ObjectID
 		nullBucketID.Valid = true
+		if q.Instance.RepositoryID != `` {
+			nullRepositoryID.String = q.Instance.RepositoryID
+			nullRepositoryID.Valid = true
+		}
+	case msg.EntityGroup, msg.EntityCluster, msg.EntityNode:
 	default:
 		// only run an unscoped query if the flag has been explicitly
 		// set
@@ -217,10 +241,21 @@ func (r *InstanceRead) list(q *msg.Request, mr *msg.Result) {
 		}
 	}
 
-	if rows, err = r.stmtList.Query(
-		nullRepositoryID,
-		nullBucketID,
-	); err != nil {
+	switch q.Instance.ObjectType {
+	case msg.EntityGroup, msg.EntityCluster, msg.EntityNode:
+		rows, err = r.stmtObjectList.Query(
+			q.Instance.ObjectID,
+			q.Instance.ObjectType,
+			q.Flag.Recursive,
+			q.Instance.BucketID,
+		)
+	default:
+		rows, err = r.stmtList.Query(
+			nullRepositoryID,
+			nullBucketID,
+		)
+	}
+	if err != nil {
 		mr.ServerError(err, q.Section)
 		return
 	}
@@ -245,6 +280,7 @@ func (r *InstanceRead) list(q *msg.Request, mr *msg.Result) {
 			return
 		}
 
+		repositoryID, bucketID = ``, ``
 		if nullRepositoryID.Valid {
 			repositoryID = nullRepositoryID.String
 		}
@@ -286,6 +322,39 @@ func (r *InstanceRead) versions(q *msg.Request, mr *msg.Result) {
 		updatedNull, notifiedNull                        pq.NullTime
 	)
 
+	if q.Instance.ObjectType != `` {
+		var (
+			nullRepositoryID, nullBucketID sql.NullString
+			objectID, objectType           string
+			inScope                        bool
+		)
+		if err = r.stmtObject.QueryRow(
+			q.Instance.ID,
+		).Scan(
+			&nullRepositoryID,
+			&nullBucketID,
+			&objectID,
+			&objectType,
+		); err == sql.ErrNoRows {
+			mr.NotFound(err, q.Section)
+			return
+		} else if err != nil {
+			mr.ServerError(err, q.Section)
+			return
+		}
+		if inScope, err = r.inScope(q, nullRepositoryID.String,
+			nullBucketID.String, objectID, objectType); err != nil {
+			mr.ServerError(err, q.Section)
+			return
+		} else if !inScope {
+			mr.NotFound(fmt.Errorf("Instance %s not found below %s %s",
+				q.Instance.ID, q.Instance.ObjectType,
+				q.Instance.ObjectID,
+			), q.Section)
+			return
+		}
+	}
+
 	if rows, err = r.stmtVersions.Query(
 		q.Instance.ID,
 	); err != nil {
@@ -348,6 +417,55 @@ func (r *InstanceRead) versions(q *msg.Request, mr *msg.Result) {
 	mr.OK()
 }
 
+// inScope checks if an instance on the object objectID of type
+// objectType is visible within the scope of the request
+func (r *InstanceRead) inScope(q *msg.Request, repositoryID, bucketID,
+	objectID, objectType string) (bool, error) {
+	var (
+		inSubtree bool
+		err       error
+	)
+
+	switch q.Instance.ObjectType {
+	case ``:
+		// unscoped request
+		return true, nil
+	case msg.EntityRepository:
+		return repositoryID == q.Instance.ObjectID, nil
+	case msg.EntityBucket:
+		if q.Instance.RepositoryID != `` &&
+			repositoryID != q.Instance.RepositoryID {
+			return false, nil
+		}
+		return bucketID == q.Instance.ObjectID, nil
+	case msg.EntityGroup, msg.EntityCluster, msg.EntityNode:
+		if bucketID != q.Instance.BucketID {
+			return false, nil
+		}
+		if objectID == q.Instance.ObjectID &&
+			objectType == q.Instance.ObjectType {
+			return true, nil
+		}
+		if !q.Flag.Recursive {
+			return false, nil
+		}
+		if err = r.stmtInSubtree.QueryRow(
+			q.Instance.ObjectID,
+			q.Instance.ObjectType,
+			q.Flag.Recursive,
+			objectID,
+			objectType,
+		).Scan(
+			&inSubtree,
+		); err != nil {
+			return false, err
+		}
+		return inSubtree, nil
+	}
+	return false, fmt.Errorf("Unknown instance scope: %s",
+		q.Instance.ObjectType)
+}
+
 // ShutdownNow signals the handler to shut down
 func (r *InstanceRead) ShutdownNow() {
 	close(r.Shutdown)
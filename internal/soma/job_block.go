@@ -10,6 +10,7 @@ package soma
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -17,8 +18,6 @@ import (
 	"github.com/mjolnir42/soma/internal/msg"
 )
 
-// XXX BUG self|job|wait permission can currently block on every known job UUID
-
 // JobBlock handles requests to block a client until an asynchronous job
 // has finished
 type JobBlock struct {
@@ -39,6 +38,25 @@ type blockSpec struct {
 	Reply chan msg.Result
 }
 
+// abort releases a client whose wait for the job did not end with
+// the job finishing. code is 503 if the wait was aborted by a
+// shutdown and 504 if it timed out. Released clients whose job
+// finished only see their reply channel closed.
+func (bs blockSpec) abort(code uint16) {
+	result := msg.Result{Code: code}
+	switch code {
+	case 503:
+		result.Unavailable(fmt.Errorf(`Shutdown in progress`))
+	default:
+		result.SetError(fmt.Errorf(`Timeout waiting for job %s`,
+			bs.JobID))
+	}
+	// the reply channel is buffered and this is the only value ever
+	// sent on it
+	bs.Reply <- result
+	close(bs.Reply)
+}
+
 // newJobBlock returns a new JobBlock handler with input and notify
 // buffers of length
 func newJobBlock(length int) (j *JobBlock) {
@@ -101,9 +119,10 @@ runloop:
 			}
 			// clean all block specifications
 			for jID := range j.blockList {
-				// disconnect all clients waiting on that job
+				// tell all clients waiting on that job that the wait
+				// was aborted by the shutdown
 				for i := range j.blockList[jID] {
-					close(j.blockList[jID][i].Reply)
+					j.blockList[jID][i].abort(503)
 				}
 				delete(j.blockList, jID)
 			}
@@ -153,7 +172,7 @@ runloop:
 					if time.Since(j.blockList[jID][i].RecvT) < (5 * time.Minute) {
 						newList = append(newList, j.blockList[jID][i])
 					} else {
-						close(j.blockList[jID][i].Reply)
+						j.blockList[jID][i].abort(504)
 					}
 				}
 				if len(newList) > 0 {
@@ -0,0 +1,375 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// number of action events per job that are kept for clients
+	// that start watching a job after it started
+	jobStreamBacklog = 500
+	// how long the events of a job are kept after the last event
+	jobStreamRetention = 2 * time.Hour
+	// number of jobs whose events are kept. If more jobs are
+	// tracked, the events of the unwatched job that has been quiet
+	// the longest are forgotten.
+	jobStreamMaxJobs = 100
+	// maximum time a client can watch a job
+	jobStreamTimeout = 30 * time.Minute
+)
+
+// JobStream distributes the lifecycle events of jobs to the clients
+// watching them. Events are published via Publish by GuidePost when a
+// job is queued and by the TreeKeeper processing the job.
+type JobStream struct {
+	Input    chan msg.Request
+	Shutdown chan struct{}
+	Events   chan proto.JobEvent
+	history  map[string]*jobHistory
+	appLog   *logrus.Logger
+	reqLog   *logrus.Logger
+	errLog   *logrus.Logger
+}
+
+// jobHistory holds the retained events and the watching clients of
+// a job
+type jobHistory struct {
+	events    []proto.JobEvent
+	actions   int
+	sequence  uint64
+	seen      map[string]bool
+	finished  bool
+	lastEvent time.Time
+	watchers  []jobWatcher
+}
+
+// jobWatcher is a client watching a job
+type jobWatcher struct {
+	RecvT time.Time
+	Reply chan msg.Result
+}
+
+// send passes events to the client without blocking the handler. It
+// returns false if the client does not keep up with the events.
+func (jw jobWatcher) send(events ...proto.JobEvent) bool {
+	result := msg.Result{Section: msg.SectionJob, Action: msg.ActionWatch}
+	result.JobEvent = events
+	result.OK()
+	select {
+	case jw.Reply <- result:
+		return true
+	default:
+		return false
+	}
+}
+
+// abort disconnects a client whose watch did not end with the job
+// finishing. code is 503 if the watch was aborted by a shutdown and
+// 504 if it timed out.
+func (jw jobWatcher) abort(code uint16, jobID string) {
+	result := msg.Result{Section: msg.SectionJob, Action: msg.ActionWatch}
+	switch code {
+	case 503:
+		result.Unavailable(fmt.Errorf(`Shutdown in progress`))
+	default:
+		result.Code = code
+		result.SetError(fmt.Errorf(`Timeout watching job %s`, jobID))
+	}
+	select {
+	case jw.Reply <- result:
+	default:
+	}
+	close(jw.Reply)
+}
+
+// newJobStream returns a new JobStream handler with input and event
+// buffers of length
+func newJobStream(length int) (j *JobStream) {
+	j = &JobStream{}
+	j.Input = make(chan msg.Request, length)
+	j.Events = make(chan proto.JobEvent, length)
+	j.Shutdown = make(chan struct{})
+	j.history = make(map[string]*jobHistory)
+	return
+}
+
+// Register initializes resources provided by the Soma app. This
+// handler does not use the database connection, but accepts it
+// to implement the interface
+func (j *JobStream) Register(c *sql.DB, l ...*logrus.Logger) {
+	j.appLog = l[0]
+	j.reqLog = l[1]
+	j.errLog = l[2]
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes
+func (j *JobStream) RegisterRequests(hmap *handler.Map) {
+	hmap.Request(msg.SectionJob, msg.ActionWatch, `job_stream`)
+}
+
+// Intake exposes the Input channel as part of the handler interface
+func (j *JobStream) Intake() chan msg.Request {
+	return j.Input
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (j *JobStream) PriorityIntake() chan msg.Request {
+	return j.Intake()
+}
+
+// Run is the event loop for JobStream
+func (j *JobStream) Run() {
+	tock := time.Tick(1 * time.Minute)
+
+runloop:
+	for {
+		select {
+		case <-j.Shutdown:
+			// disconnect all watching clients
+			for jobID, h := range j.history {
+				for _, jw := range h.watchers {
+					jw.abort(503, jobID)
+				}
+				delete(j.history, jobID)
+			}
+			break runloop
+		case ev := <-j.Events:
+			j.publish(ev)
+		case rq := <-j.Input:
+			logRequest(j.reqLog, &rq)
+			j.watch(&rq)
+		case <-tock:
+			j.prune()
+		}
+	}
+}
+
+// publish records a job event and passes it to the clients watching
+// the job
+func (j *JobStream) publish(ev proto.JobEvent) {
+	h := j.lookup(ev.JobID)
+	if !h.record(&ev) {
+		return
+	}
+
+	watchers := []jobWatcher{}
+	for _, jw := range h.watchers {
+		if !jw.send(ev) {
+			j.appLog.Printf("JobStream: disconnecting slow client watching job %s",
+				ev.JobID)
+			close(jw.Reply)
+			continue
+		}
+		watchers = append(watchers, jw)
+	}
+	h.watchers = watchers
+
+	if h.finished {
+		// the stream ends with the finished event
+		for _, jw := range h.watchers {
+			close(jw.Reply)
+		}
+		h.watchers = nil
+	}
+}
+
+// watch registers a client for the events of the job. The client
+// first receives the retained events of the job. If the server has
+// no events for the job, they are reconstructed from the job
+// information in the request.
+func (j *JobStream) watch(q *msg.Request) {
+	h, ok := j.history[q.Job.ID]
+	if !ok {
+		h = j.lookup(q.Job.ID)
+		for _, ev := range jobEventsFromJob(q.Job) {
+			h.record(&ev)
+		}
+	}
+
+	jw := jobWatcher{
+		RecvT: time.Now().UTC(),
+		Reply: q.Reply,
+	}
+	if len(h.events) > 0 && !jw.send(h.events...) {
+		close(jw.Reply)
+		return
+	}
+	if h.finished {
+		close(jw.Reply)
+		return
+	}
+	h.watchers = append(h.watchers, jw)
+}
+
+// prune disconnects clients that watched a job for too long and
+// forgets the events of jobs that have been quiet for longer than
+// the retention time
+func (j *JobStream) prune() {
+	for jobID, h := range j.history {
+		watchers := []jobWatcher{}
+		for _, jw := range h.watchers {
+			if time.Since(jw.RecvT) < jobStreamTimeout {
+				watchers = append(watchers, jw)
+				continue
+			}
+			jw.abort(504, jobID)
+		}
+		h.watchers = watchers
+
+		if len(h.watchers) == 0 && time.Since(h.lastEvent) > jobStreamRetention {
+			delete(j.history, jobID)
+		}
+	}
+}
+
+// lookup returns the history of job jobID, which is created if it
+// does not exist yet
+func (j *JobStream) lookup(jobID string) *jobHistory {
+	if h, ok := j.history[jobID]; ok {
+		return h
+	}
+	if len(j.history) >= jobStreamMaxJobs {
+		j.evict()
+	}
+	h := &jobHistory{
+		events:    []proto.JobEvent{},
+		seen:      make(map[string]bool),
+		lastEvent: time.Now().UTC(),
+	}
+	j.history[jobID] = h
+	return h
+}
+
+// evict forgets the events of the unwatched job that has been quiet
+// the longest. Jobs with watchers are never evicted.
+func (j *JobStream) evict() {
+	var (
+		oldestID string
+		oldest   time.Time
+	)
+	for jobID, h := range j.history {
+		if len(h.watchers) > 0 {
+			continue
+		}
+		if oldestID == `` || h.lastEvent.Before(oldest) {
+			oldestID = jobID
+			oldest = h.lastEvent
+		}
+	}
+	if oldestID != `` {
+		delete(j.history, oldestID)
+	}
+}
+
+// record adds ev to the history and assigns its sequence number. It
+// returns false if ev was discarded because the lifecycle step it
+// describes is already part of the history. This happens if events
+// were reconstructed for a client before the original was published.
+func (h *jobHistory) record(ev *proto.JobEvent) bool {
+	switch ev.Type {
+	case proto.JobEventQueued, proto.JobEventStarted, proto.JobEventFinished:
+		if h.seen[ev.Type] {
+			return false
+		}
+		h.seen[ev.Type] = true
+	}
+
+	h.sequence++
+	ev.Sequence = h.sequence
+	h.lastEvent = time.Now().UTC()
+	h.events = append(h.events, *ev)
+	if ev.Type == proto.JobEventFinished {
+		h.finished = true
+	}
+
+	if ev.Type != proto.JobEventAction {
+		return true
+	}
+	h.actions++
+	if h.actions <= jobStreamBacklog {
+		return true
+	}
+	// drop the oldest retained action event
+	for i := range h.events {
+		if h.events[i].Type == proto.JobEventAction {
+			h.events = append(h.events[:i], h.events[i+1:]...)
+			h.actions--
+			break
+		}
+	}
+	return true
+}
+
+// jobEventsFromJob reconstructs the lifecycle events of a job from
+// its database record
+func jobEventsFromJob(job proto.Job) []proto.JobEvent {
+	events := []proto.JobEvent{}
+	if job.TsQueued != `` {
+		events = append(events, proto.JobEvent{
+			JobID:      job.ID,
+			Type:       proto.JobEventQueued,
+			OccurredAt: job.TsQueued,
+		})
+	}
+	if job.TsStarted != `` {
+		events = append(events, proto.JobEvent{
+			JobID:      job.ID,
+			Type:       proto.JobEventStarted,
+			OccurredAt: job.TsStarted,
+		})
+	}
+	if job.TsFinished != `` {
+		ev := proto.JobEvent{
+			JobID:      job.ID,
+			Type:       proto.JobEventFinished,
+			OccurredAt: job.TsFinished,
+			Result:     job.Result,
+		}
+		if job.Error != `` {
+			ev.Errors = []string{job.Error}
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// newJobEvent returns a new event of type evType for job jobID
+func newJobEvent(jobID, evType string) proto.JobEvent {
+	return proto.JobEvent{
+		JobID:      jobID,
+		Type:       evType,
+		OccurredAt: time.Now().UTC().Format(msg.RFC3339Milli),
+	}
+}
+
+// Publish passes ev to the JobStream without blocking the caller.
+// The event is dropped if the event buffer is full, which happens if
+// the handler has been shut down.
+func (j *JobStream) Publish(ev proto.JobEvent) {
+	select {
+	case j.Events <- ev:
+	default:
+	}
+}
+
+// ShutdownNow signals the handler to shutdown
+func (j *JobStream) ShutdownNow() {
+	close(j.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -18,6 +18,7 @@ import (
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
 	"github.com/mjolnir42/soma/lib/proto"
+	metrics "github.com/rcrowley/go-metrics"
 	"gopkg.in/resty.v1"
 )
 
@@ -35,10 +36,15 @@ type LifeCycle struct {
 	stmtDeadlock              *sql.Stmt
 	stmtReschedule            *sql.Stmt
 	stmtSetNotify             *sql.Stmt
+	stmtJobBacklog            *sql.Stmt
+	stmtDeploymentStates      *sql.Stmt
+	stmtGhosts                *sql.Stmt
+	stmtTransition            *sql.Stmt
 	appLog                    *logrus.Logger
 	reqLog                    *logrus.Logger
 	errLog                    *logrus.Logger
 	pokers                    map[string]chan string
+	gauges                    map[string]struct{}
 	soma                      *Soma
 }
 
@@ -80,6 +86,7 @@ func (lc *LifeCycle) RegisterRequests(hmap *handler.Map) {
 func (lc *LifeCycle) Run() {
 	var err error
 	lc.pokers = make(map[string]chan string)
+	lc.gauges = make(map[string]struct{})
 
 	lc.tick = time.NewTicker(
 		time.Duration(lc.soma.conf.LifeCycleTick) * time.Second,
@@ -95,6 +102,10 @@ func (lc *LifeCycle) Run() {
 		stmt.LifecycleDeadLockResolver:                 &lc.stmtDeadlock,
 		stmt.LifecycleRescheduleDeployments:            &lc.stmtReschedule,
 		stmt.LifecycleSetNotified:                      &lc.stmtSetNotify,
+		stmt.LifecycleJobBacklog:                       &lc.stmtJobBacklog,
+		stmt.LifecycleDeploymentStates:                 &lc.stmtDeploymentStates,
+		stmt.LifecycleGhostConfigurations:              &lc.stmtGhosts,
+		stmt.WorkflowTransition:                        &lc.stmtTransition,
 	} {
 		if *prepStmt, err = lc.conn.Prepare(statement); err != nil {
 			lc.errLog.Fatal(`lifecycle`, err, stmt.Name(statement))
@@ -115,6 +126,7 @@ runloop:
 			break runloop
 		case <-lc.tick:
 			lc.ghost()
+			lc.expireMaintenance()
 			if err = lc.discardDeletedBlocked(); err == nil {
 				// skip unblock steps if there was an error to discard
 				// deleted blocks
@@ -125,6 +137,7 @@ runloop:
 			if !lc.soma.conf.NoPoke {
 				lc.poke()
 			}
+			lc.collectMetrics()
 		}
 	}
 exit:
@@ -132,26 +145,82 @@ exit:
 
 // ghost deletes configurations that that are still in in
 // awaiting_rollout and have update_available set, ie. they have not
-// yet been sent to the monitoring system
+// yet been sent to the monitoring system. Failed rollouts and
+// deprovisioned configurations of deleted check instances are
+// deleted as well.
 func (lc *LifeCycle) ghost() {
+	var (
+		res               sql.Result
+		err               error
+		rows              *sql.Rows
+		instCfgID, status string
+		deleted           int
+	)
+	ghosts := map[string]string{}
+
+	if rows, err = lc.stmtGhosts.Query(); err != nil {
+		lc.errLog.Println(`LifeCycle.ghost()`, err)
+		return
+	}
+	for rows.Next() {
+		if err = rows.Scan(
+			&instCfgID,
+			&status,
+		); err != nil {
+			lc.errLog.Println(`LifeCycle.ghost()`, err)
+			rows.Close()
+			return
+		}
+		ghosts[instCfgID] = status
+	}
+	if err = rows.Err(); err != nil {
+		lc.errLog.Println(`LifeCycle.ghost()`, err)
+		return
+	}
+
+	for instCfgID, status = range ghosts {
+		if err = transition(
+			lc.stmtTransition,
+			instCfgID,
+			status,
+			proto.DeploymentAwaitingDeletion,
+			actorLifeCycle,
+		); err != nil {
+			lc.appLog.Debugf("LifeCycle: ghost %s has errors: %s",
+				instCfgID, err.Error())
+			continue
+		}
+		deleted++
+	}
+	lc.appLog.Debugf("LifeCycle: ghost: %d configurations deleted", deleted)
+
+	if res, err = lc.conn.Exec(
+		stmt.LifecycleDeleteOrphanCheckInstances,
+	); err != nil {
+		lc.appLog.Debugf("LifeCycle: ghost %s has errors: %s",
+			stmt.Name(stmt.LifecycleDeleteOrphanCheckInstances), err.Error())
+	} else {
+		r, _ := res.RowsAffected()
+		lc.appLog.Debugf("LifeCycle: ghost %s: %d rows affected",
+			stmt.Name(stmt.LifecycleDeleteOrphanCheckInstances), r)
+	}
+}
+
+// expireMaintenance deletes maintenance windows that have ended.
+// Check instances are no longer reported as silenced once their last
+// active window ended, the deletion only keeps the table small.
+func (lc *LifeCycle) expireMaintenance() {
 	var (
 		res sql.Result
 		err error
 	)
 
-	for _, st := range []string{
-		stmt.LifecycleDeleteGhosts,
-		stmt.LifecycleDeleteFailedRollouts,
-		stmt.LifecycleDeleteDeprovisioned,
-		stmt.LifecycleDeleteOrphanCheckInstances,
-	} {
-		if res, err = lc.conn.Exec(st); err != nil {
-			lc.appLog.Debugf("LifeCycle: ghost %s has errors: %s", stmt.Name(st), err.Error())
-		} else {
-			r, _ := res.RowsAffected()
-			lc.appLog.Debugf("LifeCycle: ghost %s: %d rows affected", stmt.Name(st), r)
-		}
+	if res, err = lc.conn.Exec(stmt.MaintenanceExpire); err != nil {
+		lc.errLog.Printf("LifeCycle: expiring maintenance windows: %s", err.Error())
+		return
 	}
+	r, _ := res.RowsAffected()
+	lc.appLog.Debugf("LifeCycle: expired %d maintenance windows", r)
 }
 
 // search if there are check instance configurations in status blocked
@@ -198,8 +267,16 @@ func (lc *LifeCycle) discardDeletedBlocked() error {
 			return err
 		}
 
-		// set blockedID to awaiting_deletion
-		if _, err = tx.Exec(stmt.LifecycleConfigAwaitingDeletion, blockedID); err != nil {
+		// set blockedID to awaiting_deletion. A configuration
+		// blocked on multiple dependencies has already been moved
+		// by its first dependency.
+		if err = transition(
+			tx.Stmt(lc.stmtTransition),
+			blockedID,
+			proto.DeploymentBlocked,
+			proto.DeploymentAwaitingDeletion,
+			actorLifeCycle,
+		); err != nil && err != errTransitionConflict {
 			lc.errLog.Println(err)
 			tx.Rollback()
 			return err
@@ -226,7 +303,7 @@ func (lc *LifeCycle) unblock() {
 	var (
 		cfgIds                            *sql.Rows
 		blockedID, blockingID, instanceID string
-		state, next, nextNG, status       string
+		state, next, status               string
 		err                               error
 		tx                                *sql.Tx
 	)
@@ -259,7 +336,6 @@ idloop:
 		}
 
 		for name, statement := range map[string]string{
-			`update`:   stmt.LifecycleUpdateConfig,
 			`delete`:   stmt.LifecycleDeleteDependency,
 			`instance`: stmt.LifecycleUpdateInstance,
 		} {
@@ -271,20 +347,18 @@ idloop:
 			}
 		}
 
-		switch next {
-		case proto.DeploymentAwaitingRollout:
-			nextNG = proto.DeploymentRolloutInProgress
-		default:
+		if next != proto.DeploymentAwaitingRollout {
 			lc.errLog.Printf("LifeCycle.unblock() error: blocked: %s, blocking %s, next: %s, instanceID: %s",
 				blockedID, blockingID, next, instanceID)
 			tx.Rollback()
 			continue idloop
 		}
-		if _, err = txMap[`update`].Exec(
-			next,
-			nextNG,
-			false,
+		if err = transition(
+			tx.Stmt(lc.stmtTransition),
 			blockedID,
+			status,
+			next,
+			actorLifeCycle,
 		); err != nil {
 			lc.errLog.Println(`LifeCycle.unblock(moveConfig)`, err.Error())
 			tx.Rollback()
@@ -343,12 +417,20 @@ func (lc *LifeCycle) deadlockResolver() {
 			lc.errLog.Println(`LifeCycle.deadLockResolver()`, err)
 			return
 		}
-		lc.conn.Exec(stmt.LifecycleUpdateConfig,
-			proto.DeploymentAwaitingDeprovision,
-			proto.DeploymentDeprovisionInProgress,
-			false,
+		if err = transition(
+			lc.stmtTransition,
 			chkInstConfigID,
-		)
+			proto.DeploymentActive,
+			proto.DeploymentAwaitingDeprovision,
+			actorLifeCycle,
+		); err != nil {
+			// a configuration blocking multiple configurations has
+			// already been moved for the first of them
+			if err != errTransitionConflict {
+				lc.errLog.Println(`LifeCycle.deadLockResolver()`, err)
+			}
+			continue
+		}
 		lc.conn.Exec(stmt.LifecycleUpdateInstance,
 			true,
 			chkInstConfigID,
@@ -390,8 +472,12 @@ cfgloop:
 		}
 
 		// set instance configuration to awaiting_deprovision
-		if _, err = tx.Exec(
-			stmt.LifecycleDeprovisionConfiguration, instCfgID,
+		if err = transition(
+			tx.Stmt(lc.stmtTransition),
+			instCfgID,
+			proto.DeploymentActive,
+			proto.DeploymentAwaitingDeprovision,
+			actorLifeCycle,
 		); err != nil {
 			lc.errLog.Println(err)
 			tx.Rollback()
@@ -506,8 +592,12 @@ func (lc *LifeCycle) pokeSystem(callback string, in chan string) {
 					goto retry
 				}
 				lc.errLog.Println(err)
+				metrics.GetOrRegisterMeter(`.lifecycle.poke.failure`,
+					Metrics[`soma`]).Mark(1)
 				continue
 			}
+			metrics.GetOrRegisterMeter(`.lifecycle.poke.success`,
+				Metrics[`soma`]).Mark(1)
 			lc.appLog.Printf("Poked %s (%s)", callback, chkID)
 			lc.stmtSetNotify.Exec(chkID)
 		}
@@ -0,0 +1,118 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// collectMetrics updates the runtime metrics that are derived from
+// the database: the number of unprocessed jobs per repository and the
+// number of check instance configurations per monitoring system and
+// deployment status
+func (lc *LifeCycle) collectMetrics() {
+	seen := make(map[string]struct{})
+	var backlog int64
+
+	if err := lc.collectGauges(
+		lc.stmtJobBacklog,
+		seen,
+		func(rows *sql.Rows) (string, error) {
+			var repository string
+			var count int64
+			if err := rows.Scan(&repository, &count); err != nil {
+				return ``, err
+			}
+			name := MetricName(`.jobs.backlog`,
+				`repository`, repository)
+			metrics.GetOrRegisterGauge(name, Metrics[`soma`]).Update(count)
+			backlog += count
+			return name, nil
+		},
+	); err != nil {
+		lc.errLog.Println(`LifeCycle.collectMetrics()`, err)
+	}
+	// the per-repository gauges are only exported on the admin
+	// socket, the network endpoint exports the total
+	metrics.GetOrRegisterGauge(`.jobs.backlog.total`,
+		Metrics[`soma`]).Update(backlog)
+
+	if err := lc.collectGauges(
+		lc.stmtDeploymentStates,
+		seen,
+		func(rows *sql.Rows) (string, error) {
+			var monitoring, status string
+			var count int64
+			if err := rows.Scan(&monitoring, &status, &count); err != nil {
+				return ``, err
+			}
+			name := MetricName(`.deployments`,
+				`monitoring`, monitoring, `status`, status)
+			metrics.GetOrRegisterGauge(name, Metrics[`soma`]).Update(count)
+			return name, nil
+		},
+	); err != nil {
+		lc.errLog.Println(`LifeCycle.collectMetrics()`, err)
+	}
+
+	// gauges that were not part of the query results have dropped
+	// to zero
+	for name := range lc.gauges {
+		if _, ok := seen[name]; !ok {
+			metrics.GetOrRegisterGauge(name, Metrics[`soma`]).Update(0)
+		}
+	}
+	for name := range seen {
+		lc.gauges[name] = struct{}{}
+	}
+}
+
+// collectGauges runs the query prepared as statement and calls update
+// for every result row. The names of the updated gauges are recorded
+// in seen.
+func (lc *LifeCycle) collectGauges(statement *sql.Stmt,
+	seen map[string]struct{},
+	update func(*sql.Rows) (string, error)) error {
+	rows, err := statement.Query()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		name, err := update(rows)
+		if err != nil {
+			return err
+		}
+		seen[name] = struct{}{}
+	}
+	return rows.Err()
+}
+
+// MetricName returns the registry name of a metric with labels. The
+// labels are given as alternating key and value and are kept in the
+// name in the Prometheus label syntax, ie. name{key="value"}.
+func MetricName(name string, labels ...string) string {
+	pairs := []string{}
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", labels[i],
+			labels[i+1]))
+	}
+	if len(pairs) == 0 {
+		return name
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%s{%s}", name, strings.Join(pairs, `,`))
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
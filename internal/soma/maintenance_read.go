@@ -0,0 +1,275 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma // import "github.com/mjolnir42/soma/internal/soma"
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// MaintenanceRead handles read requests for maintenance windows
+type MaintenanceRead struct {
+	Input       chan msg.Request
+	Shutdown    chan struct{}
+	handlerName string
+	conn        *sql.DB
+	stmtList    *sql.Stmt
+	stmtShow    *sql.Stmt
+	appLog      *logrus.Logger
+	reqLog      *logrus.Logger
+	errLog      *logrus.Logger
+}
+
+// newMaintenanceRead return a new MaintenanceRead handler with input
+// buffer of length
+func newMaintenanceRead(length int) (string, *MaintenanceRead) {
+	r := &MaintenanceRead{}
+	r.handlerName = generateHandlerName() + `_r`
+	r.Input = make(chan msg.Request, length)
+	r.Shutdown = make(chan struct{})
+	return r.handlerName, r
+}
+
+// Register initializes resources provided by the Soma app
+func (r *MaintenanceRead) Register(c *sql.DB, l ...*logrus.Logger) {
+	r.conn = c
+	r.appLog = l[0]
+	r.reqLog = l[1]
+	r.errLog = l[2]
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes
+func (r *MaintenanceRead) RegisterRequests(hmap *handler.Map) {
+	for _, action := range []string{
+		msg.ActionList,
+		msg.ActionShow,
+	} {
+		hmap.Request(msg.SectionMaintenance, action, r.handlerName)
+	}
+}
+
+// Intake exposes the Input channel as part of the handler interface
+func (r *MaintenanceRead) Intake() chan msg.Request {
+	return r.Input
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (r *MaintenanceRead) PriorityIntake() chan msg.Request {
+	return r.Intake()
+}
+
+// Run is the event loop for MaintenanceRead
+func (r *MaintenanceRead) Run() {
+	var err error
+
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.MaintenanceList: &r.stmtList,
+		stmt.MaintenanceShow: &r.stmtShow,
+	} {
+		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
+			r.errLog.Fatal(`maintenance`, err, stmt.Name(statement))
+		}
+		defer (*prepStmt).Close()
+	}
+
+runloop:
+	for {
+		select {
+		case <-r.Shutdown:
+			break runloop
+		case req := <-r.Input:
+			go func() {
+				r.process(&req)
+			}()
+		}
+	}
+}
+
+// process is the request dispatcher
+func (r *MaintenanceRead) process(q *msg.Request) {
+	result := msg.FromRequest(q)
+	logRequest(r.reqLog, q)
+
+	switch q.Action {
+	case msg.ActionList:
+		r.list(q, &result)
+	case msg.ActionShow:
+		r.show(q, &result)
+	default:
+		result.UnknownRequest(q)
+	}
+	q.Reply <- result
+}
+
+// list returns the current and upcoming maintenance windows of a
+// repository
+func (r *MaintenanceRead) list(q *msg.Request, mr *msg.Result) {
+	var (
+		windowID, objectType, objectID string
+		startsAt, endsAt               time.Time
+		rows                           *sql.Rows
+		err                            error
+	)
+
+	if rows, err = r.stmtList.Query(
+		q.Repository.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&windowID,
+			&objectType,
+			&objectID,
+			&startsAt,
+			&endsAt,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		mr.Maintenance = append(mr.Maintenance, proto.Maintenance{
+			ID:           windowID,
+			RepositoryID: q.Repository.ID,
+			ObjectType:   objectType,
+			ObjectID:     objectID,
+			StartsAt:     startsAt.UTC().Format(msg.RFC3339Milli),
+			EndsAt:       endsAt.UTC().Format(msg.RFC3339Milli),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	mr.OK()
+}
+
+// show returns the details of a specific maintenance window
+func (r *MaintenanceRead) show(q *msg.Request, mr *msg.Result) {
+	var (
+		windowID, repositoryID, objectType string
+		objectID, reason, createdBy        string
+		startsAt, endsAt, createdAt        time.Time
+		err                                error
+	)
+
+	if err = r.stmtShow.QueryRow(
+		q.Maintenance.ID,
+		q.Repository.ID,
+	).Scan(
+		&windowID,
+		&repositoryID,
+		&objectType,
+		&objectID,
+		&startsAt,
+		&endsAt,
+		&reason,
+		&createdBy,
+		&createdAt,
+	); err == sql.ErrNoRows {
+		mr.NotFound(err, q.Section)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	mr.Maintenance = append(mr.Maintenance, proto.Maintenance{
+		ID:           windowID,
+		RepositoryID: repositoryID,
+		ObjectType:   objectType,
+		ObjectID:     objectID,
+		StartsAt:     startsAt.UTC().Format(msg.RFC3339Milli),
+		EndsAt:       endsAt.UTC().Format(msg.RFC3339Milli),
+		Reason:       reason,
+		Details: &proto.DetailsCreation{
+			CreatedAt: createdAt.Format(msg.RFC3339Milli),
+			CreatedBy: createdBy,
+		},
+	})
+	mr.OK()
+}
+
+// silencedUntil returns the end of the longest active maintenance
+// window that covers check instance checkInstanceID, or an empty
+// string if the instance is not silenced. s must be a prepared
+// stmt.MaintenanceSilencedUntil.
+func silencedUntil(s *sql.Stmt, checkInstanceID string) (string, error) {
+	var until pq.NullTime
+
+	if err := s.QueryRow(
+		checkInstanceID,
+	).Scan(
+		&until,
+	); err != nil {
+		return ``, err
+	}
+	if !until.Valid {
+		return ``, nil
+	}
+	return until.Time.UTC().Format(msg.RFC3339Milli), nil
+}
+
+// silencedInstances returns the end of the longest active
+// maintenance window for every silenced check instance in
+// checkInstanceIDs, keyed by check instance ID. s must be a prepared
+// stmt.MaintenanceSilencedInstances.
+func silencedInstances(s *sql.Stmt, checkInstanceIDs []string) (map[string]string, error) {
+	var (
+		checkInstanceID string
+		until           pq.NullTime
+		rows            *sql.Rows
+		err             error
+	)
+	silenced := map[string]string{}
+	if len(checkInstanceIDs) == 0 {
+		return silenced, nil
+	}
+
+	if rows, err = s.Query(
+		pq.Array(checkInstanceIDs),
+	); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&checkInstanceID,
+			&until,
+		); err != nil {
+			return nil, err
+		}
+		if until.Valid {
+			silenced[checkInstanceID] = until.Time.UTC().Format(
+				msg.RFC3339Milli,
+			)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return silenced, nil
+}
+
+// ShutdownNow signals the handler to shut down
+func (r *MaintenanceRead) ShutdownNow() {
+	close(r.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
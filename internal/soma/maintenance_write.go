@@ -0,0 +1,167 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma // import "github.com/mjolnir42/soma/internal/soma"
+
+import (
+	"database/sql"
+
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// MaintenanceWrite handles write requests for maintenance windows
+type MaintenanceWrite struct {
+	Input       chan msg.Request
+	Shutdown    chan struct{}
+	handlerName string
+	conn        *sql.DB
+	stmtAdd     *sql.Stmt
+	stmtRemove  *sql.Stmt
+	appLog      *logrus.Logger
+	reqLog      *logrus.Logger
+	errLog      *logrus.Logger
+}
+
+// newMaintenanceWrite return a new MaintenanceWrite handler with
+// input buffer of length
+func newMaintenanceWrite(length int) (string, *MaintenanceWrite) {
+	w := &MaintenanceWrite{}
+	w.handlerName = generateHandlerName() + `_w`
+	w.Input = make(chan msg.Request, length)
+	w.Shutdown = make(chan struct{})
+	return w.handlerName, w
+}
+
+// Register initializes resources provided by the Soma app
+func (w *MaintenanceWrite) Register(c *sql.DB, l ...*logrus.Logger) {
+	w.conn = c
+	w.appLog = l[0]
+	w.reqLog = l[1]
+	w.errLog = l[2]
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes
+func (w *MaintenanceWrite) RegisterRequests(hmap *handler.Map) {
+	for _, action := range []string{
+		msg.ActionAdd,
+		msg.ActionRemove,
+	} {
+		hmap.Request(msg.SectionMaintenance, action, w.handlerName)
+	}
+}
+
+// Intake exposes the Input channel as part of the handler interface
+func (w *MaintenanceWrite) Intake() chan msg.Request {
+	return w.Input
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (w *MaintenanceWrite) PriorityIntake() chan msg.Request {
+	return w.Intake()
+}
+
+// Run is the event loop for MaintenanceWrite
+func (w *MaintenanceWrite) Run() {
+	var err error
+
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.MaintenanceAdd:    &w.stmtAdd,
+		stmt.MaintenanceRemove: &w.stmtRemove,
+	} {
+		if *prepStmt, err = w.conn.Prepare(statement); err != nil {
+			w.errLog.Fatal(`maintenance`, err, stmt.Name(statement))
+		}
+		defer (*prepStmt).Close()
+	}
+
+runloop:
+	for {
+		select {
+		case <-w.Shutdown:
+			break runloop
+		case req := <-w.Input:
+			w.process(&req)
+		}
+	}
+}
+
+// process is the request dispatcher
+func (w *MaintenanceWrite) process(q *msg.Request) {
+	result := msg.FromRequest(q)
+	logRequest(w.reqLog, q)
+
+	switch q.Action {
+	case msg.ActionAdd:
+		w.add(q, &result)
+	case msg.ActionRemove:
+		w.remove(q, &result)
+	default:
+		result.UnknownRequest(q)
+	}
+
+	q.Reply <- result
+}
+
+// add schedules a new maintenance window. No rows are affected if
+// the object is not part of the repository.
+func (w *MaintenanceWrite) add(q *msg.Request, mr *msg.Result) {
+	var (
+		err error
+		res sql.Result
+	)
+
+	q.Maintenance.ID = uuid.Must(uuid.NewV4()).String()
+	q.Maintenance.RepositoryID = q.Repository.ID
+
+	if res, err = w.stmtAdd.Exec(
+		q.Maintenance.ID,
+		q.Maintenance.RepositoryID,
+		q.Maintenance.ObjectType,
+		q.Maintenance.ObjectID,
+		q.Maintenance.StartsAt,
+		q.Maintenance.EndsAt,
+		q.Maintenance.Reason,
+		q.AuthUser,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	if mr.RowCnt(res.RowsAffected()) {
+		mr.Maintenance = append(mr.Maintenance, q.Maintenance.Clone())
+	}
+}
+
+// remove deletes a maintenance window before it ended
+func (w *MaintenanceWrite) remove(q *msg.Request, mr *msg.Result) {
+	var (
+		err error
+		res sql.Result
+	)
+
+	if res, err = w.stmtRemove.Exec(
+		q.Maintenance.ID,
+		q.Repository.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	if mr.RowCnt(res.RowsAffected()) {
+		mr.Maintenance = append(mr.Maintenance, q.Maintenance)
+	}
+}
+
+// ShutdownNow signals the handler to shut down
+func (w *MaintenanceWrite) ShutdownNow() {
+	close(w.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
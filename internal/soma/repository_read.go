@@ -25,6 +25,8 @@ type RepositoryRead struct {
 	Shutdown        chan struct{}
 	handlerName     string
 	conn            *sql.DB
+	soma            *Soma
+	stmtAudit       *sql.Stmt
 	stmtList        *sql.Stmt
 	stmtPropCustom  *sql.Stmt
 	stmtPropOncall  *sql.Stmt
@@ -39,11 +41,12 @@ type RepositoryRead struct {
 
 // newBucketRead returns a new BucketRead handler with input
 // buffer of length
-func newRepositoryRead(length int) (string, *RepositoryRead) {
+func newRepositoryRead(length int, s *Soma) (string, *RepositoryRead) {
 	r := &RepositoryRead{}
 	r.handlerName = generateHandlerName() + `_r`
 	r.Input = make(chan msg.Request, length)
 	r.Shutdown = make(chan struct{})
+	r.soma = s
 	return r.handlerName, r
 }
 
@@ -91,6 +94,7 @@ func (r *RepositoryRead) Run() {
 
 	for statement, prepStmt := range map[string]**sql.Stmt{
 		stmt.AuthorizedRepositoryList:   &r.stmtList,
+		stmt.RepositoryAudit:            &r.stmtAudit,
 		stmt.AuthorizedRepositorySearch: &r.stmtSearch,
 		stmt.AuthorizedRepositoryShow:   &r.stmtShow,
 		stmt.RepoCstProps:               &r.stmtPropCustom,
@@ -123,6 +127,8 @@ func (r *RepositoryRead) process(q *msg.Request) {
 	logRequest(r.reqLog, q)
 
 	switch q.Action {
+	case msg.ActionAudit:
+		r.audit(q, &result)
 	case msg.ActionList:
 		r.list(q, &result)
 	case msg.ActionSearch:
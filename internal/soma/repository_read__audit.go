@@ -0,0 +1,425 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// audit returns the change history of a repository, built from the
+// finished jobs of the repository and their TreeKeeper job logs
+func (r *RepositoryRead) audit(q *msg.Request, mr *msg.Result) {
+	var (
+		err                                                error
+		rows                                               *sql.Rows
+		jobID, jobType, jobStatus, jobResult, repositoryID string
+		jobError, jobSpec, teamID, userName                string
+		jobSerial                                          int
+		jobQueued                                          time.Time
+		jobStarted, jobFinished                            pq.NullTime
+		filterUser, filterType                             sql.NullString
+		filterSince, filterUntil                           pq.NullTime
+	)
+
+	if q.Search.Audit.UserName != `` {
+		filterUser.String = q.Search.Audit.UserName
+		filterUser.Valid = true
+	}
+	if q.Search.Audit.JobType != `` {
+		filterType.String = q.Search.Audit.JobType
+		filterType.Valid = true
+	}
+	if q.Search.Audit.Since != `` {
+		if filterSince.Time, err = time.Parse(
+			time.RFC3339,
+			q.Search.Audit.Since,
+		); err != nil {
+			mr.BadRequest(err, q.Section)
+			return
+		}
+		filterSince.Valid = true
+	}
+	if q.Search.Audit.Until != `` {
+		if filterUntil.Time, err = time.Parse(
+			time.RFC3339,
+			q.Search.Audit.Until,
+		); err != nil {
+			mr.BadRequest(err, q.Section)
+			return
+		}
+		filterUntil.Valid = true
+	}
+
+	if rows, err = r.stmtAudit.Query(
+		q.Repository.ID,
+		q.Repository.TeamID,
+		filterUser,
+		filterType,
+		filterSince,
+		filterUntil,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&jobID,
+			&jobSerial,
+			&jobType,
+			&jobStatus,
+			&jobResult,
+			&jobError,
+			&repositoryID,
+			&userName,
+			&teamID,
+			&jobQueued,
+			&jobStarted,
+			&jobFinished,
+			&jobSpec,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+
+		entry := proto.Audit{
+			JobID:        jobID,
+			JobSerial:    jobSerial,
+			JobType:      jobType,
+			Status:       jobStatus,
+			Result:       jobResult,
+			Error:        jobError,
+			RepositoryID: repositoryID,
+			UserName:     userName,
+			TeamID:       teamID,
+		}
+		entry.TsQueued = jobQueued.Format(msg.RFC3339Milli)
+		if jobStarted.Valid {
+			entry.TsStarted = jobStarted.Time.Format(msg.RFC3339Milli)
+		}
+		if jobFinished.Valid {
+			entry.TsFinished = jobFinished.Time.Format(msg.RFC3339Milli)
+		}
+
+		job := auditJob{}
+		if err = json.Unmarshal([]byte(jobSpec), &job); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		auditObject(&job, &entry)
+
+		// membership changes are recorded under the parent object,
+		// but are part of the history of the member as well
+		if !auditMatch(q.Search.Audit, entry.ObjectType,
+			entry.ObjectID) {
+			memberType, memberID := auditMember(&job)
+			if memberID == `` || !auditMatch(q.Search.Audit,
+				memberType, memberID) {
+				continue
+			}
+		}
+
+		if q.Search.IsDetailed {
+			if entry.Actions, err = r.auditActions(jobID); err != nil {
+				rows.Close()
+				mr.ServerError(err, q.Section)
+				return
+			}
+		}
+		mr.Audit = append(mr.Audit, entry)
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	mr.OK()
+}
+
+// auditJob contains the parts of a saved job that are used to
+// determine the object it changed
+type auditJob struct {
+	Section      string
+	Action       string
+	TargetEntity string
+	Bucket       proto.Bucket
+	CheckConfig  proto.CheckConfig
+	Cluster      proto.Cluster
+	Group        proto.Group
+	Node         proto.Node
+	Repository   proto.Repository
+}
+
+// auditObject fills in the object that was changed by job
+func auditObject(job *auditJob, entry *proto.Audit) {
+	switch job.Section {
+	case msg.SectionRepository, msg.SectionRepositoryConfig:
+		entry.ObjectType = msg.EntityRepository
+		entry.ObjectID = job.Repository.ID
+		entry.ObjectName = job.Repository.Name
+		entry.Properties = job.Repository.Properties
+	case msg.SectionBucket:
+		entry.ObjectType = msg.EntityBucket
+		entry.ObjectID = job.Bucket.ID
+		entry.ObjectName = job.Bucket.Name
+		entry.Properties = job.Bucket.Properties
+	case msg.SectionGroup:
+		entry.ObjectType = msg.EntityGroup
+		entry.ObjectID = job.Group.ID
+		entry.ObjectName = job.Group.Name
+		entry.Properties = job.Group.Properties
+	case msg.SectionCluster:
+		entry.ObjectType = msg.EntityCluster
+		entry.ObjectID = job.Cluster.ID
+		entry.ObjectName = job.Cluster.Name
+		entry.Properties = job.Cluster.Properties
+	case msg.SectionNodeConfig:
+		entry.ObjectType = msg.EntityNode
+		entry.ObjectID = job.Node.ID
+		entry.ObjectName = job.Node.Name
+		entry.Properties = job.Node.Properties
+	case msg.SectionCheckConfig:
+		entry.ObjectType = msg.EntityCheck
+		entry.ObjectID = job.CheckConfig.ID
+		entry.ObjectName = job.CheckConfig.Name
+	}
+}
+
+// auditMatch returns true if the object objType/objID is selected by
+// the object filter of f
+func auditMatch(f proto.AuditFilter, objType, objID string) bool {
+	if f.ObjectType != `` && f.ObjectType != objType {
+		return false
+	}
+	if f.ObjectID != `` && f.ObjectID != objID {
+		return false
+	}
+	return true
+}
+
+// auditMember returns the type and ID of the object that was assigned
+// to or unassigned from its parent by job. For all other jobs the
+// returned ID is empty.
+func auditMember(job *auditJob) (string, string) {
+	var (
+		groups   *[]proto.Group
+		clusters *[]proto.Cluster
+		nodes    *[]proto.Node
+	)
+
+	switch job.Action {
+	case msg.ActionMemberAssign, msg.ActionMemberUnassign:
+	default:
+		return ``, ``
+	}
+
+	switch job.Section {
+	case msg.SectionBucket:
+		groups = job.Bucket.MemberGroups
+		clusters = job.Bucket.MemberClusters
+		nodes = job.Bucket.MemberNodes
+	case msg.SectionGroup:
+		groups = job.Group.MemberGroups
+		clusters = job.Group.MemberClusters
+		nodes = job.Group.MemberNodes
+	case msg.SectionCluster:
+		nodes = job.Cluster.Members
+	}
+
+	switch job.TargetEntity {
+	case msg.EntityGroup:
+		if groups != nil && len(*groups) > 0 {
+			return msg.EntityGroup, (*groups)[0].ID
+		}
+	case msg.EntityCluster:
+		if clusters != nil && len(*clusters) > 0 {
+			return msg.EntityCluster, (*clusters)[0].ID
+		}
+	case msg.EntityNode:
+		if nodes != nil && len(*nodes) > 0 {
+			return msg.EntityNode, (*nodes)[0].ID
+		}
+	}
+	return ``, ``
+}
+
+// auditActions reads the tree actions that were recorded in the
+// TreeKeeper job log of jobID. Jobs without a job log, for example
+// because they failed before it was opened, have no actions.
+func (r *RepositoryRead) auditActions(jobID string) (*[]proto.AuditAction, error) {
+	var (
+		err     error
+		matches []string
+		lfh     *os.File
+		actions []proto.AuditAction
+	)
+
+	// job logs are named ${timestamp}_${repoName}_${jobID}.log
+	if matches, err = filepath.Glob(filepath.Join(
+		r.soma.conf.LogPath,
+		`job`,
+		fmt.Sprintf("*_%s.log", jobID),
+	)); err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return &[]proto.AuditAction{}, nil
+	}
+
+	if lfh, err = os.Open(matches[0]); err != nil {
+		return nil, err
+	}
+	defer lfh.Close()
+
+	if actions, err = auditLogActions(lfh); err != nil {
+		return nil, err
+	}
+	return &actions, nil
+}
+
+// auditLogActions returns the tree actions recorded in the job log
+// read from rd. Lines that do not contain a tree action are skipped.
+func auditLogActions(rd io.Reader) ([]proto.AuditAction, error) {
+	actions := []proto.AuditAction{}
+
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var a tree.Action
+
+		text, ok := jobLogMessage(scanner.Text())
+		if !ok || !strings.HasPrefix(text, `{`) {
+			continue
+		}
+		if err := json.Unmarshal([]byte(text), &a); err != nil {
+			continue
+		}
+		if a.Action == `` {
+			continue
+		}
+		actions = append(actions, auditAction(&a))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// jobLogMessage returns the logged message of a job log line. Job logs
+// are written as JSON lines, job logs of older versions in logfmt.
+func jobLogMessage(line string) (string, bool) {
+	entry := struct {
+		Msg *string `json:"msg"`
+	}{}
+	if err := json.Unmarshal([]byte(line), &entry); err == nil {
+		if entry.Msg == nil {
+			return ``, false
+		}
+		return *entry.Msg, true
+	}
+	return logfmtValue(line, `msg`)
+}
+
+// logfmtValue returns the value of key in logfmt encoded line. Values
+// are either bare words or double-quoted strings with Go escapes.
+func logfmtValue(line, key string) (string, bool) {
+	for line != `` {
+		line = strings.TrimLeft(line, ` `)
+
+		// key is terminated by = or the end of a value-less pair
+		end := strings.IndexAny(line, `= `)
+		if end == -1 {
+			return ``, false
+		}
+		k := line[:end]
+		if line[end] == ' ' {
+			line = line[end:]
+			continue
+		}
+		line = line[end+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			// find the closing quote, skipping escaped characters
+			i := 1
+			for ; i < len(line); i++ {
+				if line[i] == '\\' {
+					i++
+					continue
+				}
+				if line[i] == '"' {
+					break
+				}
+			}
+			if i >= len(line) {
+				return ``, false
+			}
+			var err error
+			if value, err = strconv.Unquote(line[:i+1]); err != nil {
+				return ``, false
+			}
+			line = line[i+1:]
+		} else {
+			if end = strings.IndexByte(line, ' '); end == -1 {
+				end = len(line)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+
+		if k == key {
+			return value, true
+		}
+	}
+	return ``, false
+}
+
+// auditAction converts a tree action into an audit action
+func auditAction(a *tree.Action) proto.AuditAction {
+	action := proto.AuditAction{
+		Action:          a.Action,
+		ObjectType:      a.Type,
+		CheckID:         a.Check.CheckID,
+		CheckInstanceID: a.CheckInstance.InstanceID,
+	}
+	switch a.Type {
+	case msg.EntityRepository:
+		action.ObjectID = a.Repository.ID
+		action.ObjectName = a.Repository.Name
+	case msg.EntityBucket:
+		action.ObjectID = a.Bucket.ID
+		action.ObjectName = a.Bucket.Name
+	case msg.EntityGroup:
+		action.ObjectID = a.Group.ID
+		action.ObjectName = a.Group.Name
+	case msg.EntityCluster:
+		action.ObjectID = a.Cluster.ID
+		action.ObjectName = a.Cluster.Name
+	case msg.EntityNode:
+		action.ObjectID = a.Node.ID
+		action.ObjectName = a.Node.Name
+	}
+	return action
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
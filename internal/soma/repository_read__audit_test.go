@@ -0,0 +1,101 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAuditLogActions(t *testing.T) {
+	actions := []tree.Action{
+		{
+			Action: `create`,
+			Type:   msg.EntityBucket,
+			Bucket: proto.Bucket{ID: `bucketID`, Name: `example_test`},
+		},
+		{
+			Action: `check_instance_create`,
+			Type:   msg.EntityNode,
+			Node:   proto.Node{ID: `nodeID`, Name: "node \"a\" =b"},
+			CheckInstance: proto.CheckInstance{
+				InstanceID: `instanceID`,
+			},
+		},
+	}
+
+	for _, formatter := range []logrus.Formatter{
+		&logrus.JSONFormatter{},
+		&logrus.TextFormatter{DisableColors: true},
+	} {
+		buf := &bytes.Buffer{}
+		jobLog := logrus.New()
+		jobLog.Out = buf
+		jobLog.Formatter = formatter
+
+		jobLog.Printf("Cleaned message: %s", `{"action":"ignored"}`)
+		for i := range actions {
+			b, _ := json.Marshal(actions[i])
+			jobLog.Println(string(b))
+		}
+		// errors are logged without action
+		jobLog.Println(`{"error":"failed"}`)
+		jobLog.Printf("Aborting error: %s", `failed`)
+
+		got, err := auditLogActions(buf)
+		if err != nil {
+			t.Fatalf("%T: %s", formatter, err)
+		}
+		if len(got) != len(actions) {
+			t.Fatalf("%T: expected %d actions, got %d: %v", formatter,
+				len(actions), len(got), got)
+		}
+		for i := range actions {
+			if expected := auditAction(&actions[i]); got[i] != expected {
+				t.Errorf("%T: expected %v, got %v", formatter,
+					expected, got[i])
+			}
+		}
+	}
+}
+
+func TestLogfmtValue(t *testing.T) {
+	tests := []struct {
+		line  string
+		key   string
+		value string
+		ok    bool
+	}{
+		{`level=info msg=started`, `msg`, `started`, true},
+		{`time="2026-01-01T00:00:00Z" level=info msg="a b"`, `msg`,
+			`a b`, true},
+		{`msg="say \"hi\" msg=x" level=info`, `level`, `info`, true},
+		{`msg="say \"hi\" msg=x" level=info`, `msg`, `say "hi" msg=x`,
+			true},
+		{`flag level=info`, `level`, `info`, true},
+		{`level=info`, `msg`, ``, false},
+		{`msg="unterminated`, `msg`, ``, false},
+		{``, `msg`, ``, false},
+	}
+
+	for _, test := range tests {
+		value, ok := logfmtValue(test.line, test.key)
+		if value != test.value || ok != test.ok {
+			t.Errorf("%s: expected %q/%t, got %q/%t", test.line,
+				test.value, test.ok, value, ok)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
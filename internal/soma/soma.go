@@ -11,6 +11,7 @@ package soma
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/mjolnir42/soma/internal/config"
@@ -27,6 +28,18 @@ type Soma struct {
 	reqLog       *logrus.Logger
 	errLog       *logrus.Logger
 	auditLog     *logrus.Logger
+	frontends    []Frontend
+}
+
+// Frontend is the interface of the request frontends that have to be
+// drained during a shutdown
+type Frontend interface {
+	// Drain refuses new requests and waits up to timeout for the
+	// requests in progress to finish
+	Drain(timeout time.Duration)
+	// Stop shuts the frontend down, waiting up to timeout for
+	// the remaining replies to be delivered
+	Stop(timeout time.Duration)
 }
 
 // New returns a new SOMA application
@@ -49,6 +62,12 @@ func New(
 	return &s
 }
 
+// RegisterFrontend adds f to the frontends that are drained and
+// stopped by the GrimReaper
+func (s *Soma) RegisterFrontend(f Frontend) {
+	s.frontends = append(s.frontends, f)
+}
+
 // exportLogger returns references to the instances loggers
 func (s *Soma) exportLogger() []*logrus.Logger {
 	return []*logrus.Logger{s.appLog, s.reqLog, s.errLog}
@@ -40,6 +40,7 @@ func (s *Soma) Start() {
 	s.handlerMap.Add(newJobStatusRead(s.conf.QueueLen))
 	s.handlerMap.Add(newJobTypeRead(s.conf.QueueLen))
 	s.handlerMap.Add(newLevelRead(s.conf.QueueLen))
+	s.handlerMap.Add(newMaintenanceRead(s.conf.QueueLen))
 	s.handlerMap.Add(newMetricRead(s.conf.QueueLen))
 	s.handlerMap.Add(newModeRead(s.conf.QueueLen))
 	s.handlerMap.Add(newMonitoringRead(s.conf.QueueLen))
@@ -48,7 +49,7 @@ func (s *Soma) Start() {
 	s.handlerMap.Add(newPredicateRead(s.conf.QueueLen))
 	s.handlerMap.Add(newPropertyRead(s.conf.QueueLen))
 	s.handlerMap.Add(newProviderRead(s.conf.QueueLen))
-	s.handlerMap.Add(newRepositoryRead(s.conf.QueueLen))
+	s.handlerMap.Add(newRepositoryRead(s.conf.QueueLen, s))
 	s.handlerMap.Add(newServerRead(s.conf.QueueLen))
 	s.handlerMap.Add(newStateRead(s.conf.QueueLen))
 	s.handlerMap.Add(newStatusRead(s.conf.QueueLen))
@@ -58,6 +59,7 @@ func (s *Soma) Start() {
 	s.handlerMap.Add(newUserRead(s.conf.QueueLen))
 	s.handlerMap.Add(newValidityRead(s.conf.QueueLen))
 	s.handlerMap.Add(newViewRead(s.conf.QueueLen))
+	s.handlerMap.Add(newWebhookRead(s.conf.QueueLen))
 	s.handlerMap.Add(newWorkflowRead(s.conf.QueueLen))
 
 	if !s.conf.ReadOnly {
@@ -74,10 +76,12 @@ func (s *Soma) Start() {
 			s.handlerMap.Add(newEntityWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newEnvironmentWrite(s.conf.QueueLen))
 			s.handlerMap.Add(`job_block`, newJobBlock(s.conf.QueueLen))
+			s.handlerMap.Add(`job_stream`, newJobStream(s.conf.QueueLen))
 			s.handlerMap.Add(newJobResultWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newJobStatusWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newJobTypeWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newLevelWrite(s.conf.QueueLen))
+			s.handlerMap.Add(newMaintenanceWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newMetricWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newModeWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newMonitoringWrite(s.conf.QueueLen))
@@ -94,6 +98,8 @@ func (s *Soma) Start() {
 			s.handlerMap.Add(newUserWrite(s.conf.QueueLen, s))
 			s.handlerMap.Add(newValidityWrite(s.conf.QueueLen))
 			s.handlerMap.Add(newViewWrite(s.conf.QueueLen))
+			s.handlerMap.Add(newWebhookWrite(s.conf.QueueLen))
+			s.handlerMap.Add(`webhook_dispatcher`, newWebhookDispatcher(s))
 			s.handlerMap.Add(newWorkflowWrite(s.conf.QueueLen))
 		}
 	}
@@ -59,9 +59,12 @@ type TreeRead struct {
 	stmtListGroupMemberClusters     *sql.Stmt
 	stmtListGroupMemberNodes        *sql.Stmt
 	stmtListClusterMemberNodes      *sql.Stmt
-	appLog                          *logrus.Logger
-	reqLog                          *logrus.Logger
-	errLog                          *logrus.Logger
+	// layout export
+	stmtExportProperties *sql.Stmt
+	stmtShowCapability   *sql.Stmt
+	appLog               *logrus.Logger
+	reqLog               *logrus.Logger
+	errLog               *logrus.Logger
 }
 
 // newTreeRead return a new TreeRead handler with input buffer of
@@ -94,6 +97,7 @@ func (r *TreeRead) RegisterRequests(hmap *handler.Map) {
 	} {
 		hmap.Request(section, msg.ActionTree, r.handlerName)
 	}
+	hmap.Request(msg.SectionRepositoryConfig, msg.ActionExport, r.handlerName)
 }
 
 // Intake exposes the Input channel as part of the handler interface
@@ -112,19 +116,21 @@ func (r *TreeRead) Run() {
 
 	// single-object return statements
 	for statement, prepStmt := range map[string]**sql.Stmt{
-		stmt.TreeShowRepository:      &r.stmtShowRepository,
-		stmt.TreeShowBucket:          &r.stmtShowBucket,
-		stmt.TreeShowGroup:           &r.stmtShowGroup,
-		stmt.TreeShowCluster:         &r.stmtShowCluster,
-		stmt.TreeShowNode:            &r.stmtShowNode,
-		stmt.TreeBucketsInRepository: &r.stmtListRepositoryMemberBuckets,
-		stmt.TreeGroupsInBucket:      &r.stmtListBucketMemberGroups,
-		stmt.TreeClustersInBucket:    &r.stmtListBucketMemberClusters,
-		stmt.TreeNodesInBucket:       &r.stmtListBucketMemberNodes,
-		stmt.TreeGroupsInGroup:       &r.stmtListGroupMemberGroups,
-		stmt.TreeClustersInGroup:     &r.stmtListGroupMemberClusters,
-		stmt.TreeNodesInGroup:        &r.stmtListGroupMemberNodes,
-		stmt.TreeNodesInCluster:      &r.stmtListClusterMemberNodes,
+		stmt.TreeShowRepository:         &r.stmtShowRepository,
+		stmt.TreeShowBucket:             &r.stmtShowBucket,
+		stmt.TreeShowGroup:              &r.stmtShowGroup,
+		stmt.TreeShowCluster:            &r.stmtShowCluster,
+		stmt.TreeShowNode:               &r.stmtShowNode,
+		stmt.TreeBucketsInRepository:    &r.stmtListRepositoryMemberBuckets,
+		stmt.TreeGroupsInBucket:         &r.stmtListBucketMemberGroups,
+		stmt.TreeClustersInBucket:       &r.stmtListBucketMemberClusters,
+		stmt.TreeNodesInBucket:          &r.stmtListBucketMemberNodes,
+		stmt.TreeGroupsInGroup:          &r.stmtListGroupMemberGroups,
+		stmt.TreeClustersInGroup:        &r.stmtListGroupMemberClusters,
+		stmt.TreeNodesInGroup:           &r.stmtListGroupMemberNodes,
+		stmt.TreeNodesInCluster:         &r.stmtListClusterMemberNodes,
+		stmt.RepositoryExportProperties: &r.stmtExportProperties,
+		stmt.ShowCapability:             &r.stmtShowCapability,
 	} {
 		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
 			r.errLog.Fatal(`tree_r`, err, stmt.Name(statement))
@@ -153,6 +159,8 @@ func (r *TreeRead) process(q *msg.Request) {
 	switch q.Action {
 	case msg.ActionTree:
 		r.tree(q, &result)
+	case msg.ActionExport:
+		r.export(q, &result)
 	default:
 		result.UnknownRequest(q)
 	}
@@ -0,0 +1,359 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// layoutObject is an object of an exported layout
+type layoutObject struct {
+	name       string
+	bucket     string
+	properties *[]proto.LayoutProperty
+}
+
+// export returns the layout of a repository
+func (r *TreeRead) export(q *msg.Request, mr *msg.Result) {
+	var (
+		err          error
+		tx           *sql.Tx
+		repo         *proto.Repository
+		checkConfigs *[]proto.CheckConfig
+	)
+
+	if repo, err = r.repository(q.Repository.ID, 0); err == sql.ErrNoRows {
+		mr.NotFound(fmt.Errorf(`Repository not found`), q.Section)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	layout := proto.Layout{
+		ID:         repo.ID,
+		Repository: repo.Name,
+		Buckets:    make([]proto.LayoutBucket, 0, len(*repo.Members)),
+	}
+	for _, bucket := range *repo.Members {
+		layout.Buckets = append(layout.Buckets, exportBucket(&bucket))
+	}
+
+	// the layout is complete, the objects do not move anymore
+	objects := map[string]layoutObject{
+		repo.ID: {
+			name:       repo.Name,
+			properties: &layout.Properties,
+		},
+	}
+	for i := range layout.Buckets {
+		indexBucket(objects, &layout.Buckets[i])
+	}
+
+	if err = r.exportProperties(repo.ID, objects); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	if tx, err = r.conn.Begin(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	if checkConfigs, err = exportCheckConfigRepositoryTX(
+		tx,
+		repo.ID,
+	); err != nil {
+		tx.Rollback()
+		mr.ServerError(err, q.Section)
+		return
+	}
+	layout.CheckConfigs = make([]proto.LayoutCheckConfig, 0,
+		len(*checkConfigs))
+	for i := range *checkConfigs {
+		var c proto.LayoutCheckConfig
+		if c, err = r.exportCheckConfig(
+			&(*checkConfigs)[i],
+			objects,
+		); err != nil {
+			mr.ServerError(err, q.Section)
+			return
+		}
+		layout.CheckConfigs = append(layout.CheckConfigs, c)
+	}
+
+	layout.Sort()
+	mr.Layout = layout
+	mr.OK()
+}
+
+// exportProperties adds all locally set properties of the repository
+// to their objects
+func (r *TreeRead) exportProperties(repositoryID string,
+	objects map[string]layoutObject) error {
+	var (
+		err                                 error
+		rows                                *sql.Rows
+		objectType, objectID, propertyType  string
+		sourceInstanceID, view, name, value string
+		inheritance, childrenOnly           bool
+	)
+
+	if rows, err = r.stmtExportProperties.Query(
+		repositoryID,
+	); err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&objectType,
+			&objectID,
+			&propertyType,
+			&sourceInstanceID,
+			&view,
+			&inheritance,
+			&childrenOnly,
+			&name,
+			&value,
+		); err != nil {
+			return err
+		}
+
+		object, ok := objects[objectID]
+		if !ok {
+			return fmt.Errorf("Property %s set on unknown %s %s",
+				sourceInstanceID, objectType, objectID)
+		}
+		*object.properties = append(*object.properties,
+			proto.LayoutProperty{
+				SourceInstanceID: sourceInstanceID,
+				Type:             propertyType,
+				Name:             name,
+				Value:            value,
+				View:             view,
+				Inheritance:      inheritance,
+				ChildrenOnly:     childrenOnly,
+			})
+	}
+	return rows.Err()
+}
+
+// exportCheckConfig converts a check configuration for the layout
+func (r *TreeRead) exportCheckConfig(cnf *proto.CheckConfig,
+	objects map[string]layoutObject) (proto.LayoutCheckConfig, error) {
+	var (
+		err                                error
+		capabilityID, monitoringID, metric string
+		view, monitoringName               string
+		thresholds                         int
+	)
+
+	object, ok := objects[cnf.ObjectID]
+	if !ok {
+		return proto.LayoutCheckConfig{}, fmt.Errorf(
+			"Check configuration %s on unknown %s %s",
+			cnf.Name, cnf.ObjectType, cnf.ObjectID)
+	}
+
+	if err = r.stmtShowCapability.QueryRow(
+		cnf.CapabilityID,
+	).Scan(
+		&capabilityID,
+		&monitoringID,
+		&metric,
+		&view,
+		&thresholds,
+		&monitoringName,
+	); err != nil {
+		return proto.LayoutCheckConfig{}, err
+	}
+
+	c := proto.LayoutCheckConfig{
+		ID:           cnf.ID,
+		Name:         cnf.Name,
+		Capability:   fmt.Sprintf("%s.%s.%s", monitoringName, view, metric),
+		ObjectType:   cnf.ObjectType,
+		Object:       object.name,
+		Interval:     cnf.Interval,
+		Inheritance:  cnf.Inheritance,
+		ChildrenOnly: cnf.ChildrenOnly,
+	}
+	switch cnf.ObjectType {
+	case msg.EntityGroup, msg.EntityCluster:
+		c.Bucket = object.bucket
+	}
+	if cnf.ExternalID != `none` {
+		c.ExternalID = cnf.ExternalID
+	}
+
+	for _, thr := range cnf.Thresholds {
+		c.Thresholds = append(c.Thresholds, proto.LayoutThreshold{
+			Predicate: thr.Predicate.Symbol,
+			Level:     thr.Level.Name,
+			Value:     thr.Value,
+		})
+	}
+
+	for _, constr := range cnf.Constraints {
+		l := proto.LayoutConstraint{
+			Type:     constr.ConstraintType,
+			Operator: constr.Operator,
+			Group:    constr.Group,
+		}
+		switch constr.ConstraintType {
+		case msg.ConstraintNative:
+			l.Name, l.Value = constr.Native.Name, constr.Native.Value
+		case msg.ConstraintSystem:
+			l.Name, l.Value = constr.System.Name, constr.System.Value
+		case msg.ConstraintCustom:
+			l.Name, l.Value = constr.Custom.Name, constr.Custom.Value
+		case msg.ConstraintAttribute:
+			l.Name, l.Value = constr.Attribute.Name, constr.Attribute.Value
+		case msg.ConstraintService:
+			l.Name = constr.Service.Name
+		case msg.ConstraintOncall:
+			l.Name = constr.Oncall.Name
+		}
+		if l.Operator == proto.ConstraintOpEqual {
+			// equality is the default operator
+			l.Operator = ``
+		}
+		c.Constraints = append(c.Constraints, l)
+	}
+	return c, nil
+}
+
+// exportBucket converts a bucket tree for the layout
+func exportBucket(b *proto.Bucket) proto.LayoutBucket {
+	bucket := proto.LayoutBucket{
+		ID:          b.ID,
+		Name:        b.Name,
+		Environment: b.Environment,
+	}
+	for i := range *b.MemberGroups {
+		bucket.Groups = append(bucket.Groups,
+			exportGroup(&(*b.MemberGroups)[i]))
+	}
+	for i := range *b.MemberClusters {
+		bucket.Clusters = append(bucket.Clusters,
+			exportCluster(&(*b.MemberClusters)[i]))
+	}
+	for i := range *b.MemberNodes {
+		bucket.Nodes = append(bucket.Nodes,
+			exportNode(&(*b.MemberNodes)[i]))
+	}
+	return bucket
+}
+
+// exportGroup converts a group tree for the layout
+func exportGroup(g *proto.Group) proto.LayoutGroup {
+	group := proto.LayoutGroup{
+		ID:   g.ID,
+		Name: g.Name,
+	}
+	for i := range *g.MemberGroups {
+		group.Groups = append(group.Groups,
+			exportGroup(&(*g.MemberGroups)[i]))
+	}
+	for i := range *g.MemberClusters {
+		group.Clusters = append(group.Clusters,
+			exportCluster(&(*g.MemberClusters)[i]))
+	}
+	for i := range *g.MemberNodes {
+		group.Nodes = append(group.Nodes,
+			exportNode(&(*g.MemberNodes)[i]))
+	}
+	return group
+}
+
+// exportCluster converts a cluster tree for the layout
+func exportCluster(c *proto.Cluster) proto.LayoutCluster {
+	cluster := proto.LayoutCluster{
+		ID:   c.ID,
+		Name: c.Name,
+	}
+	for i := range *c.Members {
+		cluster.Nodes = append(cluster.Nodes,
+			exportNode(&(*c.Members)[i]))
+	}
+	return cluster
+}
+
+// exportNode converts a node for the layout
+func exportNode(n *proto.Node) proto.LayoutNode {
+	return proto.LayoutNode{
+		ID:   n.ID,
+		Name: n.Name,
+	}
+}
+
+// indexBucket adds the bucket and all objects below it to objects
+func indexBucket(objects map[string]layoutObject, b *proto.LayoutBucket) {
+	objects[b.ID] = layoutObject{
+		name:       b.Name,
+		properties: &b.Properties,
+	}
+	for i := range b.Groups {
+		indexGroup(objects, &b.Groups[i], b.Name)
+	}
+	for i := range b.Clusters {
+		indexCluster(objects, &b.Clusters[i], b.Name)
+	}
+	for i := range b.Nodes {
+		indexNode(objects, &b.Nodes[i], b.Name)
+	}
+}
+
+// indexGroup adds the group and all objects below it to objects
+func indexGroup(objects map[string]layoutObject, g *proto.LayoutGroup,
+	bucket string) {
+	objects[g.ID] = layoutObject{
+		name:       g.Name,
+		bucket:     bucket,
+		properties: &g.Properties,
+	}
+	for i := range g.Groups {
+		indexGroup(objects, &g.Groups[i], bucket)
+	}
+	for i := range g.Clusters {
+		indexCluster(objects, &g.Clusters[i], bucket)
+	}
+	for i := range g.Nodes {
+		indexNode(objects, &g.Nodes[i], bucket)
+	}
+}
+
+// indexCluster adds the cluster and its member nodes to objects
+func indexCluster(objects map[string]layoutObject,
+	c *proto.LayoutCluster, bucket string) {
+	objects[c.ID] = layoutObject{
+		name:       c.Name,
+		bucket:     bucket,
+		properties: &c.Properties,
+	}
+	for i := range c.Nodes {
+		indexNode(objects, &c.Nodes[i], bucket)
+	}
+}
+
+// indexNode adds the node to objects
+func indexNode(objects map[string]layoutObject, n *proto.LayoutNode,
+	bucket string) {
+	objects[n.ID] = layoutObject{
+		name:       n.Name,
+		bucket:     bucket,
+		properties: &n.Properties,
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -22,8 +22,8 @@ import (
 	"github.com/mjolnir42/soma/internal/handler"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
-	"github.com/mjolnir42/soma/internal/super"
 	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
 	metrics "github.com/rcrowley/go-metrics"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
@@ -52,6 +52,12 @@ type TreeKeeper struct {
 	stmtClusterOncall   *sql.Stmt
 	stmtClusterService  *sql.Stmt
 	stmtClusterSysProp  *sql.Stmt
+	stmtConstrAttribute *sql.Stmt
+	stmtConstrCustom    *sql.Stmt
+	stmtConstrNative    *sql.Stmt
+	stmtConstrOncall    *sql.Stmt
+	stmtConstrService   *sql.Stmt
+	stmtConstrSystem    *sql.Stmt
 	stmtDefaultDC       *sql.Stmt
 	stmtDelDuplicate    *sql.Stmt
 	stmtGetComputed     *sql.Stmt
@@ -71,6 +77,7 @@ type TreeKeeper struct {
 	stmtTeam            *sql.Stmt
 	stmtThreshold       *sql.Stmt
 	stmtUpdate          *sql.Stmt
+	stmtTransition      *sql.Stmt
 	appLog              *logrus.Logger
 	treeLog             *logrus.Logger
 	startLog            *logrus.Logger
@@ -149,6 +156,12 @@ func (tk *TreeKeeper) Run() {
 	// prepare statements early, some are used in tk.startupLoad()
 	var err error
 	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.CheckConfigShowConstrAttribute:            &tk.stmtConstrAttribute,
+		stmt.CheckConfigShowConstrCustom:               &tk.stmtConstrCustom,
+		stmt.CheckConfigShowConstrNative:               &tk.stmtConstrNative,
+		stmt.CheckConfigShowConstrOncall:               &tk.stmtConstrOncall,
+		stmt.CheckConfigShowConstrService:              &tk.stmtConstrService,
+		stmt.CheckConfigShowConstrSystem:               &tk.stmtConstrSystem,
 		stmt.TreekeeperDeleteDuplicateDetails:          &tk.stmtDelDuplicate,
 		stmt.TxDeployDetailClusterCustProp:             &tk.stmtClusterCustProp,
 		stmt.TxDeployDetailClusterSysProp:              &tk.stmtClusterSysProp,
@@ -179,6 +192,7 @@ func (tk *TreeKeeper) Run() {
 		stmt.TreekeeperGetPreviousDeployment:           &tk.stmtGetPrevious,
 		stmt.TreekeeperGetViewFromCapability:           &tk.stmtGetView,
 		stmt.TreekeeperStartJob:                        &tk.stmtStartJob,
+		stmt.WorkflowTransition:                        &tk.stmtTransition,
 	} {
 		if *prepStmt, err = tk.conn.Prepare(statement); err != nil {
 			tk.treeLog.Println("Error preparing SQL statement: ", err)
@@ -271,6 +285,8 @@ broken:
 			case <-tk.Stop:
 				tk.stop()
 				goto stopsign
+			case req := <-tk.Input:
+				tk.rejectQueued(&req, `broken`)
 			}
 		}
 		return
@@ -303,7 +319,8 @@ stopsign:
 		// the handlerMap)
 	drain:
 		for i := len(tk.Input); i > 0; i-- {
-			<-tk.Input
+			req := <-tk.Input
+			tk.rejectQueued(&req, `stopped`)
 		}
 		if len(tk.Input) > 0 {
 			// there were blocked writers on a full buffered channel
@@ -328,6 +345,18 @@ runloop:
 			tk.stop()
 			goto stopsign
 		case req := <-tk.Input:
+			if req.Section == msg.SectionCheckConfig &&
+				req.Action == msg.ActionExplain {
+				// explaining a check only reads the tree
+				tk.explain(&req)
+				continue runloop
+			}
+			if req.Flag.DryRun {
+				// dry runs do not modify the tree, there is no
+				// job to unblock and nothing to deploy
+				tk.plan(&req)
+				continue runloop
+			}
 			tk.process(&req)
 			tk.soma.handlerMap.Get(`job_block`).(*JobBlock).Notify <- req.JobID.String()
 			if !tk.status.isFrozen {
@@ -368,10 +397,45 @@ func (tk *TreeKeeper) isStopped() bool {
 	return tk.status.isStopped
 }
 
+// rejectQueued answers request q that was taken from the input
+// queue of a stopped or broken tree without being processed. Dry
+// runs and explains have no saved job and the client is waiting for
+// the reply. Jobs remain saved and are loaded again when the
+// repository is restarted, but clients waiting for the job are
+// released with a failed job event.
+func (tk *TreeKeeper) rejectQueued(q *msg.Request, state string) {
+	var kind string
+	switch {
+	case q.Flag.DryRun:
+		kind = `dry run`
+	case q.Section == msg.SectionCheckConfig &&
+		q.Action == msg.ActionExplain:
+		kind = `explain`
+	default:
+		ev := newJobEvent(q.JobID.String(), proto.JobEventFinished)
+		ev.Result = `failed`
+		ev.Errors = []string{fmt.Sprintf(
+			"Repository %s is %s, job postponed until restart",
+			tk.meta.repoName, state,
+		)}
+		tk.publishJobEvent(ev)
+		tk.soma.handlerMap.Get(`job_block`).(*JobBlock).Notify <- q.JobID.String()
+		return
+	}
+	result := msg.FromRequest(q)
+	result.ServerError(fmt.Errorf(
+		"Repository %s is %s, %s canceled",
+		tk.meta.repoName, state, kind,
+	), q.Section)
+	q.Reply <- result
+}
+
 func (tk *TreeKeeper) process(q *msg.Request) {
 	var (
 		err                                   error
 		hasErrors, hasJobLog, jobNeverStarted bool
+		jobErrors                             []string
+		ev                                    proto.JobEvent
 		tx                                    *sql.Tx
 		stm                                   map[string]*sql.Stmt
 		jobLog                                *logrus.Logger
@@ -384,7 +448,7 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 
 	// check if the user is still permitted to issue the asynchronous
 	// request at execution time
-	if !super.IsAuthorized(q) {
+	if !isAuthorizedJob(q) {
 		// open multi-statement transaction so we can close the job
 		// and mark it as failed inside the database, otherwise it would
 		// be loaded and attempted at every startup
@@ -408,6 +472,7 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 			goto bailout
 		}
 		tk.appLog.Printf("Processing job: %s", q.JobID.String())
+		tk.publishJobEvent(newJobEvent(q.JobID.String(), proto.JobEventStarted))
 	} else {
 		tk.appLog.Printf("Processing rebuild job: %s", q.JobID.String())
 	}
@@ -426,8 +491,11 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 	}
 	defer lfh.Close()
 	defer lfh.Sync()
+	// job logs are written as JSON lines, so that the recorded tree
+	// actions can be read back for the repository audit
 	jobLog = logrus.New()
 	jobLog.Out = lfh
+	jobLog.Formatter = &logrus.JSONFormatter{}
 	hasJobLog = true
 
 	// open multi-statement transaction
@@ -441,61 +509,7 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 
 	tk.tree.Begin()
 
-	// q.Action == `rebuild` will fall through switch
-	switch {
-	// property requests
-	case q.Action == msg.ActionPropertyCreate:
-		tk.addProperty(q)
-	case q.Action == msg.ActionPropertyDestroy:
-		tk.rmProperty(q)
-	case q.Action == msg.ActionPropertyUpdate:
-		tk.updateProperty(q)
-	// check requests
-	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionCreate:
-		err = tk.addCheck(&q.CheckConfig)
-	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionDestroy:
-		err = tk.rmCheck(&q.CheckConfig)
-	// tree object: membership requests
-	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityNode:
-		tk.treeNode(q)
-	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityNode:
-		tk.treeNode(q)
-	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityCluster:
-		tk.treeCluster(q)
-	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityCluster:
-		tk.treeCluster(q)
-	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityGroup:
-		tk.treeGroup(q)
-	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityGroup:
-		tk.treeGroup(q)
-	// tree object: create/destroy requests
-	case q.Section == msg.SectionNodeConfig && q.Action == msg.ActionAssign:
-		tk.treeNode(q)
-	case q.Section == msg.SectionNodeConfig && q.Action == msg.ActionUnassign:
-		tk.treeNode(q)
-	case q.Section == msg.SectionCluster && q.Action == msg.ActionCreate:
-		tk.treeCluster(q)
-	case q.Section == msg.SectionCluster && q.Action == msg.ActionDestroy:
-		tk.treeCluster(q)
-	case q.Section == msg.SectionGroup && q.Action == msg.ActionCreate:
-		tk.treeGroup(q)
-	case q.Section == msg.SectionGroup && q.Action == msg.ActionDestroy:
-		tk.treeGroup(q)
-	case q.Section == msg.SectionBucket && q.Action == msg.ActionCreate:
-		tk.treeBucket(q)
-	case q.Section == msg.SectionBucket && q.Action == msg.ActionDestroy:
-		tk.treeBucket(q)
-	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
-		tk.treeRepository(q)
-	// tree object: rename requests
-	case q.Section == msg.SectionBucket && q.Action == msg.ActionRename:
-		tk.treeBucket(q)
-	case q.Section == msg.SectionRepository && q.Action == msg.ActionRename:
-		tk.treeRepository(q)
-	// tree object: repossession requests
-	case q.Section == msg.SectionRepository && q.Action == msg.ActionRepossess:
-		tk.treeRepository(q)
-	}
+	err = tk.applyRequest(q)
 
 	// check if we accumulated an error in one of the switch cases
 	if err != nil {
@@ -516,33 +530,9 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 		goto bailout
 	}
 
-	switch {
-	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionCreate:
-		// save the check configuration as part of the transaction before
-		// processing the action channel
-		if err = tk.txCheckConfig(
-			q.CheckConfig,
-			stm,
-		); err != nil {
-			goto bailout
-		}
-	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionDestroy:
-		// mark the check configuration as deleted
-		if _, err = tx.Exec(
-			stmt.TxMarkCheckConfigDeleted,
-			q.CheckConfig.ID,
-		); err != nil {
-			goto bailout
-		}
-	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
-		// mark all check configurations deleted if the repository is
-		// being destroyed
-		if _, err = tx.Exec(
-			stmt.TxMarkAllCheckConfigDeletedForRepo,
-			q.Repository.ID,
-		); err != nil {
-			goto bailout
-		}
+	// persist request data that is not part of the action channel
+	if err = tk.txRequest(q, tx, stm); err != nil {
+		goto bailout
 	}
 
 	// if the error channel has entries, we can fully ignore the
@@ -554,6 +544,7 @@ func (tk *TreeKeeper) process(q *msg.Request) {
 			jobLog.Println(string(b))
 		}
 		hasErrors = true
+		jobErrors = append(jobErrors, e.Action)
 		if err == nil {
 			err = fmt.Errorf(e.Action)
 		}
@@ -571,6 +562,15 @@ actionloop:
 			b, _ := json.Marshal(a)
 			jobLog.Println(string(b))
 		}
+		// stream all actions to the clients watching the job
+		switch a.Type {
+		case `fault`, `errorchannel`:
+		default:
+			p, _ := describeAction(a)
+			ev = newJobEvent(q.JobID.String(), proto.JobEventAction)
+			ev.Action = &p
+			tk.publishJobEvent(ev)
+		}
 
 		// only check and check_instance actions are relevant during
 		// a rebuild, everything else is ignored. Even some deletes are
@@ -683,24 +683,12 @@ actionloop:
 	tk.tree.Commit()
 
 	// update permission cache
-	switch q.Section {
-	case msg.SectionRepository, msg.SectionRepositoryMgmt, msg.SectionBucket, msg.SectionGroup, msg.SectionCluster:
-		switch q.Action {
-		case msg.ActionCreate, msg.ActionDestroy:
-			go func() {
-				super := tk.soma.getSupervisor()
-				super.Update <- msg.CacheUpdateFromRequest(q)
-			}()
-		}
-	case msg.SectionNodeConfig:
-		switch q.Action {
-		case msg.ActionAssign, msg.ActionUnassign:
-			go func() {
-				super := tk.soma.getSupervisor()
-				super.Update <- msg.CacheUpdateFromRequest(q)
-			}()
-		}
-	}
+	tk.updatePermissionCache(q)
+
+	ev = newJobEvent(q.JobID.String(), proto.JobEventFinished)
+	ev.Result = `success`
+	tk.publishJobEvent(ev)
+
 	// shutdown if the successful job was a repository::destroy
 	switch {
 	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
@@ -734,6 +722,14 @@ unauthorized:
 		`failed`,
 		err.Error(),
 	)
+	// errors from the error channel are already part of the list
+	if !hasErrors {
+		jobErrors = append(jobErrors, err.Error())
+	}
+	ev = newJobEvent(q.JobID.String(), proto.JobEventFinished)
+	ev.Result = `failed`
+	ev.Errors = jobErrors
+	tk.publishJobEvent(ev)
 	for i := len(tk.actions); i > 0; i-- {
 		a := <-tk.actions
 		jB, _ := json.Marshal(a)
@@ -744,6 +740,17 @@ unauthorized:
 	return
 }
 
+// publishJobEvent passes a lifecycle event of the current job to the
+// job_stream handler. Rebuilds run as fake jobs and are not published.
+func (tk *TreeKeeper) publishJobEvent(ev proto.JobEvent) {
+	if tk.status.requiresRebuild {
+		return
+	}
+	if js, ok := tk.soma.handlerMap.Get(`job_stream`).(*JobStream); ok {
+		js.Publish(ev)
+	}
+}
+
 // ShutdownNow signals the handler to shut down
 func (tk *TreeKeeper) ShutdownNow() {
 	if !tk.isStopped() {
@@ -891,6 +898,14 @@ func (tk *TreeKeeper) startTx() (
 		`CreateCheckConfigurationConstraintCustom`:    stmt.TxCreateCheckConfigurationConstraintCustom,
 		`CreateCheckConfigurationConstraintService`:   stmt.TxCreateCheckConfigurationConstraintService,
 		`CreateCheckConfigurationConstraintAttribute`: stmt.TxCreateCheckConfigurationConstraintAttribute,
+		`UpdateCheckConfigurationBase`:                stmt.TxUpdateCheckConfigurationBase,
+		`DeleteCheckConfigurationThresholds`:          stmt.TxDeleteCheckConfigurationThresholds,
+		`DeleteCheckConfigurationConstraintSystem`:    stmt.TxDeleteCheckConfigurationConstraintSystem,
+		`DeleteCheckConfigurationConstraintNative`:    stmt.TxDeleteCheckConfigurationConstraintNative,
+		`DeleteCheckConfigurationConstraintOncall`:    stmt.TxDeleteCheckConfigurationConstraintOncall,
+		`DeleteCheckConfigurationConstraintCustom`:    stmt.TxDeleteCheckConfigurationConstraintCustom,
+		`DeleteCheckConfigurationConstraintService`:   stmt.TxDeleteCheckConfigurationConstraintService,
+		`DeleteCheckConfigurationConstraintAttribute`: stmt.TxDeleteCheckConfigurationConstraintAttribute,
 	} {
 		if stMap[name], err = tx.Prepare(statement); err != nil {
 			err = fmt.Errorf("tk.Prepare(%s) error: %s",
@@ -952,6 +967,157 @@ bailout:
 	return nil, nil, err
 }
 
+// applyRequest applies the changes requested by q to the tree. The
+// resulting actions and errors are emitted on the tree's action and
+// error channels.
+func (tk *TreeKeeper) applyRequest(q *msg.Request) error {
+	// q.Action == `rebuild` will fall through switch
+	switch {
+	// batch requests
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionBatch:
+		return tk.applyBatch(q)
+	// property requests
+	case q.Action == msg.ActionPropertyCreate:
+		tk.addProperty(q)
+	case q.Action == msg.ActionPropertyDestroy:
+		tk.rmProperty(q)
+	case q.Action == msg.ActionPropertyUpdate:
+		tk.updateProperty(q)
+	case q.Action == msg.ActionPropertyReplace:
+		tk.replaceProperty(q)
+	// check requests
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionCreate:
+		return tk.addCheck(&q.CheckConfig)
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionDestroy:
+		return tk.rmCheck(&q.CheckConfig)
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionUpdate:
+		return tk.updateCheck(&q.CheckConfig)
+	// tree object: membership requests
+	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityNode:
+		tk.treeNode(q)
+	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityNode:
+		tk.treeNode(q)
+	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityCluster:
+		tk.treeCluster(q)
+	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityCluster:
+		tk.treeCluster(q)
+	case q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityGroup:
+		tk.treeGroup(q)
+	case q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityGroup:
+		tk.treeGroup(q)
+	// tree object: create/destroy requests
+	case q.Section == msg.SectionNodeConfig && q.Action == msg.ActionAssign:
+		tk.treeNode(q)
+	case q.Section == msg.SectionNodeConfig && q.Action == msg.ActionUnassign:
+		tk.treeNode(q)
+	case q.Section == msg.SectionCluster && q.Action == msg.ActionCreate:
+		tk.treeCluster(q)
+	case q.Section == msg.SectionCluster && q.Action == msg.ActionDestroy:
+		tk.treeCluster(q)
+	case q.Section == msg.SectionGroup && q.Action == msg.ActionCreate:
+		tk.treeGroup(q)
+	case q.Section == msg.SectionGroup && q.Action == msg.ActionDestroy:
+		tk.treeGroup(q)
+	case q.Section == msg.SectionBucket && q.Action == msg.ActionCreate:
+		tk.treeBucket(q)
+	case q.Section == msg.SectionBucket && q.Action == msg.ActionDestroy:
+		tk.treeBucket(q)
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
+		tk.treeRepository(q)
+	// tree object: rename requests
+	case q.Section == msg.SectionBucket && q.Action == msg.ActionRename:
+		tk.treeBucket(q)
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionRename:
+		tk.treeRepository(q)
+	// tree object: repossession requests
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionRepossess:
+		tk.treeRepository(q)
+	}
+	return nil
+}
+
+// txRequest saves the data of request q inside transaction tx that
+// is not transported via the action channel
+func (tk *TreeKeeper) txRequest(q *msg.Request, tx *sql.Tx,
+	stm map[string]*sql.Stmt) error {
+	var err error
+
+	switch {
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionBatch:
+		for i := range q.Batch {
+			if err = tk.txRequest(&q.Batch[i], tx, stm); err != nil {
+				return err
+			}
+		}
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionCreate:
+		// save the check configuration as part of the transaction before
+		// processing the action channel
+		err = tk.txCheckConfig(
+			q.CheckConfig,
+			stm,
+		)
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionUpdate:
+		// replace the stored check configuration as part of the
+		// transaction before processing the action channel
+		err = tk.txCheckConfigUpdate(
+			q.CheckConfig,
+			stm,
+		)
+	case q.Section == msg.SectionCheckConfig && q.Action == msg.ActionDestroy:
+		// mark the check configuration as deleted
+		_, err = tx.Exec(
+			stmt.TxMarkCheckConfigDeleted,
+			q.CheckConfig.ID,
+		)
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
+		// mark all check configurations deleted if the repository is
+		// being destroyed
+		_, err = tx.Exec(
+			stmt.TxMarkAllCheckConfigDeletedForRepo,
+			q.Repository.ID,
+		)
+	}
+	return err
+}
+
+// updatePermissionCache forwards the changes of the finished request
+// q to the permission cache of the supervisor
+func (tk *TreeKeeper) updatePermissionCache(q *msg.Request) {
+	if q.Section == msg.SectionRepository && q.Action == msg.ActionBatch {
+		for i := range q.Batch {
+			tk.updatePermissionCache(&q.Batch[i])
+		}
+		return
+	}
+
+	switch q.Section {
+	case msg.SectionRepository, msg.SectionRepositoryMgmt, msg.SectionBucket, msg.SectionGroup, msg.SectionCluster:
+		switch q.Action {
+		case msg.ActionCreate, msg.ActionDestroy:
+			go func() {
+				super := tk.soma.getSupervisor()
+				super.Update <- msg.CacheUpdateFromRequest(q)
+			}()
+		}
+	case msg.SectionNodeConfig:
+		switch q.Action {
+		case msg.ActionAssign, msg.ActionUnassign:
+			go func() {
+				super := tk.soma.getSupervisor()
+				super.Update <- msg.CacheUpdateFromRequest(q)
+			}()
+		}
+	}
+	switch {
+	// unassigning a node from its bucket removes it from the repository
+	case q.Section == msg.SectionBucket && q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityNode:
+		go func() {
+			super := tk.soma.getSupervisor()
+			super.Update <- msg.CacheUpdateFromRequest(q)
+		}()
+	}
+}
+
 // panicGuard besides protecting the server process, the main job of
 // this function is to cancel jobs that cause a server PANIC
 func panicGuard(tk *TreeKeeper, tx *sql.Tx, q *msg.Request) {
@@ -970,19 +1136,36 @@ func panicGuard(tk *TreeKeeper, tx *sql.Tx, q *msg.Request) {
 		)
 		tk.treeLog.Printf("PANIC error: %s", r)
 		tk.treeLog.Printf("PANIC stacktrace: %s", debug.Stack())
-		tx.Rollback()
-		_, err := tk.conn.Exec(
-			stmt.TxFinishJob,
-			q.JobID.String(),
-			time.Now().UTC(),
-			`failed`,
-			`job canceled by panicGuard`,
-		)
-		if err != nil {
-			tk.appLog.Printf("panicGuard job(%s) cancelation error: %s",
+		if q.Flag.DryRun || q.Action == msg.ActionExplain {
+			// dry runs and explains have neither a job nor a
+			// transaction, but the client is still waiting for the
+			// reply
+			reason := `dry run canceled by panicGuard`
+			if !q.Flag.DryRun {
+				reason = `explain canceled by panicGuard`
+			}
+			result := msg.FromRequest(q)
+			result.ServerError(fmt.Errorf(reason), q.Section)
+			q.Reply <- result
+		} else {
+			tx.Rollback()
+			_, err := tk.conn.Exec(
+				stmt.TxFinishJob,
 				q.JobID.String(),
-				err.Error(),
+				time.Now().UTC(),
+				`failed`,
+				`job canceled by panicGuard`,
 			)
+			if err != nil {
+				tk.appLog.Printf("panicGuard job(%s) cancelation error: %s",
+					q.JobID.String(),
+					err.Error(),
+				)
+			}
+			ev := newJobEvent(q.JobID.String(), proto.JobEventFinished)
+			ev.Result = `failed`
+			ev.Errors = []string{`job canceled by panicGuard`}
+			tk.publishJobEvent(ev)
 		}
 		go func() {
 			tk.stop()
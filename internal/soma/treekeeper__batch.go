@@ -0,0 +1,65 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/super"
+)
+
+// applyBatch applies the requests contained in batch request q to
+// the tree in the order they were submitted. Since all requests are
+// processed as one job, the first failing request aborts the batch.
+func (tk *TreeKeeper) applyBatch(q *msg.Request) error {
+	return applyBatchWith(q, tk.applyRequest)
+}
+
+// applyBatchWith calls apply for every request contained in batch
+// request q in order, until the first error
+func applyBatchWith(q *msg.Request, apply func(*msg.Request) error) error {
+	for i := range q.Batch {
+		if err := apply(&q.Batch[i]); err != nil {
+			return fmt.Errorf("Batch operation %d (%s::%s): %s",
+				i,
+				q.Batch[i].Section,
+				q.Batch[i].Action,
+				err.Error(),
+			)
+		}
+	}
+	return nil
+}
+
+// isAuthorizedJob checks if the user is still permitted to issue the
+// request q. Batch requests are authorized via the requests they
+// contain.
+func isAuthorizedJob(q *msg.Request) bool {
+	return isAuthorizedJobWith(q, super.IsAuthorized)
+}
+
+// isAuthorizedJobWith checks request q using authorize, which is
+// called for every request contained in a batch request
+func isAuthorizedJobWith(q *msg.Request,
+	authorize func(*msg.Request) bool) bool {
+	if q.Section != msg.SectionRepository || q.Action != msg.ActionBatch {
+		return authorize(q)
+	}
+	if len(q.Batch) == 0 {
+		return false
+	}
+	for i := range q.Batch {
+		if !authorize(&q.Batch[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
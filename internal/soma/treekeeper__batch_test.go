@@ -0,0 +1,120 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+)
+
+// testBatch returns a batch request with one property request per
+// bucket ID
+func testBatch(bucketIDs ...string) *msg.Request {
+	q := &msg.Request{
+		Section: msg.SectionRepository,
+		Action:  msg.ActionBatch,
+		Batch:   []msg.Request{},
+	}
+	for _, id := range bucketIDs {
+		sub := msg.Request{
+			Section: msg.SectionBucket,
+			Action:  msg.ActionPropertyCreate,
+		}
+		sub.Bucket.ID = id
+		q.Batch = append(q.Batch, sub)
+	}
+	return q
+}
+
+func TestApplyBatchWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		batch   []string
+		fail    string
+		applied string
+		err     string
+	}{
+		{`all applied in order`, []string{`a`, `b`, `c`}, ``, `a,b,c`, ``},
+		{`stop at first failure`, []string{`a`, `b`, `c`}, `b`, `a,b`,
+			`Batch operation 1 (bucket::property-create)`},
+		{`fail first`, []string{`a`, `b`}, `a`, `a`,
+			`Batch operation 0 (bucket::property-create)`},
+	}
+
+	for _, test := range tests {
+		applied := []string{}
+		err := applyBatchWith(testBatch(test.batch...),
+			func(q *msg.Request) error {
+				applied = append(applied, q.Bucket.ID)
+				if q.Bucket.ID == test.fail {
+					return fmt.Errorf(`failed`)
+				}
+				return nil
+			})
+
+		if got := strings.Join(applied, `,`); got != test.applied {
+			t.Errorf("%s: expected applied %s, got %s", test.name,
+				test.applied, got)
+		}
+		switch {
+		case test.err == `` && err != nil:
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		case test.err != `` && err == nil:
+			t.Errorf("%s: expected error", test.name)
+		case err != nil && !strings.HasPrefix(err.Error(), test.err):
+			t.Errorf("%s: expected error %s, got %s", test.name,
+				test.err, err)
+		}
+	}
+}
+
+func TestIsAuthorizedJobWith(t *testing.T) {
+	single := &msg.Request{
+		Section: msg.SectionBucket,
+		Action:  msg.ActionPropertyCreate,
+	}
+	single.Bucket.ID = `a`
+
+	tests := []struct {
+		name    string
+		q       *msg.Request
+		denied  string
+		ok      bool
+		checked string
+	}{
+		{`single permitted`, single, ``, true, `a`},
+		{`single denied`, single, `a`, false, `a`},
+		{`batch permitted`, testBatch(`a`, `b`, `c`), ``, true, `a,b,c`},
+		{`batch one denied`, testBatch(`a`, `b`, `c`), `b`, false, `a,b`},
+		{`empty batch`, testBatch(), ``, false, ``},
+	}
+
+	for _, test := range tests {
+		checked := []string{}
+		ok := isAuthorizedJobWith(test.q, func(q *msg.Request) bool {
+			if q.Section == msg.SectionRepository {
+				t.Errorf("%s: batch request was authorized itself",
+					test.name)
+			}
+			checked = append(checked, q.Bucket.ID)
+			return q.Bucket.ID != test.denied
+		})
+		if ok != test.ok {
+			t.Errorf("%s: expected %t, got %t", test.name, test.ok, ok)
+		}
+		if got := strings.Join(checked, `,`); got != test.checked {
+			t.Errorf("%s: expected checked %s, got %s", test.name,
+				test.checked, got)
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/lib/proto"
 )
 
@@ -16,6 +17,8 @@ func (tk *TreeKeeper) buildDeploymentDetails() {
 		rows, thresh, pkgs, gSysProps, cSysProps, nSysProps *sql.Rows
 		gCustProps, cCustProps, nCustProps                  *sql.Rows
 		callback                                            sql.NullString
+		constraintValues                                    string
+		tx                                                  *sql.Tx
 	)
 
 	// TODO:
@@ -28,6 +31,15 @@ func (tk *TreeKeeper) buildDeploymentDetails() {
 	}
 	defer rows.Close()
 
+	constraintStmts := map[string]*sql.Stmt{
+		msg.ConstraintCustom:    tk.stmtConstrCustom,
+		msg.ConstraintSystem:    tk.stmtConstrSystem,
+		msg.ConstraintNative:    tk.stmtConstrNative,
+		msg.ConstraintService:   tk.stmtConstrService,
+		msg.ConstraintAttribute: tk.stmtConstrAttribute,
+		msg.ConstraintOncall:    tk.stmtConstrOncall,
+	}
+
 deploymentbuilder:
 	for rows.Next() {
 		detail := proto.Deployment{}
@@ -44,7 +56,7 @@ deploymentbuilder:
 		detail.CheckInstance = &proto.CheckInstance{
 			InstanceConfigID: instanceCfgID,
 		}
-		tk.stmtCheckInstance.QueryRow(instanceCfgID).Scan(
+		if err = tk.stmtCheckInstance.QueryRow(instanceCfgID).Scan(
 			&detail.CheckInstance.Version,
 			&detail.CheckInstance.InstanceID,
 			&detail.CheckInstance.ConstraintHash,
@@ -52,9 +64,22 @@ deploymentbuilder:
 			&detail.CheckInstance.InstanceService,
 			&detail.CheckInstance.InstanceSvcCfgHash,
 			&detail.CheckInstance.InstanceServiceConfig,
+			&constraintValues,
 			&detail.CheckInstance.CheckID,
 			&detail.CheckInstance.ConfigID,
-		)
+		); err != nil {
+			tk.treeLog.Println(`tk.stmtCheckInstance.QueryRow().Scan():`, err)
+			break deploymentbuilder
+		}
+		// add the property values the instance matched against the
+		// constraints of its check configuration
+		matched := proto.CheckInstance{}
+		if err = json.Unmarshal([]byte(constraintValues), &matched); err != nil {
+			tk.treeLog.Println(`Failed to JSON unmarshal constraint values:`,
+				err)
+			break deploymentbuilder
+		}
+		detail.CheckInstance.MergeConstraintValues(&matched)
 
 		//
 		detail.Check = &proto.Check{
@@ -127,9 +152,14 @@ deploymentbuilder:
 			detail.CheckConfig.Thresholds = append(detail.CheckConfig.Thresholds, thr)
 		}
 
-		// XXX TODO
-		//detail.CheckConfiguration.Constraints = []somaproto.CheckConfigurationConstraint{}
-		detail.CheckConfig.Constraints = nil
+		//
+		if err = loadConstraints(
+			detail.CheckConfig,
+			constraintStmts,
+		); err != nil {
+			tk.treeLog.Println(`loadConstraints():`, err)
+			break deploymentbuilder
+		}
 
 		//
 		detail.Capability = &proto.Capability{
@@ -510,13 +540,36 @@ deploymentbuilder:
 				detail.CheckInstance.InstanceConfigID, err)
 			break deploymentbuilder
 		}
-		if _, err = tk.stmtUpdate.Exec(
+		if tx, err = tk.conn.Begin(); err != nil {
+			tk.treeLog.Println("TreeKeeper/Build sql.Begin: ", err)
+			break deploymentbuilder
+		}
+		if _, err = tx.Stmt(tk.stmtUpdate).Exec(
 			detailJSON,
 			detail.Monitoring.ID,
 			detail.CheckInstance.InstanceConfigID,
 		); err != nil {
 			tk.treeLog.Println(`Failed to save DeploymentDetails.JSON:`,
 				detail.CheckInstance.InstanceConfigID, err)
+			tx.Rollback()
+			break deploymentbuilder
+		}
+		if err = transition(
+			tx.Stmt(tk.stmtTransition),
+			detail.CheckInstance.InstanceConfigID,
+			proto.DeploymentAwaitingComputation,
+			proto.DeploymentComputed,
+			actorTreeKeeper,
+		); err != nil {
+			tk.treeLog.Println(`Failed to update deployment state:`,
+				detail.CheckInstance.InstanceConfigID, err)
+			tx.Rollback()
+			break deploymentbuilder
+		}
+		if err = tx.Commit(); err != nil {
+			tk.treeLog.Println(`Failed to save DeploymentDetails.JSON:`,
+				detail.CheckInstance.InstanceConfigID, err)
+			tx.Rollback()
 			break deploymentbuilder
 		}
 	}
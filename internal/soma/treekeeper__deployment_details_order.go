@@ -82,7 +82,6 @@ deployments:
 
 			// prepare statements within transaction
 			for name, statement := range map[string]string{
-				`UpdateStatus`:   stmt.TreekeeperUpdateConfigStatus,
 				`UpdateInstance`: stmt.TreekeeperUpdateCheckInstance,
 			} {
 				if txMap[name], err = tx.Prepare(statement); err != nil {
@@ -92,11 +91,12 @@ deployments:
 				}
 			}
 
-			//
-			if _, err = txMap[`UpdateStatus`].Exec(
-				proto.DeploymentAwaitingRollout,
-				proto.DeploymentRolloutInProgress,
+			if err = transition(
+				tx.Stmt(tk.stmtTransition),
 				currentChkInstanceConfigID,
+				proto.DeploymentComputed,
+				proto.DeploymentAwaitingRollout,
+				actorTreeKeeper,
 			); err != nil {
 				goto bailout_noprev
 			}
@@ -160,7 +160,6 @@ deployments:
 
 		// prepare statements within transaction
 		for name, statement := range map[string]string{
-			`UpdateStatus`:   stmt.TreekeeperUpdateConfigStatus,
 			`UpdateExisting`: stmt.TreekeeperUpdateExistingCheckInstance,
 			`SetDependency`:  stmt.TreekeeperSetDependency,
 		} {
@@ -171,10 +170,12 @@ deployments:
 			}
 		}
 
-		if _, err = txMap[`UpdateStatus`].Exec(
-			proto.DeploymentBlocked,
-			proto.DeploymentAwaitingRollout,
+		if err = transition(
+			tx.Stmt(tk.stmtTransition),
 			currentChkInstanceConfigID,
+			proto.DeploymentComputed,
+			proto.DeploymentBlocked,
+			actorTreeKeeper,
 		); err != nil {
 			goto bailout_withprev
 		}
@@ -0,0 +1,52 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/tree"
+)
+
+// explain evaluates the check configuration of the request against
+// the object q.CheckConfig.ObjectID of the tree. The tree is not
+// modified.
+func (tk *TreeKeeper) explain(q *msg.Request) {
+	result := msg.FromRequest(q)
+	tk.treeLog.Infof("Processing explain for RequestID %s",
+		q.ID.String(),
+	)
+
+	// protect the explain via panicGuard, there is no transaction
+	defer panicGuard(tk, nil, q)
+
+	obj := tk.tree.Find(tree.FindRequest{
+		ElementType: q.CheckConfig.ObjectType,
+		ElementID:   q.CheckConfig.ObjectID,
+	}, true)
+
+	explainer, ok := obj.(tree.Explainer)
+	if !ok || obj.(tree.Builder).GetType() != q.CheckConfig.ObjectType {
+		result.NotFound(fmt.Errorf(
+			"No %s with ID %s in repository %s",
+			q.CheckConfig.ObjectType,
+			q.CheckConfig.ObjectID,
+			tk.meta.repoName,
+		), q.Section)
+		q.Reply <- result
+		return
+	}
+
+	result.CheckExplanation = append(result.CheckExplanation,
+		explainer.ExplainCheck(q.CheckConfig.ID))
+	result.OK()
+	q.Reply <- result
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -10,6 +10,7 @@ package soma
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -50,6 +51,50 @@ func (tk *TreeKeeper) txCheckConfig(conf proto.CheckConfig,
 	); err != nil {
 		return err
 	}
+	return tk.txCheckConfigDetails(conf, stm)
+}
+
+func (tk *TreeKeeper) txCheckConfigUpdate(conf proto.CheckConfig,
+	stm map[string]*sql.Stmt) error {
+	var (
+		res sql.Result
+		err error
+	)
+	if res, err = stm[`UpdateCheckConfigurationBase`].Exec(
+		conf.ID,
+		int64(conf.Interval),
+		conf.Inheritance,
+		conf.ChildrenOnly,
+	); err != nil {
+		return err
+	}
+	if rowCnt, _ := res.RowsAffected(); rowCnt != 1 {
+		return fmt.Errorf("Check configuration %s: %d rows affected by update",
+			conf.ID, rowCnt)
+	}
+
+	// thresholds and constraints are replaced with the updated set
+	for _, name := range []string{
+		`DeleteCheckConfigurationThresholds`,
+		`DeleteCheckConfigurationConstraintSystem`,
+		`DeleteCheckConfigurationConstraintNative`,
+		`DeleteCheckConfigurationConstraintOncall`,
+		`DeleteCheckConfigurationConstraintCustom`,
+		`DeleteCheckConfigurationConstraintService`,
+		`DeleteCheckConfigurationConstraintAttribute`,
+	} {
+		if _, err = stm[name].Exec(
+			conf.ID,
+		); err != nil {
+			return err
+		}
+	}
+	return tk.txCheckConfigDetails(conf, stm)
+}
+
+func (tk *TreeKeeper) txCheckConfigDetails(conf proto.CheckConfig,
+	stm map[string]*sql.Stmt) error {
+	var err error
 
 threshloop:
 	for _, thr := range conf.Thresholds {
@@ -74,6 +119,8 @@ constrloop:
 				conf.ID,
 				constr.Native.Name,
 				constr.Native.Value,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -81,6 +128,8 @@ constrloop:
 			if _, err = stm[`CreateCheckConfigurationConstraintOncall`].Exec(
 				conf.ID,
 				constr.Oncall.ID,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -90,6 +139,8 @@ constrloop:
 				constr.Custom.ID,
 				constr.Custom.RepositoryID,
 				constr.Custom.Value,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -98,6 +149,8 @@ constrloop:
 				conf.ID,
 				constr.System.Name,
 				constr.System.Value,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -113,6 +166,8 @@ constrloop:
 				tk.meta.teamID,
 				constr.Service.Name,
 				constr.Service.ID,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -121,6 +176,8 @@ constrloop:
 				conf.ID,
 				constr.Attribute.Name,
 				constr.Attribute.Value,
+				constr.GetOperator(),
+				constr.Group,
 			); err != nil {
 				break constrloop
 			}
@@ -223,8 +280,16 @@ func (tk *TreeKeeper) txCheckInstanceCreate(a *tree.Action,
 
 func (tk *TreeKeeper) txCheckInstanceConfigCreate(a *tree.Action,
 	stm map[string]*sql.Stmt) error {
+	// the matched constraint values are stored as a partial
+	// CheckInstance that is merged into the deployment details
+	constraints, err := json.Marshal(
+		a.CheckInstance.ConstraintValues())
+	if err != nil {
+		return err
+	}
+
 	statement := stm[`CreateCheckInstanceConfiguration`]
-	_, err := statement.Exec(
+	_, err = statement.Exec(
 		a.CheckInstance.InstanceConfigID,
 		a.CheckInstance.Version,
 		a.CheckInstance.InstanceID,
@@ -238,6 +303,7 @@ func (tk *TreeKeeper) txCheckInstanceConfigCreate(a *tree.Action,
 		proto.DeploymentNone,
 		false,
 		`{}`,
+		string(constraints),
 	)
 	return err
 }
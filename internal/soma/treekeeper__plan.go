@@ -0,0 +1,257 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// planMonitoring is the monitoring system a check configuration is
+// deployed to
+type planMonitoring struct {
+	capabilityID   string
+	monitoringID   string
+	monitoringName string
+}
+
+// plan processes a dry run request. The request is applied to the
+// tree, but instead of persisting the resulting actions they are
+// returned to the client and the tree changes are rolled back.
+func (tk *TreeKeeper) plan(q *msg.Request) {
+	var (
+		err     error
+		a       *tree.Action
+		e       *tree.Error
+		monitor map[string]planMonitoring
+	)
+	result := msg.FromRequest(q)
+	plan := []proto.PlanAction{}
+	monitor = make(map[string]planMonitoring)
+	tk.treeLog.Infof("Processing dry run for RequestID %s",
+		q.ID.String(),
+	)
+
+	// protect the dry run via panicGuard, there is no transaction
+	defer panicGuard(tk, nil, q)
+
+	tk.tree.Begin()
+
+	err = tk.applyRequest(q)
+
+	// recalculate check instances, unless the request destroys the
+	// repository
+	switch {
+	case err != nil:
+	case q.Section == msg.SectionRepository && q.Action == msg.ActionDestroy:
+	default:
+		tk.tree.ComputeCheckInstances()
+	}
+
+	for i := len(tk.errors); i > 0; i-- {
+		e = <-tk.errors
+		if err == nil {
+			err = fmt.Errorf(e.Action)
+		}
+	}
+
+actionloop:
+	for i := len(tk.actions); i > 0; i-- {
+		a = <-tk.actions
+		if err != nil {
+			// drain the action channel
+			continue actionloop
+		}
+
+		switch a.Type {
+		case `fault`, `errorchannel`:
+			continue actionloop
+		}
+
+		var p proto.PlanAction
+		if p, err = tk.planAction(q, a, monitor); err != nil {
+			continue actionloop
+		}
+		plan = append(plan, p)
+	}
+
+	// discard all tree changes
+	tk.tree.Rollback()
+
+	if err != nil {
+		tk.treeLog.Printf("Failed dry run for RequestID %s: %s",
+			q.ID.String(),
+			err.Error(),
+		)
+		result.ServerError(err, q.Section)
+		q.Reply <- result
+		return
+	}
+	result.Plan = plan
+	result.OK()
+	q.Reply <- result
+}
+
+// planAction converts a tree action into a PlanAction
+func (tk *TreeKeeper) planAction(q *msg.Request, a *tree.Action,
+	monitor map[string]planMonitoring) (proto.PlanAction, error) {
+	var err error
+
+	p, configID := describeAction(a)
+
+	if configID != `` {
+		var m planMonitoring
+		if m, err = tk.planMonitoring(q, configID, monitor); err != nil {
+			return p, err
+		}
+		p.CapabilityID = m.capabilityID
+		p.MonitoringID = m.monitoringID
+		p.MonitoringName = m.monitoringName
+	}
+	return p, nil
+}
+
+// describeAction converts a tree action into a PlanAction without
+// the monitoring system information. It also returns the check
+// configuration the action refers to, if any.
+func describeAction(a *tree.Action) (p proto.PlanAction, configID string) {
+	p = proto.PlanAction{
+		Action:     a.Action,
+		ObjectType: a.Type,
+		ChildType:  a.ChildType,
+	}
+
+	switch a.Type {
+	case `repository`:
+		p.ObjectID, p.ObjectName = a.Repository.ID, a.Repository.Name
+	case `bucket`:
+		p.ObjectID, p.ObjectName = a.Bucket.ID, a.Bucket.Name
+	case `group`:
+		p.ObjectID, p.ObjectName = a.Group.ID, a.Group.Name
+	case `cluster`:
+		p.ObjectID, p.ObjectName = a.Cluster.ID, a.Cluster.Name
+	case `node`:
+		p.ObjectID, p.ObjectName = a.Node.ID, a.Node.Name
+	}
+
+	switch a.ChildType {
+	case `group`:
+		p.ChildID, p.ChildName = a.ChildGroup.ID, a.ChildGroup.Name
+	case `cluster`:
+		p.ChildID, p.ChildName = a.ChildCluster.ID, a.ChildCluster.Name
+	case `node`:
+		p.ChildID, p.ChildName = a.ChildNode.ID, a.ChildNode.Name
+	}
+
+	switch a.Action {
+	case
+		tree.ActionPropertyDelete,
+		tree.ActionPropertyNew,
+		tree.ActionPropertyUpdate:
+		p.PropertyType = a.Property.Type
+		p.PropertyView = a.Property.View
+		switch {
+		case a.Property.Custom != nil:
+			p.PropertyName = a.Property.Custom.Name
+		case a.Property.System != nil:
+			p.PropertyName = a.Property.System.Name
+		case a.Property.Service != nil:
+			p.PropertyName = a.Property.Service.Name
+		case a.Property.Native != nil:
+			p.PropertyName = a.Property.Native.Name
+		case a.Property.Oncall != nil:
+			p.PropertyName = a.Property.Oncall.Name
+		}
+	case
+		tree.ActionCheckNew,
+		tree.ActionCheckRemoved:
+		p.CheckID = a.Check.CheckID
+		p.CapabilityID = a.Check.CapabilityID
+		configID = a.Check.CheckConfigID
+	case
+		tree.ActionCheckInstanceCreate,
+		tree.ActionCheckInstanceDelete,
+		tree.ActionCheckInstanceUpdate:
+		p.CheckID = a.CheckInstance.CheckID
+		p.CheckInstanceID = a.CheckInstance.InstanceID
+		configID = a.CheckInstance.ConfigID
+	}
+	return
+}
+
+// planMonitoring looks up the monitoring system of a check
+// configuration. Check configurations created by the dry run request
+// are not in the database yet, their capability is taken from the
+// request.
+func (tk *TreeKeeper) planMonitoring(q *msg.Request, configID string,
+	monitor map[string]planMonitoring) (planMonitoring, error) {
+	var (
+		err          error
+		m            planMonitoring
+		ok           bool
+		metric, view string
+		thresholds   int
+		capabilityID string
+	)
+	if m, ok = monitor[configID]; ok {
+		return m, nil
+	}
+
+	if err = tk.conn.QueryRow(
+		stmt.CheckConfigMonitoring,
+		configID,
+	).Scan(
+		&m.capabilityID,
+		&m.monitoringID,
+		&m.monitoringName,
+	); err == sql.ErrNoRows {
+		if capabilityID, ok = planCapability(q, configID); !ok {
+			return m, err
+		}
+		err = tk.conn.QueryRow(
+			stmt.ShowCapability,
+			capabilityID,
+		).Scan(
+			&m.capabilityID,
+			&m.monitoringID,
+			&metric,
+			&view,
+			&thresholds,
+			&m.monitoringName,
+		)
+	}
+	if err != nil {
+		return m, err
+	}
+	monitor[configID] = m
+	return m, nil
+}
+
+// planCapability returns the capability of check configuration
+// configID if it is created by request q
+func planCapability(q *msg.Request, configID string) (string, bool) {
+	if q.Section == msg.SectionRepository && q.Action == msg.ActionBatch {
+		for i := range q.Batch {
+			if id, ok := planCapability(&q.Batch[i], configID); ok {
+				return id, true
+			}
+		}
+		return ``, false
+	}
+	if q.Section == msg.SectionCheckConfig && q.CheckConfig.ID == configID {
+		return q.CheckConfig.CapabilityID, true
+	}
+	return ``, false
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
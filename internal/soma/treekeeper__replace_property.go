@@ -0,0 +1,173 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/satori/go.uuid"
+)
+
+// Results of comparing a requested property with the locally set
+// property it replaces
+const (
+	propertyUnchanged = iota
+	propertyUpdated
+	propertyReplaced
+)
+
+// replaceProperty replaces all properties of type q.Property.Type that
+// are set locally on the target object with the properties of the
+// request. Unchanged properties are kept, properties that only
+// changed their value or inheritance are updated in place. All other
+// locally set properties are deleted, the remaining requested
+// properties are added.
+func (tk *TreeKeeper) replaceProperty(q *msg.Request) {
+	props, id := tk.convProperty(`add`, q)
+	obj := tk.tree.Find(tree.FindRequest{
+		ElementType: q.TargetEntity,
+		ElementID:   id,
+	}, true).(tree.Propertier)
+
+	del, upd, add := propertyDelta(
+		obj.LocalProperties(q.Property.Type), props)
+
+	// deletes are applied first to free up the keys of properties
+	// that are set again
+	for _, prop := range del {
+		obj.DeleteProperty(prop)
+	}
+	for _, prop := range upd {
+		obj.UpdateProperty(prop)
+	}
+	for _, prop := range add {
+		obj.SetProperty(prop)
+	}
+}
+
+// propertyDelta compares the locally set properties current with the
+// requested properties props. It returns the properties that have
+// to be deleted, updated in place and added. Updated properties keep
+// the ID of the property they replace.
+func propertyDelta(current, props []tree.Property) (del, upd,
+	add []tree.Property) {
+	matched := make([]bool, len(current))
+	del = []tree.Property{}
+	upd = []tree.Property{}
+	add = []tree.Property{}
+
+requested:
+	for _, prop := range props {
+		for i := range current {
+			if matched[i] || !samePropertyKey(current[i], prop) {
+				continue
+			}
+			matched[i] = true
+
+			switch compareProperty(current[i], prop) {
+			case propertyUpdated:
+				srcUUID, _ := uuid.FromString(current[i].GetID())
+				prop.SetID(srcUUID)
+				prop.SetSourceID(srcUUID)
+				upd = append(upd, prop)
+			case propertyReplaced:
+				del = append(del, current[i])
+				add = append(add, prop)
+			}
+			continue requested
+		}
+		add = append(add, prop)
+	}
+	for i := range current {
+		if !matched[i] {
+			del = append(del, current[i])
+		}
+	}
+	return
+}
+
+// samePropertyKey returns true if properties a and b can not be set
+// both on the same object
+func samePropertyKey(a, b tree.Property) bool {
+	if a.GetType() != b.GetType() ||
+		a.GetKey() != b.GetKey() ||
+		a.GetView() != b.GetView() {
+		return false
+	}
+	if a.GetType() == msg.PropertySystem {
+		switch a.GetKey() {
+		case msg.SystemPropertyTag,
+			msg.SystemPropertyDisableCheckConfiguration:
+			// these system properties can be set multiple times
+			// with different values
+			return a.GetValue() == b.GetValue()
+		}
+	}
+	return true
+}
+
+// compareProperty compares the locally set property current with the
+// requested property prop of the same key. Only custom and system
+// properties support in-place updates, and only if ChildrenOnly is
+// unchanged.
+func compareProperty(current, prop tree.Property) int {
+	switch c := current.(type) {
+	case *tree.PropertyCustom:
+		p := prop.(*tree.PropertyCustom)
+		switch {
+		case c.ChildrenOnly != p.ChildrenOnly:
+			return propertyReplaced
+		case c.Value != p.Value, c.Inheritance != p.Inheritance:
+			return propertyUpdated
+		}
+	case *tree.PropertySystem:
+		p := prop.(*tree.PropertySystem)
+		switch {
+		case c.ChildrenOnly != p.ChildrenOnly:
+			return propertyReplaced
+		case c.Value != p.Value, c.Inheritance != p.Inheritance:
+			return propertyUpdated
+		}
+	case *tree.PropertyService:
+		p := prop.(*tree.PropertyService)
+		if c.ChildrenOnly != p.ChildrenOnly ||
+			c.Inheritance != p.Inheritance ||
+			!sameServiceAttributes(c.Attributes, p.Attributes) {
+			return propertyReplaced
+		}
+	case *tree.PropertyOncall:
+		p := prop.(*tree.PropertyOncall)
+		if c.ChildrenOnly != p.ChildrenOnly ||
+			c.Inheritance != p.Inheritance {
+			return propertyReplaced
+		}
+	}
+	return propertyUnchanged
+}
+
+// sameServiceAttributes returns true if a and b contain the same
+// service attributes, regardless of their order
+func sameServiceAttributes(a, b []proto.ServiceAttribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := map[proto.ServiceAttribute]int{}
+	for _, attr := range a {
+		count[attr]++
+	}
+	for _, attr := range b {
+		if count[attr] == 0 {
+			return false
+		}
+		count[attr]--
+	}
+	return true
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,238 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/tree"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/satori/go.uuid"
+)
+
+func TestPropertyDelta(t *testing.T) {
+	id := func(n int) uuid.UUID {
+		return uuid.Must(uuid.FromString(fmt.Sprintf(
+			"3e1c2a7b-0000-4000-8000-%012d", n)))
+	}
+	// properties set locally on the object carry an ID, requested
+	// properties do not
+	custom := func(n int, value string, inherit, children bool) *tree.PropertyCustom {
+		return &tree.PropertyCustom{
+			ID:           id(n),
+			CustomID:     id(100),
+			View:         `internal`,
+			Key:          `owner`,
+			Value:        value,
+			Inheritance:  inherit,
+			ChildrenOnly: children,
+		}
+	}
+	system := func(n int, key, value string) *tree.PropertySystem {
+		return &tree.PropertySystem{
+			ID:          id(n),
+			View:        `internal`,
+			Key:         key,
+			Value:       value,
+			Inheritance: true,
+		}
+	}
+	service := func(n int, attrs ...string) *tree.PropertyService {
+		p := &tree.PropertyService{
+			ID:          id(n),
+			ServiceID:   id(200),
+			ServiceName: `https`,
+			View:        `internal`,
+			Inheritance: true,
+			Attributes:  []proto.ServiceAttribute{},
+		}
+		for i := 0; i+1 < len(attrs); i += 2 {
+			p.Attributes = append(p.Attributes, proto.ServiceAttribute{
+				Name:  attrs[i],
+				Value: attrs[i+1],
+			})
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		current []tree.Property
+		props   []tree.Property
+		del     []string
+		upd     []string
+		add     []string
+	}{
+		{
+			name:    `unchanged custom`,
+			current: []tree.Property{custom(1, `alice`, true, false)},
+			props:   []tree.Property{custom(0, `alice`, true, false)},
+		},
+		{
+			name:    `unchanged system`,
+			current: []tree.Property{system(1, `fqdn`, `a.example.com`)},
+			props:   []tree.Property{system(0, `fqdn`, `a.example.com`)},
+		},
+		{
+			name:    `custom value change`,
+			current: []tree.Property{custom(1, `alice`, true, false)},
+			props:   []tree.Property{custom(0, `bob`, true, false)},
+			upd:     []string{`custom/ownerbob`},
+		},
+		{
+			name:    `custom inheritance change`,
+			current: []tree.Property{custom(1, `alice`, true, false)},
+			props:   []tree.Property{custom(0, `alice`, false, false)},
+			upd:     []string{`custom/owneralice`},
+		},
+		{
+			name:    `system value change`,
+			current: []tree.Property{system(1, `fqdn`, `a.example.com`)},
+			props:   []tree.Property{system(0, `fqdn`, `b.example.com`)},
+			upd:     []string{`system/b.example.com`},
+		},
+		{
+			name:    `custom children only change`,
+			current: []tree.Property{custom(1, `alice`, true, false)},
+			props:   []tree.Property{custom(0, `alice`, true, true)},
+			del:     []string{`custom/owneralice`},
+			add:     []string{`custom/owneralice`},
+		},
+		{
+			name: `tag matched by value`,
+			current: []tree.Property{
+				system(1, msg.SystemPropertyTag, `web`),
+				system(2, msg.SystemPropertyTag, `db`),
+			},
+			props: []tree.Property{
+				system(0, msg.SystemPropertyTag, `db`),
+				system(0, msg.SystemPropertyTag, `cache`),
+			},
+			del: []string{`system/web`},
+			add: []string{`system/cache`},
+		},
+		{
+			name: `disable check configuration matched by value`,
+			current: []tree.Property{
+				system(1, msg.SystemPropertyDisableCheckConfiguration,
+					`ping`),
+			},
+			props: []tree.Property{
+				system(0, msg.SystemPropertyDisableCheckConfiguration,
+					`ping`),
+				system(0, msg.SystemPropertyDisableCheckConfiguration,
+					`http`),
+			},
+			add: []string{`system/http`},
+		},
+		{
+			name: `service attribute order`,
+			current: []tree.Property{
+				service(1, `port`, `443`, `transport_protocol`, `tcp`),
+			},
+			props: []tree.Property{
+				service(0, `transport_protocol`, `tcp`, `port`, `443`),
+			},
+		},
+		{
+			name: `service attribute change`,
+			current: []tree.Property{
+				service(1, `port`, `443`, `transport_protocol`, `tcp`),
+			},
+			props: []tree.Property{
+				service(0, `port`, `8443`, `transport_protocol`, `tcp`),
+			},
+			del: []string{`service/https`},
+			add: []string{`service/https`},
+		},
+		{
+			name: `omitted properties are deleted`,
+			current: []tree.Property{
+				system(1, `fqdn`, `a.example.com`),
+				system(2, `cluster_state`, `active`),
+			},
+			props: []tree.Property{system(0, `fqdn`, `a.example.com`)},
+			del:   []string{`system/active`},
+		},
+		{
+			name: `empty list removes everything`,
+			current: []tree.Property{
+				system(1, `fqdn`, `a.example.com`),
+				system(2, msg.SystemPropertyTag, `web`),
+			},
+			props: []tree.Property{},
+			del:   []string{`system/a.example.com`, `system/web`},
+		},
+		{
+			name:    `new property`,
+			current: []tree.Property{},
+			props:   []tree.Property{system(0, `fqdn`, `a.example.com`)},
+			add:     []string{`system/a.example.com`},
+		},
+	}
+
+	describe := func(props []tree.Property) []string {
+		r := []string{}
+		for _, p := range props {
+			r = append(r, p.GetType()+`/`+p.GetValue())
+		}
+		sort.Strings(r)
+		return r
+	}
+	equal := func(a, b []string) bool {
+		if b == nil {
+			b = []string{}
+		}
+		sort.Strings(b)
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	}
+
+	for _, test := range tests {
+		del, upd, add := propertyDelta(test.current, test.props)
+
+		if d := describe(del); !equal(d, test.del) {
+			t.Errorf("%s: expected delete %v, got %v", test.name,
+				test.del, d)
+		}
+		if u := describe(upd); !equal(u, test.upd) {
+			t.Errorf("%s: expected update %v, got %v", test.name,
+				test.upd, u)
+		}
+		if a := describe(add); !equal(a, test.add) {
+			t.Errorf("%s: expected add %v, got %v", test.name,
+				test.add, a)
+		}
+
+		// deleted properties must be the locally set ones
+		for _, p := range del {
+			if p.GetID() == uuid.Nil.String() {
+				t.Errorf("%s: deleting requested property %s",
+					test.name, p.GetValue())
+			}
+		}
+		// updates must keep the ID of the property they replace
+		for _, p := range upd {
+			found := false
+			for _, c := range test.current {
+				if c.GetID() == p.GetID() &&
+					samePropertyKey(c, p) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s: update of %s lost its source ID %s",
+					test.name, p.GetValue(), p.GetID())
+			}
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
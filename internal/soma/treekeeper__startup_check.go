@@ -99,6 +99,7 @@ func (tk *TreeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 		capabilityID, objID, objType, cfgName, cfgObjID, cfgObjType  string
 		externalID, predicate, threshold, levelName, levelShort      string
 		cstrType, value1, value2, value3, itemID, itemCfgID          string
+		cstrOperator, cstrGroup                                      string
 		monitoringID, cstrHash, cstrValHash, instSvc, instSvcCfgHash string
 		instSvcCfg, errLocation                                      string
 		levelNumeric, numVal, interval, version                      int64
@@ -271,12 +272,12 @@ func (tk *TreeKeeper) startupScopedChecks(typ string, stMap map[string]*sql.Stmt
 			// iterate over returned constraints - no rows is valid, as
 			// constraints are not mandatory
 			for cstrRows.Next() {
-				if err = cstrRows.Scan(&value1, &value2, &value3); err != nil {
+				if err = cstrRows.Scan(&value1, &value2, &value3, &cstrOperator, &cstrGroup); err != nil {
 					cstrRows.Close()
 					goto fail
 				}
 				//replace and deduplicate this huge switch statement..
-				addConstraint(cstrType, value1, value2, value3, tk.meta.repoID, &victim)
+				addConstraint(cstrType, value1, value2, value3, cstrOperator, cstrGroup, tk.meta.repoID, &victim)
 			} // for cstrRows.Next()
 			if cstrRows.Err() != nil {
 				goto fail
@@ -638,6 +639,7 @@ func (tk *TreeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 		configRows, threshRows, cstrRows            *sql.Rows
 		predicate, threshold, levelName, levelShort string
 		cstrType, value1, value2, value3            string
+		cstrOperator, cstrGroup                     string
 		levelNumeric, numVal                        int64
 		treeCheck                                   *tree.Check
 		nullBucketID                                sql.NullString
@@ -736,12 +738,12 @@ func (tk *TreeKeeper) startupScopedReapplyCheckConfig(typ string, stMap map[stri
 			}
 
 			for cstrRows.Next() {
-				if err = cstrRows.Scan(&value1, &value2, &value3); err != nil {
+				if err = cstrRows.Scan(&value1, &value2, &value3, &cstrOperator, &cstrGroup); err != nil {
 					cstrRows.Close()
 					goto fail
 				}
 				//deduplicate this huge switch statement..
-				addConstraint(cstrType, value1, value2, value3, tk.meta.repoID, &conf)
+				addConstraint(cstrType, value1, value2, value3, cstrOperator, cstrGroup, tk.meta.repoID, &conf)
 			} // for cstrRows.Next()
 			if cstrRows.Err() != nil {
 				goto fail
@@ -919,12 +921,14 @@ orderloop:
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
 
-func addConstraint(cstrType, value1, value2, value3, repoid string, config *proto.CheckConfig) {
+func addConstraint(cstrType, value1, value2, value3, operator, group, repoid string, config *proto.CheckConfig) {
 	switch cstrType {
 	case `custom`:
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				Custom: &proto.PropertyCustom{
 					ID:           value1,
 					Name:         value2,
@@ -937,6 +941,8 @@ func addConstraint(cstrType, value1, value2, value3, repoid string, config *prot
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				Native: &proto.PropertyNative{
 					Name:  value1,
 					Value: value2,
@@ -947,6 +953,8 @@ func addConstraint(cstrType, value1, value2, value3, repoid string, config *prot
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				Oncall: &proto.PropertyOncall{
 					ID:     value1,
 					Name:   value2,
@@ -958,6 +966,8 @@ func addConstraint(cstrType, value1, value2, value3, repoid string, config *prot
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				Attribute: &proto.ServiceAttribute{
 					Name:  value1,
 					Value: value2,
@@ -968,6 +978,8 @@ func addConstraint(cstrType, value1, value2, value3, repoid string, config *prot
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				Service: &proto.PropertyService{
 					ID:     value3,
 					Name:   value2,
@@ -979,6 +991,8 @@ func addConstraint(cstrType, value1, value2, value3, repoid string, config *prot
 		config.Constraints = append(config.Constraints,
 			proto.CheckConfigConstraint{
 				ConstraintType: cstrType,
+				Operator:       operator,
+				Group:          group,
 				System: &proto.PropertySystem{
 					Name:  value1,
 					Value: value2,
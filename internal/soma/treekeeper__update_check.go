@@ -41,6 +41,19 @@ func (tk *TreeKeeper) rmCheck(config *proto.CheckConfig) error {
 	return err
 }
 
+func (tk *TreeKeeper) updateCheck(config *proto.CheckConfig) error {
+	var err error
+	var chk *tree.Check
+	if chk, err = tk.convertCheckForUpdate(config); err == nil {
+		tk.tree.Find(tree.FindRequest{
+			ElementType: config.ObjectType,
+			ElementID:   config.ObjectID,
+		}, true).UpdateCheck(*chk)
+		return nil
+	}
+	return err
+}
+
 func (tk *TreeKeeper) convertCheck(conf *proto.CheckConfig) (*tree.Check, error) {
 	treechk := &tree.Check{
 		ID:            uuid.Nil,
@@ -69,7 +82,9 @@ func (tk *TreeKeeper) convertCheck(conf *proto.CheckConfig) (*tree.Check, error)
 	treechk.Constraints = make([]tree.CheckConstraint, len(conf.Constraints))
 	for i, constr := range conf.Constraints {
 		ncon := tree.CheckConstraint{
-			Type: constr.ConstraintType,
+			Type:     constr.ConstraintType,
+			Operator: constr.Operator,
+			Group:    constr.Group,
 		}
 		switch constr.ConstraintType {
 		case msg.ConstraintNative:
@@ -91,6 +106,9 @@ func (tk *TreeKeeper) convertCheck(conf *proto.CheckConfig) (*tree.Check, error)
 			ncon.Key = constr.Attribute.Name
 			ncon.Value = constr.Attribute.Value
 		}
+		if err := ncon.Compile(); err != nil {
+			return &tree.Check{}, err
+		}
 		treechk.Constraints[i] = ncon
 	}
 	return treechk, nil
@@ -111,4 +129,16 @@ func (tk *TreeKeeper) convertCheckForDelete(conf *proto.CheckConfig) (*tree.Chec
 	return treechk, nil
 }
 
+func (tk *TreeKeeper) convertCheckForUpdate(conf *proto.CheckConfig) (*tree.Check, error) {
+	var err error
+	var treechk *tree.Check
+	if treechk, err = tk.convertCheck(conf); err != nil {
+		return nil, err
+	}
+	if treechk.SourceID, err = uuid.FromString(conf.ExternalID); err != nil {
+		return nil, err
+	}
+	return treechk, nil
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -16,53 +16,70 @@ import (
 )
 
 func (tk *TreeKeeper) addProperty(q *msg.Request) {
-	prop, id := tk.convProperty(`add`, q)
-	tk.tree.Find(tree.FindRequest{
+	props, id := tk.convProperty(`add`, q)
+	obj := tk.tree.Find(tree.FindRequest{
 		ElementType: q.TargetEntity,
 		ElementID:   id,
-	}, true).(tree.Propertier).SetProperty(prop)
+	}, true).(tree.Propertier)
+	for _, prop := range props {
+		obj.SetProperty(prop)
+	}
 }
 
 func (tk *TreeKeeper) rmProperty(q *msg.Request) {
-	prop, id := tk.convProperty(`rm`, q)
-	tk.tree.Find(tree.FindRequest{
+	props, id := tk.convProperty(`rm`, q)
+	obj := tk.tree.Find(tree.FindRequest{
 		ElementType: q.TargetEntity,
 		ElementID:   id,
-	}, true).(tree.Propertier).DeleteProperty(prop)
+	}, true).(tree.Propertier)
+	for _, prop := range props {
+		obj.DeleteProperty(prop)
+	}
 }
 
 func (tk *TreeKeeper) updateProperty(q *msg.Request) {
-	prop, id := tk.convProperty(`update`, q)
-	tk.tree.Find(tree.FindRequest{
+	props, id := tk.convProperty(`update`, q)
+	obj := tk.tree.Find(tree.FindRequest{
 		ElementType: q.TargetEntity,
 		ElementID:   id,
-	}, true).(tree.Propertier).UpdateProperty(prop)
+	}, true).(tree.Propertier)
+	for _, prop := range props {
+		obj.UpdateProperty(prop)
+	}
 }
 
 func (tk *TreeKeeper) convProperty(task string, q *msg.Request) (
-	tree.Property, string) {
+	[]tree.Property, string) {
 
-	var prop tree.Property
+	var props *[]proto.Property
 	var id string
 
 	switch q.Section {
 	case msg.SectionNodeConfig:
 		id = q.Node.ID
-		prop = tk.pTT(task, (*q.Node.Properties)[0])
+		props = q.Node.Properties
 	case msg.SectionCluster:
 		id = q.Cluster.ID
-		prop = tk.pTT(task, (*q.Cluster.Properties)[0])
+		props = q.Cluster.Properties
 	case msg.SectionGroup:
 		id = q.Group.ID
-		prop = tk.pTT(task, (*q.Group.Properties)[0])
+		props = q.Group.Properties
 	case msg.SectionBucket:
 		id = q.Bucket.ID
-		prop = tk.pTT(task, (*q.Bucket.Properties)[0])
+		props = q.Bucket.Properties
 	case msg.SectionRepositoryConfig:
 		id = q.Repository.ID
-		prop = tk.pTT(task, (*q.Repository.Properties)[0])
+		props = q.Repository.Properties
+	}
+
+	conv := []tree.Property{}
+	if props == nil {
+		return conv, id
+	}
+	for _, pp := range *props {
+		conv = append(conv, tk.pTT(task, pp))
 	}
-	return prop, id
+	return conv, id
 }
 
 func (tk *TreeKeeper) pTT(task string, pp proto.Property) tree.Property {
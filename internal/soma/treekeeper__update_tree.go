@@ -98,6 +98,11 @@ func (tk *TreeKeeper) treeGroup(q *msg.Request) {
 
 	if q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityGroup {
 		switch q.Section {
+		case msg.SectionBucket:
+			tk.tree.Find(tree.FindRequest{
+				ElementType: msg.EntityGroup,
+				ElementID:   (*q.Bucket.MemberGroups)[0].ID,
+			}, true).(tree.BucketAttacher).Detach()
 		case msg.SectionGroup:
 			tk.tree.Find(tree.FindRequest{
 				ElementType: msg.EntityGroup,
@@ -144,6 +149,11 @@ func (tk *TreeKeeper) treeCluster(q *msg.Request) {
 
 	if q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityCluster {
 		switch q.Section {
+		case msg.SectionBucket:
+			tk.tree.Find(tree.FindRequest{
+				ElementType: msg.EntityCluster,
+				ElementID:   (*q.Bucket.MemberClusters)[0].ID,
+			}, true).(tree.BucketAttacher).Detach()
 		case msg.SectionGroup:
 			tk.tree.Find(tree.FindRequest{
 				ElementType: msg.EntityCluster,
@@ -184,6 +194,11 @@ func (tk *TreeKeeper) treeNode(q *msg.Request) {
 
 	if q.Action == msg.ActionMemberUnassign && q.TargetEntity == msg.EntityNode {
 		switch q.Section {
+		case msg.SectionBucket:
+			tk.tree.Find(tree.FindRequest{
+				ElementType: msg.EntityNode,
+				ElementID:   (*q.Bucket.MemberNodes)[0].ID,
+			}, true).(tree.BucketAttacher).Destroy()
 		case msg.SectionCluster:
 			tk.tree.Find(tree.FindRequest{
 				ElementType: msg.EntityNode,
@@ -199,6 +214,11 @@ func (tk *TreeKeeper) treeNode(q *msg.Request) {
 
 	if q.Action == msg.ActionMemberAssign && q.TargetEntity == msg.EntityNode {
 		switch q.Section {
+		case msg.SectionBucket:
+			tk.tree.Find(tree.FindRequest{
+				ElementType: msg.EntityNode,
+				ElementID:   (*q.Bucket.MemberNodes)[0].ID,
+			}, true).(tree.BucketAttacher).Detach()
 		case msg.SectionGroup:
 			tk.tree.Find(tree.FindRequest{
 				ElementType: msg.EntityNode,
@@ -0,0 +1,409 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma // import "github.com/mjolnir42/soma/internal/soma"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/resty.v1"
+)
+
+const (
+	// number of events or deliveries handled per database query
+	webhookBatchSize = 500
+	// retry delays double per attempt, starting with the base
+	// delay and capped at the maximum delay
+	webhookRetryBase = 10 * time.Second
+	webhookRetryMax  = 1 * time.Hour
+	// how often delivered and expired data is removed
+	webhookPruneInterval = 1 * time.Hour
+)
+
+// WebhookDispatcher delivers recorded deployment state changes to the
+// registered webhook subscribers. Every status change of a check
+// instance configuration is recorded by the database as a deployment
+// event. The dispatcher queues one delivery per matching webhook in
+// the outbox, from which deliveries are sent and retried with
+// exponential backoff.
+type WebhookDispatcher struct {
+	Shutdown      chan struct{}
+	conn          *sql.DB
+	tick          <-chan time.Time
+	busy          chan struct{}
+	lastPrune     time.Time
+	stmtPending   *sql.Stmt
+	stmtDue       *sql.Stmt
+	stmtDelivered *sql.Stmt
+	stmtRetry     *sql.Stmt
+	stmtAbandon   *sql.Stmt
+	appLog        *logrus.Logger
+	reqLog        *logrus.Logger
+	errLog        *logrus.Logger
+	soma          *Soma
+}
+
+// webhookDelivery is a single queued delivery from the outbox
+type webhookDelivery struct {
+	id       string
+	attempts uint64
+	url      string
+	secret   string
+	event    proto.DeploymentEvent
+}
+
+// newWebhookDispatcher returns a new WebhookDispatcher handler
+func newWebhookDispatcher(s *Soma) (d *WebhookDispatcher) {
+	d = &WebhookDispatcher{}
+	d.Shutdown = make(chan struct{})
+	d.busy = make(chan struct{}, 1)
+	d.soma = s
+	return
+}
+
+// Register initializes resources provided by the Soma app
+func (d *WebhookDispatcher) Register(c *sql.DB, l ...*logrus.Logger) {
+	d.conn = c
+	d.appLog = l[0]
+	d.reqLog = l[1]
+	d.errLog = l[2]
+}
+
+// Intake exposes a dummy channel required to fulfull the Handler
+// interface
+func (d *WebhookDispatcher) Intake() chan msg.Request {
+	c := make(chan msg.Request)
+	return c
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (d *WebhookDispatcher) PriorityIntake() chan msg.Request {
+	return d.Intake()
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes. For WebhookDispatcher this is a dummy method to fulfill
+// the handler.Handler interface
+func (d *WebhookDispatcher) RegisterRequests(hmap *handler.Map) {
+}
+
+// Run is the loop for WebhookDispatcher
+func (d *WebhookDispatcher) Run() {
+	var err error
+
+	d.tick = time.NewTicker(
+		time.Duration(d.soma.conf.WebhookTick) * time.Second,
+	).C
+
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.WebhookPendingEvents: &d.stmtPending,
+		stmt.WebhookDueDeliveries: &d.stmtDue,
+		stmt.WebhookDelivered:     &d.stmtDelivered,
+		stmt.WebhookRetry:         &d.stmtRetry,
+		stmt.WebhookAbandon:       &d.stmtAbandon,
+	} {
+		if *prepStmt, err = d.conn.Prepare(statement); err != nil {
+			d.errLog.Fatal(`webhook_dispatcher`, err, stmt.Name(statement))
+		}
+		defer (*prepStmt).Close()
+	}
+
+runloop:
+	for {
+		select {
+		case <-d.Shutdown:
+			break runloop
+		case <-d.tick:
+			d.fanOut()
+			select {
+			case d.busy <- struct{}{}:
+				go func() {
+					d.deliver()
+					<-d.busy
+				}()
+			default:
+				// the previous delivery run is still in progress
+			}
+			if time.Since(d.lastPrune) > webhookPruneInterval {
+				d.prune()
+			}
+		}
+	}
+	// wait for a running delivery before the prepared statements
+	// are closed
+	d.busy <- struct{}{}
+}
+
+// fanOut queues a delivery for every pending deployment event and
+// webhook whose filters match the event
+func (d *WebhookDispatcher) fanOut() {
+	var (
+		err     error
+		rows    *sql.Rows
+		tx      *sql.Tx
+		eventID int64
+	)
+
+	for {
+		eventIDs := []int64{}
+		if rows, err = d.stmtPending.Query(webhookBatchSize); err != nil {
+			d.errLog.Println(`WebhookDispatcher.fanOut()`, err)
+			return
+		}
+		for rows.Next() {
+			if err = rows.Scan(&eventID); err != nil {
+				d.errLog.Println(`WebhookDispatcher.fanOut()`, err)
+				rows.Close()
+				return
+			}
+			eventIDs = append(eventIDs, eventID)
+		}
+		if err = rows.Err(); err != nil {
+			d.errLog.Println(`WebhookDispatcher.fanOut()`, err)
+			return
+		}
+		if len(eventIDs) == 0 {
+			return
+		}
+
+		// queueing the deliveries and marking the events as
+		// dispatched must happen together
+		if tx, err = d.conn.Begin(); err != nil {
+			d.errLog.Println(`WebhookDispatcher.fanOut()`, err)
+			return
+		}
+		for _, statement := range []string{
+			stmt.WebhookFanOut,
+			stmt.WebhookMarkDispatched,
+		} {
+			if _, err = tx.Exec(statement, pq.Array(eventIDs)); err != nil {
+				d.errLog.Println(`WebhookDispatcher.fanOut()`, stmt.Name(statement), err)
+				tx.Rollback()
+				return
+			}
+		}
+		if err = tx.Commit(); err != nil {
+			d.errLog.Println(`WebhookDispatcher.fanOut()`, err)
+			return
+		}
+
+		if len(eventIDs) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// deliver sends all deliveries that are due. Deliveries to the same
+// webhook are sent sequentially in event order, different webhooks
+// are served in parallel.
+func (d *WebhookDispatcher) deliver() {
+	var (
+		err                                 error
+		rows                                *sql.Rows
+		webhookID                           string
+		checkID, repositoryID, monitoringID sql.NullString
+		previous                            sql.NullString
+		occurredAt                          time.Time
+		wg                                  sync.WaitGroup
+	)
+
+	if rows, err = d.stmtDue.Query(webhookBatchSize); err != nil {
+		d.errLog.Println(`WebhookDispatcher.deliver()`, err)
+		return
+	}
+
+	queue := map[string][]webhookDelivery{}
+	for rows.Next() {
+		dl := webhookDelivery{}
+		if err = rows.Scan(
+			&dl.id,
+			&dl.attempts,
+			&webhookID,
+			&dl.url,
+			&dl.secret,
+			&dl.event.EventID,
+			&checkID,
+			&dl.event.CheckInstanceID,
+			&dl.event.CheckInstanceConfigID,
+			&dl.event.Version,
+			&repositoryID,
+			&monitoringID,
+			&previous,
+			&dl.event.State,
+			&dl.event.NextState,
+			&occurredAt,
+		); err != nil {
+			d.errLog.Println(`WebhookDispatcher.deliver()`, err)
+			rows.Close()
+			return
+		}
+		dl.event.Type = proto.WebhookEventDeployment
+		dl.event.CheckID = checkID.String
+		dl.event.RepositoryID = repositoryID.String
+		dl.event.MonitoringID = monitoringID.String
+		dl.event.PreviousState = previous.String
+		dl.event.OccurredAt = occurredAt.UTC().Format(msg.RFC3339Milli)
+		queue[webhookID] = append(queue[webhookID], dl)
+	}
+	if err = rows.Err(); err != nil {
+		d.errLog.Println(`WebhookDispatcher.deliver()`, err)
+		return
+	}
+
+	for webhookID := range queue {
+		wg.Add(1)
+		go func(deliveries []webhookDelivery) {
+			defer wg.Done()
+			client := resty.New().SetTimeout(time.Duration(
+				d.soma.conf.WebhookTime) * time.Millisecond,
+			)
+			for _, dl := range deliveries {
+				if !d.send(client, dl) {
+					// keep the order of events for this webhook,
+					// the remaining deliveries are retried on the
+					// next run
+					return
+				}
+			}
+		}(queue[webhookID])
+	}
+	wg.Wait()
+}
+
+// send performs a single delivery and records its outcome in the
+// outbox. It returns true if the delivery was successful.
+func (d *WebhookDispatcher) send(client *resty.Client, dl webhookDelivery) bool {
+	var err error
+
+	if err = webhookPost(client, dl); err == nil {
+		if _, err = d.stmtDelivered.Exec(dl.id); err != nil {
+			d.errLog.Println(`WebhookDispatcher.send()`, err)
+		}
+		metrics.GetOrRegisterMeter(`.webhook.delivery.success`,
+			Metrics[`soma`]).Mark(1)
+		d.appLog.Printf("Delivered webhook event %d to %s (%s)",
+			dl.event.EventID, dl.url, dl.id)
+		return true
+	}
+
+	metrics.GetOrRegisterMeter(`.webhook.delivery.failure`,
+		Metrics[`soma`]).Mark(1)
+	if webhookExhausted(dl.attempts, d.soma.conf.WebhookTries) {
+		d.errLog.Printf("Abandoned webhook delivery %s to %s after %d attempts: %s",
+			dl.id, dl.url, dl.attempts+1, err.Error())
+		metrics.GetOrRegisterMeter(`.webhook.delivery.abandoned`,
+			Metrics[`soma`]).Mark(1)
+		if _, err = d.stmtAbandon.Exec(dl.id, err.Error()); err != nil {
+			d.errLog.Println(`WebhookDispatcher.send()`, err)
+		}
+		return false
+	}
+
+	delay := webhookRetryDelay(dl.attempts)
+	d.appLog.Printf("Webhook delivery %s to %s failed, retrying in %s: %s",
+		dl.id, dl.url, delay, err.Error())
+	if _, err = d.stmtRetry.Exec(
+		dl.id,
+		err.Error(),
+		int64(delay/time.Second),
+	); err != nil {
+		d.errLog.Println(`WebhookDispatcher.send()`, err)
+	}
+	return false
+}
+
+// webhookPost sends delivery dl as signed request. Responses without a 2xx
+// status code are returned as error.
+func webhookPost(client *resty.Client, dl webhookDelivery) error {
+	body, err := json.Marshal(dl.event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.R().
+		SetHeader(`Content-Type`, `application/json`).
+		SetHeader(proto.WebhookHeaderDelivery, dl.id).
+		SetHeader(proto.WebhookHeaderEvent, dl.event.Type).
+		SetHeader(proto.WebhookHeaderSignature,
+			webhookSignature(dl.secret, body)).
+		SetBody(body).
+		Post(dl.url)
+	if err == nil && !resp.IsSuccess() {
+		err = fmt.Errorf("Webhook returned status %s", resp.Status())
+	}
+	return err
+}
+
+// webhookSignature returns the signature header value of body, the
+// hex encoded HMAC-SHA256 keyed with secret
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return `sha256=` + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay returns the delay before the next attempt of a
+// delivery that failed attempts times before the current attempt
+func webhookRetryDelay(attempts uint64) time.Duration {
+	delay := webhookRetryBase
+	for i := uint64(0); i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
+
+// webhookExhausted returns true if a delivery that failed attempts
+// times before the current, failed attempt has used up all tries
+func webhookExhausted(attempts, tries uint64) bool {
+	return attempts+1 >= tries
+}
+
+// prune removes finished deliveries and dispatched events once they
+// have expired
+func (d *WebhookDispatcher) prune() {
+	var (
+		res sql.Result
+		err error
+	)
+
+	for _, st := range []string{
+		stmt.WebhookPruneOutbox,
+		stmt.WebhookPruneEvents,
+	} {
+		if res, err = d.conn.Exec(st); err != nil {
+			d.errLog.Println(`WebhookDispatcher.prune()`, stmt.Name(st), err)
+			return
+		}
+		r, _ := res.RowsAffected()
+		d.appLog.Debugf("WebhookDispatcher: prune %s: %d rows affected", stmt.Name(st), r)
+	}
+	d.lastPrune = time.Now()
+}
+
+// ShutdownNow signals the handler to shut down
+func (d *WebhookDispatcher) ShutdownNow() {
+	close(d.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
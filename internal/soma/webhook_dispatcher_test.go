@@ -0,0 +1,128 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mjolnir42/soma/lib/proto"
+	"gopkg.in/resty.v1"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts uint64
+		delay    time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 20 * time.Second},
+		{2, 40 * time.Second},
+		{8, 2560 * time.Second},
+		{9, webhookRetryMax},
+		{11, webhookRetryMax},
+		{64, webhookRetryMax},
+		{^uint64(0), webhookRetryMax},
+	}
+
+	for _, test := range tests {
+		if delay := webhookRetryDelay(test.attempts); delay != test.delay {
+			t.Errorf("attempts %d: expected %s, got %s", test.attempts,
+				test.delay, delay)
+		}
+	}
+}
+
+func TestWebhookExhausted(t *testing.T) {
+	tests := []struct {
+		attempts, tries uint64
+		exhausted       bool
+	}{
+		{0, 12, false},
+		{10, 12, false},
+		{11, 12, true},
+		{12, 12, true},
+		{0, 1, true},
+	}
+
+	for _, test := range tests {
+		if ok := webhookExhausted(test.attempts, test.tries); ok != test.exhausted {
+			t.Errorf("attempts %d of %d: expected %t, got %t",
+				test.attempts, test.tries, test.exhausted, ok)
+		}
+	}
+}
+
+func TestWebhookPost(t *testing.T) {
+	dl := webhookDelivery{
+		id:     `5d2a3c1e-0000-4000-8000-000000000001`,
+		secret: `s3cr3t`,
+		event: proto.DeploymentEvent{
+			Type:            proto.WebhookEventDeployment,
+			EventID:         42,
+			CheckInstanceID: `5d2a3c1e-0000-4000-8000-000000000002`,
+			State:           proto.DeploymentActive,
+		},
+	}
+
+	for _, status := range []int{http.StatusOK, http.StatusNoContent,
+		http.StatusInternalServerError} {
+		srv := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+
+				mac := hmac.New(sha256.New, []byte(dl.secret))
+				mac.Write(body)
+				expected := `sha256=` + hex.EncodeToString(mac.Sum(nil))
+				if sig := r.Header.Get(proto.WebhookHeaderSignature); sig != expected {
+					t.Errorf("expected signature %s, got %s", expected, sig)
+				}
+				if id := r.Header.Get(proto.WebhookHeaderDelivery); id != dl.id {
+					t.Errorf("expected delivery %s, got %s", dl.id, id)
+				}
+				if ev := r.Header.Get(proto.WebhookHeaderEvent); ev != dl.event.Type {
+					t.Errorf("expected event %s, got %s", dl.event.Type, ev)
+				}
+
+				ev := proto.DeploymentEvent{}
+				if err := json.Unmarshal(body, &ev); err != nil {
+					t.Error(err)
+				} else if ev != dl.event {
+					t.Errorf("expected event %v, got %v", dl.event, ev)
+				}
+				w.WriteHeader(status)
+			}))
+		dl.url = srv.URL
+
+		err := webhookPost(resty.New(), dl)
+		if success := status < 300; success != (err == nil) {
+			t.Errorf("status %d: unexpected error state: %v", status, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	// RFC 4231 test case 2
+	sig := webhookSignature(`Jefe`,
+		[]byte(`what do ya want for nothing?`))
+	expected := `sha256=` +
+		`5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843`
+	if sig != expected {
+		t.Errorf("expected %s, got %s", expected, sig)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,201 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma // import "github.com/mjolnir42/soma/internal/soma"
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookRead handles read requests for webhook subscriptions
+type WebhookRead struct {
+	Input       chan msg.Request
+	Shutdown    chan struct{}
+	handlerName string
+	conn        *sql.DB
+	stmtList    *sql.Stmt
+	stmtShow    *sql.Stmt
+	appLog      *logrus.Logger
+	reqLog      *logrus.Logger
+	errLog      *logrus.Logger
+}
+
+// newWebhookRead return a new WebhookRead handler with input buffer
+// of length
+func newWebhookRead(length int) (string, *WebhookRead) {
+	r := &WebhookRead{}
+	r.handlerName = generateHandlerName() + `_r`
+	r.Input = make(chan msg.Request, length)
+	r.Shutdown = make(chan struct{})
+	return r.handlerName, r
+}
+
+// Register initializes resources provided by the Soma app
+func (r *WebhookRead) Register(c *sql.DB, l ...*logrus.Logger) {
+	r.conn = c
+	r.appLog = l[0]
+	r.reqLog = l[1]
+	r.errLog = l[2]
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes
+func (r *WebhookRead) RegisterRequests(hmap *handler.Map) {
+	for _, action := range []string{
+		msg.ActionList,
+		msg.ActionShow,
+	} {
+		hmap.Request(msg.SectionWebhook, action, r.handlerName)
+	}
+}
+
+// Intake exposes the Input channel as part of the handler interface
+func (r *WebhookRead) Intake() chan msg.Request {
+	return r.Input
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (r *WebhookRead) PriorityIntake() chan msg.Request {
+	return r.Intake()
+}
+
+// Run is the event loop for WebhookRead
+func (r *WebhookRead) Run() {
+	var err error
+
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.WebhookList: &r.stmtList,
+		stmt.WebhookShow: &r.stmtShow,
+	} {
+		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
+			r.errLog.Fatal(`webhook`, err, stmt.Name(statement))
+		}
+		defer (*prepStmt).Close()
+	}
+
+runloop:
+	for {
+		select {
+		case <-r.Shutdown:
+			break runloop
+		case req := <-r.Input:
+			go func() {
+				r.process(&req)
+			}()
+		}
+	}
+}
+
+// process is the request dispatcher
+func (r *WebhookRead) process(q *msg.Request) {
+	result := msg.FromRequest(q)
+	logRequest(r.reqLog, q)
+
+	switch q.Action {
+	case msg.ActionList:
+		r.list(q, &result)
+	case msg.ActionShow:
+		r.show(q, &result)
+	default:
+		result.UnknownRequest(q)
+	}
+	q.Reply <- result
+}
+
+// list returns all webhooks
+func (r *WebhookRead) list(q *msg.Request, mr *msg.Result) {
+	var (
+		webhookID, name string
+		rows            *sql.Rows
+		err             error
+	)
+
+	if rows, err = r.stmtList.Query(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&webhookID,
+			&name,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		mr.Webhook = append(mr.Webhook, proto.Webhook{
+			ID:   webhookID,
+			Name: name,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	mr.OK()
+}
+
+// show returns the details of a specific webhook. The secret is
+// only returned when the webhook is created.
+func (r *WebhookRead) show(q *msg.Request, mr *msg.Result) {
+	var (
+		webhookID, name, url, createdBy string
+		repositoryID, monitoringID      sql.NullString
+		states                          []string
+		createdAt                       time.Time
+		err                             error
+	)
+
+	if err = r.stmtShow.QueryRow(
+		q.Webhook.ID,
+	).Scan(
+		&webhookID,
+		&name,
+		&url,
+		&repositoryID,
+		&monitoringID,
+		pq.Array(&states),
+		&createdBy,
+		&createdAt,
+	); err == sql.ErrNoRows {
+		mr.NotFound(err, q.Section)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	mr.Webhook = append(mr.Webhook, proto.Webhook{
+		ID:           webhookID,
+		Name:         name,
+		URL:          url,
+		RepositoryID: repositoryID.String,
+		MonitoringID: monitoringID.String,
+		States:       states,
+		Details: &proto.DetailsCreation{
+			CreatedAt: createdAt.Format(msg.RFC3339Milli),
+			CreatedBy: createdBy,
+		},
+	})
+	mr.OK()
+}
+
+// ShutdownNow signals the handler to shut down
+func (r *WebhookRead) ShutdownNow() {
+	close(r.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
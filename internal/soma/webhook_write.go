@@ -0,0 +1,190 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma // import "github.com/mjolnir42/soma/internal/soma"
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookWrite handles write requests for webhook subscriptions
+type WebhookWrite struct {
+	Input       chan msg.Request
+	Shutdown    chan struct{}
+	handlerName string
+	conn        *sql.DB
+	stmtAdd     *sql.Stmt
+	stmtRemove  *sql.Stmt
+	appLog      *logrus.Logger
+	reqLog      *logrus.Logger
+	errLog      *logrus.Logger
+}
+
+// newWebhookWrite return a new WebhookWrite handler with input buffer
+// of length
+func newWebhookWrite(length int) (string, *WebhookWrite) {
+	w := &WebhookWrite{}
+	w.handlerName = generateHandlerName() + `_w`
+	w.Input = make(chan msg.Request, length)
+	w.Shutdown = make(chan struct{})
+	return w.handlerName, w
+}
+
+// Register initializes resources provided by the Soma app
+func (w *WebhookWrite) Register(c *sql.DB, l ...*logrus.Logger) {
+	w.conn = c
+	w.appLog = l[0]
+	w.reqLog = l[1]
+	w.errLog = l[2]
+}
+
+// RegisterRequests links the handler inside the handlermap to the requests
+// it processes
+func (w *WebhookWrite) RegisterRequests(hmap *handler.Map) {
+	for _, action := range []string{
+		msg.ActionAdd,
+		msg.ActionRemove,
+	} {
+		hmap.Request(msg.SectionWebhook, action, w.handlerName)
+	}
+}
+
+// Intake exposes the Input channel as part of the handler interface
+func (w *WebhookWrite) Intake() chan msg.Request {
+	return w.Input
+}
+
+// PriorityIntake aliases Intake as part of the handler interface
+func (w *WebhookWrite) PriorityIntake() chan msg.Request {
+	return w.Intake()
+}
+
+// Run is the event loop for WebhookWrite
+func (w *WebhookWrite) Run() {
+	var err error
+
+	for statement, prepStmt := range map[string]**sql.Stmt{
+		stmt.WebhookAdd:    &w.stmtAdd,
+		stmt.WebhookRemove: &w.stmtRemove,
+	} {
+		if *prepStmt, err = w.conn.Prepare(statement); err != nil {
+			w.errLog.Fatal(`webhook`, err, stmt.Name(statement))
+		}
+		defer (*prepStmt).Close()
+	}
+
+runloop:
+	for {
+		select {
+		case <-w.Shutdown:
+			break runloop
+		case req := <-w.Input:
+			w.process(&req)
+		}
+	}
+}
+
+// process is the request dispatcher
+func (w *WebhookWrite) process(q *msg.Request) {
+	result := msg.FromRequest(q)
+	logRequest(w.reqLog, q)
+
+	switch q.Action {
+	case msg.ActionAdd:
+		w.add(q, &result)
+	case msg.ActionRemove:
+		w.remove(q, &result)
+	default:
+		result.UnknownRequest(q)
+	}
+
+	q.Reply <- result
+}
+
+// add inserts a new webhook subscription. The signing secret is
+// generated here and returned only in this reply.
+func (w *WebhookWrite) add(q *msg.Request, mr *msg.Result) {
+	var (
+		err                        error
+		res                        sql.Result
+		repositoryID, monitoringID sql.NullString
+		states                     interface{}
+	)
+
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	q.Webhook.ID = uuid.Must(uuid.NewV4()).String()
+	q.Webhook.Secret = hex.EncodeToString(secret)
+
+	if q.Webhook.RepositoryID != `` {
+		repositoryID.String = q.Webhook.RepositoryID
+		repositoryID.Valid = true
+	}
+	if q.Webhook.MonitoringID != `` {
+		monitoringID.String = q.Webhook.MonitoringID
+		monitoringID.Valid = true
+	}
+	// an empty state filter is stored as NULL and matches all states
+	if len(q.Webhook.States) > 0 {
+		states = pq.Array(q.Webhook.States)
+	}
+
+	if res, err = w.stmtAdd.Exec(
+		q.Webhook.ID,
+		q.Webhook.Name,
+		q.Webhook.URL,
+		q.Webhook.Secret,
+		repositoryID,
+		monitoringID,
+		states,
+		q.AuthUser,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	if mr.RowCnt(res.RowsAffected()) {
+		mr.Webhook = append(mr.Webhook, q.Webhook.Clone())
+	}
+}
+
+// remove deletes a webhook subscription together with its pending
+// deliveries
+func (w *WebhookWrite) remove(q *msg.Request, mr *msg.Result) {
+	var (
+		err error
+		res sql.Result
+	)
+
+	if res, err = w.stmtRemove.Exec(
+		q.Webhook.ID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	if mr.RowCnt(res.RowsAffected()) {
+		mr.Webhook = append(mr.Webhook, q.Webhook)
+	}
+}
+
+// ShutdownNow signals the handler to shut down
+func (w *WebhookWrite) ShutdownNow() {
+	close(w.Shutdown)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -30,6 +30,7 @@ type WorkflowRead struct {
 	stmtSummary *sql.Stmt
 	stmtList    *sql.Stmt
 	stmtSearch  *sql.Stmt
+	stmtHistory *sql.Stmt
 	appLog      *logrus.Logger
 	reqLog      *logrus.Logger
 	errLog      *logrus.Logger
@@ -60,6 +61,7 @@ func (r *WorkflowRead) RegisterRequests(hmap *handler.Map) {
 		msg.ActionSummary,
 		msg.ActionList,
 		msg.ActionSearch,
+		msg.ActionHistory,
 	} {
 		hmap.Request(msg.SectionWorkflow, action, r.handlerName)
 	}
@@ -83,6 +85,7 @@ func (r *WorkflowRead) Run() {
 		stmt.WorkflowSummary: &r.stmtSummary,
 		stmt.WorkflowList:    &r.stmtList,
 		stmt.WorkflowSearch:  &r.stmtSearch,
+		stmt.WorkflowHistory: &r.stmtHistory,
 	} {
 		if *prepStmt, err = r.conn.Prepare(statement); err != nil {
 			r.errLog.Fatal(`workflow_r`, err, stmt.Name(statement))
@@ -115,6 +118,8 @@ func (r *WorkflowRead) process(q *msg.Request) {
 		r.list(q, &result)
 	case msg.ActionSearch:
 		r.search(q, &result)
+	case msg.ActionHistory:
+		r.history(q, &result)
 	default:
 		result.UnknownRequest(q)
 		return
@@ -290,6 +295,52 @@ func (r *WorkflowRead) search(q *msg.Request, mr *msg.Result) {
 	mr.OK()
 }
 
+// history returns the recorded deployment state transitions of a
+// check instance
+func (r *WorkflowRead) history(q *msg.Request, mr *msg.Result) {
+	var (
+		err        error
+		rows       *sql.Rows
+		occurredAt time.Time
+	)
+
+	workflow := proto.Workflow{
+		InstanceID:  q.Workflow.InstanceID,
+		Transitions: &[]proto.WorkflowTransition{},
+	}
+
+	if rows, err = r.stmtHistory.Query(
+		q.Workflow.InstanceID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	for rows.Next() {
+		tr := proto.WorkflowTransition{}
+		if err = rows.Scan(
+			&tr.InstanceConfigID,
+			&tr.PreviousStatus,
+			&tr.PreviousNextStatus,
+			&tr.Status,
+			&tr.NextStatus,
+			&tr.Actor,
+			&occurredAt,
+		); err != nil {
+			rows.Close()
+			mr.ServerError(err, q.Section)
+			return
+		}
+		tr.OccurredAt = occurredAt.UTC().Format(msg.RFC3339Milli)
+		*workflow.Transitions = append(*workflow.Transitions, tr)
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+	mr.Workflow = append(mr.Workflow, workflow)
+	mr.OK()
+}
+
 // summary returns counts for the workflow status distribution
 func (r *WorkflowRead) summary(q *msg.Request, mr *msg.Result) {
 	var (
@@ -314,32 +365,7 @@ func (r *WorkflowRead) summary(q *msg.Request, mr *msg.Result) {
 			return
 		}
 
-		switch status {
-		case proto.DeploymentAwaitingComputation:
-			summary.AwaitingComputation = uint64(count)
-		case proto.DeploymentComputed:
-			summary.Computed = uint64(count)
-		case proto.DeploymentAwaitingRollout:
-			summary.AwaitingRollout = uint64(count)
-		case proto.DeploymentRolloutInProgress:
-			summary.RolloutInProgress = uint64(count)
-		case proto.DeploymentRolloutFailed:
-			summary.RolloutFailed = uint64(count)
-		case proto.DeploymentActive:
-			summary.Active = uint64(count)
-		case proto.DeploymentAwaitingDeprovision:
-			summary.AwaitingDeprovision = uint64(count)
-		case proto.DeploymentDeprovisionInProgress:
-			summary.DeprovisionInProgress = uint64(count)
-		case proto.DeploymentDeprovisionFailed:
-			summary.DeprovisionFailed = uint64(count)
-		case proto.DeploymentDeprovisioned:
-			summary.Deprovisioned = uint64(count)
-		case proto.DeploymentAwaitingDeletion:
-			summary.AwaitingDeletion = uint64(count)
-		case proto.DeploymentBlocked:
-			summary.Blocked = uint64(count)
-		}
+		summary.Add(status, uint64(count))
 	}
 	if err = rows.Err(); err != nil {
 		mr.ServerError(err, q.Section)
@@ -0,0 +1,60 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// Actors recorded for deployment state transitions that are not
+// requested by a user
+const (
+	actorLifeCycle  = `lifecycle`
+	actorMonitoring = `monitoring`
+	actorTreeKeeper = `treekeeper`
+)
+
+// errTransitionConflict is returned by transition if the check
+// instance configuration was no longer in the expected state
+var errTransitionConflict = fmt.Errorf(
+	`Deployment state was changed concurrently`)
+
+// transition moves check instance configuration cfgID from deployment
+// state from into state to and records the transition for actor. The
+// next status is taken from the deployment workflow. s must be the
+// prepared statement stmt.WorkflowTransition, within a transaction it
+// must be bound to the transaction via tx.Stmt.
+func transition(s *sql.Stmt, cfgID, from, to, actor string) error {
+	var (
+		err  error
+		res  sql.Result
+		next string
+		rows int64
+	)
+
+	if err = proto.ValidateDeploymentTransition(from, to); err != nil {
+		return err
+	}
+	next, _ = proto.DeploymentNextStatus(to)
+
+	if res, err = s.Exec(cfgID, from, to, next, actor); err != nil {
+		return err
+	}
+	if rows, err = res.RowsAffected(); err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errTransitionConflict
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
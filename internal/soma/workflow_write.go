@@ -10,11 +10,13 @@ package soma
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"github.com/mjolnir42/soma/internal/handler"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
 )
 
 // WorkflowWrite handles write requests to modify workflows
@@ -23,9 +25,11 @@ type WorkflowWrite struct {
 	Shutdown                   chan struct{}
 	handlerName                string
 	conn                       *sql.DB
-	stmtRetryDeployment        *sql.Stmt
+	stmtBulkCandidates         *sql.Stmt
+	stmtCurrentConfig          *sql.Stmt
+	stmtConfigStatus           *sql.Stmt
 	stmtTriggerAvailableUpdate *sql.Stmt
-	stmtSet                    *sql.Stmt
+	stmtTransition             *sql.Stmt
 	appLog                     *logrus.Logger
 	reqLog                     *logrus.Logger
 	errLog                     *logrus.Logger
@@ -55,6 +59,8 @@ func (w *WorkflowWrite) RegisterRequests(hmap *handler.Map) {
 	for _, action := range []string{
 		msg.ActionRetry,
 		msg.ActionSet,
+		msg.ActionBulkRetry,
+		msg.ActionBulkSet,
 	} {
 		hmap.Request(msg.SectionWorkflow, action, w.handlerName)
 	}
@@ -75,9 +81,11 @@ func (w *WorkflowWrite) Run() {
 	var err error
 
 	for statement, prepStmt := range map[string]**sql.Stmt{
-		stmt.WorkflowRetry:           &w.stmtRetryDeployment,
+		stmt.WorkflowBulkCandidates:  &w.stmtBulkCandidates,
+		stmt.WorkflowCurrentConfig:   &w.stmtCurrentConfig,
+		stmt.WorkflowConfigStatus:    &w.stmtConfigStatus,
 		stmt.WorkflowUpdateAvailable: &w.stmtTriggerAvailableUpdate,
-		stmt.WorkflowSet:             &w.stmtSet,
+		stmt.WorkflowTransition:      &w.stmtTransition,
 	} {
 		if *prepStmt, err = w.conn.Prepare(statement); err != nil {
 			w.errLog.Fatal(`workflow_w`, err, stmt.Name(statement))
@@ -106,6 +114,10 @@ func (w *WorkflowWrite) process(q *msg.Request) {
 		w.retry(q, &result)
 	case msg.ActionSet:
 		w.set(q, &result)
+	case msg.ActionBulkRetry:
+		w.bulkRetry(q, &result)
+	case msg.ActionBulkSet:
+		w.bulkSet(q, &result)
 	default:
 		result.UnknownRequest(q)
 	}
@@ -116,41 +128,57 @@ func (w *WorkflowWrite) process(q *msg.Request) {
 // retry reschedules a failed deployment task
 func (w *WorkflowWrite) retry(q *msg.Request, mr *msg.Result) {
 	var (
-		err error
-		tx  *sql.Tx
-		res sql.Result
+		err                      error
+		tx                       *sql.Tx
+		res                      sql.Result
+		instanceConfigID, status string
+		retryStatus              string
 	)
-	txMap := map[string]*sql.Stmt{}
-	if tx, err = w.conn.Begin(); err != nil {
+
+	if err = w.stmtCurrentConfig.QueryRow(
+		q.Workflow.InstanceID,
+	).Scan(
+		&instanceConfigID,
+		&status,
+	); err == sql.ErrNoRows {
+		mr.NotFound(err, q.Section)
+		return
+	} else if err != nil {
 		mr.ServerError(err, q.Section)
 		return
 	}
 
-	for name, statement := range map[string]string{
-		`retry`:  stmt.WorkflowRetry,
-		`update`: stmt.WorkflowUpdateAvailable,
-	} {
-		if txMap[name], err = tx.Prepare(statement); err != nil {
-			// tx.Rollback() closes open prepared statements
-			tx.Rollback()
-			mr.ServerError(err, q.Section)
-			return
-		}
+	switch status {
+	case proto.DeploymentRolloutFailed:
+		retryStatus = proto.DeploymentAwaitingRollout
+	case proto.DeploymentDeprovisionFailed:
+		retryStatus = proto.DeploymentAwaitingDeprovision
+	default:
+		mr.BadRequest(fmt.Errorf(
+			"Check instance %s is not in a failed deployment state: %s",
+			q.Workflow.InstanceID, status,
+		), q.Section)
+		return
 	}
 
-	if res, err = txMap[`retry`].Exec(
-		q.Workflow.InstanceID,
-	); err != nil {
-		tx.Rollback()
+	if tx, err = w.conn.Begin(); err != nil {
 		mr.ServerError(err, q.Section)
 		return
 	}
-	if !mr.RowCnt(res.RowsAffected()) {
+
+	if err = transition(
+		tx.Stmt(w.stmtTransition),
+		instanceConfigID,
+		status,
+		retryStatus,
+		q.AuthUser,
+	); err != nil {
 		tx.Rollback()
+		w.transitionError(err, q, mr)
 		return
 	}
 
-	if res, err = txMap[`update`].Exec(
+	if res, err = tx.Stmt(w.stmtTriggerAvailableUpdate).Exec(
 		q.Workflow.InstanceID,
 	); err != nil {
 		tx.Rollback()
@@ -172,23 +200,65 @@ func (w *WorkflowWrite) retry(q *msg.Request, mr *msg.Result) {
 }
 
 // set updates the workflow state of a deployment task to a user
-// supplied value
+// supplied value. The state change must be a valid transition of the
+// deployment workflow.
 func (w *WorkflowWrite) set(q *msg.Request, mr *msg.Result) {
 	var (
-		err error
-		res sql.Result
+		err          error
+		status, next string
 	)
-	if res, err = w.stmtSet.Exec(
+
+	if err = w.stmtConfigStatus.QueryRow(
 		q.Workflow.InstanceConfigID,
+	).Scan(
+		&status,
+	); err == sql.ErrNoRows {
+		mr.NotFound(err, q.Section)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	if err = proto.ValidateDeploymentTransition(
+		status, q.Workflow.Status,
+	); err != nil {
+		mr.BadRequest(err, q.Section)
+		return
+	}
+	next, _ = proto.DeploymentNextStatus(q.Workflow.Status)
+	if q.Workflow.NextStatus != `` && q.Workflow.NextStatus != next {
+		mr.BadRequest(fmt.Errorf(
+			"Deployment state %s requires next status %s, not %s",
+			q.Workflow.Status, next, q.Workflow.NextStatus,
+		), q.Section)
+		return
+	}
+
+	if err = transition(
+		w.stmtTransition,
+		q.Workflow.InstanceConfigID,
+		status,
 		q.Workflow.Status,
-		q.Workflow.NextStatus,
+		q.AuthUser,
 	); err != nil {
-		mr.ServerError(err, q.Section)
+		w.transitionError(err, q, mr)
 		return
 	}
-	if mr.RowCnt(res.RowsAffected()) {
-		mr.Workflow = append(mr.Workflow, q.Workflow)
-		mr.OK()
+	q.Workflow.NextStatus = next
+	mr.Workflow = append(mr.Workflow, q.Workflow)
+	mr.OK()
+}
+
+// transitionError sets the result for a failed deployment state
+// transition
+func (w *WorkflowWrite) transitionError(err error, q *msg.Request,
+	mr *msg.Result) {
+	switch err {
+	case errTransitionConflict:
+		mr.Conflict(err, q.Section)
+	default:
+		mr.ServerError(err, q.Section)
 	}
 }
 
@@ -0,0 +1,283 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// workflowBulkBatchSize is the number of check instances that bulk
+// workflow operations update within one transaction
+const workflowBulkBatchSize = 250
+
+// workflowCandidate is a check instance selected by a bulk workflow
+// operation
+type workflowCandidate struct {
+	instanceID       string
+	instanceConfigID string
+	status           string
+}
+
+// bulkRetry reschedules all failed deployment tasks matching the
+// request filter
+func (w *WorkflowWrite) bulkRetry(q *msg.Request, mr *msg.Result) {
+	states := q.Search.Workflow.States
+	if len(states) == 0 {
+		states = []string{
+			proto.DeploymentRolloutFailed,
+			proto.DeploymentDeprovisionFailed,
+		}
+	}
+
+	w.bulkApply(q, mr, states, true, bulkRetryTarget)
+}
+
+// bulkRetryTarget returns the state a failed deployment task in state
+// status is rescheduled into
+func bulkRetryTarget(status string) string {
+	switch status {
+	case proto.DeploymentRolloutFailed:
+		return proto.DeploymentAwaitingRollout
+	case proto.DeploymentDeprovisionFailed:
+		return proto.DeploymentAwaitingDeprovision
+	}
+	return ``
+}
+
+// bulkEligible returns true if a check instance whose current
+// configuration is in state status can be selected by a bulk workflow
+// operation. Deleted check instances are only selected while they
+// are not in a rollout state, so that their deprovisioning can still
+// be retried or forced.
+func bulkEligible(status string, deleted bool) bool {
+	if !deleted {
+		return true
+	}
+	switch status {
+	case proto.DeploymentComputed,
+		proto.DeploymentAwaitingRollout,
+		proto.DeploymentRolloutInProgress,
+		proto.DeploymentRolloutFailed,
+		proto.DeploymentActive:
+		return false
+	}
+	return true
+}
+
+// bulkSet moves all check instances matching the request filter into
+// the requested workflow state
+func (w *WorkflowWrite) bulkSet(q *msg.Request, mr *msg.Result) {
+	w.bulkApply(q, mr, q.Search.Workflow.States, false,
+		func(status string) string {
+			return q.Workflow.Status
+		})
+}
+
+// bulkApply moves the check instances matching the request filter
+// in one of states into the state returned by target. Instances are
+// updated in batches of workflowBulkBatchSize, each batch within one
+// transaction. If updateAvailable is set, the check instances are
+// flagged for notification of their monitoring system. The result
+// contains the number of updated instances per resulting state.
+func (w *WorkflowWrite) bulkApply(q *msg.Request, mr *msg.Result,
+	states []string, updateAvailable bool, target func(string) string) {
+	var (
+		err        error
+		candidates []workflowCandidate
+		skipped    int
+	)
+
+	if candidates, err = w.bulkCandidates(q, states); err != nil {
+		mr.ServerError(err, q.Section)
+		return
+	}
+
+	summary := proto.WorkflowSummary{}
+	for start := 0; start < len(candidates); start += workflowBulkBatchSize {
+		end := start + workflowBulkBatchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+
+		var n int
+		if n, err = w.bulkBatch(q, candidates[start:end], updateAvailable,
+			target, &summary); err != nil {
+			if start == 0 {
+				mr.ServerError(err, q.Section)
+				return
+			}
+			mr.Workflow = append(mr.Workflow, proto.Workflow{
+				Summary: &summary,
+			})
+			mr.OK()
+			mr.SetError(fmt.Errorf(
+				"Aborted after %d of %d check instances: %s",
+				start, len(candidates), err.Error(),
+			))
+			return
+		}
+		skipped += n
+	}
+
+	mr.Workflow = append(mr.Workflow, proto.Workflow{
+		Summary: &summary,
+	})
+	mr.OK()
+	if skipped > 0 {
+		mr.SetError(fmt.Errorf(
+			"%d check instances changed their state concurrently"+
+				" and were skipped", skipped,
+		))
+	}
+}
+
+// bulkBatch updates candidates within one transaction. The resulting
+// states are only added to summary if the transaction is committed.
+// It returns the number of candidates that were skipped because
+// their state changed concurrently.
+func (w *WorkflowWrite) bulkBatch(q *msg.Request,
+	candidates []workflowCandidate, updateAvailable bool,
+	target func(string) string, summary *proto.WorkflowSummary) (int, error) {
+	var (
+		err     error
+		tx      *sql.Tx
+		skipped int
+	)
+	counts := map[string]uint64{}
+
+	if tx, err = w.conn.Begin(); err != nil {
+		return 0, err
+	}
+	transitionStmt := tx.Stmt(w.stmtTransition)
+	updateStmt := tx.Stmt(w.stmtTriggerAvailableUpdate)
+
+	for _, c := range candidates {
+		to := target(c.status)
+		if err = transition(
+			transitionStmt,
+			c.instanceConfigID,
+			c.status,
+			to,
+			q.AuthUser,
+		); err == errTransitionConflict {
+			skipped++
+			continue
+		} else if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if updateAvailable {
+			if _, err = updateStmt.Exec(
+				c.instanceID,
+			); err != nil {
+				tx.Rollback()
+				return 0, err
+			}
+		}
+		counts[to]++
+	}
+
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	for status, count := range counts {
+		summary.Add(status, count)
+	}
+	return skipped, nil
+}
+
+// bulkCandidates returns the check instances matching the request
+// filter whose current configuration is in one of states
+func (w *WorkflowWrite) bulkCandidates(q *msg.Request,
+	states []string) ([]workflowCandidate, error) {
+	var (
+		err                             error
+		rows                            *sql.Rows
+		args                            []interface{}
+		candidates                      []workflowCandidate
+		instanceID, instanceConfigID, s string
+		deleted                         bool
+	)
+
+	if args, err = bulkFilterArgs(q.Search.Workflow,
+		time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if rows, err = w.stmtBulkCandidates.Query(
+		append([]interface{}{pq.Array(states)}, args...)...,
+	); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&instanceID,
+			&instanceConfigID,
+			&s,
+			&deleted,
+		); err != nil {
+			return nil, err
+		}
+		if !bulkEligible(s, deleted) {
+			continue
+		}
+		candidates = append(candidates, workflowCandidate{
+			instanceID:       instanceID,
+			instanceConfigID: instanceConfigID,
+			status:           s,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// bulkFilterArgs returns the monitoring system, repository, capability
+// and unchanged since arguments of stmt.WorkflowBulkCandidates for
+// filter, relative to now
+func bulkFilterArgs(filter proto.WorkflowFilter,
+	now time.Time) ([]interface{}, error) {
+	var (
+		err                         error
+		monitoringID, repoID, capID sql.NullString
+		unchangedSince              pq.NullTime
+		age                         time.Duration
+	)
+
+	for ptr, value := range map[*sql.NullString]string{
+		&monitoringID: filter.MonitoringID,
+		&repoID:       filter.RepositoryID,
+		&capID:        filter.CapabilityID,
+	} {
+		if value != `` {
+			ptr.String = value
+			ptr.Valid = true
+		}
+	}
+	if filter.MinAge != `` {
+		if age, err = time.ParseDuration(filter.MinAge); err != nil {
+			return nil, err
+		}
+		unchangedSince.Time = now.Add(-age)
+		unchangedSince.Valid = true
+	}
+	return []interface{}{monitoringID, repoID, capID, unchangedSince}, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,114 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package soma
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+func TestBulkEligible(t *testing.T) {
+	tests := []struct {
+		status   string
+		deleted  bool
+		eligible bool
+	}{
+		{proto.DeploymentRolloutFailed, false, true},
+		{proto.DeploymentActive, false, true},
+		{proto.DeploymentDeprovisionFailed, false, true},
+		{proto.DeploymentComputed, true, false},
+		{proto.DeploymentAwaitingRollout, true, false},
+		{proto.DeploymentRolloutInProgress, true, false},
+		{proto.DeploymentRolloutFailed, true, false},
+		{proto.DeploymentActive, true, false},
+		{proto.DeploymentAwaitingDeprovision, true, true},
+		{proto.DeploymentDeprovisionInProgress, true, true},
+		{proto.DeploymentDeprovisionFailed, true, true},
+		{proto.DeploymentDeprovisioned, true, true},
+		{proto.DeploymentAwaitingDeletion, true, true},
+		{proto.DeploymentBlocked, true, true},
+	}
+
+	for _, test := range tests {
+		if ok := bulkEligible(test.status, test.deleted); ok != test.eligible {
+			t.Errorf("%s deleted=%t: expected %t, got %t", test.status,
+				test.deleted, test.eligible, ok)
+		}
+	}
+}
+
+func TestBulkRetryTarget(t *testing.T) {
+	tests := map[string]string{
+		proto.DeploymentRolloutFailed:     proto.DeploymentAwaitingRollout,
+		proto.DeploymentDeprovisionFailed: proto.DeploymentAwaitingDeprovision,
+		proto.DeploymentActive:            ``,
+	}
+
+	for status, target := range tests {
+		if to := bulkRetryTarget(status); to != target {
+			t.Errorf("%s: expected %q, got %q", status, target, to)
+		}
+	}
+}
+
+func TestBulkFilterArgs(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	null := sql.NullString{}
+	valid := func(s string) sql.NullString {
+		return sql.NullString{String: s, Valid: true}
+	}
+
+	tests := []struct {
+		name   string
+		filter proto.WorkflowFilter
+		args   []interface{}
+		err    bool
+	}{
+		{`empty`, proto.WorkflowFilter{},
+			[]interface{}{null, null, null, pq.NullTime{}}, false},
+		{`all`, proto.WorkflowFilter{
+			MonitoringID: `mon`,
+			RepositoryID: `repo`,
+			CapabilityID: `cap`,
+			MinAge:       `90m`,
+		}, []interface{}{valid(`mon`), valid(`repo`), valid(`cap`),
+			pq.NullTime{
+				Time:  now.Add(-90 * time.Minute),
+				Valid: true,
+			}}, false},
+		{`repository`, proto.WorkflowFilter{RepositoryID: `repo`},
+			[]interface{}{null, valid(`repo`), null, pq.NullTime{}},
+			false},
+		{`invalid age`, proto.WorkflowFilter{MinAge: `1 day`}, nil, true},
+	}
+
+	for _, test := range tests {
+		args, err := bulkFilterArgs(test.filter, now)
+		if (err != nil) != test.err {
+			t.Errorf("%s: unexpected error state: %v", test.name, err)
+			continue
+		}
+		if len(args) != len(test.args) {
+			t.Errorf("%s: expected %d arguments, got %d", test.name,
+				len(test.args), len(args))
+			continue
+		}
+		for i := range args {
+			if args[i] != test.args[i] {
+				t.Errorf("%s: argument %d: expected %v, got %v",
+					test.name, i, test.args[i], args[i])
+			}
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
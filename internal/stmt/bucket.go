@@ -103,11 +103,59 @@ SELECT bucket_id,
        organizational_team_id
 FROM   soma.buckets
 WHERE  bucket_id = $1::uuid;`
+
+	BucketMemberGroupList = `
+SELECT sg.group_id,
+       sg.group_name,
+       sb.bucket_name
+FROM   soma.groups sg
+JOIN   soma.buckets sb
+  ON   sg.bucket_id = sb.bucket_id
+WHERE  sg.bucket_id = $1::uuid
+  AND  NOT EXISTS (
+       SELECT 1
+       FROM   soma.group_membership_groups sgmg
+       WHERE  sgmg.child_group_id = sg.group_id);`
+
+	BucketMemberClusterList = `
+SELECT sc.cluster_id,
+       sc.cluster_name,
+       sb.bucket_name
+FROM   soma.clusters sc
+JOIN   soma.buckets sb
+  ON   sc.bucket_id = sb.bucket_id
+WHERE  sc.bucket_id = $1::uuid
+  AND  NOT EXISTS (
+       SELECT 1
+       FROM   soma.group_membership_clusters sgmc
+       WHERE  sgmc.child_cluster_id = sc.cluster_id);`
+
+	BucketMemberNodeList = `
+SELECT sn.node_id,
+       sn.node_name,
+       sb.bucket_name
+FROM   soma.node_bucket_assignment snba
+JOIN   soma.nodes sn
+  ON   snba.node_id = sn.node_id
+JOIN   soma.buckets sb
+  ON   snba.bucket_id = sb.bucket_id
+WHERE  snba.bucket_id = $1::uuid
+  AND  NOT EXISTS (
+       SELECT 1
+       FROM   soma.group_membership_nodes sgmn
+       WHERE  sgmn.child_node_id = sn.node_id)
+  AND  NOT EXISTS (
+       SELECT 1
+       FROM   soma.cluster_membership scm
+       WHERE  scm.node_id = sn.node_id);`
 )
 
 func init() {
 	m[BucketCstProps] = `BucketCstProps`
 	m[BucketCustomPropertyForDelete] = `BucketCustomPropertyForDelete`
+	m[BucketMemberClusterList] = `BucketMemberClusterList`
+	m[BucketMemberGroupList] = `BucketMemberGroupList`
+	m[BucketMemberNodeList] = `BucketMemberNodeList`
 	m[BucketOncProps] = `BucketOncProps`
 	m[BucketOncallPropertyForDelete] = `BucketOncallPropertyForDelete`
 	m[BucketServicePropertyForDelete] = `BucketServicePropertyForDelete`
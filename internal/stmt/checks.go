@@ -21,6 +21,19 @@ SELECT scc.configuration_object,
        scc.configuration_object_type,
        sc.source_check_id
 FROM   soma.check_configurations scc
+JOIN   soma.checks sc
+  ON   scc.configuration_id = sc.configuration_id
+WHERE  scc.configuration_id = $1::uuid
+  AND  scc.repository_id    = $2::uuid
+  AND  sc.check_id          = sc.source_check_id
+  AND  NOT sc.deleted;`
+
+	CheckDetailsForUpdate = `
+SELECT scc.configuration_object,
+       scc.configuration_object_type,
+       scc.capability_id,
+       sc.source_check_id
+FROM   soma.check_configurations scc
 JOIN   soma.checks sc
   ON   scc.configuration_id = sc.configuration_id
 WHERE  scc.configuration_id = $1::uuid
@@ -73,7 +86,9 @@ SELECT scc.configuration_id,
        sccp.custom_property_id,
        sccp.repository_id,
        sccp.property_value,
-       scp.custom_property
+       scp.custom_property,
+       sccp.match_operator,
+       sccp.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_custom_property sccp
 ON     scc.configuration_id = sccp.configuration_id
@@ -85,7 +100,9 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrSystem = `
 SELECT scc.configuration_id,
        scsp.system_property,
-       scsp.property_value
+       scsp.property_value,
+       scsp.match_operator,
+       scsp.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_system_property scsp
 ON     scc.configuration_id = scsp.configuration_id
@@ -94,7 +111,9 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrNative = `
 SELECT scc.configuration_id,
        scnp.native_property,
-       scnp.property_value
+       scnp.property_value,
+       scnp.match_operator,
+       scnp.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_native_property scnp
 ON     scc.configuration_id = scnp.configuration_id
@@ -103,7 +122,9 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrService = `
 SELECT scc.configuration_id,
        scsvp.team_id,
-       scsvp.name
+       scsvp.name,
+       scsvp.match_operator,
+       scsvp.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_service_property scsvp
 ON     scc.configuration_id = scsvp.configuration_id
@@ -112,7 +133,9 @@ WHERE  scc.configuration_id = $1::uuid;`
 	CheckConfigShowConstrAttribute = `
 SELECT scc.configuration_id,
        scsa.attribute,
-       scsa.value
+       scsa.value,
+       scsa.match_operator,
+       scsa.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_service_attribute scsa
 ON     scc.configuration_id = scsa.configuration_id
@@ -122,7 +145,9 @@ WHERE  scc.configuration_id = $1::uuid;`
 SELECT scc.configuration_id,
        scop.oncall_duty_id,
        iot.name,
-       iot.phone_number
+       iot.phone_number,
+       scop.match_operator,
+       scop.match_group
 FROM   soma.check_configurations scc
 JOIN   soma.constraints_oncall_property scop
 ON     scc.configuration_id = scop.configuration_id
@@ -168,12 +193,24 @@ SELECT sc.configuration_id
 FROM   soma.checks sc
 WHERE  sc.object_id = $1::uuid
 AND NOT deleted;`
+
+	CheckConfigMonitoring = `
+SELECT scc.capability_id,
+       smc.capability_monitoring,
+       sms.monitoring_name
+FROM   soma.check_configurations scc
+JOIN   soma.monitoring_capabilities smc
+  ON   scc.capability_id = smc.capability_id
+JOIN   soma.monitoring_systems sms
+  ON   smc.capability_monitoring = sms.monitoring_id
+WHERE  scc.configuration_id = $1::uuid;`
 )
 
 func init() {
 	m[CheckConfigForChecksOnObject] = `CheckConfigForChecksOnObject`
 	m[CheckConfigInstanceInfo] = `CheckConfigInstanceInfo`
 	m[CheckConfigList] = `CheckConfigList`
+	m[CheckConfigMonitoring] = `CheckConfigMonitoring`
 	m[CheckConfigObjectInstanceInfo] = `CheckConfigObjectInstanceInfo`
 	m[CheckConfigShowBase] = `CheckConfigShowBase`
 	m[CheckConfigShowConstrAttribute] = `CheckConfigShowConstrAttribute`
@@ -184,6 +221,7 @@ func init() {
 	m[CheckConfigShowConstrSystem] = `CheckConfigShowConstrSystem`
 	m[CheckConfigShowThreshold] = `CheckConfigShowThreshold`
 	m[CheckDetailsForDelete] = `CheckDetailsForDelete`
+	m[CheckDetailsForUpdate] = `CheckDetailsForUpdate`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -35,13 +35,6 @@ AND    (  scic.status = '` + proto.DeploymentAwaitingRollout + `'::varchar
        OR scic.status = '` + proto.DeploymentDeprovisionInProgress + `'::varchar
        OR scic.status = '` + proto.DeploymentDeprovisionFailed + `'::varchar);`
 
-	DeploymentUpdate = `
-UPDATE soma.check_instance_configurations
-SET    status = $1::varchar,
-       next_status = $2::varchar,
-       status_last_updated_at = NOW()::timestamptz
-WHERE  check_instance_config_id = $3::uuid;`
-
 	DeploymentStatus = `
 SELECT scic.check_instance_config_id,
        scic.status,
@@ -52,22 +45,6 @@ ON     sci.check_instance_id = scic.check_instance_id
 AND    sci.current_instance_config_id = scic.check_instance_config_id
 WHERE  sci.check_instance_id = $1::uuid;`
 
-	DeploymentActivate = `
-UPDATE soma.check_instance_configurations
-SET    status = $1::varchar,
-       next_status = $2::varchar,
-       activated_at = $3::timestamptz,
-       status_last_updated_at = NOW()::timestamptz
-WHERE  check_instance_config_id = $4::uuid;`
-
-	DeploymentDeprovision = `
-UPDATE soma.check_instance_configurations
-SET    status = $1::varchar,
-       next_status = $2::varchar,
-       deprovisioned_at = $3::timestamptz,
-       status_last_updated_at = NOW()::timestamptz
-WHERE  check_instance_config_id = $4::uuid;`
-
 	DeploymentList = `
 SELECT sci.check_instance_id
 FROM   soma.monitoring_systems sms
@@ -95,6 +72,36 @@ AND    (  scic.status = '` + proto.DeploymentAwaitingRollout + `'::varchar
        OR scic.status = '` + proto.DeploymentAwaitingDeprovision + `'::varchar
        OR scic.status = '` + proto.DeploymentDeprovisionInProgress + `'::varchar);`
 
+	DeploymentFilter = `
+SELECT sci.check_instance_id,
+       scic.status,
+       scic.deployment_details,
+       EXISTS(SELECT scicd.blocked_instance_config_id
+              FROM   soma.check_instance_configuration_dependencies scicd
+              WHERE  scicd.blocking_instance_config_id = sci.current_instance_config_id
+       )::boolean AS has_update
+FROM   soma.check_instance_configurations scic
+JOIN   soma.check_instances sci
+ON     scic.check_instance_id = sci.check_instance_id
+AND    scic.check_instance_config_id = sci.current_instance_config_id
+JOIN   soma.checks sc
+ON     sci.check_id = sc.check_id
+JOIN   soma.monitoring_capabilities smc
+ON     sc.capability_id = smc.capability_id
+WHERE  scic.monitoring_id = $1::uuid
+AND    scic.status = $2::varchar
+AND    (   sc.repository_id::varchar = $3::varchar
+        OR scic.deployment_details->>'repository' = $3::varchar
+        OR $3::varchar IS NULL)
+AND    (   sc.bucket_id::varchar = $4::varchar
+        OR scic.deployment_details->>'bucket' = $4::varchar
+        OR $4::varchar IS NULL)
+AND    (smc.capability_view = $5::varchar OR $5::varchar IS NULL)
+AND    (sc.capability_id = $6::uuid OR $6::uuid IS NULL)
+AND    (scic.deployment_details->>'datacenter' = $7::varchar OR $7::varchar IS NULL)
+AND    (   COALESCE(scic.status_last_updated_at, scic.created) >= $8::timestamptz
+        OR $8::timestamptz IS NULL);`
+
 	DeploymentClearFlag = `
 UPDATE soma.check_instances
 SET    update_available = 'false'::boolean,
@@ -137,9 +144,8 @@ WHERE  sci.check_instance_id = $1::uuid)::boolean AS result;`
 )
 
 func init() {
-	m[DeploymentActivate] = `DeploymentActivate`
 	m[DeploymentClearFlag] = `DeploymentClearFlag`
-	m[DeploymentDeprovision] = `DeploymentDeprovision`
+	m[DeploymentFilter] = `DeploymentFilter`
 	m[DeploymentGet] = `DeploymentGet`
 	m[DeploymentInstancesForNode] = `DeploymentInstancesForNode`
 	m[DeploymentLastInstanceVersion] = `DeploymentLastInstanceVersion`
@@ -147,7 +153,6 @@ func init() {
 	m[DeploymentList] = `DeploymentList`
 	m[DeploymentStatus] = `DeploymentStatus`
 	m[DeploymentDeprovisionStyle] = `DeploymentDeprovisionStyle`
-	m[DeploymentUpdate] = `DeploymentUpdate`
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
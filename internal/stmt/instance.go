@@ -9,6 +9,48 @@
 
 package stmt
 
+import "github.com/mjolnir42/soma/internal/msg"
+
+// instanceSubtree is a common table expression that contains the
+// object $1 of type $2 and, if $3 is true, all objects that are
+// members below it.
+const instanceSubtree = `
+WITH RECURSIVE subtree ( object_id, object_type ) AS (
+    SELECT $1::uuid,
+           $2::varchar
+  UNION
+    SELECT child.object_id,
+           child.object_type
+    FROM   subtree
+    JOIN   ( SELECT group_id         AS parent_id,
+                    '` + msg.EntityGroup + `'::varchar AS parent_type,
+                    child_group_id   AS object_id,
+                    '` + msg.EntityGroup + `'::varchar AS object_type
+             FROM   soma.group_membership_groups
+             UNION ALL
+             SELECT group_id,
+                    '` + msg.EntityGroup + `'::varchar,
+                    child_cluster_id,
+                    '` + msg.EntityCluster + `'::varchar
+             FROM   soma.group_membership_clusters
+             UNION ALL
+             SELECT group_id,
+                    '` + msg.EntityGroup + `'::varchar,
+                    child_node_id,
+                    '` + msg.EntityNode + `'::varchar
+             FROM   soma.group_membership_nodes
+             UNION ALL
+             SELECT cluster_id,
+                    '` + msg.EntityCluster + `'::varchar,
+                    node_id,
+                    '` + msg.EntityNode + `'::varchar
+             FROM   soma.cluster_membership
+           ) child
+      ON   subtree.object_id = child.parent_id
+     AND   subtree.object_type = child.parent_type
+    WHERE  $3::boolean
+)`
+
 const (
 	InstanceStatements = ``
 
@@ -41,6 +83,57 @@ WHERE  (sc.repository_id = $1::uuid OR $1::uuid IS NULL)
   AND  NOT sc.deleted
   AND  NOT sci.deleted;`
 
+	// InstanceObjectList returns all instances of a group, cluster
+	// or node ($1 object_id, $2 object_type) in bucket $4. If $3 is
+	// true, the instances of all objects below it in the tree are
+	// included. Result columns are sufficient to fill proto.Instance.
+	InstanceObjectList = instanceSubtree + `
+SELECT sci.check_instance_id,
+       scic.version,
+       sc.check_id,
+       sc.configuration_id,
+       sci.current_instance_config_id,
+       sc.repository_id,
+       sc.bucket_id,
+       sc.object_id,
+       sc.object_type,
+       scic.status,
+       scic.next_status,
+       (sc.object_id = sc.source_object_id)::boolean
+FROM   subtree
+JOIN   soma.checks sc
+  ON   subtree.object_id = sc.object_id
+ AND   subtree.object_type = sc.object_type
+JOIN   soma.check_instances sci
+  ON   sc.check_id = sci.check_id
+JOIN   soma.check_instance_configurations scic
+  ON   sci.current_instance_config_id = scic.check_instance_config_id
+WHERE  sc.bucket_id = $4::uuid
+  AND  NOT sc.deleted
+  AND  NOT sci.deleted;`
+
+	// InstanceInSubtree returns true if the object $4 of type $5 is
+	// part of the subtree below $1 of type $2, using the same
+	// parameters $1-$3 as InstanceObjectList.
+	InstanceInSubtree = instanceSubtree + `
+SELECT EXISTS (
+       SELECT 1
+       FROM   subtree
+       WHERE  object_id = $4::uuid
+         AND  object_type = $5::varchar)::boolean;`
+
+	// InstanceObject returns the object a check instance belongs
+	// to, including deleted instances.
+	InstanceObject = `
+SELECT sc.repository_id,
+       sc.bucket_id,
+       sc.object_id,
+       sc.object_type
+FROM   soma.check_instances sci
+JOIN   soma.checks sc
+  ON   sci.check_id = sc.check_id
+WHERE  sci.check_instance_id = $1::uuid;`
+
 	// InstanceShow returns information about a single check instance.
 	// Result columns are sufficient to fill proto.Instance.
 	InstanceShow = `
@@ -91,6 +184,9 @@ WHERE  scic.check_instance_id  = $1::uuid;`
 )
 
 func init() {
+	m[InstanceObjectList] = `InstanceObjectList`
+	m[InstanceInSubtree] = `InstanceInSubtree`
+	m[InstanceObject] = `InstanceObject`
 	m[InstanceScopedList] = `InstanceScopedList`
 	m[InstanceShow] = `InstanceShow`
 	m[InstanceVersions] = `InstanceVersions`
@@ -39,14 +39,6 @@ SET     update_available = $1::boolean,
         current_instance_config_id = $2::uuid
 WHERE   check_instance_id = $3::uuid;`
 
-	LifecycleUpdateConfig = `
-UPDATE  soma.check_instance_configurations
-SET     status = $1::varchar,
-        next_status = $2::varchar,
-        awaiting_deletion = $3::boolean,
-        status_last_updated_at = NOW()::timestamptz
-WHERE   check_instance_config_id = $4::uuid;`
-
 	LifecycleDeleteDependency = `
 DELETE FROM soma.check_instance_configuration_dependencies
 WHERE       blocked_instance_config_id = $1::uuid
@@ -116,44 +108,18 @@ JOIN   soma.check_instance_configuration_dependencies scicd
 WHERE  sci.deleted
   AND  scic.status = '` + proto.DeploymentBlocked + `'::varchar;`
 
-	LifecycleConfigAwaitingDeletion = `
-UPDATE soma.check_instance_configurations
-SET    status = '` + proto.DeploymentAwaitingDeletion + `'::varchar,
-       next_status = '` + proto.DeploymentNone + `'::varchar,
-       awaiting_deletion = 'yes'::boolean
-WHERE  check_instance_config_id = $1::uuid;`
-
-	LifecycleDeleteGhosts = `
-UPDATE soma.check_instance_configurations scic
-SET    status = '` + proto.DeploymentAwaitingDeletion + `'::varchar,
-       next_status = '` + proto.DeploymentNone + `'::varchar,
-       awaiting_deletion = 'yes'::boolean
-FROM   soma.check_instances sci
-WHERE  scic.check_instance_id = sci.check_instance_id
-AND    scic.status = '` + proto.DeploymentAwaitingRollout + `'::varchar
-AND    sci.deleted
-AND    sci.update_available;`
-
-	LifecycleDeleteFailedRollouts = `
-UPDATE soma.check_instance_configurations scic
-SET    status = '` + proto.DeploymentAwaitingDeletion + `'::varchar,
-       next_status = '` + proto.DeploymentNone + `'::varchar,
-       awaiting_deletion = 'yes'::boolean
-FROM   soma.check_instances sci
-WHERE  scic.check_instance_id = sci.check_instance_id
-AND    sci.deleted
-AND    scic.status = '` + proto.DeploymentRolloutFailed + `'::varchar;`
-
-	LifecycleDeleteDeprovisioned = `
-UPDATE soma.check_instance_configurations scic
-SET    status = '` + proto.DeploymentAwaitingDeletion + `'::varchar,
-       next_status = '` + proto.DeploymentNone + `'::varchar,
-       awaiting_deletion = 'yes'::boolean
-FROM   soma.check_instances sci
-WHERE  scic.check_instance_id = sci.check_instance_id
-AND    sci.deleted
-AND    scic.status = '` + proto.DeploymentDeprovisioned + `'::varchar
-AND    scic.next_status = '` + proto.DeploymentNone + `'::varchar;`
+	LifecycleGhostConfigurations = `
+SELECT scic.check_instance_config_id,
+       scic.status
+FROM   soma.check_instance_configurations scic
+JOIN   soma.check_instances sci
+  ON   scic.check_instance_id = sci.check_instance_id
+WHERE  sci.deleted
+  AND  (  ( scic.status = '` + proto.DeploymentAwaitingRollout + `'::varchar
+            AND sci.update_available )
+       OR scic.status = '` + proto.DeploymentRolloutFailed + `'::varchar
+       OR ( scic.status = '` + proto.DeploymentDeprovisioned + `'::varchar
+            AND scic.next_status = '` + proto.DeploymentNone + `'::varchar ) );`
 
 	LifecycleDeleteOrphanCheckInstances = `
 UPDATE soma.check_instances sci
@@ -175,12 +141,6 @@ WHERE  sci.deleted
   AND  scic.status = '` + proto.DeploymentActive + `'::varchar
   AND  scic.next_status = '` + proto.DeploymentNone + `'::varchar;`
 
-	LifecycleDeprovisionConfiguration = `
-UPDATE soma.check_instance_configurations
-SET    status = '` + proto.DeploymentAwaitingDeprovision + `'::varchar,
-       next_status = '` + proto.DeploymentDeprovisionInProgress + `'::varchar
-WHERE  check_instance_config_id = $1::uuid;`
-
 	LifecycleDeadLockResolver = `
 SELECT ci.check_instance_id,
        ci.current_instance_config_id
@@ -191,25 +151,43 @@ JOIN   check_instance_configurations cic
 JOIN   check_instance_configuration_dependencies cicd
   ON   ci.current_instance_config_id = cicd.blocking_instance_config_id
 WHERE  cic.status = '` + proto.DeploymentActive + `'::varchar;`
+
+	LifecycleJobBacklog = `
+SELECT sr.name,
+       count(sj.id)
+FROM   soma.job sj
+JOIN   soma.repository sr
+  ON   sj.repository_id = sr.id
+WHERE  sj.status != 'processed'
+GROUP  BY sr.name;`
+
+	LifecycleDeploymentStates = `
+SELECT sms.monitoring_name,
+       scic.status,
+       count(scic.check_instance_config_id)
+FROM   soma.check_instance_configurations scic
+JOIN   soma.check_instances sci
+  ON   scic.check_instance_id = sci.check_instance_id
+ AND   scic.check_instance_config_id = sci.current_instance_config_id
+JOIN   soma.monitoring_systems sms
+  ON   scic.monitoring_id = sms.monitoring_id
+GROUP  BY sms.monitoring_name, scic.status;`
 )
 
 func init() {
 	m[LifecycleActiveUnblockCondition] = `LifecycleActiveUnblockCondition`
 	m[LifecycleBlockedConfigsForDeletedInstance] = `LifecycleBlockedConfigsForDeletedInstance`
 	m[LifecycleClearUpdateFlag] = `LifecycleClearUpdateFlag`
-	m[LifecycleConfigAwaitingDeletion] = `LifecycleConfigAwaitingDeletion`
 	m[LifecycleDeadLockResolver] = `LifecycleDeadLockResolver`
+	m[LifecycleDeploymentStates] = `LifecycleDeploymentStates`
 	m[LifecycleDeleteDependency] = `LifecycleDeleteDependency`
-	m[LifecycleDeleteDeprovisioned] = `LifecycleDeleteDeprovisioned`
 	m[LifecycleDeleteOrphanCheckInstances] = `LifecycleDeleteOrphanCheckInstances`
-	m[LifecycleDeleteFailedRollouts] = `LifecycleDeleteFailedRollouts`
-	m[LifecycleDeleteGhosts] = `LifecycleDeleteGhosts`
-	m[LifecycleDeprovisionConfiguration] = `LifecycleDeprovisionConfiguration`
 	m[LifecycleDeprovisionDeletedActive] = `LifecycleDeprovisionDeletedActive`
+	m[LifecycleGhostConfigurations] = `LifecycleGhostConfigurations`
+	m[LifecycleJobBacklog] = `LifecycleJobBacklog`
 	m[LifecycleReadyDeployments] = `LifecycleReadyDeployments`
 	m[LifecycleRescheduleDeployments] = `LifecycleRescheduleDeployments`
 	m[LifecycleSetNotified] = `LifecycleSetNotified`
-	m[LifecycleUpdateConfig] = `LifecycleUpdateConfig`
 	m[LifecycleUpdateInstance] = `LifecycleUpdateInstance`
 }
 
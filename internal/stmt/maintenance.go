@@ -0,0 +1,197 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt
+
+const (
+	MaintenanceStatements = ``
+
+	MaintenanceList = `
+SELECT window_id,
+       object_type,
+       object_id,
+       starts_at,
+       ends_at
+FROM   soma.maintenance_windows
+WHERE  repository_id = $1::uuid
+  AND  ends_at > NOW()
+ORDER  BY starts_at;`
+
+	MaintenanceShow = `
+SELECT smw.window_id,
+       smw.repository_id,
+       smw.object_type,
+       smw.object_id,
+       smw.starts_at,
+       smw.ends_at,
+       smw.reason,
+       iu.uid,
+       smw.created_at
+FROM   soma.maintenance_windows smw
+JOIN   inventory.user iu
+  ON   smw.created_by = iu.id
+WHERE  smw.window_id = $1::uuid
+  AND  smw.repository_id = $2::uuid;`
+
+	// the object of the window must be part of the repository
+	MaintenanceAdd = `
+INSERT INTO soma.maintenance_windows (
+            window_id,
+            repository_id,
+            object_type,
+            object_id,
+            starts_at,
+            ends_at,
+            reason,
+            created_by)
+SELECT $1::uuid,
+       $2::uuid,
+       $3::varchar,
+       $4::uuid,
+       $5::timestamptz,
+       $6::timestamptz,
+       $7::text,
+       iu.id
+FROM   inventory.user iu
+WHERE  iu.uid = $8::varchar
+  AND  (    ( $3::varchar = 'repository'
+              AND $4::uuid = $2::uuid )
+         OR ( $3::varchar = 'bucket'
+              AND EXISTS (
+                  SELECT sb.bucket_id
+                  FROM   soma.buckets sb
+                  WHERE  sb.bucket_id = $4::uuid
+                    AND  sb.repository_id = $2::uuid ))
+         OR ( $3::varchar = 'group'
+              AND EXISTS (
+                  SELECT sg.group_id
+                  FROM   soma.groups sg
+                  JOIN   soma.buckets sb
+                    ON   sg.bucket_id = sb.bucket_id
+                  WHERE  sg.group_id = $4::uuid
+                    AND  sb.repository_id = $2::uuid ))
+         OR ( $3::varchar = 'cluster'
+              AND EXISTS (
+                  SELECT sc.cluster_id
+                  FROM   soma.clusters sc
+                  JOIN   soma.buckets sb
+                    ON   sc.bucket_id = sb.bucket_id
+                  WHERE  sc.cluster_id = $4::uuid
+                    AND  sb.repository_id = $2::uuid ))
+         OR ( $3::varchar = 'node'
+              AND EXISTS (
+                  SELECT snba.node_id
+                  FROM   soma.node_bucket_assignment snba
+                  JOIN   soma.buckets sb
+                    ON   snba.bucket_id = sb.bucket_id
+                  WHERE  snba.node_id = $4::uuid
+                    AND  sb.repository_id = $2::uuid )));`
+
+	MaintenanceRemove = `
+DELETE FROM soma.maintenance_windows
+WHERE  window_id = $1::uuid
+  AND  repository_id = $2::uuid;`
+
+	MaintenanceExpire = `
+DELETE FROM soma.maintenance_windows
+WHERE  ends_at <= NOW();`
+
+	// the check instance is silenced by active windows on the object
+	// it was created on, on every group or cluster the object is a
+	// member of, as well as on its bucket and repository
+	MaintenanceSilencedUntil = `
+WITH RECURSIVE ancestors ( object_id ) AS (
+    SELECT unnest( ARRAY[ sc.object_id, sc.bucket_id, sc.repository_id ] )
+    FROM   soma.check_instances sci
+    JOIN   soma.checks sc
+      ON   sci.check_id = sc.check_id
+    WHERE  sci.check_instance_id = $1::uuid
+    UNION
+    SELECT edge.parent_id
+    FROM   ancestors
+    JOIN   (
+           SELECT node_id AS child_id,
+                  cluster_id AS parent_id
+           FROM   soma.cluster_membership
+           UNION ALL
+           SELECT child_node_id,
+                  group_id
+           FROM   soma.group_membership_nodes
+           UNION ALL
+           SELECT child_cluster_id,
+                  group_id
+           FROM   soma.group_membership_clusters
+           UNION ALL
+           SELECT child_group_id,
+                  group_id
+           FROM   soma.group_membership_groups
+           ) edge
+      ON   ancestors.object_id = edge.child_id
+)
+SELECT MAX( smw.ends_at )
+FROM   soma.maintenance_windows smw
+JOIN   ancestors
+  ON   smw.object_id = ancestors.object_id
+WHERE  smw.starts_at <= NOW()
+  AND  smw.ends_at > NOW();`
+
+	// same rules as MaintenanceSilencedUntil for a list of check
+	// instances. The objects covered by active windows are resolved
+	// once, walking the memberships downward from the window objects.
+	// Only silenced instances are returned.
+	MaintenanceSilencedInstances = `
+WITH RECURSIVE silenced ( object_id, ends_at ) AS (
+    SELECT smw.object_id,
+           smw.ends_at
+    FROM   soma.maintenance_windows smw
+    WHERE  smw.starts_at <= NOW()
+      AND  smw.ends_at > NOW()
+    UNION
+    SELECT edge.child_id,
+           silenced.ends_at
+    FROM   silenced
+    JOIN   (
+           SELECT node_id AS child_id,
+                  cluster_id AS parent_id
+           FROM   soma.cluster_membership
+           UNION ALL
+           SELECT child_node_id,
+                  group_id
+           FROM   soma.group_membership_nodes
+           UNION ALL
+           SELECT child_cluster_id,
+                  group_id
+           FROM   soma.group_membership_clusters
+           UNION ALL
+           SELECT child_group_id,
+                  group_id
+           FROM   soma.group_membership_groups
+           ) edge
+      ON   silenced.object_id = edge.parent_id
+)
+SELECT sci.check_instance_id,
+       MAX( silenced.ends_at )
+FROM   soma.check_instances sci
+JOIN   soma.checks sc
+  ON   sci.check_id = sc.check_id
+JOIN   silenced
+  ON   silenced.object_id IN ( sc.object_id, sc.bucket_id, sc.repository_id )
+WHERE  sci.check_instance_id = ANY( $1::uuid[] )
+GROUP  BY sci.check_instance_id;`
+)
+
+func init() {
+	m[MaintenanceAdd] = `MaintenanceAdd`
+	m[MaintenanceExpire] = `MaintenanceExpire`
+	m[MaintenanceList] = `MaintenanceList`
+	m[MaintenanceRemove] = `MaintenanceRemove`
+	m[MaintenanceShow] = `MaintenanceShow`
+	m[MaintenanceSilencedInstances] = `MaintenanceSilencedInstances`
+	m[MaintenanceSilencedUntil] = `MaintenanceSilencedUntil`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,49 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt // import "github.com/mjolnir42/soma/internal/stmt"
+
+const RepositoryAudit = `
+-- $1 repository.id      ::uuid
+-- $2 repository.team_id ::uuid
+-- $3 user.uid           ::varchar
+-- $4 job.type           ::varchar
+-- $5 finished since     ::timestamptz
+-- $6 finished until     ::timestamptz
+-------------------------------
+SELECT      soma.job.id,
+            soma.job.serial,
+            soma.job.type,
+            soma.job.status,
+            soma.job.result,
+            soma.job.error,
+            soma.job.repository_id,
+            inventory.user.uid,
+            soma.job.team_id,
+            soma.job.queued_at,
+            soma.job.started_at,
+            soma.job.finished_at,
+            soma.job.job
+FROM        soma.job
+JOIN        soma.repository
+  ON        soma.job.repository_id = soma.repository.id
+JOIN        inventory.user
+  ON        soma.job.user_id = inventory.user.id
+WHERE       soma.job.repository_id = $1::uuid
+  AND       soma.repository.team_id = $2::uuid
+  AND       soma.job.status = 'processed'
+  AND       (inventory.user.uid = $3::varchar OR $3::varchar IS NULL)
+  AND       (soma.job.type = $4::varchar OR $4::varchar IS NULL)
+  AND       (soma.job.finished_at >= $5::timestamptz OR $5::timestamptz IS NULL)
+  AND       (soma.job.finished_at <= $6::timestamptz OR $6::timestamptz IS NULL)
+ORDER BY    soma.job.serial;`
+
+func init() {
+	m[RepositoryAudit] = `RepositoryAudit`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,323 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt // import "github.com/mjolnir42/soma/internal/stmt"
+
+const RepositoryExportProperties = `
+-- $1 repository.id ::uuid
+--
+-- returns all properties that are set locally on an object of the
+-- repository, inherited property instances are not included
+-------------------------------
+SELECT 'repository'::varchar AS object_type,
+       sp.repository_id,
+       'system'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       sp.system_property,
+       sp.value
+FROM   soma.repository_system_properties sp
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'repository'::varchar AS object_type,
+       sp.repository_id,
+       'custom'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       scp.custom_property,
+       sp.value
+FROM   soma.repository_custom_properties sp
+JOIN   soma.custom_properties scp
+  ON   sp.custom_property_id = scp.custom_property_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'repository'::varchar AS object_type,
+       sp.repository_id,
+       'service'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       ssp.name,
+       ''
+FROM   soma.repository_service_property sp
+JOIN   soma.service_property ssp
+  ON   sp.service_id = ssp.id
+ AND   sp.team_id = ssp.team_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'repository'::varchar AS object_type,
+       sp.repository_id,
+       'oncall'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       iot.name,
+       ''
+FROM   soma.repository_oncall_properties sp
+JOIN   inventory.oncall_team iot
+  ON   sp.oncall_duty_id = iot.id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'bucket'::varchar AS object_type,
+       sp.bucket_id,
+       'system'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       sp.system_property,
+       sp.value
+FROM   soma.bucket_system_properties sp
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'bucket'::varchar AS object_type,
+       sp.bucket_id,
+       'custom'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       scp.custom_property,
+       sp.value
+FROM   soma.bucket_custom_properties sp
+JOIN   soma.custom_properties scp
+  ON   sp.custom_property_id = scp.custom_property_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'bucket'::varchar AS object_type,
+       sp.bucket_id,
+       'service'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       ssp.name,
+       ''
+FROM   soma.bucket_service_property sp
+JOIN   soma.service_property ssp
+  ON   sp.service_id = ssp.id
+ AND   sp.team_id = ssp.team_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'bucket'::varchar AS object_type,
+       sp.bucket_id,
+       'oncall'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       iot.name,
+       ''
+FROM   soma.bucket_oncall_properties sp
+JOIN   inventory.oncall_team iot
+  ON   sp.oncall_duty_id = iot.id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'group'::varchar AS object_type,
+       sp.group_id,
+       'system'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       sp.system_property,
+       sp.value
+FROM   soma.group_system_properties sp
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'group'::varchar AS object_type,
+       sp.group_id,
+       'custom'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       scp.custom_property,
+       sp.value
+FROM   soma.group_custom_properties sp
+JOIN   soma.custom_properties scp
+  ON   sp.custom_property_id = scp.custom_property_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'group'::varchar AS object_type,
+       sp.group_id,
+       'service'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       ssp.name,
+       ''
+FROM   soma.group_service_property sp
+JOIN   soma.service_property ssp
+  ON   sp.service_id = ssp.id
+ AND   sp.team_id = ssp.team_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'group'::varchar AS object_type,
+       sp.group_id,
+       'oncall'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       iot.name,
+       ''
+FROM   soma.group_oncall_properties sp
+JOIN   inventory.oncall_team iot
+  ON   sp.oncall_duty_id = iot.id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'cluster'::varchar AS object_type,
+       sp.cluster_id,
+       'system'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       sp.system_property,
+       sp.value
+FROM   soma.cluster_system_properties sp
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'cluster'::varchar AS object_type,
+       sp.cluster_id,
+       'custom'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       scp.custom_property,
+       sp.value
+FROM   soma.cluster_custom_properties sp
+JOIN   soma.custom_properties scp
+  ON   sp.custom_property_id = scp.custom_property_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'cluster'::varchar AS object_type,
+       sp.cluster_id,
+       'service'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       ssp.name,
+       ''
+FROM   soma.cluster_service_property sp
+JOIN   soma.service_property ssp
+  ON   sp.service_id = ssp.id
+ AND   sp.team_id = ssp.team_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'cluster'::varchar AS object_type,
+       sp.cluster_id,
+       'oncall'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       iot.name,
+       ''
+FROM   soma.cluster_oncall_properties sp
+JOIN   inventory.oncall_team iot
+  ON   sp.oncall_duty_id = iot.id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'node'::varchar AS object_type,
+       sp.node_id,
+       'system'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       sp.system_property,
+       sp.value
+FROM   soma.node_system_properties sp
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'node'::varchar AS object_type,
+       sp.node_id,
+       'custom'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       scp.custom_property,
+       sp.value
+FROM   soma.node_custom_properties sp
+JOIN   soma.custom_properties scp
+  ON   sp.custom_property_id = scp.custom_property_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'node'::varchar AS object_type,
+       sp.node_id,
+       'service'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       ssp.name,
+       ''
+FROM   soma.node_service_property sp
+JOIN   soma.service_property ssp
+  ON   sp.service_id = ssp.id
+ AND   sp.team_id = ssp.team_id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid
+UNION ALL
+SELECT 'node'::varchar AS object_type,
+       sp.node_id,
+       'oncall'::varchar AS property_type,
+       sp.source_instance_id,
+       sp.view,
+       sp.inheritance_enabled,
+       sp.children_only,
+       iot.name,
+       ''
+FROM   soma.node_oncall_property sp
+JOIN   inventory.oncall_team iot
+  ON   sp.oncall_duty_id = iot.id
+WHERE  sp.instance_id = sp.source_instance_id
+  AND  sp.repository_id = $1::uuid;`
+
+const RepositoryExportCheckConfigs = `
+SELECT configuration_id
+FROM   soma.check_configurations
+WHERE  repository_id = $1::uuid
+  AND  NOT deleted
+ORDER BY configuration_name;`
+
+func init() {
+	m[RepositoryExportCheckConfigs] = `RepositoryExportCheckConfigs`
+	m[RepositoryExportProperties] = `RepositoryExportProperties`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,162 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt
+
+const (
+	SupervisorAPIKeyStatements = ``
+
+	// lookup the account that manages its API keys
+	APIKeyAccount = `
+SELECT id,
+       team_id
+FROM   inventory.user
+WHERE  uid = $1::varchar
+  AND  is_active
+  AND  NOT is_deleted;`
+
+	APIKeyAdd = `
+INSERT INTO auth.api_keys (
+            id,
+            name,
+            key_hash,
+            user_id,
+            team_id,
+            created_by,
+            created_at,
+            expires_at)
+SELECT $1::uuid,
+       $2::varchar,
+       $3::varchar,
+       $4::uuid,
+       $5::uuid,
+       inventory.user.id,
+       $7::timestamptz,
+       $8::timestamptz
+FROM   inventory.user
+WHERE  inventory.user.uid = $6::varchar;`
+
+	APIKeyAddNetwork = `
+INSERT INTO auth.api_key_networks (
+            api_key_id,
+            network
+) VALUES (
+            $1::uuid,
+            $2::cidr);`
+
+	APIKeyAddPermission = `
+INSERT INTO auth.api_key_permissions (
+            api_key_id,
+            permission_id,
+            category
+) VALUES (
+            $1::uuid,
+            $2::uuid,
+            $3::varchar);`
+
+	APIKeyRevoke = `
+UPDATE auth.api_keys
+SET    revoked_at = $2::timestamptz
+WHERE  id = $1::uuid
+  AND  revoked_at IS NULL;`
+
+	// list the keys of a user and the user's team
+	APIKeyList = `
+SELECT    auth.api_keys.id,
+          auth.api_keys.name,
+          auth.api_keys.user_id,
+          owner.uid,
+          auth.api_keys.team_id,
+          creator.uid,
+          auth.api_keys.created_at,
+          auth.api_keys.expires_at,
+          auth.api_keys.revoked_at
+FROM      auth.api_keys
+JOIN      inventory.user AS creator
+  ON      auth.api_keys.created_by = creator.id
+LEFT JOIN inventory.user AS owner
+  ON      auth.api_keys.user_id = owner.id
+WHERE     auth.api_keys.user_id = $1::uuid
+   OR     auth.api_keys.team_id = $2::uuid;`
+
+	APIKeyShow = `
+SELECT    auth.api_keys.id,
+          auth.api_keys.name,
+          auth.api_keys.user_id,
+          owner.uid,
+          auth.api_keys.team_id,
+          creator.uid,
+          auth.api_keys.created_at,
+          auth.api_keys.expires_at,
+          auth.api_keys.revoked_at
+FROM      auth.api_keys
+JOIN      inventory.user AS creator
+  ON      auth.api_keys.created_by = creator.id
+LEFT JOIN inventory.user AS owner
+  ON      auth.api_keys.user_id = owner.id
+WHERE     auth.api_keys.id = $1::uuid;`
+
+	APIKeyPermissions = `
+SELECT    auth.api_key_permissions.permission_id,
+          soma.permission.name,
+          auth.api_key_permissions.category
+FROM      auth.api_key_permissions
+JOIN      soma.permission
+  ON      auth.api_key_permissions.permission_id = soma.permission.id
+WHERE     auth.api_key_permissions.api_key_id = $1::uuid;`
+
+	APIKeyNetworks = `
+SELECT network::text
+FROM   auth.api_key_networks
+WHERE  api_key_id = $1::uuid;`
+
+	// startup loading all usable keys
+	APIKeyLoad = `
+SELECT auth.api_keys.id,
+       auth.api_keys.key_hash,
+       auth.api_keys.user_id,
+       auth.api_keys.team_id,
+       inventory.user.uid,
+       auth.api_keys.expires_at
+FROM   auth.api_keys
+JOIN   inventory.user
+  ON   auth.api_keys.created_by = inventory.user.id
+WHERE  auth.api_keys.revoked_at IS NULL
+  AND  (   auth.api_keys.expires_at IS NULL
+        OR NOW() < auth.api_keys.expires_at );`
+
+	// lookup a specific usable key (readonly instances)
+	APIKeySelect = `
+SELECT auth.api_keys.key_hash,
+       auth.api_keys.user_id,
+       auth.api_keys.team_id,
+       inventory.user.uid,
+       auth.api_keys.expires_at
+FROM   auth.api_keys
+JOIN   inventory.user
+  ON   auth.api_keys.created_by = inventory.user.id
+WHERE  auth.api_keys.id = $1::uuid
+  AND  auth.api_keys.revoked_at IS NULL
+  AND  (   auth.api_keys.expires_at IS NULL
+        OR NOW() < auth.api_keys.expires_at );`
+)
+
+func init() {
+	m[APIKeyAdd] = `APIKeyAdd`
+	m[APIKeyAddNetwork] = `APIKeyAddNetwork`
+	m[APIKeyAddPermission] = `APIKeyAddPermission`
+	m[APIKeyAccount] = `APIKeyAccount`
+	m[APIKeyList] = `APIKeyList`
+	m[APIKeyLoad] = `APIKeyLoad`
+	m[APIKeyNetworks] = `APIKeyNetworks`
+	m[APIKeyPermissions] = `APIKeyPermissions`
+	m[APIKeyRevoke] = `APIKeyRevoke`
+	m[APIKeySelect] = `APIKeySelect`
+	m[APIKeyShow] = `APIKeyShow`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -45,7 +45,9 @@ INSERT INTO soma.authorizations_global (
             team_id,
             permission_id,
             category,
-            created_by)
+            created_by,
+            not_before,
+            not_after)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
@@ -53,7 +55,9 @@ SELECT $1::uuid,
        $5::uuid,
        $6::uuid,
        $7::varchar,
-       inventory.user.id
+       inventory.user.id,
+       $9::timestamptz,
+       $10::timestamptz
 FROM   inventory.user
 LEFT   JOIN auth.admin
   ON   inventory.user.uid = auth.admin.user_uid
@@ -74,7 +78,9 @@ INSERT INTO soma.authorizations_repository (
             group_id,
             cluster_id,
             node_id,
-            created_by)
+            created_by,
+            not_before,
+            not_after)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
@@ -87,7 +93,9 @@ SELECT $1::uuid,
        $10::uuid,
        $11::uuid,
        $12::uuid,
-       inventory.user.id
+       inventory.user.id,
+       $14::timestamptz,
+       $15::timestamptz
 FROM   inventory.user
 LEFT   JOIN auth.admin
   ON   inventory.user.uid = auth.admin.user_uid
@@ -103,7 +111,9 @@ INSERT INTO soma.authorizations_team (
             category,
             permission_id,
             authorized_team_id,
-            created_by)
+            created_by,
+            not_before,
+            not_after)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
@@ -111,7 +121,9 @@ SELECT $1::uuid,
        $5::varchar,
        $6::uuid,
        $7::uuid,
-       inventory.user.id
+       inventory.user.id,
+       $9::timestamptz,
+       $10::timestamptz
 FROM   inventory.user
 LEFT   JOIN auth.admin
   ON   inventory.user.uid = auth.admin.user_uid
@@ -127,7 +139,9 @@ INSERT INTO soma.authorizations_monitoring (
             category,
             permission_id,
             monitoring_id,
-            created_by)
+            created_by,
+            not_before,
+            not_after)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
@@ -135,7 +149,9 @@ SELECT $1::uuid,
        $5::varchar,
        $6::uuid,
        $7::uuid,
-       inventory.user.id
+       inventory.user.id,
+       $9::timestamptz,
+       $10::timestamptz
 FROM   inventory.user
 LEFT   JOIN auth.admin
   ON   inventory.user.uid = auth.admin.user_uid
@@ -156,7 +172,9 @@ SELECT grant_id,
        admin_id,
        user_id,
        tool_id,
-       team_id
+       team_id,
+       not_before,
+       not_after
 FROM   soma.authorizations_global
 WHERE  permission_id = $1::uuid
   AND  category = $2::varchar;`
@@ -168,7 +186,9 @@ SELECT grant_id,
        tool_id,
        team_id,
        permission_id,
-       category
+       category,
+       not_before,
+       not_after
 FROM   soma.authorizations_global;`
 
 	ListRepositoryAuthorization = `
@@ -182,7 +202,9 @@ SELECT grant_id,
        bucket_id,
        group_id,
        cluster_id,
-       node_id
+       node_id,
+       not_before,
+       not_after
 FROM   soma.authorizations_repository
 WHERE  permission_id = $1::uuid
   AND  category = $2::varchar;`
@@ -199,7 +221,9 @@ SELECT grant_id,
        bucket_id,
        group_id,
        cluster_id,
-       node_id
+       node_id,
+       not_before,
+       not_after
 FROM   soma.authorizations_repository;`
 
 	ListMonitoringAuthorization = `
@@ -207,7 +231,9 @@ SELECT grant_id,
        user_id,
        tool_id,
        team_id,
-       monitoring_id
+       monitoring_id,
+       not_before,
+       not_after
 FROM   soma.authorizations_monitoring
 WHERE  permission_id = $1::uuid
   AND  category = $2::varchar;`
@@ -219,7 +245,9 @@ SELECT grant_id,
        team_id,
        monitoring_id,
        permission_id,
-       category
+       category,
+       not_before,
+       not_after
 FROM   soma.authorizations_monitoring;`
 
 	ListTeamAuthorization = `
@@ -227,7 +255,9 @@ SELECT grant_id,
        user_id,
        tool_id,
        team_id,
-       authorized_team_id
+       authorized_team_id,
+       not_before,
+       not_after
 FROM   soma.authorizations_team
 WHERE  permission_id = $1::uuid
   AND  category = $2::varchar;`
@@ -239,7 +269,9 @@ SELECT grant_id,
        team_id,
        authorized_team_id,
        permission_id,
-       category
+       category,
+       not_before,
+       not_after
 FROM   soma.authorizations_team;`
 
 	ShowGlobalAuthorization = `
@@ -347,6 +379,64 @@ WHERE  permission_id = $1::uuid
   AND  'monitoring' = $5::varchar
   AND  monitoring_id = $6::uuid;`
 
+	ExpireGlobalAuthorization = `
+DELETE FROM soma.authorizations_global
+WHERE       not_after <= NOW()
+RETURNING   grant_id,
+            CASE WHEN admin_id IS NOT NULL THEN 'admin'
+                 WHEN user_id IS NOT NULL THEN 'user'
+                 WHEN tool_id IS NOT NULL THEN 'tool'
+                 ELSE 'team' END,
+            COALESCE(admin_id, user_id, tool_id, team_id),
+            permission_id,
+            category,
+            '',
+            NULL::uuid,
+            not_after;`
+
+	ExpireRepositoryAuthorization = `
+DELETE FROM soma.authorizations_repository
+WHERE       not_after <= NOW()
+RETURNING   grant_id,
+            CASE WHEN admin_id IS NOT NULL THEN 'admin'
+                 WHEN user_id IS NOT NULL THEN 'user'
+                 WHEN tool_id IS NOT NULL THEN 'tool'
+                 ELSE 'team' END,
+            COALESCE(admin_id, user_id, tool_id, team_id),
+            permission_id,
+            category,
+            object_type,
+            COALESCE(node_id, cluster_id, group_id, bucket_id, repository_id),
+            not_after;`
+
+	ExpireTeamAuthorization = `
+DELETE FROM soma.authorizations_team
+WHERE       not_after <= NOW()
+RETURNING   grant_id,
+            CASE WHEN user_id IS NOT NULL THEN 'user'
+                 WHEN tool_id IS NOT NULL THEN 'tool'
+                 ELSE 'team' END,
+            COALESCE(user_id, tool_id, team_id),
+            permission_id,
+            category,
+            'team',
+            authorized_team_id,
+            not_after;`
+
+	ExpireMonitoringAuthorization = `
+DELETE FROM soma.authorizations_monitoring
+WHERE       not_after <= NOW()
+RETURNING   grant_id,
+            CASE WHEN user_id IS NOT NULL THEN 'user'
+                 WHEN tool_id IS NOT NULL THEN 'tool'
+                 ELSE 'team' END,
+            COALESCE(user_id, tool_id, team_id),
+            permission_id,
+            category,
+            'monitoring',
+            monitoring_id,
+            not_after;`
+
 	GrantRemoveSystem = `
 DELETE FROM soma.authorizations_global sag
 USING       soma.permission sp
@@ -365,6 +455,10 @@ FROM   soma.authorizations_global;`
 )
 
 func init() {
+	m[ExpireGlobalAuthorization] = `ExpireGlobalAuthorization`
+	m[ExpireMonitoringAuthorization] = `ExpireMonitoringAuthorization`
+	m[ExpireRepositoryAuthorization] = `ExpireRepositoryAuthorization`
+	m[ExpireTeamAuthorization] = `ExpireTeamAuthorization`
 	m[GrantGlobalAuthorization] = `GrantGlobalAuthorization`
 	m[GrantMonitoringAuthorization] = `GrantMonitoringAuthorization`
 	m[GrantRemoveSystem] = `GrantRemoveSystem`
@@ -0,0 +1,85 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt
+
+const (
+	SupervisorMailTokenStatements = ``
+
+	// lookup the mail address and state of a user
+	MailTokenUser = `
+SELECT id,
+       mail_address,
+       is_active
+FROM   inventory.user
+WHERE  uid = $1::varchar
+AND    NOT is_deleted;`
+
+	// check if a token was issued to a user since $2, tokens that
+	// were invalidated do not count
+	MailTokenRecent = `
+SELECT EXISTS (
+       SELECT 1
+       FROM   auth.password_reset
+       WHERE  user_id = $1::uuid
+       AND    valid_from > $2::timestamptz
+       AND    NOT token_invalidated);`
+
+	// invalidate all outstanding tokens of a user
+	MailTokenInvalidate = `
+UPDATE auth.password_reset
+SET    token_invalidated = 'yes'::boolean
+WHERE  user_id = $1::uuid
+AND    NOT token_used
+AND    NOT token_invalidated;`
+
+	// store a new token
+	MailTokenInsert = `
+INSERT INTO auth.password_reset (
+    user_id,
+    token,
+    valid_from,
+    valid_until
+) VALUES (
+    $1::uuid,
+    $2::varchar,
+    $3::timestamptz,
+    $4::timestamptz);`
+
+	// verify a token without consuming it
+	MailTokenVerify = `
+SELECT EXISTS (
+       SELECT 1
+       FROM   auth.password_reset
+       WHERE  user_id = $1::uuid
+       AND    token = $2::varchar
+       AND    NOT token_used
+       AND    NOT token_invalidated
+       AND    NOW() BETWEEN valid_from AND valid_until);`
+
+	// consume a token, affects exactly one row if the token is
+	// valid
+	MailTokenConsume = `
+UPDATE auth.password_reset
+SET    token_used = 'yes'::boolean
+WHERE  user_id = $1::uuid
+AND    token = $2::varchar
+AND    NOT token_used
+AND    NOT token_invalidated
+AND    NOW() BETWEEN valid_from AND valid_until;`
+)
+
+func init() {
+	m[MailTokenConsume] = `MailTokenConsume`
+	m[MailTokenInsert] = `MailTokenInsert`
+	m[MailTokenInvalidate] = `MailTokenInvalidate`
+	m[MailTokenRecent] = `MailTokenRecent`
+	m[MailTokenUser] = `MailTokenUser`
+	m[MailTokenVerify] = `MailTokenVerify`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -52,13 +52,6 @@ AND    check_instance_id = $1::uuid
 ORDER  BY version DESC
 LIMIT  1;`
 
-	TreekeeperUpdateConfigStatus = `
-UPDATE soma.check_instance_configurations
-SET    status = $1::varchar,
-       next_status = $2::varchar,
-       status_last_updated_at = NOW()::timestamptz
-WHERE  check_instance_config_id = $3::uuid;`
-
 	TreekeeperUpdateCheckInstance = `
 UPDATE soma.check_instances
 SET    last_configuration_created = $1::timestamptz,
@@ -94,7 +87,6 @@ func init() {
 	m[TreekeeperSetDependency] = `TreekeeperSetDependency`
 	m[TreekeeperStartJob] = `TreekeeperStartJob`
 	m[TreekeeperUpdateCheckInstance] = `TreekeeperUpdateCheckInstance`
-	m[TreekeeperUpdateConfigStatus] = `TreekeeperUpdateConfigStatus`
 	m[TreekeeperUpdateExistingCheckInstance] = `TreekeeperUpdateExistingCheckInstance`
 }
 
@@ -93,7 +93,9 @@ WHERE  configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintCustom = `
 SELECT sccp.custom_property_id,
        scp.custom_property,
-       sccp.property_value
+       sccp.property_value,
+       sccp.match_operator,
+       sccp.match_group
 FROM   soma.constraints_custom_property sccp
 JOIN   soma.custom_properties scp
 ON     sccp.custom_property_id = scp.custom_property_id
@@ -101,12 +103,15 @@ AND    sccp.repository_id = scp.repository_id
 WHERE  configuration_id = $1::uuid;`
 
 	// do not get distracted by the squirrels! All constraint
-	// statements are constructed to use three result variables,
-	// so they can be loaded in one unified loop.
+	// statements are constructed to use three result variables
+	// followed by operator and group, so they can be loaded in
+	// one unified loop.
 	TkStartLoadCheckConstraintNative = `
 SELECT native_property,
        property_value,
-       'squirrel'
+       'squirrel',
+       match_operator,
+       match_group
 FROM   soma.constraints_native_property
 WHERE  configuration_id = $1::uuid;`
 
@@ -114,7 +119,9 @@ WHERE  configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintOncall = `
 SELECT scop.oncall_duty_id,
        name,
-       phone_number
+       phone_number,
+       scop.match_operator,
+       scop.match_group
 FROM   soma.constraints_oncall_property scop
 JOIN   inventory.oncall_team iot
 ON     scop.oncall_duty_id = iot.id
@@ -123,21 +130,27 @@ WHERE  scop.configuration_id = $1::uuid;`
 	TkStartLoadCheckConstraintAttribute = `
 SELECT attribute,
        value,
-       'squirrel'
+       'squirrel',
+       match_operator,
+       match_group
 FROM   soma.constraints_service_attribute
 WHERE  configuration_id = $1::uuid;`
 
 	TkStartLoadCheckConstraintService = `
 SELECT team_id,
        name,
-       service_property_id
+       service_property_id,
+       match_operator,
+       match_group
 FROM   soma.constraints_service_property
 WHERE  configuration_id = $1::uuid;`
 
 	TkStartLoadCheckConstraintSystem = `
 SELECT system_property,
        property_value,
-       'squirrel'
+       'squirrel',
+       match_operator,
+       match_group
 FROM   soma.constraints_system_property
 WHERE  configuration_id = $1::uuid;`
 
@@ -87,7 +87,8 @@ INSERT INTO soma.check_instance_configurations (
             status,
             next_status,
             awaiting_deletion,
-            deployment_details)
+            deployment_details,
+            constraint_values)
 SELECT $1::uuid,
        $2::integer,
        $3::uuid,
@@ -100,7 +101,8 @@ SELECT $1::uuid,
        $10::varchar,
        $11::varchar,
        $12::boolean,
-       $13::jsonb;`
+       $13::jsonb,
+       $14::jsonb;`
 
 	TxCreateCheckConfigurationBase = `
 INSERT INTO soma.check_configurations (
@@ -146,57 +148,117 @@ SELECT $1::uuid,
 INSERT INTO soma.constraints_system_property (
             configuration_id,
             system_property,
-            property_value)
+            property_value,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
        $2::varchar,
-       $3::text;`
+       $3::text,
+       $4::varchar,
+       $5::varchar;`
 
 	TxCreateCheckConfigurationConstraintNative = `
 INSERT INTO soma.constraints_native_property (
             configuration_id,
             native_property,
-            property_value)
+            property_value,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
        $2::varchar,
-       $3::text;`
+       $3::text,
+       $4::varchar,
+       $5::varchar;`
 
 	TxCreateCheckConfigurationConstraintOncall = `
 INSERT INTO soma.constraints_oncall_property (
             configuration_id,
-            oncall_duty_id)
+            oncall_duty_id,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
-       $2::uuid;`
+       $2::uuid,
+       $3::varchar,
+       $4::varchar;`
 
 	TxCreateCheckConfigurationConstraintCustom = `
 INSERT INTO soma.constraints_custom_property (
             configuration_id,
             custom_property_id,
             repository_id,
-            property_value)
+            property_value,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
        $2::uuid,
        $3::uuid,
-       $4::text;`
+       $4::text,
+       $5::varchar,
+       $6::varchar;`
 
 	TxCreateCheckConfigurationConstraintService = `
 INSERT INTO soma.constraints_service_property (
             configuration_id,
             team_id,
             name,
-            service_property_id)
+            service_property_id,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
        $2::uuid,
        $3::varchar,
-    	   $4::uuid;`
+    	   $4::uuid,
+       $5::varchar,
+       $6::varchar;`
 
 	TxCreateCheckConfigurationConstraintAttribute = `
 INSERT INTO soma.constraints_service_attribute (
             configuration_id,
             attribute,
-            value)
+            value,
+            match_operator,
+            match_group)
 SELECT $1::uuid,
        $2::varchar,
-       $3::varchar;`
+       $3::varchar,
+       $4::varchar,
+       $5::varchar;`
+
+	TxUpdateCheckConfigurationBase = `
+UPDATE soma.check_configurations
+SET    interval = $2::integer,
+       inheritance_enabled = $3::boolean,
+       children_only = $4::boolean
+WHERE  configuration_id = $1::uuid
+  AND  NOT deleted;`
+
+	TxDeleteCheckConfigurationThresholds = `
+DELETE FROM soma.configuration_thresholds
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintSystem = `
+DELETE FROM soma.constraints_system_property
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintNative = `
+DELETE FROM soma.constraints_native_property
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintOncall = `
+DELETE FROM soma.constraints_oncall_property
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintCustom = `
+DELETE FROM soma.constraints_custom_property
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintService = `
+DELETE FROM soma.constraints_service_property
+WHERE       configuration_id = $1::uuid;`
+
+	TxDeleteCheckConfigurationConstraintAttribute = `
+DELETE FROM soma.constraints_service_attribute
+WHERE       configuration_id = $1::uuid;`
 
 	TxPropertyInstanceCreate = `
 INSERT INTO soma.property_instances (
@@ -1016,10 +1078,8 @@ WHERE  scic.status = '` + proto.DeploymentAwaitingComputation + `'::varchar
 
 	TxDeployDetailsUpdate = `
 UPDATE soma.check_instance_configurations
-SET    status = '` + proto.DeploymentComputed + `'::varchar,
-       deployment_details = $1::jsonb,
-       monitoring_id = $2::uuid,
-       status_last_updated_at = NOW()::timestamptz
+SET    deployment_details = $1::jsonb,
+       monitoring_id = $2::uuid
 WHERE  check_instance_config_id = $3::uuid;`
 
 	TxRepositoryRename = `
@@ -1117,6 +1177,7 @@ SELECT scic.version,
        scic.instance_service,
        scic.instance_service_cfg_hash,
        scic.instance_service_cfg,
+       scic.constraint_values,
        sci.check_id,
        sci.check_configuration_id
 FROM   soma.check_instance_configurations scic
@@ -1414,6 +1475,13 @@ func init() {
 	m[TxCreateCheckInstance] = `TxCreateCheckInstance`
 	m[TxCreateCheck] = `TxCreateCheck`
 	m[TxDeferAllConstraints] = `TxDeferAllConstraints`
+	m[TxDeleteCheckConfigurationConstraintAttribute] = `TxDeleteCheckConfigurationConstraintAttribute`
+	m[TxDeleteCheckConfigurationConstraintCustom] = `TxDeleteCheckConfigurationConstraintCustom`
+	m[TxDeleteCheckConfigurationConstraintNative] = `TxDeleteCheckConfigurationConstraintNative`
+	m[TxDeleteCheckConfigurationConstraintOncall] = `TxDeleteCheckConfigurationConstraintOncall`
+	m[TxDeleteCheckConfigurationConstraintService] = `TxDeleteCheckConfigurationConstraintService`
+	m[TxDeleteCheckConfigurationConstraintSystem] = `TxDeleteCheckConfigurationConstraintSystem`
+	m[TxDeleteCheckConfigurationThresholds] = `TxDeleteCheckConfigurationThresholds`
 	m[TxDeployDetailClusterCustProp] = `TxDeployDetailClusterCustProp`
 	m[TxDeployDetailClusterSysProp] = `TxDeployDetailClusterSysProp`
 	m[TxDeployDetailDefaultDatacenter] = `TxDeployDetailDefaultDatacenter`
@@ -1485,6 +1553,7 @@ func init() {
 	m[TxRepositoryPropertySystemCreate] = `TxRepositoryPropertySystemCreate`
 	m[TxRepositoryPropertySystemUpdate] = `TxRepositoryPropertySystemUpdate`
 	m[TxRepositoryPropertySystemDelete] = `TxRepositoryPropertySystemDelete`
+	m[TxUpdateCheckConfigurationBase] = `TxUpdateCheckConfigurationBase`
 	m[TxUpdateNodeState] = `TxUpdateNodeState`
 	m[TxRepositoryDestroy] = `TxRepositoryDestroy`
 	m[TxRepositoryRename] = `TxRepositoryRename`
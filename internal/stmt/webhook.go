@@ -0,0 +1,178 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package stmt
+
+const (
+	WebhookStatements = ``
+
+	WebhookList = `
+SELECT webhook_id,
+       name
+FROM   soma.webhooks;`
+
+	WebhookShow = `
+SELECT sw.webhook_id,
+       sw.name,
+       sw.url,
+       sw.repository_id,
+       sw.monitoring_id,
+       sw.states,
+       iu.uid,
+       sw.created_at
+FROM   soma.webhooks sw
+JOIN   inventory.user iu
+  ON   sw.created_by = iu.id
+WHERE  sw.webhook_id = $1::uuid;`
+
+	WebhookAdd = `
+INSERT INTO soma.webhooks (
+            webhook_id,
+            name,
+            url,
+            secret,
+            repository_id,
+            monitoring_id,
+            states,
+            created_by)
+SELECT $1::uuid,
+       $2::varchar,
+       $3::text,
+       $4::varchar,
+       $5::uuid,
+       $6::uuid,
+       $7::varchar[],
+       iu.id
+FROM   inventory.user iu
+WHERE  iu.uid = $8::varchar
+  AND  NOT EXISTS (
+       SELECT webhook_id
+       FROM   soma.webhooks
+       WHERE  name = $2::varchar);`
+
+	WebhookRemove = `
+DELETE FROM soma.webhooks
+WHERE  webhook_id = $1::uuid;`
+
+	WebhookPendingEvents = `
+SELECT event_id
+FROM   soma.deployment_events
+WHERE  NOT dispatched
+ORDER  BY event_id
+LIMIT  $1::integer;`
+
+	// queue one delivery per matching webhook for every event. A NULL
+	// filter column matches everything
+	WebhookFanOut = `
+INSERT INTO soma.webhook_outbox (
+            webhook_id,
+            event_id)
+SELECT sw.webhook_id,
+       sde.event_id
+FROM   soma.deployment_events sde
+JOIN   soma.webhooks sw
+  ON   ( sw.repository_id IS NULL OR sw.repository_id = sde.repository_id )
+ AND   ( sw.monitoring_id IS NULL OR sw.monitoring_id = sde.monitoring_id )
+ AND   ( sw.states IS NULL OR sde.status = ANY( sw.states ))
+WHERE  sde.event_id = ANY( $1::bigint[] );`
+
+	WebhookMarkDispatched = `
+UPDATE soma.deployment_events
+SET    dispatched = 'yes'::boolean
+WHERE  event_id = ANY( $1::bigint[] );`
+
+	// deliveries are only due if the webhook has no earlier
+	// delivery pending, which keeps the event order per webhook
+	// across retries
+	WebhookDueDeliveries = `
+SELECT swo.delivery_id,
+       swo.attempts,
+       sw.webhook_id,
+       sw.url,
+       sw.secret,
+       sde.event_id,
+       sde.check_id,
+       sde.check_instance_id,
+       sde.check_instance_config_id,
+       sde.version,
+       sde.repository_id,
+       sde.monitoring_id,
+       sde.previous_status,
+       sde.status,
+       sde.next_status,
+       sde.occurred_at
+FROM   soma.webhook_outbox swo
+JOIN   soma.webhooks sw
+  ON   swo.webhook_id = sw.webhook_id
+JOIN   soma.deployment_events sde
+  ON   swo.event_id = sde.event_id
+WHERE  swo.delivered_at IS NULL
+  AND  swo.failed_at IS NULL
+  AND  swo.next_attempt_at <= NOW()
+  AND  NOT EXISTS (
+       SELECT 1
+       FROM   soma.webhook_outbox prev
+       WHERE  prev.webhook_id = swo.webhook_id
+         AND  prev.event_id < swo.event_id
+         AND  prev.delivered_at IS NULL
+         AND  prev.failed_at IS NULL )
+ORDER  BY sde.event_id
+LIMIT  $1::integer;`
+
+	WebhookDelivered = `
+UPDATE soma.webhook_outbox
+SET    attempts = attempts + 1,
+       last_error = NULL,
+       delivered_at = NOW()::timestamptz(3)
+WHERE  delivery_id = $1::uuid;`
+
+	WebhookRetry = `
+UPDATE soma.webhook_outbox
+SET    attempts = attempts + 1,
+       last_error = $2::text,
+       next_attempt_at = NOW()::timestamptz(3) + $3::integer * interval '1 second'
+WHERE  delivery_id = $1::uuid;`
+
+	WebhookAbandon = `
+UPDATE soma.webhook_outbox
+SET    attempts = attempts + 1,
+       last_error = $2::text,
+       failed_at = NOW()::timestamptz(3)
+WHERE  delivery_id = $1::uuid;`
+
+	WebhookPruneOutbox = `
+DELETE FROM soma.webhook_outbox
+WHERE  delivered_at < NOW() - interval '7 days'
+   OR  failed_at < NOW() - interval '7 days';`
+
+	WebhookPruneEvents = `
+DELETE FROM soma.deployment_events sde
+WHERE  sde.dispatched
+  AND  sde.occurred_at < NOW() - interval '7 days'
+  AND  NOT EXISTS (
+       SELECT delivery_id
+       FROM   soma.webhook_outbox swo
+       WHERE  swo.event_id = sde.event_id);`
+)
+
+func init() {
+	m[WebhookAbandon] = `WebhookAbandon`
+	m[WebhookAdd] = `WebhookAdd`
+	m[WebhookDelivered] = `WebhookDelivered`
+	m[WebhookDueDeliveries] = `WebhookDueDeliveries`
+	m[WebhookFanOut] = `WebhookFanOut`
+	m[WebhookList] = `WebhookList`
+	m[WebhookMarkDispatched] = `WebhookMarkDispatched`
+	m[WebhookPendingEvents] = `WebhookPendingEvents`
+	m[WebhookPruneEvents] = `WebhookPruneEvents`
+	m[WebhookPruneOutbox] = `WebhookPruneOutbox`
+	m[WebhookRemove] = `WebhookRemove`
+	m[WebhookRetry] = `WebhookRetry`
+	m[WebhookShow] = `WebhookShow`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
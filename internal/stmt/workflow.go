@@ -74,26 +74,39 @@ JOIN   soma.check_instance_configurations scic
 WHERE  NOT sci.deleted
   AND  scic.status = $1::varchar;`
 
-	// Reset rollout status for retry of check instance
-	// configuration rollout
-	WorkflowRetry = `
-UPDATE soma.check_instance_configurations scic
-SET    status = (
-           CASE status
-           WHEN '` + proto.DeploymentRolloutFailed + `'::varchar     THEN '` + proto.DeploymentAwaitingRollout + `'::varchar
-           WHEN '` + proto.DeploymentDeprovisionFailed + `'::varchar THEN '` + proto.DeploymentAwaitingDeprovision + `'::varchar
-           END),
-       next_status = (
-           CASE status
-           WHEN '` + proto.DeploymentRolloutFailed + `'::varchar     THEN '` + proto.DeploymentRolloutInProgress + `'::varchar
-           WHEN '` + proto.DeploymentDeprovisionFailed + `'::varchar THEN '` + proto.DeploymentDeprovisionInProgress + `'::varchar
-           END)
+	// WorkflowCurrentConfig returns the current check instance
+	// configuration of a check instance and its deployment state
+	WorkflowCurrentConfig = `
+SELECT scic.check_instance_config_id,
+       scic.status
 FROM   soma.check_instances sci
-WHERE  sci.current_instance_config_id = scic.check_instance_config_id
-  AND  scic.status IN (
-         '` + proto.DeploymentRolloutFailed + `'::varchar,
-         '` + proto.DeploymentDeprovisionFailed + `'::varchar )
-  AND  sci.check_instance_id = $1::uuid;`
+JOIN   soma.check_instance_configurations scic
+  ON   sci.current_instance_config_id = scic.check_instance_config_id
+WHERE  sci.check_instance_id = $1::uuid;`
+
+	// WorkflowBulkCandidates returns the current check instance
+	// configurations in one of the deployment states $1 that match
+	// the optional monitoring system, repository and capability
+	// filters and have not changed their state since $5. Deleted
+	// check instances are included, since they are deprovisioned
+	// through the same workflow.
+	WorkflowBulkCandidates = `
+SELECT sci.check_instance_id,
+       scic.check_instance_config_id,
+       scic.status,
+       sci.deleted
+FROM   soma.check_instances sci
+JOIN   soma.check_instance_configurations scic
+  ON   sci.current_instance_config_id = scic.check_instance_config_id
+JOIN   soma.checks sc
+  ON   sci.check_id = sc.check_id
+WHERE  scic.status = ANY($1::varchar[])
+  AND  ( $2::uuid IS NULL OR scic.monitoring_id = $2::uuid )
+  AND  ( $3::uuid IS NULL OR sc.repository_id = $3::uuid )
+  AND  ( $4::uuid IS NULL OR sc.capability_id = $4::uuid )
+  AND  ( $5::timestamptz IS NULL
+         OR COALESCE(scic.status_last_updated_at, scic.created) < $5::timestamptz )
+ORDER  BY sci.check_instance_id;`
 
 	// Set update available flag for check instance
 	WorkflowUpdateAvailable = `
@@ -101,20 +114,89 @@ UPDATE soma.check_instances
 SET    update_available = 'true'::boolean
 WHERE  check_instance_id = $1::uuid;`
 
-	// Hard-set rollout status for check instance configuration
-	WorkflowSet = `
-UPDATE soma.check_instance_configurations
-SET    status = $2::varchar,
-       next_status = $3::varchar
+	// WorkflowConfigStatus returns the deployment state of a check
+	// instance configuration
+	WorkflowConfigStatus = `
+SELECT status
+FROM   soma.check_instance_configurations
 WHERE  check_instance_config_id = $1::uuid;`
+
+	// WorkflowTransition moves a check instance configuration from
+	// state $2 into state $3 with next status $4 and records the
+	// transition for actor $5. Nothing is updated if the configuration
+	// is no longer in state $2.
+	WorkflowTransition = `
+WITH previous AS (
+    SELECT check_instance_config_id,
+           check_instance_id,
+           status,
+           next_status
+    FROM   soma.check_instance_configurations
+    WHERE  check_instance_config_id = $1::uuid
+      AND  status = $2::varchar
+    FOR    UPDATE ),
+transition AS (
+    UPDATE soma.check_instance_configurations scic
+    SET    status = $3::varchar,
+           next_status = $4::varchar,
+           awaiting_deletion = ( $3::varchar = '` + proto.DeploymentAwaitingDeletion + `'::varchar ),
+           activated_at = (
+               CASE $3::varchar
+               WHEN '` + proto.DeploymentActive + `'::varchar THEN NOW()::timestamptz
+               ELSE scic.activated_at
+               END),
+           deprovisioned_at = (
+               CASE $3::varchar
+               WHEN '` + proto.DeploymentDeprovisioned + `'::varchar THEN NOW()::timestamptz
+               ELSE scic.deprovisioned_at
+               END),
+           status_last_updated_at = NOW()::timestamptz
+    FROM   previous
+    WHERE  scic.check_instance_config_id = previous.check_instance_config_id
+    RETURNING scic.check_instance_config_id )
+INSERT INTO soma.deployment_transitions (
+            check_instance_config_id,
+            check_instance_id,
+            previous_status,
+            previous_next_status,
+            status,
+            next_status,
+            actor)
+SELECT previous.check_instance_config_id,
+       previous.check_instance_id,
+       previous.status,
+       previous.next_status,
+       $3::varchar,
+       $4::varchar,
+       $5::varchar
+FROM   previous
+JOIN   transition
+  ON   previous.check_instance_config_id = transition.check_instance_config_id;`
+
+	// WorkflowHistory returns the recorded deployment state
+	// transitions of a check instance
+	WorkflowHistory = `
+SELECT check_instance_config_id,
+       previous_status,
+       previous_next_status,
+       status,
+       next_status,
+       actor,
+       occurred_at
+FROM   soma.deployment_transitions
+WHERE  check_instance_id = $1::uuid
+ORDER  BY transition_id;`
 )
 
 func init() {
+	m[WorkflowBulkCandidates] = `WorkflowBulkCandidates`
+	m[WorkflowConfigStatus] = `WorkflowConfigStatus`
+	m[WorkflowCurrentConfig] = `WorkflowCurrentConfig`
+	m[WorkflowHistory] = `WorkflowHistory`
 	m[WorkflowList] = `WorkflowList`
-	m[WorkflowRetry] = `WorkflowRetry`
 	m[WorkflowSearch] = `WorkflowSearch`
-	m[WorkflowSet] = `WorkflowSet`
 	m[WorkflowSummary] = `WorkflowSummary`
+	m[WorkflowTransition] = `WorkflowTransition`
 	m[WorkflowUpdateAvailable] = `WorkflowUpdateAvailable`
 }
 
@@ -74,12 +74,11 @@ func (s *Supervisor) activateUser(q *msg.Request, mr *msg.Result) {
 				return
 			}
 		case `token`:
-			str := `Mailtoken activation is not implemented`
-
-			mr.NotImplemented(fmt.Errorf(str), q.Section)
-			mr.Super.Audit.WithField(`Code`, mr.Code).
-				Errorln(str)
-			return
+			// token.Token is the mail token, it is consumed
+			// together with the activation
+			if !s.verifyMailToken(userID, token.Token, mr) {
+				return
+			}
 		default:
 			str := fmt.Sprintf("Unknown activation: %s",
 				s.conf.Auth.Activation)
@@ -124,6 +123,14 @@ func (s *Supervisor) activateUser(q *msg.Request, mr *msg.Result) {
 		return
 	}
 
+	// Consume the mail token together with the activation
+	if !s.conf.OpenInstance && s.activation == `token` {
+		if !s.consumeMailToken(tx, userID, token.Token, mr) {
+			tx.Rollback()
+			return
+		}
+	}
+
 	// Insert new credentials
 	if !s.saveCred(tx, token.UserName, msg.SubjectUser, userUUID,
 		mcf, validFrom.UTC(), credExpiresAt, mr) {
@@ -0,0 +1,236 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super // import "github.com/mjolnir42/soma/internal/super"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (s *Supervisor) apiKeyRead(q *msg.Request, mr *msg.Result) {
+	var userID, teamID string
+	var err error
+
+	if userID, teamID, err = s.apiKeyAccount(q, mr); err != nil {
+		return
+	}
+
+	switch q.Action {
+	case msg.ActionList:
+		s.apiKeyList(q, mr, userID, teamID)
+	case msg.ActionShow:
+		s.apiKeyShow(q, mr, userID, teamID)
+	}
+}
+
+func (s *Supervisor) apiKeyList(q *msg.Request, mr *msg.Result,
+	userID, teamID string) {
+	var (
+		err  error
+		rows *sql.Rows
+		key  proto.APIKey
+	)
+
+	if rows, err = s.stmtAPIKeyList.Query(
+		userID,
+		teamID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if key, err = scanAPIKey(rows); err != nil {
+			mr.ServerError(err, q.Section)
+			mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+			mr.APIKey = []proto.APIKey{}
+			return
+		}
+		mr.APIKey = append(mr.APIKey, key)
+	}
+	if err = rows.Err(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		mr.APIKey = []proto.APIKey{}
+		return
+	}
+	mr.OK()
+	mr.Super.Audit.WithField(`Code`, mr.Code).Infoln(`OK`)
+}
+
+func (s *Supervisor) apiKeyShow(q *msg.Request, mr *msg.Result,
+	userID, teamID string) {
+	var (
+		err error
+		key proto.APIKey
+	)
+
+	if key, err = scanAPIKey(s.stmtAPIKeyShow.QueryRow(
+		q.APIKey.ID,
+	)); err == sql.ErrNoRows {
+		mr.NotFound(fmt.Errorf("Unknown API key: %s", q.APIKey.ID),
+			q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// keys of other accounts are not disclosed
+	if key.UserID != userID && key.TeamID != teamID {
+		mr.NotFound(fmt.Errorf("Unknown API key: %s", q.APIKey.ID),
+			q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	if key.Networks, err = s.apiKeyNetworks(key.ID); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	if key.Permissions, err = s.apiKeyPermissions(key.ID); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	mr.APIKey = append(mr.APIKey, key)
+	mr.OK()
+	mr.Super.Audit.WithField(`Code`, mr.Code).Infoln(`OK`)
+}
+
+// apiKeyAccount returns the user and team ID of the account that
+// manages API keys with request q
+func (s *Supervisor) apiKeyAccount(q *msg.Request, mr *msg.Result) (string, string, error) {
+	var userID, teamID string
+
+	if err := s.stmtAPIKeyAccount.QueryRow(
+		q.AuthUser,
+	).Scan(
+		&userID,
+		&teamID,
+	); err == sql.ErrNoRows {
+		// admin and tool accounts are not found either
+		mr.Forbidden(fmt.Errorf(
+			"Account %s can not manage API keys", q.AuthUser),
+			q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return ``, ``, mr.Error
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return ``, ``, err
+	}
+	return userID, teamID, nil
+}
+
+// apiKeyNetworks returns the networks an API key may be used from
+func (s *Supervisor) apiKeyNetworks(keyID string) ([]string, error) {
+	var (
+		err      error
+		rows     *sql.Rows
+		network  string
+		networks []string
+	)
+
+	if rows, err = s.conn.Query(stmt.APIKeyNetworks, keyID); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = rows.Scan(&network); err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, rows.Err()
+}
+
+// apiKeyPermissions returns the permissions an API key may be used
+// for
+func (s *Supervisor) apiKeyPermissions(keyID string) ([]proto.Permission, error) {
+	var (
+		err         error
+		rows        *sql.Rows
+		permission  proto.Permission
+		permissions []proto.Permission
+	)
+
+	if rows, err = s.conn.Query(stmt.APIKeyPermissions, keyID); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		permission = proto.Permission{}
+		if err = rows.Scan(
+			&permission.ID,
+			&permission.Name,
+			&permission.Category,
+		); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+// scanAPIKey reads the result columns of stmt.APIKeyList and
+// stmt.APIKeyShow
+func scanAPIKey(row rowScanner) (proto.APIKey, error) {
+	var (
+		err                      error
+		key                      proto.APIKey
+		userID, userName, teamID sql.NullString
+		createdBy                string
+		createdAt                pq.NullTime
+		expiresAt, revokedAt     pq.NullTime
+	)
+
+	if err = row.Scan(
+		&key.ID,
+		&key.Name,
+		&userID,
+		&userName,
+		&teamID,
+		&createdBy,
+		&createdAt,
+		&expiresAt,
+		&revokedAt,
+	); err != nil {
+		return key, err
+	}
+	key.UserID = userID.String
+	key.UserName = userName.String
+	key.TeamID = teamID.String
+	key.ExpiresAt = formatNullTime(expiresAt)
+	key.RevokedAt = formatNullTime(revokedAt)
+	key.Details = &proto.DetailsCreation{
+		CreatedAt: formatNullTime(createdAt),
+		CreatedBy: createdBy,
+	}
+	return key, nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
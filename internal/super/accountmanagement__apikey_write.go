@@ -0,0 +1,316 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super // import "github.com/mjolnir42/soma/internal/super"
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
+	uuid "github.com/satori/go.uuid"
+)
+
+func (s *Supervisor) apiKeyWrite(q *msg.Request, mr *msg.Result) {
+	var userID, teamID string
+	var err error
+
+	if s.readonly {
+		mr.ReadOnly()
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	if userID, teamID, err = s.apiKeyAccount(q, mr); err != nil {
+		return
+	}
+
+	switch q.Action {
+	case msg.ActionCreate:
+		s.apiKeyCreate(q, mr, userID, teamID)
+	case msg.ActionRevoke:
+		s.apiKeyRevoke(q, mr, userID, teamID)
+	}
+
+	if mr.IsOK() {
+		go func() {
+			s.Update <- msg.CacheUpdateFromRequest(q)
+		}()
+	}
+}
+
+func (s *Supervisor) apiKeyCreate(q *msg.Request, mr *msg.Result,
+	userID, teamID string) {
+	var (
+		err                  error
+		tx                   *sql.Tx
+		res                  sql.Result
+		secret, hash         string
+		ownerUser, ownerTeam sql.NullString
+		expiresAt            time.Time
+	)
+
+	// keys must not be able to extend their own lifetime
+	if q.AuthAPIKey != `` {
+		mr.Forbidden(fmt.Errorf(
+			`API keys can not be used to create API keys`), q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	// keys are bound to either the account itself or its team
+	switch {
+	case q.APIKey.TeamID != `` && q.APIKey.UserID != ``:
+		mr.BadRequest(fmt.Errorf(
+			`API key can not be bound to a user and a team`), q.Section)
+	case q.APIKey.TeamID != `` && q.APIKey.TeamID != teamID:
+		mr.Forbidden(fmt.Errorf(
+			`API keys can only be created for the own team`), q.Section)
+	case q.APIKey.TeamID != ``:
+		ownerTeam.String, ownerTeam.Valid = teamID, true
+	case q.APIKey.UserID != `` && q.APIKey.UserID != userID:
+		mr.Forbidden(fmt.Errorf(
+			`API keys can only be created for the own account`), q.Section)
+	default:
+		q.APIKey.UserID = userID
+		q.APIKey.UserName = q.AuthUser
+		ownerUser.String, ownerUser.Valid = userID, true
+	}
+	if mr.Error != nil {
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	if err = s.apiKeyValidate(q); err != nil {
+		mr.BadRequest(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+	if q.APIKey.ExpiresAt != `` {
+		expiresAt, _ = time.Parse(msg.RFC3339Milli, q.APIKey.ExpiresAt)
+	}
+
+	if secret, hash, err = generateAPIKey(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	q.APIKey.ID = uuid.Must(uuid.NewV4()).String()
+	q.APIKey.RevokedAt = ``
+	q.APIKey.Details = &proto.DetailsCreation{
+		CreatedAt: time.Now().UTC().Format(msg.RFC3339Milli),
+		CreatedBy: q.AuthUser,
+	}
+	mr.Super.Audit = mr.Super.Audit.WithField(`APIKeyID`, q.APIKey.ID)
+
+	if tx, err = s.conn.Begin(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	defer tx.Rollback()
+
+	if res, err = tx.Exec(
+		stmt.APIKeyAdd,
+		q.APIKey.ID,
+		q.APIKey.Name,
+		hash,
+		ownerUser,
+		ownerTeam,
+		q.AuthUser,
+		q.APIKey.Details.CreatedAt,
+		nullTimestamp(q.APIKey.ExpiresAt),
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	if !mr.RowCnt(res.RowsAffected()) {
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	for _, network := range q.APIKey.Networks {
+		if _, err = tx.Exec(
+			stmt.APIKeyAddNetwork,
+			q.APIKey.ID,
+			network,
+		); err != nil {
+			mr.ServerError(err, q.Section)
+			mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+			return
+		}
+	}
+
+	for _, permission := range q.APIKey.Permissions {
+		if _, err = tx.Exec(
+			stmt.APIKeyAddPermission,
+			q.APIKey.ID,
+			permission.ID,
+			permission.Category,
+		); err != nil {
+			mr.ServerError(err, q.Section)
+			mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	if err = s.apiKeys.insert(q.APIKey.ID, hash, q.AuthUser, expiresAt,
+		q.APIKey.Networks); err != nil {
+		// the key is stored, but can not be used until the next
+		// restart
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// the secret is only returned once, it is not part of the
+	// cache update
+	key := q.APIKey.Clone()
+	key.Secret = secret
+	mr.APIKey = append(mr.APIKey, key)
+	mr.OK()
+	mr.Super.Audit.WithField(`Code`, mr.Code).Infoln(`OK`)
+}
+
+func (s *Supervisor) apiKeyRevoke(q *msg.Request, mr *msg.Result,
+	userID, teamID string) {
+	var (
+		err error
+		res sql.Result
+		key proto.APIKey
+	)
+	mr.Super.Audit = mr.Super.Audit.WithField(`APIKeyID`, q.APIKey.ID)
+
+	if key, err = scanAPIKey(s.stmtAPIKeyShow.QueryRow(
+		q.APIKey.ID,
+	)); err == sql.ErrNoRows {
+		mr.NotFound(fmt.Errorf("Unknown API key: %s", q.APIKey.ID),
+			q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// keys of other accounts are not disclosed
+	if key.UserID != userID && key.TeamID != teamID {
+		mr.NotFound(fmt.Errorf("Unknown API key: %s", q.APIKey.ID),
+			q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return
+	}
+
+	key.RevokedAt = time.Now().UTC().Format(msg.RFC3339Milli)
+	if res, err = s.conn.Exec(
+		stmt.APIKeyRevoke,
+		key.ID,
+		key.RevokedAt,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// revoked keys are removed even if they already had been
+	// revoked in the database
+	s.apiKeys.remove(key.ID)
+
+	if mr.RowCnt(res.RowsAffected()) {
+		mr.APIKey = append(mr.APIKey, key)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Infoln(`OK`)
+		return
+	}
+	mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+}
+
+// apiKeyValidate checks the name, expiry, networks and permissions
+// of the API key in q and normalizes them. Permissions given by
+// name are resolved to their ID.
+func (s *Supervisor) apiKeyValidate(q *msg.Request) error {
+	var (
+		err       error
+		expiresAt time.Time
+		network   *net.IPNet
+		id, name  string
+	)
+
+	if q.APIKey.Name == `` {
+		return fmt.Errorf(`API key requires a name`)
+	}
+
+	if q.APIKey.ExpiresAt != `` {
+		if expiresAt, err = time.Parse(time.RFC3339,
+			q.APIKey.ExpiresAt); err != nil {
+			return fmt.Errorf("Invalid expiresAt timestamp: %s",
+				err.Error())
+		}
+		if !expiresAt.After(time.Now()) {
+			return fmt.Errorf("API key would already be expired at %s",
+				q.APIKey.ExpiresAt)
+		}
+		q.APIKey.ExpiresAt = expiresAt.UTC().Format(msg.RFC3339Milli)
+	}
+
+	// single addresses are accepted as host networks
+	for i, n := range q.APIKey.Networks {
+		if !strings.Contains(n, `/`) {
+			if ip := net.ParseIP(n); ip != nil && ip.To4() != nil {
+				n = n + `/32`
+			} else {
+				n = n + `/128`
+			}
+		}
+		if _, network, err = net.ParseCIDR(n); err != nil {
+			return fmt.Errorf("Invalid network %s: %s",
+				q.APIKey.Networks[i], err.Error())
+		}
+		q.APIKey.Networks[i] = network.String()
+	}
+
+	if len(q.APIKey.Permissions) == 0 {
+		return fmt.Errorf(`API key requires at least one permission`)
+	}
+	for i := range q.APIKey.Permissions {
+		switch q.APIKey.Permissions[i].Category {
+		case msg.CategoryOmnipotence, msg.CategorySystem:
+			return fmt.Errorf("Permissions from category %s can not be"+
+				" used with API keys",
+				q.APIKey.Permissions[i].Category)
+		}
+		if err = s.stmtPermissionSearch.QueryRow(
+			q.APIKey.Permissions[i].Name,
+			q.APIKey.Permissions[i].Category,
+		).Scan(
+			&id,
+			&name,
+		); err == sql.ErrNoRows {
+			return fmt.Errorf("Unknown permission: %s::%s",
+				q.APIKey.Permissions[i].Category,
+				q.APIKey.Permissions[i].Name)
+		} else if err != nil {
+			return err
+		}
+		q.APIKey.Permissions[i].ID = id
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -61,7 +61,7 @@ func (s *Supervisor) passwordModify(q *msg.Request, mr *msg.Result) {
 
 	switch q.Super.Task {
 	case msg.TaskReset:
-		if !s.passwordReset(token, mr) {
+		if !s.passwordReset(token, userID, mr) {
 			return
 		}
 	case msg.TaskChange:
@@ -101,6 +101,14 @@ func (s *Supervisor) passwordModify(q *msg.Request, mr *msg.Result) {
 		return
 	}
 
+	// Consume the mail token together with the credential update
+	if q.Super.Task == msg.TaskReset && s.activation == `token` {
+		if !s.consumeMailToken(tx, userID, token.Token, mr) {
+			tx.Rollback()
+			return
+		}
+	}
+
 	// Invalidate existing credentials
 	if s.txExpireCred(tx, oldCredDeactivateAt, userUUID, mr) {
 		tx.Rollback()
@@ -22,7 +22,8 @@ import (
 
 // passwordReset performs the required verification for a password
 // reset
-func (s *Supervisor) passwordReset(token *auth.Token, mr *msg.Result) bool {
+func (s *Supervisor) passwordReset(token *auth.Token, userID string,
+	mr *msg.Result) bool {
 
 	// decrypt e2e encrypted request
 	// token.UserName is the username
@@ -44,12 +45,9 @@ func (s *Supervisor) passwordReset(token *auth.Token, mr *msg.Result) bool {
 			return false
 		}
 	case `token`:
-		mr.NotImplemented(fmt.Errorf(`Mail-Token not supported yet`),
-			mr.Section)
-		mr.Super.Audit.
-			WithField(`Code`, mr.Code).
-			Warningln(mr.Error)
-		return false
+		if !s.verifyMailToken(userID, token.Token, mr) {
+			return false
+		}
 	default:
 		mr.ServerError(fmt.Errorf("Unknown activation method: %s",
 			s.conf.Auth.Activation), mr.Section)
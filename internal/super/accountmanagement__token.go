@@ -39,19 +39,29 @@ func (s *Supervisor) token(q *msg.Request) {
 		WithField(`Request`, fmt.Sprintf("%s::%s", q.Section, q.Action)).
 		WithField(`Supervisor`, fmt.Sprintf("%s::%s=%s", q.Section, q.Action, q.Super.Task))
 
-	// tokenRequest/tokenInvalidate are master instance functions
+	// tokenRequest/tokenInvalidate/tokenMail are master instance functions
 	if s.readonly {
 		result.ReadOnly()
 		result.Super.Audit.WithField(`Code`, result.Code).Warningln(result.Error)
 		goto returnImmediate
 	}
 
+	// requests authenticated via API key carry no token that could
+	// be invalidated
+	if q.AuthAPIKey != `` {
+		result.Forbidden(fmt.Errorf(
+			`API keys can not be used to manage tokens`))
+		result.Super.Audit.WithField(`Code`, result.Code).Warningln(result.Error)
+		goto returnImmediate
+	}
+
 	// filter requests with invalid task
 	switch q.Super.Task {
 	case msg.TaskRequest:
 	case msg.TaskInvalidateGlobal:
 	case msg.TaskInvalidateAccount:
 	case msg.TaskInvalidate:
+	case msg.TaskMailToken:
 	default:
 		result.UnknownTask(q)
 		result.Super.Audit.WithField(`Code`, result.Code).Warningln(result.Error)
@@ -68,6 +78,8 @@ func (s *Supervisor) token(q *msg.Request) {
 		s.tokenInvalidateAccount(q, &result)
 	case msg.TaskInvalidate:
 		s.tokenInvalidate(q, &result)
+	case msg.TaskMailToken:
+		s.tokenMail(q, &result)
 	}
 
 	// wait for delay timer to trigger
@@ -0,0 +1,281 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super // import "github.com/mjolnir42/soma/internal/super"
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/internal/stmt"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// tokenMail handles requests to send a single-use mail token to a
+// user. The mail token can be used instead of the LDAP password to
+// activate the account or reset its password. The reply does not
+// disclose whether the account exists or a token was sent: requests
+// for unknown accounts perform the same database work, requests
+// within the cooldown of a previous token are ignored and the mail
+// is sent after the reply.
+func (s *Supervisor) tokenMail(q *msg.Request, mr *msg.Result) {
+	var (
+		err                   error
+		tx                    *sql.Tx
+		userID, mailAddress   string
+		mailToken, tokenHash  string
+		active, known, recent bool
+		validFrom, validUntil time.Time
+	)
+
+	// update auditlog entry
+	mr.Super.Audit = mr.Super.Audit.WithField(`UserName`, q.Super.MailTokenFor)
+
+	if s.activation != `token` {
+		str := `Mail tokens are not enabled`
+
+		mr.NotImplemented(fmt.Errorf(str), q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(str)
+		return
+	}
+
+	// root can not use mail tokens
+	if q.Super.MailTokenFor == msg.SubjectRoot {
+		str := `Invalid mail token request: root`
+
+		mr.BadRequest(fmt.Errorf(str), q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(str)
+		return
+	}
+
+	known = true
+	if err = s.stmtMailTokenUser.QueryRow(
+		q.Super.MailTokenFor,
+	).Scan(
+		&userID,
+		&mailAddress,
+		&active,
+	); err == sql.ErrNoRows {
+		// unknown accounts continue with a random ID that matches
+		// no user
+		known = false
+		userID = uuid.Must(uuid.NewV4()).String()
+	} else if err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// update auditlog entry
+	mr.Super.Audit = mr.Super.Audit.WithField(`UserID`, userID)
+
+	if mailToken, tokenHash, err = generateMailToken(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+	validFrom = time.Now().UTC()
+	validUntil = validFrom.Add(
+		time.Duration(s.mailTokenExpiry) * time.Minute).UTC()
+
+	// open multi statement transaction
+	if tx, err = s.conn.Begin(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	// only one mail token is issued per cooldown period
+	if err = tx.QueryRow(
+		stmt.MailTokenRecent,
+		userID,
+		validFrom.Add(
+			time.Duration(s.mailTokenCooldown)*time.Minute*-1).UTC(),
+	).Scan(
+		&recent,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		tx.Rollback()
+		return
+	}
+
+	// only the most recently requested mail token is valid
+	if _, err = tx.Exec(
+		stmt.MailTokenInvalidate,
+		userID,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		tx.Rollback()
+		return
+	}
+
+	switch {
+	case !known:
+		tx.Rollback()
+		mr.Super.Audit.WithField(`Code`, 404).
+			Warningln(`Mail token requested for unknown user`)
+		mr.Super.Verdict = 200
+		mr.OK()
+		return
+	case recent:
+		// the outstanding token remains valid
+		tx.Rollback()
+		mr.Super.Audit.WithField(`Code`, 429).
+			Warningln(`Mail token requested within cooldown`)
+		mr.Super.Verdict = 200
+		mr.OK()
+		return
+	}
+
+	// only the hash of the token is stored
+	if _, err = tx.Exec(
+		stmt.MailTokenInsert,
+		userID,
+		tokenHash,
+		validFrom,
+		validUntil,
+	); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		tx.Rollback()
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		mr.ServerError(err, q.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return
+	}
+
+	go s.sendMailToken(mr.Super.Audit, userID, q.Super.MailTokenFor,
+		mailAddress, mailToken, active, validUntil)
+
+	mr.Super.Verdict = 200
+	mr.OK()
+	mr.Super.Audit.Infoln(`Successfully issued mail token`)
+}
+
+// sendMailToken mails mailToken to the user. If the mail can not be
+// sent, the token is invalidated so that the user is not subject to
+// the cooldown of a token they never received.
+func (s *Supervisor) sendMailToken(audit *logrus.Entry, userID, userName,
+	mailAddress, mailToken string, active bool, validUntil time.Time) {
+	subject := `SOMA password reset`
+	if !active {
+		subject = `SOMA account activation`
+	}
+	body := fmt.Sprintf(
+		"A mail token was requested for the SOMA account %s.\n\n"+
+			"    %s\n\n"+
+			"The token can be used once and is valid until %s.\n"+
+			"If you did not request this token, you can ignore "+
+			"this mail.\n",
+		userName,
+		mailToken,
+		validUntil.Format(time.RFC3339),
+	)
+
+	if err := s.mailer.Send(mailAddress, subject, body); err != nil {
+		audit.WithField(`Code`, 500).Warningln(err)
+		if _, err = s.conn.Exec(
+			stmt.MailTokenInvalidate,
+			userID,
+		); err != nil {
+			audit.WithField(`Code`, 500).Warningln(err)
+		}
+		return
+	}
+	audit.Infoln(`Successfully sent mail token`)
+}
+
+// verifyMailToken checks that mailToken is a valid mail token for
+// userID without consuming it
+func (s *Supervisor) verifyMailToken(userID, mailToken string,
+	mr *msg.Result) bool {
+	var (
+		err   error
+		valid bool
+	)
+
+	if err = s.conn.QueryRow(
+		stmt.MailTokenVerify,
+		userID,
+		hashMailToken(mailToken),
+	).Scan(
+		&valid,
+	); err != nil {
+		mr.ServerError(err, mr.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return false
+	}
+	if !valid {
+		mr.Forbidden(fmt.Errorf(`Invalid mail token`))
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return false
+	}
+	return true
+}
+
+// consumeMailToken marks mailToken as used within tx, which must be
+// the transaction that updates the credentials. It fails if the
+// token is no longer valid.
+func (s *Supervisor) consumeMailToken(tx *sql.Tx, userID, mailToken string,
+	mr *msg.Result) bool {
+	var (
+		err      error
+		res      sql.Result
+		affected int64
+	)
+
+	if res, err = tx.Exec(
+		stmt.MailTokenConsume,
+		userID,
+		hashMailToken(mailToken),
+	); err != nil {
+		mr.ServerError(err, mr.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return false
+	}
+	if affected, err = res.RowsAffected(); err != nil {
+		mr.ServerError(err, mr.Section)
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(err)
+		return false
+	}
+	if affected != 1 {
+		mr.Forbidden(fmt.Errorf(`Invalid mail token`))
+		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+		return false
+	}
+	return true
+}
+
+// generateMailToken returns a new random mail token and its hash
+func generateMailToken() (string, string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ``, ``, err
+	}
+	token := hex.EncodeToString(buf)
+	return token, hashMailToken(token), nil
+}
+
+// hashMailToken returns the hash of token that is stored in the
+// database
+func hashMailToken(token string) string {
+	sum := sha512.Sum512([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
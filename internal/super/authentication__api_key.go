@@ -0,0 +1,94 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super // import "github.com/mjolnir42/soma/internal/super"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	uuid "github.com/satori/go.uuid"
+)
+
+// authenticateAPIKey performs BasicAuth authentication with an API
+// key. The BasicAuth user is the key ID prefixed with
+// msg.APIKeyUserPrefix, the password is the key's secret.
+func (s *Supervisor) authenticateAPIKey(q *msg.Request, mr *msg.Result) {
+	keyID := strings.TrimPrefix(q.Super.BasicAuth.User, msg.APIKeyUserPrefix)
+	mr.Super.Audit = mr.Super.Audit.WithField(`APIKeyID`, keyID)
+
+	if _, err := uuid.FromString(keyID); err != nil {
+		mr.NotFound(fmt.Errorf(`Unknown API key: invalid key ID`))
+		mr.Super.Audit.
+			WithField(`Code`, mr.Code).
+			Warningln(mr.Error)
+		return
+	}
+
+	// the rw instance knows every usable key, readonly instances
+	// load them on demand
+	key := s.apiKeys.read(keyID)
+	if key == nil && !s.readonly {
+		mr.NotFound(fmt.Errorf(
+			`Unknown API key: not found in in-memory APIKeyMap`))
+		mr.Super.Audit.
+			WithField(`Code`, mr.Code).
+			Warningln(mr.Error)
+		return
+	} else if key == nil {
+		if !s.fetchAPIKeyFromDB(keyID) {
+			mr.NotFound(fmt.Errorf(
+				`Unknown API key: not found in pgSQL database`))
+			mr.Super.Audit.
+				WithField(`Code`, mr.Code).
+				Warningln(mr.Error)
+			return
+		}
+		key = s.apiKeys.read(keyID)
+	}
+
+	if key.isExpired() {
+		mr.Unauthorized(fmt.Errorf(
+			`Authentication failed, API key expired`))
+		mr.Super.Audit.
+			WithField(`Code`, mr.Code).
+			Warningln(mr.Error)
+		return
+	}
+
+	if !key.verify(q.Super.BasicAuth.Token) {
+		mr.Unauthorized(fmt.Errorf(`Authentication failed`))
+		mr.Super.Audit.
+			WithField(`Code`, mr.Code).
+			Warningln(mr.Error)
+		return
+	}
+
+	if !key.permits(q.RemoteAddr) {
+		mr.Unauthorized(fmt.Errorf(
+			"Authentication failed, API key not permitted from %s",
+			q.RemoteAddr))
+		mr.Super.Audit.
+			WithField(`Code`, mr.Code).
+			Warningln(mr.Error)
+		return
+	}
+
+	// the request is performed as the account that created the key
+	mr.Super.BasicAuth.User = key.userName
+
+	mr.OK()
+	mr.Super.Verdict = 200
+	mr.Super.Audit.
+		WithField(`UserName`, key.userName).
+		WithField(`Code`, mr.Code).
+		WithField(`Verdict`, mr.Super.Verdict).
+		Infoln(`Authentication OK`)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
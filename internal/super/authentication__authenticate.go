@@ -42,6 +42,7 @@ func (s *Supervisor) authenticate(q *msg.Request) {
 	// filter requests with invalid task
 	switch q.Super.Task {
 	case msg.TaskBasicAuth:
+	case msg.TaskAPIKey:
 	default:
 		result.UnknownTask(q)
 		result.Super.Audit.
@@ -54,6 +55,8 @@ func (s *Supervisor) authenticate(q *msg.Request) {
 	switch q.Super.Task {
 	case msg.TaskBasicAuth:
 		s.authenticateBasicAuth(q, &result)
+	case msg.TaskAPIKey:
+		s.authenticateAPIKey(q, &result)
 	}
 
 unauthorized:
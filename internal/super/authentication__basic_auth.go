@@ -34,8 +34,8 @@ func (s *Supervisor) authenticateBasicAuth(q *msg.Request, mr *msg.Result) {
 	}
 
 	// basic auth always fails for root if root is restricted and
-	// the request comes from an unrestricted endpoint. Note: there
-	// are currently no restricted endpoints (https over unix socket)
+	// the request comes from an unrestricted endpoint. The only
+	// restricted endpoint is the optional admin UNIX socket
 	if q.Super.BasicAuth.User == msg.SubjectRoot && s.rootRestricted && !q.Super.RestrictedEndpoint {
 		mr.Forbidden(fmt.Errorf(
 			`Attempted root authentication on unrestricted endpoint`))
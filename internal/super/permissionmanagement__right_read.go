@@ -11,6 +11,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/lib/proto"
 )
@@ -122,6 +123,7 @@ func (s *Supervisor) rightListGlobal(q *msg.Request, mr *msg.Result) {
 		rows                            *sql.Rows
 		grantID                         string
 		adminID, userID, toolID, teamID sql.NullString
+		notBefore, notAfter             pq.NullTime
 	)
 
 	if rows, err = s.stmtListAuthorizationGlobal.Query(
@@ -140,6 +142,8 @@ func (s *Supervisor) rightListGlobal(q *msg.Request, mr *msg.Result) {
 			&userID,
 			&toolID,
 			&teamID,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			rows.Close()
 			mr.ServerError(err, q.Section)
@@ -150,6 +154,8 @@ func (s *Supervisor) rightListGlobal(q *msg.Request, mr *msg.Result) {
 			ID:           grantID,
 			PermissionID: q.Search.Grant.PermissionID,
 			Category:     q.Search.Grant.Category,
+			NotBefore:    formatNullTime(notBefore),
+			NotAfter:     formatNullTime(notAfter),
 		}
 		switch {
 		case adminID.Valid:
@@ -184,6 +190,7 @@ func (s *Supervisor) rightListScoped(q *msg.Request, mr *msg.Result) {
 		rows                                               *sql.Rows
 		adminID, userID, toolID, teamID                    sql.NullString
 		repositoryID, bucketID, groupID, clusterID, nodeID sql.NullString
+		notBefore, notAfter                                pq.NullTime
 	)
 
 	switch q.Grant.Category {
@@ -228,6 +235,8 @@ func (s *Supervisor) rightListScoped(q *msg.Request, mr *msg.Result) {
 			&groupID,
 			&clusterID,
 			&nodeID,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			rows.Close()
 			mr.ServerError(err, q.Section)
@@ -239,6 +248,8 @@ func (s *Supervisor) rightListScoped(q *msg.Request, mr *msg.Result) {
 			PermissionID: q.Search.Grant.PermissionID,
 			Category:     q.Search.Grant.Category,
 			ObjectType:   objType,
+			NotBefore:    formatNullTime(notBefore),
+			NotAfter:     formatNullTime(notAfter),
 		}
 		switch {
 		case adminID.Valid:
@@ -10,9 +10,11 @@ package super // import "github.com/mjolnir42/soma/internal/super"
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
+	"github.com/mjolnir42/soma/lib/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -49,6 +51,12 @@ func (s *Supervisor) rightWrite(q *msg.Request, mr *msg.Result) {
 			return
 		}
 
+		if err := validateGrantPeriod(&q.Grant); err != nil {
+			mr.BadRequest(err)
+			mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
+			return
+		}
+
 		switch q.Grant.Category {
 		case msg.CategorySystem,
 			msg.CategoryGlobal,
@@ -156,6 +164,8 @@ func (s *Supervisor) rightGrantGlobal(q *msg.Request, mr *msg.Result) {
 		q.Grant.PermissionID,
 		q.Grant.Category,
 		q.AuthUser,
+		nullTimestamp(q.Grant.NotBefore),
+		nullTimestamp(q.Grant.NotAfter),
 	); err != nil {
 		mr.ServerError(err, q.Section)
 		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
@@ -241,6 +251,8 @@ func (s *Supervisor) rightGrantRepository(q *msg.Request, mr *msg.Result) {
 		clusterID,
 		nodeID,
 		q.AuthUser,
+		nullTimestamp(q.Grant.NotBefore),
+		nullTimestamp(q.Grant.NotAfter),
 	); err != nil {
 		mr.ServerError(err, q.Section)
 		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
@@ -283,6 +295,8 @@ func (s *Supervisor) rightGrantTeam(q *msg.Request, mr *msg.Result) {
 		q.Grant.PermissionID,
 		q.Grant.ObjectID,
 		q.AuthUser,
+		nullTimestamp(q.Grant.NotBefore),
+		nullTimestamp(q.Grant.NotAfter),
 	); err != nil {
 		mr.ServerError(err, q.Section)
 		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
@@ -325,6 +339,8 @@ func (s *Supervisor) rightGrantMonitoring(q *msg.Request, mr *msg.Result) {
 		q.Grant.PermissionID,
 		q.Grant.ObjectID,
 		q.AuthUser,
+		nullTimestamp(q.Grant.NotBefore),
+		nullTimestamp(q.Grant.NotAfter),
 	); err != nil {
 		mr.ServerError(err, q.Section)
 		mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
@@ -422,4 +438,39 @@ func (s *Supervisor) rightRevokeMonitoring(q *msg.Request, mr *msg.Result) {
 	mr.Super.Audit.WithField(`Code`, mr.Code).Warningln(mr.Error)
 }
 
+// validateGrantPeriod checks the optional validity period of grant g
+// and normalizes its timestamps
+func validateGrantPeriod(g *proto.Grant) error {
+	var notBefore, notAfter time.Time
+	var err error
+
+	if g.NotBefore != `` {
+		if notBefore, err = time.Parse(time.RFC3339, g.NotBefore); err != nil {
+			return fmt.Errorf("Invalid notBefore timestamp: %s", err.Error())
+		}
+		g.NotBefore = notBefore.UTC().Format(msg.RFC3339Milli)
+	}
+	if g.NotAfter != `` {
+		if notAfter, err = time.Parse(time.RFC3339, g.NotAfter); err != nil {
+			return fmt.Errorf("Invalid notAfter timestamp: %s", err.Error())
+		}
+		if !notAfter.After(time.Now()) {
+			return fmt.Errorf("Grant would already be expired at %s",
+				g.NotAfter)
+		}
+		g.NotAfter = notAfter.UTC().Format(msg.RFC3339Milli)
+	}
+	if g.NotBefore != `` && g.NotAfter != `` && !notBefore.Before(notAfter) {
+		return fmt.Errorf("Grant notBefore %s is not before notAfter %s",
+			g.NotBefore, g.NotAfter)
+	}
+	return nil
+}
+
+// nullTimestamp returns ts as sql.NullString that is NULL if ts is
+// empty
+func nullTimestamp(ts string) sql.NullString {
+	return sql.NullString{String: ts, Valid: ts != ``}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
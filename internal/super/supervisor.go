@@ -15,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/mjolnir42/soma/internal/config"
 	"github.com/mjolnir42/soma/internal/handler"
+	"github.com/mjolnir42/soma/internal/mail"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/perm"
 	"github.com/mjolnir42/soma/internal/stmt"
@@ -39,19 +40,24 @@ type Supervisor struct {
 	tokenExpiry                       uint64
 	kexExpiry                         uint64
 	credExpiry                        uint64
+	mailTokenExpiry                   uint64
+	mailTokenCooldown                 uint64
 	activation                        string
 	rootDisabled                      bool
 	rootRestricted                    bool
 	kex                               *kexMap
 	tokens                            *tokenMap
 	credentials                       *credentialMap
+	apiKeys                           *apiKeyMap
 	permCache                         *perm.Cache
+	mailer                            mail.Sender
 	stmtTokenSelect                   *sql.Stmt
 	stmtFindUserID                    *sql.Stmt
 	stmtFindAdminID                   *sql.Stmt
 	stmtFindUserName                  *sql.Stmt
 	stmtCheckUserActive               *sql.Stmt
 	stmtCheckAdminActive              *sql.Stmt
+	stmtMailTokenUser                 *sql.Stmt
 	stmtCategoryList                  *sql.Stmt
 	stmtCategoryShow                  *sql.Stmt
 	stmtSectionList                   *sql.Stmt
@@ -70,6 +76,10 @@ type Supervisor struct {
 	stmtGrantAuthorizationRepository  *sql.Stmt
 	stmtGrantAuthorizationTeam        *sql.Stmt
 	stmtGrantAuthorizationMonitoring  *sql.Stmt
+	stmtExpireAuthorizationGlobal     *sql.Stmt
+	stmtExpireAuthorizationRepository *sql.Stmt
+	stmtExpireAuthorizationTeam       *sql.Stmt
+	stmtExpireAuthorizationMonitoring *sql.Stmt
 	stmtSearchAuthorizationGlobal     *sql.Stmt
 	stmtSearchAuthorizationRepository *sql.Stmt
 	stmtSearchAuthorizationTeam       *sql.Stmt
@@ -86,6 +96,10 @@ type Supervisor struct {
 	stmtPermissionSearch              *sql.Stmt
 	stmtPermissionMapEntry            *sql.Stmt
 	stmtPermissionUnmapEntry          *sql.Stmt
+	stmtAPIKeyAccount                 *sql.Stmt
+	stmtAPIKeyList                    *sql.Stmt
+	stmtAPIKeyShow                    *sql.Stmt
+	stmtAPIKeySelect                  *sql.Stmt
 	appLog                            *logrus.Logger
 	reqLog                            *logrus.Logger
 	errLog                            *logrus.Logger
@@ -127,7 +141,14 @@ func New(c *config.Config) *Supervisor {
 	s.tokenExpiry = s.conf.Auth.TokenExpirySeconds
 	s.kexExpiry = s.conf.Auth.KexExpirySeconds
 	s.credExpiry = s.conf.Auth.CredentialExpiryDays
+	s.mailTokenExpiry = s.conf.Auth.MailTokenExpiryMin
+	s.mailTokenCooldown = s.conf.Auth.MailTokenCooldownMin
 	s.activation = s.conf.Auth.Activation
+	if s.activation == `token` {
+		if s.mailer, err = mail.New(s.conf.Mail); err != nil {
+			panic(err)
+		}
+	}
 
 	// set package variable config for functions
 	cfg = c
@@ -183,6 +204,10 @@ func (s *Supervisor) RegisterRequests(hmap *handler.Map) {
 	hmap.Request(msg.SectionAction, msg.ActionAdd, `supervisor`)
 	hmap.Request(msg.SectionAction, msg.ActionRemove, `supervisor`)
 	hmap.Request(msg.SectionSystem, msg.ActionToken, `supervisor`)
+	hmap.Request(msg.SectionAPIKey, msg.ActionCreate, `supervisor`)
+	hmap.Request(msg.SectionAPIKey, msg.ActionRevoke, `supervisor`)
+	hmap.Request(msg.SectionAPIKey, msg.ActionList, `supervisor`)
+	hmap.Request(msg.SectionAPIKey, msg.ActionShow, `supervisor`)
 }
 
 // RegisterAuditLog initializes the audit log provided by the Soma app
@@ -211,6 +236,7 @@ func (s *Supervisor) Run() {
 	// initialize maps
 	s.tokens = newTokenMap()
 	s.credentials = newCredentialMap()
+	s.apiKeys = newAPIKeyMap()
 	s.kex = newKexMap()
 
 	// start permission cache
@@ -246,6 +272,10 @@ func (s *Supervisor) Run() {
 		stmt.ShowRepositoryAuthorization:   &s.stmtShowAuthorizationRepository,
 		stmt.ShowTeamAuthorization:         &s.stmtShowAuthorizationTeam,
 		stmt.ShowMonitoringAuthorization:   &s.stmtShowAuthorizationMonitoring,
+		stmt.APIKeyAccount:                 &s.stmtAPIKeyAccount,
+		stmt.APIKeyList:                    &s.stmtAPIKeyList,
+		stmt.APIKeyShow:                    &s.stmtAPIKeyShow,
+		stmt.APIKeySelect:                  &s.stmtAPIKeySelect,
 	} {
 		if *prepStmt, err = s.conn.Prepare(statement); err != nil {
 			s.errLog.Fatal(`supervisor`, err, stmt.Name(statement))
@@ -257,6 +287,7 @@ func (s *Supervisor) Run() {
 		for statement, prepStmt := range map[string]**sql.Stmt{
 			stmt.CheckUserActive:               &s.stmtCheckUserActive,
 			stmt.CheckAdminActive:              &s.stmtCheckAdminActive,
+			stmt.MailTokenUser:                 &s.stmtMailTokenUser,
 			stmt.SectionAdd:                    &s.stmtSectionAdd,
 			stmt.ActionAdd:                     &s.stmtActionAdd,
 			stmt.RevokeGlobalAuthorization:     &s.stmtRevokeAuthorizationGlobal,
@@ -267,6 +298,10 @@ func (s *Supervisor) Run() {
 			stmt.GrantRepositoryAuthorization:  &s.stmtGrantAuthorizationRepository,
 			stmt.GrantTeamAuthorization:        &s.stmtGrantAuthorizationTeam,
 			stmt.GrantMonitoringAuthorization:  &s.stmtGrantAuthorizationMonitoring,
+			stmt.ExpireGlobalAuthorization:     &s.stmtExpireAuthorizationGlobal,
+			stmt.ExpireRepositoryAuthorization: &s.stmtExpireAuthorizationRepository,
+			stmt.ExpireTeamAuthorization:       &s.stmtExpireAuthorizationTeam,
+			stmt.ExpireMonitoringAuthorization: &s.stmtExpireAuthorizationMonitoring,
 			stmt.PermissionMapEntry:            &s.stmtPermissionMapEntry,
 			stmt.PermissionUnmapEntry:          &s.stmtPermissionUnmapEntry,
 		} {
@@ -348,6 +383,8 @@ func (s *Supervisor) process(q *msg.Request) {
 		s.action(q)
 	case msg.SectionSystem:
 		s.token(q)
+	case msg.SectionAPIKey:
+		s.apiKey(q)
 	}
 }
 
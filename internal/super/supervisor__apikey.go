@@ -0,0 +1,150 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super // import "github.com/mjolnir42/soma/internal/super"
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+)
+
+// apiKeySecretBytes is the amount of random data in an API key
+const apiKeySecretBytes = 32
+
+// apiKey is the internal storage format for API keys
+type apiKey struct {
+	hash      []byte
+	userName  string
+	expiresAt time.Time
+	networks  []*net.IPNet
+}
+
+// isExpired returns if the key is expired. Keys without expiry
+// have a zero expiresAt.
+func (k *apiKey) isExpired() bool {
+	return !k.expiresAt.IsZero() &&
+		time.Now().UTC().After(k.expiresAt.UTC())
+}
+
+// verify returns true if secret is the secret of the key
+func (k *apiKey) verify(secret string) bool {
+	sum := sha512.Sum512([]byte(secret))
+	return subtle.ConstantTimeCompare(sum[:], k.hash) == 1
+}
+
+// permits returns true if the key may be used from addr. Keys
+// without networks can be used from everywhere.
+func (k *apiKey) permits(addr string) bool {
+	if len(k.networks) == 0 {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range k.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiKeyMap is a read/write locked map of API keys
+type apiKeyMap struct {
+	// keyID -> apiKey
+	KMap  map[string]apiKey
+	mutex sync.RWMutex
+}
+
+// newAPIKeyMap returns a new apiKeyMap
+func newAPIKeyMap() *apiKeyMap {
+	m := apiKeyMap{}
+	m.KMap = make(map[string]apiKey)
+	return &m
+}
+
+// read returns a copy of the requested key
+func (m *apiKeyMap) read(keyID string) *apiKey {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	if k, ok := m.KMap[keyID]; ok {
+		return &k
+	}
+	return nil
+}
+
+// insert adds a key to the apiKeyMap. hash is the hex encoded hash
+// of the key's secret.
+func (m *apiKeyMap) insert(keyID, hash, userName string,
+	expiresAt time.Time, networks []string) error {
+	var (
+		err     error
+		binHash []byte
+		network *net.IPNet
+	)
+	if binHash, err = hex.DecodeString(hash); err != nil {
+		return err
+	}
+	k := apiKey{
+		hash:      binHash,
+		userName:  userName,
+		expiresAt: expiresAt,
+		networks:  make([]*net.IPNet, 0, len(networks)),
+	}
+	for _, n := range networks {
+		if _, network, err = net.ParseCIDR(n); err != nil {
+			return err
+		}
+		k.networks = append(k.networks, network)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.KMap[keyID] = k
+	return nil
+}
+
+// remove deletes a key from the apiKeyMap
+func (m *apiKeyMap) remove(keyID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.KMap, keyID)
+}
+
+// sweep deletes all expired keys. If all is true, every key is
+// deleted; this is used on readonly instances, which load keys on
+// demand and would otherwise never notice revocations.
+func (m *apiKeyMap) sweep(all bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for keyID := range m.KMap {
+		k := m.KMap[keyID]
+		if all || k.isExpired() {
+			delete(m.KMap, keyID)
+		}
+	}
+}
+
+// generateAPIKey returns a new random API key secret and the hex
+// encoded hash of it that is stored in the database
+func generateAPIKey() (string, string, error) {
+	buf := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return ``, ``, err
+	}
+	secret := hex.EncodeToString(buf)
+	sum := sha512.Sum512([]byte(secret))
+	return secret, hex.EncodeToString(sum[:]), nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
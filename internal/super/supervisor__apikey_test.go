@@ -0,0 +1,119 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package super
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKeyVerify(t *testing.T) {
+	secret, hash, err := generateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newAPIKeyMap()
+	if err = m.insert(`key`, hash, `alice`, time.Time{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	k := m.read(`key`)
+
+	tests := []struct {
+		name   string
+		secret string
+		ok     bool
+	}{
+		{`correct secret`, secret, true},
+		{`empty secret`, ``, false},
+		{`truncated secret`, secret[:len(secret)-1], false},
+		{`hash as secret`, hash, false},
+	}
+
+	for _, test := range tests {
+		if ok := k.verify(test.secret); ok != test.ok {
+			t.Errorf("%s: expected %t, got %t", test.name, test.ok, ok)
+		}
+	}
+}
+
+func TestAPIKeyPermits(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []string
+		addr     string
+		ok       bool
+	}{
+		{`no networks`, nil, `192.0.2.1`, true},
+		{`no networks unparseable`, nil, `localhost`, true},
+		{`inside`, []string{`192.0.2.0/24`}, `192.0.2.17`, true},
+		{`inside second`, []string{`198.51.100.0/24`, `192.0.2.0/24`},
+			`192.0.2.17`, true},
+		{`inside ipv6`, []string{`2001:db8::/32`}, `2001:db8::1`, true},
+		{`outside`, []string{`192.0.2.0/24`}, `198.51.100.1`, false},
+		{`outside ipv6`, []string{`192.0.2.0/24`}, `2001:db8::1`, false},
+		{`unparseable`, []string{`192.0.2.0/24`}, `localhost`, false},
+		{`with port`, []string{`192.0.2.0/24`}, `192.0.2.17:443`, false},
+		{`empty`, []string{`192.0.2.0/24`}, ``, false},
+	}
+
+	for _, test := range tests {
+		m := newAPIKeyMap()
+		if err := m.insert(`key`, ``, `alice`, time.Time{},
+			test.networks); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if ok := m.read(`key`).permits(test.addr); ok != test.ok {
+			t.Errorf("%s: expected %t, got %t", test.name, test.ok, ok)
+		}
+	}
+}
+
+func TestAPIKeyIsExpired(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		expired   bool
+	}{
+		{`no expiry`, time.Time{}, false},
+		{`future`, now.Add(time.Hour), false},
+		{`past`, now.Add(-time.Hour), true},
+		{`other timezone future`,
+			now.Add(time.Hour).In(time.FixedZone(`UTC-10`, -10*3600)),
+			false},
+		{`other timezone past`,
+			now.Add(-time.Hour).In(time.FixedZone(`UTC+10`, 10*3600)),
+			true},
+	}
+
+	for _, test := range tests {
+		k := apiKey{expiresAt: test.expiresAt}
+		if expired := k.isExpired(); expired != test.expired {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expired,
+				expired)
+		}
+	}
+}
+
+func TestAPIKeyMapInsertInvalid(t *testing.T) {
+	m := newAPIKeyMap()
+	if err := m.insert(`key`, `not hex`, `alice`, time.Time{},
+		nil); err == nil {
+		t.Error(`invalid hash did not error`)
+	}
+	if err := m.insert(`key`, ``, `alice`, time.Time{},
+		[]string{`192.0.2.1`}); err == nil {
+		t.Error(`network without prefix length did not error`)
+	}
+	if m.read(`key`) != nil {
+		t.Error(`invalid key was inserted`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
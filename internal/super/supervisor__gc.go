@@ -8,8 +8,13 @@
 package super // import "github.com/mjolnir42/soma/internal/super"
 
 import (
+	"database/sql"
 	"encoding/hex"
 	"sync"
+	"time"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
 )
 
 // gc runs garbage collection on various supervisor data structures
@@ -41,6 +46,16 @@ func (s *Supervisor) gc() {
 	// remove old token expire entries
 	s.appLog.Debug(`Supervisor.GC cleanup expired tokens`)
 	s.tokens.cleanExpireUnlocked()
+
+	// remove permission grants that reached the end of their
+	// validity period
+	s.appLog.Debug(`Supervisor.GC expiring permission grants`)
+	s.gcExpireGrants()
+
+	// remove expired API keys. Readonly instances drop all cached
+	// keys, since they can not see revocations
+	s.appLog.Debug(`Supervisor.GC cleanup expired API keys`)
+	s.apiKeys.sweep(s.readonly)
 	s.appLog.Debug(`Supervisor.GC FINISHED`)
 }
 
@@ -104,6 +119,82 @@ func (s *Supervisor) gcMarkCredentials() {
 	}
 }
 
+// gcExpireGrants deletes all permission grants whose validity period
+// has ended, revokes them from the permission cache and records the
+// expiry in the audit log. The permission cache already refuses
+// expired grants, this keeps the database and the cache from
+// accumulating them.
+func (s *Supervisor) gcExpireGrants() {
+	if s.readonly {
+		return
+	}
+
+	for _, statement := range []*sql.Stmt{
+		s.stmtExpireAuthorizationGlobal,
+		s.stmtExpireAuthorizationRepository,
+		s.stmtExpireAuthorizationTeam,
+		s.stmtExpireAuthorizationMonitoring,
+	} {
+		rows, err := statement.Query()
+		if err != nil {
+			s.errLog.Errorln(`Supervisor.GC: expire grants:`, err)
+			continue
+		}
+
+		for rows.Next() {
+			var (
+				grant      proto.Grant
+				objectType string
+				objectID   sql.NullString
+				notAfter   time.Time
+			)
+			if err = rows.Scan(
+				&grant.ID,
+				&grant.RecipientType,
+				&grant.RecipientID,
+				&grant.PermissionID,
+				&grant.Category,
+				&objectType,
+				&objectID,
+				&notAfter,
+			); err != nil {
+				s.errLog.Errorln(`Supervisor.GC: expire grants:`, err)
+				continue
+			}
+			grant.ObjectType = objectType
+			grant.ObjectID = objectID.String
+			grant.NotAfter = notAfter.UTC().Format(msg.RFC3339Milli)
+
+			s.auditLog.
+				WithField(`UserName`, `supervisor`).
+				WithField(`Section`, msg.SectionRight).
+				WithField(`Action`, msg.ActionRevoke).
+				WithField(`GrantID`, grant.ID).
+				WithField(`Category`, grant.Category).
+				WithField(`PermissionID`, grant.PermissionID).
+				WithField(`RecipientType`, grant.RecipientType).
+				WithField(`RecipientID`, grant.RecipientID).
+				WithField(`ObjectType`, grant.ObjectType).
+				WithField(`ObjectID`, grant.ObjectID).
+				WithField(`NotAfter`, grant.NotAfter).
+				WithField(`Code`, 200).
+				Infoln(`Grant expired`)
+
+			go func(q msg.Request) {
+				s.Update <- msg.CacheUpdateFromRequest(&q)
+			}(msg.Request{
+				Section: msg.SectionRight,
+				Action:  msg.ActionRevoke,
+				Grant:   grant,
+			})
+		}
+		if err = rows.Err(); err != nil {
+			s.errLog.Errorln(`Supervisor.GC: expire grants:`, err)
+		}
+		rows.Close()
+	}
+}
+
 // gcSweep removes data marked for garbage collection
 func (s *Supervisor) gcSweep() {
 	wg := sync.WaitGroup{}
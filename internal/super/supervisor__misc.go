@@ -14,10 +14,12 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/mjolnir42/scrypth64"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
 	"github.com/mjolnir42/soma/lib/auth"
+	"github.com/mjolnir42/soma/lib/proto"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -45,6 +47,67 @@ func (s *Supervisor) fetchTokenFromDB(token string) bool {
 	return false
 }
 
+// fetchAPIKeyFromDB loads a usable API key from the database into
+// the in-memory map and the permission cache (readonly instances)
+func (s *Supervisor) fetchAPIKeyFromDB(keyID string) bool {
+	var (
+		err            error
+		hash, userName string
+		userID, teamID sql.NullString
+		expiresAt      pq.NullTime
+		networks       []string
+		permissions    []proto.Permission
+		expiry         time.Time
+	)
+
+	err = s.stmtAPIKeySelect.QueryRow(keyID).Scan(
+		&hash,
+		&userID,
+		&teamID,
+		&userName,
+		&expiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return false
+	} else if err != nil {
+		s.errLog.WithField(`Function`, `fetchAPIKeyFromDB`).Errorln(err)
+		return false
+	}
+
+	if networks, err = s.apiKeyNetworks(keyID); err != nil {
+		s.errLog.WithField(`Function`, `fetchAPIKeyFromDB`).Errorln(err)
+		return false
+	}
+	if permissions, err = s.apiKeyPermissions(keyID); err != nil {
+		s.errLog.WithField(`Function`, `fetchAPIKeyFromDB`).Errorln(err)
+		return false
+	}
+	if expiresAt.Valid {
+		expiry = expiresAt.Time.UTC()
+	}
+
+	if err = s.apiKeys.insert(keyID, hash, userName, expiry,
+		networks); err != nil {
+		s.errLog.WithField(`Function`, `fetchAPIKeyFromDB`).Errorln(err)
+		return false
+	}
+
+	// the permission cache must know the key before the request
+	// authenticated with it is authorized. Cache updates are
+	// processed before regular requests.
+	s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
+		Section: msg.SectionAPIKey,
+		Action:  msg.ActionCreate,
+		APIKey: proto.APIKey{
+			ID:          keyID,
+			UserID:      userID.String,
+			TeamID:      teamID.String,
+			Permissions: permissions,
+		},
+	})
+	return true
+}
+
 func (s *Supervisor) fetchRootToken() (string, error) {
 	var (
 		err   error
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/mjolnir42/scrypth64"
 	"github.com/mjolnir42/soma/internal/msg"
 	"github.com/mjolnir42/soma/internal/stmt"
@@ -30,6 +31,8 @@ func (s *Supervisor) startupLoad() {
 
 	s.startupTokens()
 
+	s.startupAPIKeys()
+
 	s.startupTeam()
 
 	s.startupUser()
@@ -229,6 +232,83 @@ func (s *Supervisor) startupTokens() {
 	}
 }
 
+func (s *Supervisor) startupAPIKeys() {
+	var (
+		err                  error
+		keyID, hash, creator string
+		userID, teamID       sql.NullString
+		expiresAt            pq.NullTime
+		rows                 *sql.Rows
+		keys                 []proto.APIKey
+		hashes, creators     map[string]string
+		expiry               map[string]time.Time
+	)
+	hashes = map[string]string{}
+	creators = map[string]string{}
+	expiry = map[string]time.Time{}
+
+	rows, err = s.conn.Query(stmt.APIKeyLoad)
+	if err != nil {
+		s.errLog.Fatal(`supervisor/load-apikeys,query: `, err)
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(
+			&keyID,
+			&hash,
+			&userID,
+			&teamID,
+			&creator,
+			&expiresAt,
+		); err != nil {
+			rows.Close()
+			s.errLog.Fatal(`supervisor/load-apikeys,scan: `, err)
+		}
+		keys = append(keys, proto.APIKey{
+			ID:     keyID,
+			UserID: userID.String,
+			TeamID: teamID.String,
+		})
+		hashes[keyID] = hash
+		creators[keyID] = creator
+		if expiresAt.Valid {
+			expiry[keyID] = expiresAt.Time
+		}
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		s.errLog.Fatal(`supervisor/load-apikeys,next: `, err)
+	}
+	rows.Close()
+
+	for i := range keys {
+		if keys[i].Networks, err = s.apiKeyNetworks(
+			keys[i].ID); err != nil {
+			s.errLog.Fatal(`supervisor/load-apikeys,networks: `, err)
+		}
+		if keys[i].Permissions, err = s.apiKeyPermissions(
+			keys[i].ID); err != nil {
+			s.errLog.Fatal(`supervisor/load-apikeys,permissions: `, err)
+		}
+		if err = s.apiKeys.insert(
+			keys[i].ID,
+			hashes[keys[i].ID],
+			creators[keys[i].ID],
+			expiry[keys[i].ID],
+			keys[i].Networks,
+		); err != nil {
+			s.errLog.Fatal(`supervisor/load-apikeys,insert: `, err)
+		}
+		go func(key proto.APIKey) {
+			s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
+				Section: msg.SectionAPIKey,
+				Action:  msg.ActionCreate,
+				APIKey:  key,
+			})
+		}(keys[i])
+	}
+}
+
 func (s *Supervisor) startupTeam() {
 	var (
 		err              error
@@ -634,6 +714,7 @@ func (s *Supervisor) startupGrantGlobalAuthorization() {
 		grantID, permissionID, category     string
 		recipientType, recipientID          string
 		nAdminID, nUserID, nToolID, nTeamID sql.NullString
+		notBefore, notAfter                 pq.NullTime
 		rows                                *sql.Rows
 	)
 
@@ -652,6 +733,8 @@ func (s *Supervisor) startupGrantGlobalAuthorization() {
 			&nTeamID,
 			&permissionID,
 			&category,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			s.errLog.Fatal(`supervisor/load-grant,scan: `, err)
 		}
@@ -671,7 +754,7 @@ func (s *Supervisor) startupGrantGlobalAuthorization() {
 			recipientType = msg.SubjectTeam
 			recipientID = nTeamID.String
 		}
-		go func(gID, cat, pID, rTyp, rID string) {
+		go func(gID, cat, pID, rTyp, rID, nb, na string) {
 			s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
 				Section: msg.SectionRight,
 				Action:  msg.ActionGrant,
@@ -681,9 +764,12 @@ func (s *Supervisor) startupGrantGlobalAuthorization() {
 					PermissionID:  pID,
 					RecipientType: rTyp,
 					RecipientID:   rID,
+					NotBefore:     nb,
+					NotAfter:      na,
 				},
 			})
-		}(grantID, category, permissionID, recipientType, recipientID)
+		}(grantID, category, permissionID, recipientType, recipientID,
+			formatNullTime(notBefore), formatNullTime(notAfter))
 
 		s.appLog.Infof("supervisor/startup: permCache update - loaded right grant: %s|%s|%s|%s|%s",
 			grantID,
@@ -706,6 +792,7 @@ func (s *Supervisor) startupGrantRepositoryAuthorization() {
 		entityType, entityID                              string
 		nUserID, nToolID, nTeamID                         sql.NullString
 		nRepoID, nBucketID, nGroupID, nClusterID, nNodeID sql.NullString
+		notBefore, notAfter                               pq.NullTime
 		rows                                              *sql.Rows
 	)
 
@@ -729,6 +816,8 @@ func (s *Supervisor) startupGrantRepositoryAuthorization() {
 			&nGroupID,
 			&nClusterID,
 			&nNodeID,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			s.errLog.Fatal(`supervisor/load-grant-repository,scan: `, err)
 		}
@@ -777,7 +866,7 @@ func (s *Supervisor) startupGrantRepositoryAuthorization() {
 			}
 			entityID = nNodeID.String
 		}
-		go func(gID, cat, pID, rTyp, rID, oTyp, oID string) {
+		go func(gID, cat, pID, rTyp, rID, oTyp, oID, nb, na string) {
 			s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
 				Section: msg.SectionRight,
 				Action:  msg.ActionGrant,
@@ -789,9 +878,12 @@ func (s *Supervisor) startupGrantRepositoryAuthorization() {
 					RecipientID:   rID,
 					ObjectType:    oTyp,
 					ObjectID:      oID,
+					NotBefore:     nb,
+					NotAfter:      na,
 				},
 			})
-		}(grantID, category, permissionID, recipientType, recipientID, entityType, entityID)
+		}(grantID, category, permissionID, recipientType, recipientID, entityType, entityID,
+			formatNullTime(notBefore), formatNullTime(notAfter))
 
 		s.appLog.Infof("supervisor/startup: permCache update - loaded repository right grant: %s|%s|%s|%s|%s|%s|%s",
 			grantID,
@@ -814,6 +906,7 @@ func (s *Supervisor) startupGrantMonitoringAuthorization() {
 		grantID, permissionID, monitoringID, category string
 		recipientType, recipientID                    string
 		nUserID, nToolID, nTeamID                     sql.NullString
+		notBefore, notAfter                           pq.NullTime
 		rows                                          *sql.Rows
 	)
 
@@ -832,6 +925,8 @@ func (s *Supervisor) startupGrantMonitoringAuthorization() {
 			&monitoringID,
 			&permissionID,
 			&category,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			s.errLog.Fatal(`supervisor/load-grant-monitoring,scan: `, err)
 		}
@@ -848,7 +943,7 @@ func (s *Supervisor) startupGrantMonitoringAuthorization() {
 			recipientType = msg.SubjectTeam
 			recipientID = nTeamID.String
 		}
-		go func(gID, cat, pID, rTyp, rID, oID string) {
+		go func(gID, cat, pID, rTyp, rID, oID, nb, na string) {
 			s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
 				Section: msg.SectionRight,
 				Action:  msg.ActionGrant,
@@ -860,9 +955,12 @@ func (s *Supervisor) startupGrantMonitoringAuthorization() {
 					RecipientID:   rID,
 					ObjectType:    msg.EntityMonitoring,
 					ObjectID:      oID,
+					NotBefore:     nb,
+					NotAfter:      na,
 				},
 			})
-		}(grantID, category, permissionID, recipientType, recipientID, monitoringID)
+		}(grantID, category, permissionID, recipientType, recipientID, monitoringID,
+			formatNullTime(notBefore), formatNullTime(notAfter))
 
 		s.appLog.Infof("supervisor/startup: permCache update - loaded monitoring right grant: %s|%s|%s|%s|%s|%s",
 			grantID,
@@ -884,6 +982,7 @@ func (s *Supervisor) startupGrantTeamAuthorization() {
 		grantID, permissionID, targetTeamID, category string
 		recipientType, recipientID                    string
 		nUserID, nToolID, nTeamID                     sql.NullString
+		notBefore, notAfter                           pq.NullTime
 		rows                                          *sql.Rows
 	)
 
@@ -902,6 +1001,8 @@ func (s *Supervisor) startupGrantTeamAuthorization() {
 			&targetTeamID,
 			&permissionID,
 			&category,
+			&notBefore,
+			&notAfter,
 		); err != nil {
 			s.errLog.Fatal(`supervisor/load-grant-team,scan: `, err)
 		}
@@ -918,7 +1019,7 @@ func (s *Supervisor) startupGrantTeamAuthorization() {
 			recipientType = msg.SubjectTeam
 			recipientID = nTeamID.String
 		}
-		go func(gID, cat, pID, rTyp, rID, oID string) {
+		go func(gID, cat, pID, rTyp, rID, oID, nb, na string) {
 			s.Update <- msg.CacheUpdateFromRequest(&msg.Request{
 				Section: msg.SectionRight,
 				Action:  msg.ActionGrant,
@@ -930,9 +1031,12 @@ func (s *Supervisor) startupGrantTeamAuthorization() {
 					RecipientID:   rID,
 					ObjectType:    msg.EntityTeam,
 					ObjectID:      oID,
+					NotBefore:     nb,
+					NotAfter:      na,
 				},
 			})
-		}(grantID, category, permissionID, recipientType, recipientID, targetTeamID)
+		}(grantID, category, permissionID, recipientType, recipientID, targetTeamID,
+			formatNullTime(notBefore), formatNullTime(notAfter))
 
 		s.appLog.Infof("supervisor/startup: permCache update - loaded team right grant: %s|%s|%s|%s|%s|%s",
 			grantID,
@@ -948,4 +1052,13 @@ func (s *Supervisor) startupGrantTeamAuthorization() {
 	}
 }
 
+// formatNullTime returns t formatted as RFC3339Milli, or the empty
+// string if t is NULL
+func formatNullTime(t pq.NullTime) string {
+	if !t.Valid {
+		return ``
+	}
+	return t.Time.UTC().Format(msg.RFC3339Milli)
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -28,6 +28,7 @@ func TestAttachRepository(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -71,6 +72,7 @@ func TestAttachBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -130,6 +132,7 @@ func TestAttachGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -201,6 +204,7 @@ func TestAttachGroupToGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -282,6 +286,7 @@ func TestAttachCluster(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -353,6 +358,7 @@ func TestAttachNode(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -429,6 +435,7 @@ func TestAttachNodeToGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -517,6 +524,7 @@ func TestMoveNodeToGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -616,6 +624,7 @@ func TestMoveClusterToGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -711,6 +720,7 @@ func TestMoveGroupToGroup(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -807,6 +817,7 @@ func TestMoveNodeToCluster(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -906,6 +917,7 @@ func TestDetachGroupToBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1007,6 +1019,7 @@ func TestDetachClusterToBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1110,6 +1123,7 @@ func TestDetachNodeToBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1239,6 +1253,7 @@ func TestDestroyRepository(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1373,6 +1388,7 @@ func TestDestroyBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1502,6 +1518,7 @@ func TestRollbackDetachNodeToBucket(t *testing.T) {
 		ID:     rootID,
 		Name:   `root_testing`,
 		Action: actionC,
+		Log:    newDiscardLogger(),
 	})
 	sTree.RegisterErrChan(errC)
 
@@ -1636,6 +1653,10 @@ func TestRollbackDetachNodeToBucket(t *testing.T) {
 	}
 }
 
+// newDiscardLogger returns a logger that drops all output. Every tree
+// built by the tests in this file gets one, since moving elements runs
+// updateCheckInstances, which logs through the tree logger and panics
+// on a nil logger (TestMoveNodeToGroup).
 func newDiscardLogger() *logrus.Logger {
 	discardLog := logrus.New()
 	discardLog.Out = ioutil.Discard
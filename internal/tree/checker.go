@@ -12,8 +12,12 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
+	"path"
+	"regexp"
 	"sort"
+	"strconv"
 
 	"github.com/mjolnir42/soma/lib/proto"
 
@@ -34,6 +38,11 @@ type Checker interface {
 	deleteCheckLocalAll()
 	rmCheck(c Check)
 
+	UpdateCheck(c Check)
+	updateCheckInherited(c Check)
+	updateCheckOnChildren(c Check)
+	switchCheck(c Check) bool
+
 	syncCheck(childID string)
 	checkCheck(checkID string) bool
 }
@@ -103,6 +112,41 @@ func (c *Check) Clone() Check {
 	return ng
 }
 
+// updateFrom returns a copy of c with the configurable fields
+// replaced by the values from u. Identifiers and inheritance
+// information of c are retained.
+func (c *Check) updateFrom(u Check) Check {
+	ng := c.Clone()
+	ng.Interval = u.Interval
+	ng.Inheritance = u.Inheritance
+	ng.ChildrenOnly = u.ChildrenOnly
+
+	ng.Thresholds = make([]CheckThreshold, len(u.Thresholds))
+	for i := range u.Thresholds {
+		ng.Thresholds[i] = u.Thresholds[i].Clone()
+	}
+
+	ng.Constraints = make([]CheckConstraint, len(u.Constraints))
+	for i := range u.Constraints {
+		ng.Constraints[i] = u.Constraints[i].Clone()
+	}
+	return ng
+}
+
+// sameSettings reports whether c and u share the interval and
+// thresholds, which are part of every check instance configuration
+func (c *Check) sameSettings(u Check) bool {
+	if c.Interval != u.Interval || len(c.Thresholds) != len(u.Thresholds) {
+		return false
+	}
+	for i := range c.Thresholds {
+		if c.Thresholds[i] != u.Thresholds[i] {
+			return false
+		}
+	}
+	return true
+}
+
 type CheckItem struct {
 	ObjectID   uuid.UUID
 	ObjectType string
@@ -134,17 +178,128 @@ func (ct *CheckThreshold) Clone() CheckThreshold {
 }
 
 type CheckConstraint struct {
-	Type  string
-	Key   string
-	Value string
+	Type     string
+	Key      string
+	Value    string
+	Operator string
+	Group    string
+	// compiled Value of =~ and !~ constraints, see Compile
+	re *regexp.Regexp
 }
 
 func (cc *CheckConstraint) Clone() CheckConstraint {
 	return CheckConstraint{
-		Type:  cc.Type,
-		Key:   cc.Key,
-		Value: cc.Value,
+		Type:     cc.Type,
+		Key:      cc.Key,
+		Value:    cc.Value,
+		Operator: cc.Operator,
+		Group:    cc.Group,
+		// compiled regular expressions are safe for concurrent use
+		re: cc.re,
+	}
+}
+
+// Compile compiles the regular expression of =~ and !~ constraints
+// once, instead of on every evaluation. It is shared by all clones of
+// cc. Constraints that are not compiled still match correctly.
+func (cc *CheckConstraint) Compile() error {
+	switch cc.Operator {
+	case proto.ConstraintOpMatch, proto.ConstraintOpNotMatch:
+		re, err := regexp.Compile(cc.Value)
+		if err != nil {
+			return err
+		}
+		cc.re = re
 	}
+	return nil
+}
+
+// isDefault reports whether cc is a plain equality constraint that
+// is not part of an OR group
+func (cc *CheckConstraint) isDefault() bool {
+	return cc.Group == `` &&
+		(cc.Operator == `` || cc.Operator == proto.ConstraintOpEqual)
+}
+
+// negated reports whether the operator of cc is a negation. Negated
+// constraints hit if no property value matches the positive form
+// of the operator.
+func (cc *CheckConstraint) negated() bool {
+	switch cc.Operator {
+	case proto.ConstraintOpNotEqual, proto.ConstraintOpNotMatch,
+		proto.ConstraintOpNotGlob:
+		return true
+	}
+	return false
+}
+
+// match reports whether value satisfies the positive form of the
+// operator of cc
+func (cc *CheckConstraint) match(value string) bool {
+	switch cc.Operator {
+	case ``, proto.ConstraintOpEqual, proto.ConstraintOpNotEqual:
+		return value == cc.Value || cc.Value == `@defined`
+	case proto.ConstraintOpMatch, proto.ConstraintOpNotMatch:
+		re := cc.re
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(cc.Value); err != nil {
+				return false
+			}
+		}
+		return re.MatchString(value)
+	case proto.ConstraintOpGlob, proto.ConstraintOpNotGlob:
+		ok, err := path.Match(cc.Value, value)
+		return err == nil && ok
+	case proto.ConstraintOpLess, proto.ConstraintOpLessEqual,
+		proto.ConstraintOpGreater, proto.ConstraintOpGreaterEqual:
+		have, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		want, err := strconv.ParseFloat(cc.Value, 64)
+		if err != nil {
+			return false
+		}
+		switch cc.Operator {
+		case proto.ConstraintOpLess:
+			return have < want
+		case proto.ConstraintOpLessEqual:
+			return have <= want
+		case proto.ConstraintOpGreater:
+			return have > want
+		case proto.ConstraintOpGreaterEqual:
+			return have >= want
+		}
+	}
+	return false
+}
+
+// expression returns the string representation of cc that is
+// included in the constraint hash of check instances
+func (cc *CheckConstraint) expression() string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s",
+		cc.Group, cc.Type, cc.Key, cc.Operator, cc.Value)
+}
+
+// constraintGroups tracks the evaluation of OR groups during the
+// constraint check. A group is satisfied if any of its constraints
+// hit.
+type constraintGroups map[string]bool
+
+// record registers the result of evaluating a constraint of group
+func (cg constraintGroups) record(group string, hit bool) {
+	cg[group] = cg[group] || hit
+}
+
+// satisfied reports whether all recorded groups had a hit
+func (cg constraintGroups) satisfied() bool {
+	for _, hit := range cg {
+		if !hit {
+			return false
+		}
+	}
+	return true
 }
 
 type CheckInstance struct {
@@ -161,6 +316,7 @@ type CheckInstance struct {
 	ConstraintCustom      map[string]string              // Id->value
 	ConstraintNative      map[string]string              // prop->value
 	ConstraintAttribute   map[string]map[string][]string // svcID->attr->[ value, value, ... ]
+	ConstraintExpression  []string                       // non-default expressions
 	InstanceServiceConfig map[string]string              // attr->value
 	InstanceService       string
 	InstanceSvcCfgHash    string
@@ -279,6 +435,8 @@ func (tci *CheckInstance) Clone() CheckInstance {
 		t := v
 		cl.InstanceServiceConfig[k] = t
 	}
+	cl.ConstraintExpression = make([]string, len(tci.ConstraintExpression))
+	copy(cl.ConstraintExpression, tci.ConstraintExpression)
 	cl.ConstraintAttribute = make(map[string]map[string][]string, 0)
 	for k := range tci.ConstraintAttribute {
 		cl.ConstraintAttribute[k] = make(map[string][]string)
@@ -355,6 +513,15 @@ func (tci *CheckInstance) calcConstraintHash() {
 			io.WriteString(h, l)
 		}
 	}
+
+	// plain equality constraints are fully described by the bound
+	// keys, only operators and OR groups are hashed explicitly
+	expressions := make([]string, len(tci.ConstraintExpression))
+	copy(expressions, tci.ConstraintExpression)
+	sort.Strings(expressions)
+	for _, i := range expressions {
+		io.WriteString(h, i)
+	}
 	tci.oldConstraintHash = base64.URLEncoding.EncodeToString(h.Sum(nil))
 	io.WriteString(h, tci.ConfigID.String())
 	io.WriteString(h, tci.CheckID.String())
@@ -465,6 +632,7 @@ func (tci CheckInstance) MakeAction() Action {
 	if err != nil {
 		serviceCfg = []byte{}
 	}
+	cl := tci.Clone()
 
 	return Action{
 		CheckInstance: proto.CheckInstance{
@@ -478,6 +646,12 @@ func (tci CheckInstance) MakeAction() Action {
 			InstanceSvcCfgHash:    tci.InstanceSvcCfgHash,
 			InstanceService:       tci.InstanceService,
 			InstanceServiceConfig: string(serviceCfg),
+			ConstraintOncall:      cl.ConstraintOncall,
+			ConstraintService:     cl.ConstraintService,
+			ConstraintSystem:      cl.ConstraintSystem,
+			ConstraintCustom:      cl.ConstraintCustom,
+			ConstraintNative:      cl.ConstraintNative,
+			ConstraintAttribute:   cl.ConstraintAttribute,
 		},
 	}
 }
@@ -557,8 +731,10 @@ type checkContext struct {
 	serviceConstr          map[string]string              // ID -> Value
 	customConstr           map[string]string              // ID -> Value
 	attributeConstr        map[string]map[string][]string // svcID -> attr -> [ value, ... ]
+	expressions            []string
 	newCheckInstances      []string
 	newInstances           map[string]CheckInstance
+	configUpdate           bool
 }
 
 func newCheckContext(uuid, view string, startup bool) *checkContext {
@@ -573,6 +749,7 @@ func newCheckContext(uuid, view string, startup bool) *checkContext {
 	cc.serviceConstr = make(map[string]string)
 	cc.customConstr = make(map[string]string)
 	cc.attributeConstr = make(map[string]map[string][]string)
+	cc.expressions = []string{}
 	cc.newCheckInstances = []string{}
 	cc.newInstances = make(map[string]CheckInstance)
 	return &cc
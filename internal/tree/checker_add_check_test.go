@@ -281,6 +281,148 @@ func TestCheckerDeleteCheck(t *testing.T) {
 	deterministicInheritanceOrder = false
 }
 
+func TestCheckerUpdateCheck(t *testing.T) {
+	deterministicInheritanceOrder = true
+
+	sTree, actionC, errC := testSpawnCheckTree()
+
+	chkConfigID := uuid.Must(uuid.NewV4())
+	capID := uuid.Must(uuid.NewV4())
+	chkID := uuid.Must(uuid.NewV4())
+
+	chk := Check{
+		ID:            chkID,
+		SourceID:      uuid.Nil,
+		InheritedFrom: uuid.Nil,
+		Inheritance:   true,
+		ChildrenOnly:  false,
+		Interval:      60,
+		ConfigID:      chkConfigID,
+		CapabilityID:  capID,
+		View:          `any`,
+		Thresholds: []CheckThreshold{
+			{
+				Predicate: `>=`,
+				Level:     1,
+				Value:     100,
+			},
+			{
+				Predicate: `>=`,
+				Level:     3,
+				Value:     450,
+			},
+		},
+		Constraints: []CheckConstraint{},
+	}
+
+	sTree.Find(FindRequest{
+		ElementType: `repository`,
+		ElementName: `checkTest`,
+	}, true).SetCheck(chk)
+
+	sTree.ComputeCheckInstances()
+
+	node := sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementName: `testnode1`,
+	}, true).(*Node)
+	before := map[string]CheckInstance{}
+	for id, inst := range node.Instances {
+		before[id] = inst.Clone()
+	}
+	if len(before) != 1 {
+		t.Fatal(`Expected 1 check instance, found`, len(before))
+	}
+
+	// discard the actions from building the tree
+	for len(actionC) > 0 {
+		<-actionC
+	}
+
+	updChk := chk.Clone()
+	updChk.ID = uuid.Nil
+	updChk.SourceID = chkID
+	updChk.Interval = 300
+	updChk.Thresholds[1].Value = 500
+
+	sTree.Find(FindRequest{
+		ElementType: `repository`,
+		ElementName: `checkTest`,
+	}, true).UpdateCheck(updChk)
+
+	sTree.ComputeCheckInstances()
+
+	counts := map[string]int{}
+	for len(actionC) > 0 {
+		a := <-actionC
+		counts[a.Action]++
+	}
+	if len(counts) != 1 || counts[ActionCheckInstanceUpdate] != 8 {
+		t.Error(`Expected 8 instance updates, received`, counts)
+	}
+
+	if len(node.Instances) != 1 {
+		t.Error(`Expected 1 check instance, found`, len(node.Instances))
+	}
+	for id, inst := range node.Instances {
+		old, ok := before[id]
+		switch {
+		case !ok:
+			t.Error(`Check instance was replaced instead of updated`)
+		case inst.Version != old.Version+1:
+			t.Error(`Expected version`, old.Version+1, `found`, inst.Version)
+		case uuid.Equal(inst.InstanceConfigID, old.InstanceConfigID):
+			t.Error(`Updated check instance kept its InstanceConfigID`)
+		}
+	}
+	for _, c := range node.Checks {
+		if c.Interval != 300 || c.Thresholds[1].Value != 500 {
+			t.Error(`Inherited check was not updated`)
+		}
+	}
+
+	// an update without changes does not touch any instance
+	sTree.Find(FindRequest{
+		ElementType: `repository`,
+		ElementName: `checkTest`,
+	}, true).UpdateCheck(updChk)
+
+	sTree.ComputeCheckInstances()
+
+	if len(actionC) > 0 {
+		t.Error(`Unchanged check update created`, len(actionC), `actions`)
+		for len(actionC) > 0 {
+			<-actionC
+		}
+	}
+
+	// disabling inheritance removes the check from all children
+	updChk.Inheritance = false
+	sTree.Find(FindRequest{
+		ElementType: `repository`,
+		ElementName: `checkTest`,
+	}, true).UpdateCheck(updChk)
+
+	sTree.ComputeCheckInstances()
+
+	close(actionC)
+	close(errC)
+
+	if len(errC) > 0 {
+		t.Error(`Error channel not empty`)
+	}
+
+	counts = map[string]int{}
+	for a := range actionC {
+		counts[a.Action]++
+	}
+	if len(counts) != 2 || counts[ActionCheckRemoved] != 9 ||
+		counts[ActionCheckInstanceDelete] != 8 {
+		t.Error(`Unexpected actions after disabling inheritance`, counts)
+	}
+	deterministicInheritanceOrder = false
+}
+
 func TestCheckerDestroyRepoWithChecks(t *testing.T) {
 	deterministicInheritanceOrder = true
 
@@ -92,6 +92,70 @@ func (teb *Bucket) addCheck(c Check) {
 	teb.actionCheckNew(teb.setupCheckAction(c))
 }
 
+//
+// Checker:> Update Check
+
+func (teb *Bucket) UpdateCheck(c Check) {
+	for id := range teb.Checks {
+		if !uuid.Equal(teb.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		if teb.Checks[id].Inherited {
+			break
+		}
+		if teb.switchCheck(c) {
+			teb.updateCheckOnChildren(c)
+		}
+		return
+	}
+	teb.Fault.Error <- &Error{Action: `update_check_on_non_source`}
+}
+
+func (teb *Bucket) updateCheckInherited(c Check) {
+	if teb.switchCheck(c) {
+		teb.updateCheckOnChildren(c)
+	}
+}
+
+func (teb *Bucket) updateCheckOnChildren(c Check) {
+	var wg sync.WaitGroup
+	for child := range teb.Children {
+		wg.Add(1)
+		go func(stc Check, ch string) {
+			defer wg.Done()
+			teb.Children[ch].(Checker).updateCheckInherited(stc)
+		}(c, child)
+	}
+	wg.Wait()
+}
+
+func (teb *Bucket) switchCheck(c Check) bool {
+	for id := range teb.Checks {
+		if !uuid.Equal(teb.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		curr := teb.Checks[id]
+		upd := curr.updateFrom(c)
+		teb.Checks[id] = upd
+		if !upd.Inheritance && curr.Inheritance {
+			// replacing inheritance with !inheritance:
+			// remove the inherited copies from the children
+			teb.deleteCheckOnChildren(curr.Clone())
+		}
+		if upd.Inheritance && !curr.Inheritance {
+			// replacing !inheritance with inheritance:
+			// send a scrubbed copy downward
+			f := upd.Clone()
+			f.Inherited = true
+			f.ID = uuid.Nil
+			f.Items = nil
+			teb.setCheckOnChildren(f)
+		}
+		return upd.Inheritance && curr.Inheritance
+	}
+	return false
+}
+
 //
 // Checker:> Remove Check
 
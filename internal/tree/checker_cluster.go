@@ -81,6 +81,73 @@ func (tec *Cluster) addCheck(c Check) {
 	tec.actionCheckNew(tec.setupCheckAction(c))
 }
 
+//
+// Checker:> Update Check
+
+func (tec *Cluster) UpdateCheck(c Check) {
+	for id := range tec.Checks {
+		if !uuid.Equal(tec.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		if tec.Checks[id].Inherited {
+			break
+		}
+		if tec.switchCheck(c) {
+			tec.updateCheckOnChildren(c)
+		}
+		return
+	}
+	tec.Fault.Error <- &Error{Action: `update_check_on_non_source`}
+}
+
+func (tec *Cluster) updateCheckInherited(c Check) {
+	if tec.switchCheck(c) {
+		tec.updateCheckOnChildren(c)
+	}
+}
+
+func (tec *Cluster) updateCheckOnChildren(c Check) {
+	var wg sync.WaitGroup
+	for child := range tec.Children {
+		wg.Add(1)
+		go func(stc Check, ch string) {
+			defer wg.Done()
+			tec.Children[ch].(Checker).updateCheckInherited(stc)
+		}(c, child)
+	}
+	wg.Wait()
+}
+
+func (tec *Cluster) switchCheck(c Check) bool {
+	for id := range tec.Checks {
+		if !uuid.Equal(tec.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		curr := tec.Checks[id]
+		upd := curr.updateFrom(c)
+		tec.Checks[id] = upd
+		// flag the check for recomputation, its instances require
+		// a new version if the shared settings changed
+		tec.updatedChecks[id] = tec.updatedChecks[id] || !curr.sameSettings(upd)
+		if !upd.Inheritance && curr.Inheritance {
+			// replacing inheritance with !inheritance:
+			// remove the inherited copies from the children
+			tec.deleteCheckOnChildren(curr.Clone())
+		}
+		if upd.Inheritance && !curr.Inheritance {
+			// replacing !inheritance with inheritance:
+			// send a scrubbed copy downward
+			f := upd.Clone()
+			f.Inherited = true
+			f.ID = uuid.Nil
+			f.Items = nil
+			tec.setCheckOnChildren(f)
+		}
+		return upd.Inheritance && curr.Inheritance
+	}
+	return false
+}
+
 //
 // Checker:> Remove Check
 
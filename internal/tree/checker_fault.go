@@ -37,6 +37,19 @@ func (tef *Fault) deleteCheckLocalAll() {
 func (tef *Fault) rmCheck(c Check) {
 }
 
+func (tef *Fault) UpdateCheck(c Check) {
+}
+
+func (tef *Fault) updateCheckInherited(c Check) {
+}
+
+func (tef *Fault) updateCheckOnChildren(c Check) {
+}
+
+func (tef *Fault) switchCheck(c Check) bool {
+	return false
+}
+
 func (tef *Fault) syncCheck(childID string) {
 }
 
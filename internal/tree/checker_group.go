@@ -93,6 +93,73 @@ func (teg *Group) addCheck(c Check) {
 	teg.actionCheckNew(c.MakeAction())
 }
 
+//
+// Checker:> Update Check
+
+func (teg *Group) UpdateCheck(c Check) {
+	for id := range teg.Checks {
+		if !uuid.Equal(teg.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		if teg.Checks[id].Inherited {
+			break
+		}
+		if teg.switchCheck(c) {
+			teg.updateCheckOnChildren(c)
+		}
+		return
+	}
+	teg.Fault.Error <- &Error{Action: `update_check_on_non_source`}
+}
+
+func (teg *Group) updateCheckInherited(c Check) {
+	if teg.switchCheck(c) {
+		teg.updateCheckOnChildren(c)
+	}
+}
+
+func (teg *Group) updateCheckOnChildren(c Check) {
+	var wg sync.WaitGroup
+	for child := range teg.Children {
+		wg.Add(1)
+		go func(stc Check, ch string) {
+			defer wg.Done()
+			teg.Children[ch].(Checker).updateCheckInherited(stc)
+		}(c, child)
+	}
+	wg.Wait()
+}
+
+func (teg *Group) switchCheck(c Check) bool {
+	for id := range teg.Checks {
+		if !uuid.Equal(teg.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		curr := teg.Checks[id]
+		upd := curr.updateFrom(c)
+		teg.Checks[id] = upd
+		// flag the check for recomputation, its instances require
+		// a new version if the shared settings changed
+		teg.updatedChecks[id] = teg.updatedChecks[id] || !curr.sameSettings(upd)
+		if !upd.Inheritance && curr.Inheritance {
+			// replacing inheritance with !inheritance:
+			// remove the inherited copies from the children
+			teg.deleteCheckOnChildren(curr.Clone())
+		}
+		if upd.Inheritance && !curr.Inheritance {
+			// replacing !inheritance with inheritance:
+			// send a scrubbed copy downward
+			f := upd.Clone()
+			f.Inherited = true
+			f.ID = uuid.Nil
+			f.Items = nil
+			teg.setCheckOnChildren(f)
+		}
+		return upd.Inheritance && curr.Inheritance
+	}
+	return false
+}
+
 //
 // Checker:> Remove Check
 
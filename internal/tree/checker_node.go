@@ -50,6 +50,64 @@ func (ten *Node) addCheck(c Check) {
 	ten.actionCheckNew(ten.setupCheckAction(c))
 }
 
+//
+// Checker:> Update Check
+
+func (ten *Node) UpdateCheck(c Check) {
+	for id := range ten.Checks {
+		if !uuid.Equal(ten.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		if ten.Checks[id].Inherited {
+			break
+		}
+		if ten.switchCheck(c) {
+			ten.updateCheckOnChildren(c)
+		}
+		return
+	}
+	ten.Fault.Error <- &Error{Action: `update_check_on_non_source`}
+}
+
+func (ten *Node) updateCheckInherited(c Check) {
+	if ten.switchCheck(c) {
+		ten.updateCheckOnChildren(c)
+	}
+}
+
+func (ten *Node) updateCheckOnChildren(c Check) {
+}
+
+func (ten *Node) switchCheck(c Check) bool {
+	for id := range ten.Checks {
+		if !uuid.Equal(ten.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		curr := ten.Checks[id]
+		upd := curr.updateFrom(c)
+		ten.Checks[id] = upd
+		// flag the check for recomputation, its instances require
+		// a new version if the shared settings changed
+		ten.updatedChecks[id] = ten.updatedChecks[id] || !curr.sameSettings(upd)
+		if !upd.Inheritance && curr.Inheritance {
+			// replacing inheritance with !inheritance:
+			// remove the inherited copies from the children
+			ten.deleteCheckOnChildren(curr.Clone())
+		}
+		if upd.Inheritance && !curr.Inheritance {
+			// replacing !inheritance with inheritance:
+			// send a scrubbed copy downward
+			f := upd.Clone()
+			f.Inherited = true
+			f.ID = uuid.Nil
+			f.Items = nil
+			ten.setCheckOnChildren(f)
+		}
+		return upd.Inheritance && curr.Inheritance
+	}
+	return false
+}
+
 //
 // Checker:> Remove Check
 
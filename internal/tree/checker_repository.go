@@ -79,6 +79,70 @@ func (ter *Repository) addCheck(c Check) {
 	ter.actionCheckNew(c.MakeAction())
 }
 
+//
+// Checker:> Update Check
+
+func (ter *Repository) UpdateCheck(c Check) {
+	for id := range ter.Checks {
+		if !uuid.Equal(ter.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		if ter.Checks[id].Inherited {
+			break
+		}
+		if ter.switchCheck(c) {
+			ter.updateCheckOnChildren(c)
+		}
+		return
+	}
+	ter.Fault.Error <- &Error{Action: `update_check_on_non_source`}
+}
+
+func (ter *Repository) updateCheckInherited(c Check) {
+	if ter.switchCheck(c) {
+		ter.updateCheckOnChildren(c)
+	}
+}
+
+func (ter *Repository) updateCheckOnChildren(c Check) {
+	var wg sync.WaitGroup
+	for child := range ter.Children {
+		wg.Add(1)
+		go func(stc Check, ch string) {
+			defer wg.Done()
+			ter.Children[ch].(Checker).updateCheckInherited(stc)
+		}(c, child)
+	}
+	wg.Wait()
+}
+
+func (ter *Repository) switchCheck(c Check) bool {
+	for id := range ter.Checks {
+		if !uuid.Equal(ter.Checks[id].SourceID, c.SourceID) {
+			continue
+		}
+		curr := ter.Checks[id]
+		upd := curr.updateFrom(c)
+		ter.Checks[id] = upd
+		if !upd.Inheritance && curr.Inheritance {
+			// replacing inheritance with !inheritance:
+			// remove the inherited copies from the children
+			ter.deleteCheckOnChildren(curr.Clone())
+		}
+		if upd.Inheritance && !curr.Inheritance {
+			// replacing !inheritance with inheritance:
+			// send a scrubbed copy downward
+			f := upd.Clone()
+			f.Inherited = true
+			f.ID = uuid.Nil
+			f.Items = nil
+			ter.setCheckOnChildren(f)
+		}
+		return upd.Inheritance && curr.Inheritance
+	}
+	return false
+}
+
 //
 // Checker:> Remove Check
 
@@ -9,8 +9,11 @@
 package tree
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
+	"github.com/mjolnir42/soma/lib/proto"
 	"github.com/satori/go.uuid"
 )
 
@@ -186,6 +189,288 @@ func TestCheckInstanceAction(t *testing.T) {
 	}
 }
 
+func TestCheckInstanceConstraintValues(t *testing.T) {
+	check := testSpawnCheck(false, false, false)
+	instance := testSpawnCheckInstance(check)
+
+	switch {
+	case instance.ConstraintOncall == ``,
+		len(instance.ConstraintService) == 0,
+		len(instance.ConstraintSystem) == 0,
+		len(instance.ConstraintCustom) == 0,
+		len(instance.ConstraintNative) == 0,
+		len(instance.ConstraintAttribute) == 0:
+		t.Fatalf(`Test check instance is missing constraint values`)
+	}
+
+	// the constraint values are persisted as JSON encoded partial
+	// CheckInstance and merged into the deployment details
+	action := instance.MakeAction()
+	values := action.CheckInstance.ConstraintValues()
+	raw, err := json.Marshal(&values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matched := proto.CheckInstance{}
+	if err = json.Unmarshal(raw, &matched); err != nil {
+		t.Fatal(err)
+	}
+	detail := proto.CheckInstance{
+		InstanceID: action.CheckInstance.InstanceID,
+	}
+	detail.MergeConstraintValues(&matched)
+
+	if detail.InstanceID != instance.InstanceID.String() {
+		t.Errorf(`Merging constraint values modified InstanceID`)
+	}
+	if detail.ConstraintOncall != instance.ConstraintOncall {
+		t.Errorf("ConstraintOncall: expected %s, got %s",
+			instance.ConstraintOncall, detail.ConstraintOncall)
+	}
+	for name, pair := range map[string][2]map[string]string{
+		`ConstraintService`: {instance.ConstraintService, detail.ConstraintService},
+		`ConstraintSystem`:  {instance.ConstraintSystem, detail.ConstraintSystem},
+		`ConstraintCustom`:  {instance.ConstraintCustom, detail.ConstraintCustom},
+		`ConstraintNative`:  {instance.ConstraintNative, detail.ConstraintNative},
+	} {
+		if !reflect.DeepEqual(pair[0], pair[1]) {
+			t.Errorf("%s: expected %v, got %v", name, pair[0], pair[1])
+		}
+	}
+	if !reflect.DeepEqual(instance.ConstraintAttribute,
+		detail.ConstraintAttribute) {
+		t.Errorf("ConstraintAttribute: expected %v, got %v",
+			instance.ConstraintAttribute, detail.ConstraintAttribute)
+	}
+}
+
+func TestCheckConstraintMatch(t *testing.T) {
+	tests := []struct {
+		op, want, have string
+		hit            bool
+	}{
+		{``, `qa`, `qa`, true},
+		{`==`, `@defined`, `anything`, true},
+		{`!=`, `qa`, `qa`, true},
+		{`=~`, `^web[0-9]+$`, `web42`, true},
+		{`=~`, `^web[0-9]+$`, `db42`, false},
+		{`=~`, `[`, `[`, false},
+		{`glob`, `*.example.org`, `host.example.org`, true},
+		{`!glob`, `*.example.org`, `host.example.com`, false},
+		{`>`, `16`, `32`, true},
+		{`>`, `16`, `16`, false},
+		{`>=`, `16`, `16`, true},
+		{`<`, `16`, `8`, true},
+		{`<=`, `16`, `many`, false},
+	}
+
+	for _, test := range tests {
+		cc := CheckConstraint{Operator: test.op, Value: test.want}
+		if hit := cc.match(test.have); hit != test.hit {
+			t.Errorf("%s %s %s: expected %t, got %t",
+				test.have, test.op, test.want, test.hit, hit)
+		}
+		// compiled constraints and their clones match the same
+		cc.Compile()
+		clone := cc.Clone()
+		if hit := clone.match(test.have); hit != test.hit {
+			t.Errorf("%s %s %s compiled: expected %t, got %t",
+				test.have, test.op, test.want, test.hit, hit)
+		}
+	}
+}
+
+func TestCheckConstraintCompile(t *testing.T) {
+	cc := CheckConstraint{Operator: `=~`, Value: `^web`}
+	if err := cc.Compile(); err != nil || cc.re == nil {
+		t.Fatalf(`Regular expression was not compiled`)
+	}
+	if clone := cc.Clone(); clone.re != cc.re {
+		t.Errorf(`Clone does not share the compiled regular expression`)
+	}
+
+	cc = CheckConstraint{Operator: `!~`, Value: `[`}
+	if err := cc.Compile(); err == nil {
+		t.Errorf(`Compiled invalid regular expression`)
+	}
+
+	cc = CheckConstraint{Operator: `glob`, Value: `[`}
+	if err := cc.Compile(); err != nil || cc.re != nil {
+		t.Errorf(`Compiled regular expression for glob constraint`)
+	}
+}
+
+func TestNodeConstraintCheckOperators(t *testing.T) {
+	node := NewNode(NodeSpec{
+		ID:       uuid.Must(uuid.NewV4()).String(),
+		AssetID:  1,
+		Name:     `testnode`,
+		Team:     uuid.Must(uuid.NewV4()).String(),
+		ServerID: uuid.Must(uuid.NewV4()).String(),
+		Online:   true,
+		Deleted:  false,
+	})
+	node.PropertySystem[uuid.Must(uuid.NewV4()).String()] = &PropertySystem{
+		View:  `any`,
+		Key:   `cpu_cores`,
+		Value: `32`,
+	}
+	node.PropertySystem[uuid.Must(uuid.NewV4()).String()] = &PropertySystem{
+		View:  `any`,
+		Key:   `fqdn`,
+		Value: `web01.example.org`,
+	}
+
+	tests := []struct {
+		name        string
+		constraints []CheckConstraint
+		hit         bool
+	}{
+		{`numeric`, []CheckConstraint{
+			{Type: `system`, Key: `cpu_cores`, Operator: `>`, Value: `16`},
+		}, true},
+		{`numeric miss`, []CheckConstraint{
+			{Type: `system`, Key: `cpu_cores`, Operator: `>`, Value: `64`},
+		}, false},
+		{`negation`, []CheckConstraint{
+			{Type: `native`, Key: `state`, Operator: `!=`, Value: `decommissioned`},
+			{Type: `system`, Key: `tag`, Operator: `!=`, Value: `legacy`},
+		}, true},
+		{`negation miss`, []CheckConstraint{
+			{Type: `native`, Key: `state`, Operator: `!=`, Value: `floating`},
+		}, false},
+		{`or group`, []CheckConstraint{
+			{Type: `system`, Key: `fqdn`, Operator: `glob`, Value: `db*`, Group: `a`},
+			{Type: `system`, Key: `fqdn`, Operator: `=~`, Value: `^web`, Group: `a`},
+		}, true},
+		{`or group miss`, []CheckConstraint{
+			{Type: `system`, Key: `fqdn`, Operator: `glob`, Value: `db*`, Group: `a`},
+			{Type: `system`, Key: `cpu_cores`, Operator: `<`, Value: `4`, Group: `a`},
+		}, false},
+		{`and of groups`, []CheckConstraint{
+			{Type: `system`, Key: `fqdn`, Operator: `glob`, Value: `web*`, Group: `a`},
+			{Type: `system`, Key: `cpu_cores`, Operator: `<`, Value: `4`, Group: `b`},
+		}, false},
+	}
+
+	for _, test := range tests {
+		chk := testSpawnCheck(false, false, false)
+		chk.View = `any`
+		chk.Constraints = test.constraints
+		node.Checks[chk.ID.String()] = chk
+
+		ctx := newCheckContext(chk.ID.String(), chk.View, false)
+		node.constraintCheck(ctx)
+		if ctx.brokeConstraint == test.hit {
+			t.Errorf("%s: expected hit %t", test.name, test.hit)
+		}
+		if test.hit && len(ctx.expressions) != len(test.constraints) {
+			t.Errorf("%s: expected %d expressions, got %d", test.name,
+				len(test.constraints), len(ctx.expressions))
+		}
+	}
+}
+
+func TestNodeExplainCheck(t *testing.T) {
+	node := NewNode(NodeSpec{
+		ID:       uuid.Must(uuid.NewV4()).String(),
+		AssetID:  1,
+		Name:     `testnode`,
+		Team:     uuid.Must(uuid.NewV4()).String(),
+		ServerID: uuid.Must(uuid.NewV4()).String(),
+		Online:   true,
+		Deleted:  false,
+	})
+	node.PropertySystem[uuid.Must(uuid.NewV4()).String()] = &PropertySystem{
+		View:  `any`,
+		Key:   `fqdn`,
+		Value: `web01.example.org`,
+	}
+	svcID := uuid.Must(uuid.NewV4())
+	node.PropertyService[svcID.String()] = &PropertyService{
+		ID:          svcID,
+		View:        `any`,
+		ServiceID:   uuid.Must(uuid.NewV4()),
+		ServiceName: `http`,
+		Attributes: []proto.ServiceAttribute{
+			{Name: `port`, Value: `80`},
+			{Name: `port`, Value: `443`},
+		},
+	}
+
+	chk := testSpawnCheck(false, false, false)
+	chk.View = `any`
+	chk.Constraints = []CheckConstraint{
+		{Type: `system`, Key: `fqdn`, Operator: `glob`, Value: `web*`},
+		{Type: `service`, Key: `name`, Value: `http`},
+	}
+
+	if x := node.ExplainCheck(chk.ConfigID.String()); x.Applies ||
+		len(x.Inheritance) != 1 || x.Inheritance[0].HasCheck {
+		t.Errorf(`Explained check that is not set on the node`)
+	}
+
+	node.Checks[chk.ID.String()] = chk
+	x := node.ExplainCheck(chk.ConfigID.String())
+	if !x.Applies {
+		t.Fatalf("Check does not apply: %s", x.Reason)
+	}
+	if len(x.Constraints) != 2 || !x.Constraints[0].Match ||
+		len(x.Constraints[0].Actual) != 1 ||
+		x.Constraints[0].Actual[0] != `web01.example.org` {
+		t.Errorf(`Incorrect constraint explanation`)
+	}
+	if len(x.Instances) != 2 || x.Instances[0].ServiceName != `http` {
+		t.Errorf("Expected 2 service instances, got %d", len(x.Instances))
+	}
+	if len(x.Inheritance) != 1 || !x.Inheritance[0].IsSource {
+		t.Errorf(`Node is not the source of the check`)
+	}
+
+	chk.Constraints = append(chk.Constraints, CheckConstraint{
+		Type: `system`, Key: `fqdn`, Operator: `=~`, Value: `^db`,
+	})
+	node.Checks[chk.ID.String()] = chk
+	x = node.ExplainCheck(chk.ConfigID.String())
+	if x.Applies || len(x.Constraints) != 3 || x.Constraints[2].Match {
+		t.Errorf(`Explained constraint disagrees with the constraint check`)
+	}
+	chk.Constraints = chk.Constraints[:2]
+	node.Checks[chk.ID.String()] = chk
+
+	node.PropertySystem[uuid.Must(uuid.NewV4()).String()] = &PropertySystem{
+		View:  `any`,
+		Key:   `disable_check_configuration`,
+		Value: chk.ConfigID.String(),
+	}
+	x = node.ExplainCheck(chk.ConfigID.String())
+	if x.Applies || len(x.Disabled) != 1 || !x.Disabled[0].Effective {
+		t.Errorf(`Explained check ignores disable_check_configuration`)
+	}
+	if len(x.Instances) != 0 {
+		t.Errorf(`Disabled check spawns instances`)
+	}
+}
+
+func TestCheckInstanceConstraintHashOperator(t *testing.T) {
+	check := testSpawnCheck(false, false, false)
+	instance := testSpawnCheckInstance(check)
+	hash := instance.ConstraintHash
+
+	instance.ConstraintExpression = []string{
+		(&CheckConstraint{
+			Type:     `system`,
+			Key:      `cpu_cores`,
+			Operator: `>`,
+			Value:    `16`,
+		}).expression(),
+	}
+	instance.calcConstraintHash()
+	if instance.ConstraintHash == hash {
+		t.Errorf(`Constraint hash ignores operator expressions`)
+	}
+}
+
 func testSpawnCheckInstance(chk Check) CheckInstance {
 	ci := CheckInstance{
 		InstanceID: uuid.Must(uuid.NewV4()),
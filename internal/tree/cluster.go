@@ -39,6 +39,7 @@ type Cluster struct {
 	ordNumChildNod  int
 	ordChildrenNod  map[int]string
 	hasUpdate       bool
+	updatedChecks   map[string]bool
 	log             *log.Logger
 	lock            *sync.RWMutex
 }
@@ -74,6 +75,7 @@ func NewCluster(spec ClusterSpec) *Cluster {
 	tec.CheckInstances = make(map[string][]string)
 	tec.Instances = make(map[string]CheckInstance)
 	tec.loadedInstances = make(map[string]map[string]CheckInstance)
+	tec.updatedChecks = make(map[string]bool)
 	tec.ordNumChildNod = 0
 	tec.ordChildrenNod = make(map[int]string)
 
@@ -136,6 +138,7 @@ func (tec Cluster) Clone() *Cluster {
 	}
 	cl.Instances = cki
 	cl.loadedInstances = make(map[string]map[string]CheckInstance)
+	cl.updatedChecks = make(map[string]bool)
 
 	ci := make(map[string][]string)
 	for k := range tec.CheckInstances {
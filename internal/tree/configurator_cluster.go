@@ -15,85 +15,96 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func (c *Cluster) evalNativeProp(prop string, val string) bool {
-	switch prop {
+func (c *Cluster) evalNativeProp(cc CheckConstraint) (bool, string) {
+	var val string
+	switch cc.Key {
 	case msg.NativePropertyEnvironment:
-		env := c.Parent.(Bucketeer).GetEnvironment()
-		if val == env {
-			return true
-		}
+		val = c.Parent.(Bucketeer).GetEnvironment()
 	case msg.NativePropertyEntity:
-		if val == msg.EntityCluster {
-			return true
-		}
+		val = msg.EntityCluster
 	case msg.NativePropertyState:
-		if val == c.State {
-			return true
-		}
+		val = c.State
 	case msg.NativePropertyHardwareNode:
 		// cluster != hardware
-		return false
+		return false, ""
+	default:
+		return false, ""
+	}
+	if cc.match(val) != cc.negated() {
+		return true, val
 	}
-	return false
+	return false, ""
 }
 
-func (c *Cluster) evalSystemProp(prop string, val string, view string) (string, bool, string) {
+func (c *Cluster) evalSystemProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range c.PropertySystem {
 		t := v.(*PropertySystem)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (c *Cluster) evalOncallProp(prop string, val string, view string) (string, bool) {
+func (c *Cluster) evalOncallProp(cc CheckConstraint, view string) (string, bool) {
 	for _, v := range c.PropertyOncall {
 		t := v.(*PropertyOncall)
-		if "OncallID" == prop && t.ID.String() == val && (t.View == view || t.View == `any`) {
+		if "OncallID" == cc.Key && cc.match(t.ID.String()) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false
+			}
 			return t.ID.String(), true
 		}
 	}
-	return "", false
+	return "", cc.negated()
 }
 
-func (c *Cluster) evalCustomProp(prop string, val string, view string) (string, bool, string) {
+func (c *Cluster) evalCustomProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range c.PropertyCustom {
 		t := v.(*PropertyCustom)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (c *Cluster) evalServiceProp(prop string, val string, view string) (string, bool, string) {
+func (c *Cluster) evalServiceProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range c.PropertyService {
 		t := v.(*PropertyService)
-		if ((prop == "name" && (t.ServiceName == val || val == `@defined`)) || (prop == "id" && (t.ServiceID.String() == val))) && (t.View == view || t.View == `any`) {
+		if ((cc.Key == "name" && cc.match(t.ServiceName)) || (cc.Key == "id" && cc.match(t.ServiceID.String()))) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.ID.String(), true, t.ServiceName
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (c *Cluster) evalAttributeOfService(svcID string, view string, attribute string, value string) (bool, string) {
+func (c *Cluster) evalAttributeOfService(svcID string, view string, cc CheckConstraint) (bool, string) {
 	t := c.PropertyService[svcID].(*PropertyService)
 	for _, a := range t.Attributes {
-		if a.Name == attribute && (t.View == view || t.View == `any`) && (a.Value == value || value == `@defined`) {
+		if a.Name == cc.Key && (t.View == view || t.View == `any`) && cc.match(a.Value) {
 			return true, a.Value
 		}
 	}
 	return false, ""
 }
 
-func (c *Cluster) evalAttributeProp(view string, attr string, value string) (bool, map[string]string) {
+func (c *Cluster) evalAttributeProp(view string, cc CheckConstraint) (bool, map[string]string) {
 	f := map[string]string{}
 svcloop:
 	for _, v := range c.PropertyService {
 		t := v.(*PropertyService)
 		for _, a := range t.Attributes {
-			if a.Name == attr && (a.Value == value || value == `@defined`) && (t.View == view || t.View == `any`) {
+			if a.Name == cc.Key && cc.match(a.Value) && (t.View == view || t.View == `any`) {
 				f[t.ID.String()] = a.Value
 				continue svcloop
 			}
@@ -143,7 +154,7 @@ func (c *Cluster) updateCheckInstances() {
 
 	// if this is not the startupLoad and there are no updates, then there
 	// is noting to do
-	if !startup && !c.hasUpdate {
+	if !startup && !c.hasUpdate && len(c.updatedChecks) == 0 {
 		return
 	}
 
@@ -191,12 +202,19 @@ func (c *Cluster) removeDisabledCheckInstances() {
 	// its check instances. And with disable we mean delete.
 	for chk := range c.Checks {
 		disableThis := false
+		// a check that was switched to childrenOnly no longer
+		// has instances on its source object
+		if !c.Checks[chk].Inherited && c.Checks[chk].ChildrenOnly {
+			disableThis = true
+		}
 		// disable this check if the system property
 		// `disable_all_monitoring` is set for the view that the check
 		// uses.
 		if _, hit, _ := c.evalSystemProp(
-			msg.SystemPropertyDisableAllMonitoring,
-			`true`,
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableAllMonitoring,
+				Value: `true`,
+			},
 			c.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -205,8 +223,10 @@ func (c *Cluster) removeDisabledCheckInstances() {
 		// `disable_check_configuration` is set to the
 		// check_configuration that spawned this check
 		if _, hit, _ := c.evalSystemProp(
-			msg.SystemPropertyDisableCheckConfiguration,
-			c.Checks[chk].ConfigID.String(),
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableCheckConfiguration,
+				Value: c.Checks[chk].ConfigID.String(),
+			},
 			c.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -237,6 +257,10 @@ func (c *Cluster) calculateCheckInstances(startup bool) {
 	wg := sync.WaitGroup{}
 	c.lock.RLock()
 	for i := range c.Checks {
+		if _, ok := c.updatedChecks[i]; !ok && !startup && !c.hasUpdate {
+			// only updated checks need to be recomputed
+			continue
+		}
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
@@ -246,8 +270,9 @@ func (c *Cluster) calculateCheckInstances(startup bool) {
 	c.lock.RUnlock()
 	wg.Wait()
 
-	// completed the pass, reset update flag
+	// completed the pass, reset update flags
 	c.hasUpdate = false
+	c.updatedChecks = make(map[string]bool)
 }
 
 func (c *Cluster) processCheckForUpdates(chkName string, startup bool) {
@@ -264,8 +289,10 @@ func (c *Cluster) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := c.evalSystemProp(
 		// skip check if `disable_all_monitoring` property is set
-		msg.SystemPropertyDisableAllMonitoring,
-		`true`,
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableAllMonitoring,
+			Value: `true`,
+		},
 		c.Checks[chkName].View,
 	); hit {
 		c.lock.RUnlock()
@@ -273,8 +300,10 @@ func (c *Cluster) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := c.evalSystemProp(
 		// skip check if `disable_check_configuration` property is set
-		msg.SystemPropertyDisableCheckConfiguration,
-		c.Checks[chkName].ConfigID.String(),
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableCheckConfiguration,
+			Value: c.Checks[chkName].ConfigID.String(),
+		},
 		c.Checks[chkName].View,
 	); hit {
 		c.lock.RUnlock()
@@ -282,6 +311,7 @@ func (c *Cluster) processCheckForUpdates(chkName string, startup bool) {
 	}
 
 	ctx := newCheckContext(chkName, c.Checks[chkName].View, startup)
+	ctx.configUpdate = c.hasUpdate || c.updatedChecks[chkName]
 	c.lock.RUnlock()
 
 	c.constraintCheck(ctx)
@@ -343,49 +373,57 @@ func (c *Cluster) constraintCheck(ctx *checkContext) {
 	defer c.lock.RUnlock()
 	// these constaint types must always match for the instance to
 	// be valid. defer service and attribute
+	// OR groups hit if any of their constraints hit
+	groups := constraintGroups{}
 	for _, cc := range c.Checks[ctx.uuid].Constraints {
+		if !cc.isDefault() {
+			ctx.expressions = append(ctx.expressions, cc.expression())
+		}
+		var hit bool
 		switch cc.Type {
 		case msg.ConstraintNative:
-			if c.evalNativeProp(cc.Key, cc.Value) {
-				ctx.nativeConstr[cc.Key] = cc.Value
-				continue
+			var bind string
+			if hit, bind = c.evalNativeProp(cc); hit && !cc.negated() {
+				ctx.nativeConstr[cc.Key] = bind
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintSystem:
-			if id, hit, bind := c.evalSystemProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = c.evalSystemProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.systemConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintOncall:
-			if id, hit := c.evalOncallProp(cc.Key, cc.Value, ctx.view); hit {
+			var id string
+			if id, hit = c.evalOncallProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.oncallConstr = id
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintCustom:
-			if id, hit, bind := c.evalCustomProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = c.evalCustomProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.customConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintService:
-			ctx.hasServiceConstraint = true
-			if id, hit, bind := c.evalServiceProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = c.evalServiceProp(cc, ctx.view); hit && !cc.negated() {
+				ctx.hasServiceConstraint = true
 				ctx.serviceConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintAttribute:
 			ctx.hasAttributeConstraint = true
 			ctx.attributes = append(ctx.attributes, cc)
+			continue
+		}
+		switch {
+		case cc.Group != ``:
+			groups.record(cc.Group, hit)
+		case !hit:
+			ctx.brokeConstraint = true
+			return
 		}
 	}
+	if !groups.satisfied() {
+		ctx.brokeConstraint = true
+		return
+	}
 
 	switch {
 	case ctx.hasServiceConstraint && ctx.hasAttributeConstraint:
@@ -396,7 +434,7 @@ func (c *Cluster) constraintCheck(ctx *checkContext) {
 		 */
 		for id := range ctx.serviceConstr {
 			for _, attr := range ctx.attributes {
-				hit, bind := c.evalAttributeOfService(id, ctx.view, attr.Key, attr.Value)
+				hit, bind := c.evalAttributeOfService(id, ctx.view, attr)
 				if hit {
 					// attributeC[id] might still be a nil map
 					if ctx.attributeConstr[id] == nil {
@@ -420,7 +458,7 @@ func (c *Cluster) constraintCheck(ctx *checkContext) {
 		 */
 		attrCount := len(ctx.attributes)
 		for _, attr := range ctx.attributes {
-			if hit, svcIDMap := c.evalAttributeProp(ctx.view, attr.Key, attr.Value); hit {
+			if hit, svcIDMap := c.evalAttributeProp(ctx.view, attr); hit {
 				for id, bind := range svcIDMap {
 					ctx.serviceConstr[id] = svcIDMap[id]
 					// attributeC[id] might still be a nil map
@@ -477,6 +515,7 @@ func (c *Cluster) createNoServiceCheckInstance(ctx *checkContext) {
 		ConstraintCustom:      ctx.customConstr,
 		ConstraintNative:      ctx.nativeConstr,
 		ConstraintAttribute:   ctx.attributeConstr,
+		ConstraintExpression:  ctx.expressions,
 		InstanceService:       ``,
 		InstanceServiceConfig: nil,
 		InstanceSvcCfgHash:    ``,
@@ -575,36 +614,8 @@ func (c *Cluster) createPerServiceCheckInstances(ctx *checkContext) {
 	for svcID := range ctx.serviceConstr {
 		svcCfg := c.getServiceMap(svcID)
 
-		// calculate how many instances this service spawns
-		combinations := 1
-		for attr := range svcCfg {
-			combinations = combinations * len(svcCfg[attr])
-		}
-
 		// build all attribute combinations
-		results := make([]map[string]string, 0, combinations)
-		for attr := range svcCfg {
-			if len(results) == 0 {
-				for i := range svcCfg[attr] {
-					res := map[string]string{}
-					res[attr] = svcCfg[attr][i]
-					results = append(results, res)
-				}
-				continue
-			}
-			ires := make([]map[string]string, 0, combinations)
-			for r := range results {
-				for j := range svcCfg[attr] {
-					res := map[string]string{}
-					for k, v := range results[r] {
-						res[k] = v
-					}
-					res[attr] = svcCfg[attr][j]
-					ires = append(ires, res)
-				}
-			}
-			results = ires
-		}
+		results := serviceConfigCombinations(svcCfg)
 		// build a CheckInstance for every result
 		for _, y := range results {
 			// ensure we have a full copy and not a header copy
@@ -629,6 +640,7 @@ func (c *Cluster) createPerServiceCheckInstances(ctx *checkContext) {
 				ConstraintCustom:      ctx.customConstr,
 				ConstraintNative:      ctx.nativeConstr,
 				ConstraintAttribute:   ctx.attributeConstr,
+				ConstraintExpression:  ctx.expressions,
 				InstanceService:       svcID,
 				InstanceServiceConfig: cfg,
 			}
@@ -753,6 +765,12 @@ func (c *Cluster) dispatchCheckInstanceUpdates(ctx *checkContext) {
 	defer c.lock.Unlock()
 
 	for _, oldInstanceID := range c.CheckInstances[ctx.uuid] {
+		if !ctx.configUpdate && c.Instances[oldInstanceID].ConstraintValHash ==
+			ctx.newInstances[oldInstanceID].ConstraintValHash {
+			// neither the check configuration nor the bound
+			// constraint values changed, keep the current version
+			continue
+		}
 		delete(c.Instances, oldInstanceID)
 		c.Instances[oldInstanceID] = ctx.newInstances[oldInstanceID]
 		c.actionCheckInstanceUpdate(c.Instances[oldInstanceID].MakeAction())
@@ -15,85 +15,96 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func (g *Group) evalNativeProp(prop string, val string) bool {
-	switch prop {
+func (g *Group) evalNativeProp(cc CheckConstraint) (bool, string) {
+	var val string
+	switch cc.Key {
 	case msg.NativePropertyEnvironment:
-		env := g.Parent.(Bucketeer).GetEnvironment()
-		if val == env {
-			return true
-		}
+		val = g.Parent.(Bucketeer).GetEnvironment()
 	case msg.NativePropertyEntity:
-		if val == msg.EntityGroup {
-			return true
-		}
+		val = msg.EntityGroup
 	case msg.NativePropertyState:
-		if val == g.State {
-			return true
-		}
+		val = g.State
 	case msg.NativePropertyHardwareNode:
 		// group != hardware
-		return false
+		return false, ""
+	default:
+		return false, ""
+	}
+	if cc.match(val) != cc.negated() {
+		return true, val
 	}
-	return false
+	return false, ""
 }
 
-func (g *Group) evalSystemProp(prop string, val string, view string) (string, bool, string) {
+func (g *Group) evalSystemProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range g.PropertySystem {
 		t := v.(*PropertySystem)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (g *Group) evalOncallProp(prop string, val string, view string) (string, bool) {
+func (g *Group) evalOncallProp(cc CheckConstraint, view string) (string, bool) {
 	for _, v := range g.PropertyOncall {
 		t := v.(*PropertyOncall)
-		if "OncallID" == prop && t.ID.String() == val && (t.View == view || t.View == `any`) {
+		if "OncallID" == cc.Key && cc.match(t.ID.String()) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false
+			}
 			return t.ID.String(), true
 		}
 	}
-	return "", false
+	return "", cc.negated()
 }
 
-func (g *Group) evalCustomProp(prop string, val string, view string) (string, bool, string) {
+func (g *Group) evalCustomProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range g.PropertyCustom {
 		t := v.(*PropertyCustom)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (g *Group) evalServiceProp(prop string, val string, view string) (string, bool, string) {
+func (g *Group) evalServiceProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range g.PropertyService {
 		t := v.(*PropertyService)
-		if ((prop == "name" && (t.ServiceName == val || val == `@defined`)) || (prop == "id" && (t.ServiceID.String() == val))) && (t.View == view || t.View == `any`) {
+		if ((cc.Key == "name" && cc.match(t.ServiceName)) || (cc.Key == "id" && cc.match(t.ServiceID.String()))) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.ID.String(), true, t.ServiceName
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (g *Group) evalAttributeOfService(svcID string, view string, attribute string, value string) (bool, string) {
+func (g *Group) evalAttributeOfService(svcID string, view string, cc CheckConstraint) (bool, string) {
 	t := g.PropertyService[svcID].(*PropertyService)
 	for _, a := range t.Attributes {
-		if a.Name == attribute && (t.View == view || t.View == `any`) && (a.Value == value || value == `@defined`) {
+		if a.Name == cc.Key && (t.View == view || t.View == `any`) && cc.match(a.Value) {
 			return true, a.Value
 		}
 	}
 	return false, ""
 }
 
-func (g *Group) evalAttributeProp(view string, attr string, value string) (bool, map[string]string) {
+func (g *Group) evalAttributeProp(view string, cc CheckConstraint) (bool, map[string]string) {
 	f := map[string]string{}
 svcloop:
 	for _, v := range g.PropertyService {
 		t := v.(*PropertyService)
 		for _, a := range t.Attributes {
-			if a.Name == attr && (a.Value == value || value == `@defined`) && (t.View == view || t.View == `any`) {
+			if a.Name == cc.Key && cc.match(a.Value) && (t.View == view || t.View == `any`) {
 				f[t.ID.String()] = a.Value
 				continue svcloop
 			}
@@ -142,7 +153,7 @@ func (g *Group) updateCheckInstances() {
 	g.lock.RUnlock()
 	// if this is not the startupLoad and there are no updates, then there
 	// is noting to do
-	if !startup && !g.hasUpdate {
+	if !startup && !g.hasUpdate && len(g.updatedChecks) == 0 {
 		return
 	}
 
@@ -190,12 +201,19 @@ func (g *Group) removeDisabledCheckInstances() {
 	// its check instances. And with disable we mean delete.
 	for chk := range g.Checks {
 		disableThis := false
+		// a check that was switched to childrenOnly no longer
+		// has instances on its source object
+		if !g.Checks[chk].Inherited && g.Checks[chk].ChildrenOnly {
+			disableThis = true
+		}
 		// disable this check if the system property
 		// `disable_all_monitoring` is set for the view that the check
 		// uses.
 		if _, hit, _ := g.evalSystemProp(
-			msg.SystemPropertyDisableAllMonitoring,
-			`true`,
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableAllMonitoring,
+				Value: `true`,
+			},
 			g.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -204,8 +222,10 @@ func (g *Group) removeDisabledCheckInstances() {
 		// `disable_check_configuration` is set to the
 		// check_configuration that spawned this check
 		if _, hit, _ := g.evalSystemProp(
-			msg.SystemPropertyDisableCheckConfiguration,
-			g.Checks[chk].ConfigID.String(),
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableCheckConfiguration,
+				Value: g.Checks[chk].ConfigID.String(),
+			},
 			g.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -236,6 +256,10 @@ func (g *Group) calculateCheckInstances(startup bool) {
 	wg := sync.WaitGroup{}
 	g.lock.RLock()
 	for i := range g.Checks {
+		if _, ok := g.updatedChecks[i]; !ok && !startup && !g.hasUpdate {
+			// only updated checks need to be recomputed
+			continue
+		}
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
@@ -245,8 +269,9 @@ func (g *Group) calculateCheckInstances(startup bool) {
 	g.lock.RUnlock()
 	wg.Wait()
 
-	// completed the pass, reset update flag
+	// completed the pass, reset update flags
 	g.hasUpdate = false
+	g.updatedChecks = make(map[string]bool)
 }
 
 func (g *Group) processCheckForUpdates(chkName string, startup bool) {
@@ -263,8 +288,10 @@ func (g *Group) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := g.evalSystemProp(
 		// skip check if `disable_all_monitoring` property is set
-		msg.SystemPropertyDisableAllMonitoring,
-		`true`,
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableAllMonitoring,
+			Value: `true`,
+		},
 		g.Checks[chkName].View,
 	); hit {
 		g.lock.RUnlock()
@@ -272,8 +299,10 @@ func (g *Group) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := g.evalSystemProp(
 		// skip check if `disable_check_configuration` property is set
-		msg.SystemPropertyDisableCheckConfiguration,
-		g.Checks[chkName].ConfigID.String(),
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableCheckConfiguration,
+			Value: g.Checks[chkName].ConfigID.String(),
+		},
 		g.Checks[chkName].View,
 	); hit {
 		g.lock.RUnlock()
@@ -281,6 +310,7 @@ func (g *Group) processCheckForUpdates(chkName string, startup bool) {
 	}
 
 	ctx := newCheckContext(chkName, g.Checks[chkName].View, startup)
+	ctx.configUpdate = g.hasUpdate || g.updatedChecks[chkName]
 	g.lock.RUnlock()
 
 	g.constraintCheck(ctx)
@@ -342,49 +372,57 @@ func (g *Group) constraintCheck(ctx *checkContext) {
 	defer g.lock.RUnlock()
 	// these constaint types must always match for the instance to
 	// be valid. defer service and attribute
+	// OR groups hit if any of their constraints hit
+	groups := constraintGroups{}
 	for _, c := range g.Checks[ctx.uuid].Constraints {
+		if !c.isDefault() {
+			ctx.expressions = append(ctx.expressions, c.expression())
+		}
+		var hit bool
 		switch c.Type {
 		case msg.ConstraintNative:
-			if g.evalNativeProp(c.Key, c.Value) {
-				ctx.nativeConstr[c.Key] = c.Value
-				continue
+			var bind string
+			if hit, bind = g.evalNativeProp(c); hit && !c.negated() {
+				ctx.nativeConstr[c.Key] = bind
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintSystem:
-			if id, hit, bind := g.evalSystemProp(c.Key, c.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = g.evalSystemProp(c, ctx.view); hit && !c.negated() {
 				ctx.systemConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintOncall:
-			if id, hit := g.evalOncallProp(c.Key, c.Value, ctx.view); hit {
+			var id string
+			if id, hit = g.evalOncallProp(c, ctx.view); hit && !c.negated() {
 				ctx.oncallConstr = id
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintCustom:
-			if id, hit, bind := g.evalCustomProp(c.Key, c.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = g.evalCustomProp(c, ctx.view); hit && !c.negated() {
 				ctx.customConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintService:
-			ctx.hasServiceConstraint = true
-			if id, hit, bind := g.evalServiceProp(c.Key, c.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = g.evalServiceProp(c, ctx.view); hit && !c.negated() {
+				ctx.hasServiceConstraint = true
 				ctx.serviceConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintAttribute:
 			ctx.hasAttributeConstraint = true
 			ctx.attributes = append(ctx.attributes, c)
+			continue
+		}
+		switch {
+		case c.Group != ``:
+			groups.record(c.Group, hit)
+		case !hit:
+			ctx.brokeConstraint = true
+			return
 		}
 	}
+	if !groups.satisfied() {
+		ctx.brokeConstraint = true
+		return
+	}
 
 	switch {
 	case ctx.hasServiceConstraint && ctx.hasAttributeConstraint:
@@ -395,7 +433,7 @@ func (g *Group) constraintCheck(ctx *checkContext) {
 		 */
 		for id := range ctx.serviceConstr {
 			for _, attr := range ctx.attributes {
-				hit, bind := g.evalAttributeOfService(id, ctx.view, attr.Key, attr.Value)
+				hit, bind := g.evalAttributeOfService(id, ctx.view, attr)
 				if hit {
 					// attributeC[id] might still be a nil map
 					if ctx.attributeConstr[id] == nil {
@@ -419,7 +457,7 @@ func (g *Group) constraintCheck(ctx *checkContext) {
 		 */
 		attrCount := len(ctx.attributes)
 		for _, attr := range ctx.attributes {
-			if hit, svcIDMap := g.evalAttributeProp(ctx.view, attr.Key, attr.Value); hit {
+			if hit, svcIDMap := g.evalAttributeProp(ctx.view, attr); hit {
 				for id, bind := range svcIDMap {
 					ctx.serviceConstr[id] = svcIDMap[id]
 					// attributeC[id] might still be a nil map
@@ -476,6 +514,7 @@ func (g *Group) createNoServiceCheckInstance(ctx *checkContext) {
 		ConstraintCustom:      ctx.customConstr,
 		ConstraintNative:      ctx.nativeConstr,
 		ConstraintAttribute:   ctx.attributeConstr,
+		ConstraintExpression:  ctx.expressions,
 		InstanceService:       ``,
 		InstanceServiceConfig: nil,
 		InstanceSvcCfgHash:    ``,
@@ -575,36 +614,8 @@ func (g *Group) createPerServiceCheckInstances(ctx *checkContext) {
 	for svcID := range ctx.serviceConstr {
 		svcCfg := g.getServiceMap(svcID)
 
-		// calculate how many instances this service spawns
-		combinations := 1
-		for attr := range svcCfg {
-			combinations = combinations * len(svcCfg[attr])
-		}
-
 		// build all attribute combinations
-		results := make([]map[string]string, 0, combinations)
-		for attr := range svcCfg {
-			if len(results) == 0 {
-				for i := range svcCfg[attr] {
-					res := map[string]string{}
-					res[attr] = svcCfg[attr][i]
-					results = append(results, res)
-				}
-				continue
-			}
-			ires := make([]map[string]string, 0, combinations)
-			for r := range results {
-				for j := range svcCfg[attr] {
-					res := map[string]string{}
-					for k, v := range results[r] {
-						res[k] = v
-					}
-					res[attr] = svcCfg[attr][j]
-					ires = append(ires, res)
-				}
-			}
-			results = ires
-		}
+		results := serviceConfigCombinations(svcCfg)
 		// build a CheckInstance for every result
 		for _, y := range results {
 			// ensure we have a full copy and not a header copy
@@ -629,6 +640,7 @@ func (g *Group) createPerServiceCheckInstances(ctx *checkContext) {
 				ConstraintCustom:      ctx.customConstr,
 				ConstraintNative:      ctx.nativeConstr,
 				ConstraintAttribute:   ctx.attributeConstr,
+				ConstraintExpression:  ctx.expressions,
 				InstanceService:       svcID,
 				InstanceServiceConfig: cfg,
 			}
@@ -753,6 +765,12 @@ func (g *Group) dispatchCheckInstanceUpdates(ctx *checkContext) {
 	defer g.lock.Unlock()
 
 	for _, oldInstanceID := range g.CheckInstances[ctx.uuid] {
+		if !ctx.configUpdate && g.Instances[oldInstanceID].ConstraintValHash ==
+			ctx.newInstances[oldInstanceID].ConstraintValHash {
+			// neither the check configuration nor the bound
+			// constraint values changed, keep the current version
+			continue
+		}
 		delete(g.Instances, oldInstanceID)
 		g.Instances[oldInstanceID] = ctx.newInstances[oldInstanceID]
 		g.actionCheckInstanceUpdate(g.Instances[oldInstanceID].MakeAction())
@@ -15,86 +15,97 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func (n *Node) evalNativeProp(prop string, val string) bool {
-	switch prop {
+func (n *Node) evalNativeProp(cc CheckConstraint) (bool, string) {
+	var val string
+	switch cc.Key {
 	case msg.NativePropertyEnvironment:
-		env := n.Parent.(Bucketeer).GetEnvironment()
-		if val == env {
-			return true
-		}
+		val = n.Parent.(Bucketeer).GetEnvironment()
 	case msg.NativePropertyEntity:
-		if val == msg.EntityNode {
-			return true
-		}
+		val = msg.EntityNode
 	case msg.NativePropertyState:
-		if val == n.State {
-			return true
-		}
+		val = n.State
 	case msg.NativePropertyHardwareNode:
 		// XX needs n.ServerName extension of ten
 		// if val == n.ServerName { return true }
-		return false
+		return false, ""
+	default:
+		return false, ""
 	}
-	return false
+	if cc.match(val) != cc.negated() {
+		return true, val
+	}
+	return false, ""
 }
 
-func (n *Node) evalSystemProp(prop string, val string, view string) (string, bool, string) {
+func (n *Node) evalSystemProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range n.PropertySystem {
 		t := v.(*PropertySystem)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (n *Node) evalOncallProp(prop string, val string, view string) (string, bool) {
+func (n *Node) evalOncallProp(cc CheckConstraint, view string) (string, bool) {
 	for _, v := range n.PropertyOncall {
 		t := v.(*PropertyOncall)
-		if "OncallID" == prop && t.ID.String() == val && (t.View == view || t.View == `any`) {
+		if "OncallID" == cc.Key && cc.match(t.ID.String()) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false
+			}
 			return t.ID.String(), true
 		}
 	}
-	return "", false
+	return "", cc.negated()
 }
 
-func (n *Node) evalCustomProp(prop string, val string, view string) (string, bool, string) {
+func (n *Node) evalCustomProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range n.PropertyCustom {
 		t := v.(*PropertyCustom)
-		if t.Key == prop && (t.Value == val || val == `@defined`) && (t.View == view || t.View == `any`) {
+		if t.Key == cc.Key && cc.match(t.Value) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.Key, true, t.Value
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (n *Node) evalServiceProp(prop string, val string, view string) (string, bool, string) {
+func (n *Node) evalServiceProp(cc CheckConstraint, view string) (string, bool, string) {
 	for _, v := range n.PropertyService {
 		t := v.(*PropertyService)
-		if ((prop == "name" && (t.ServiceName == val || val == `@defined`)) || (prop == "id" && (t.ServiceID.String() == val))) && (t.View == view || t.View == `any`) {
+		if ((cc.Key == "name" && cc.match(t.ServiceName)) || (cc.Key == "id" && cc.match(t.ServiceID.String()))) && (t.View == view || t.View == `any`) {
+			if cc.negated() {
+				return "", false, ""
+			}
 			return t.ID.String(), true, t.ServiceName
 		}
 	}
-	return "", false, ""
+	return "", cc.negated(), ""
 }
 
-func (n *Node) evalAttributeOfService(svcID string, view string, attribute string, value string) (bool, string) {
+func (n *Node) evalAttributeOfService(svcID string, view string, cc CheckConstraint) (bool, string) {
 	t := n.PropertyService[svcID].(*PropertyService)
 	for _, a := range t.Attributes {
-		if a.Name == attribute && (t.View == view || t.View == `any`) && (a.Value == value || value == `@defined`) {
+		if a.Name == cc.Key && (t.View == view || t.View == `any`) && cc.match(a.Value) {
 			return true, a.Value
 		}
 	}
 	return false, ""
 }
 
-func (n *Node) evalAttributeProp(view string, attr string, value string) (bool, map[string]string) {
+func (n *Node) evalAttributeProp(view string, cc CheckConstraint) (bool, map[string]string) {
 	f := map[string]string{}
 svcloop:
 	for _, v := range n.PropertyService {
 		t := v.(*PropertyService)
 		for _, a := range t.Attributes {
-			if a.Name == attr && (a.Value == value || value == `@defined`) && (t.View == view || t.View == `any`) {
+			if a.Name == cc.Key && cc.match(a.Value) && (t.View == view || t.View == `any`) {
 				f[t.ID.String()] = a.Value
 				continue svcloop
 			}
@@ -141,7 +152,7 @@ func (n *Node) updateCheckInstances() {
 
 	// if this is not the startupLoad and there are no updates, then there
 	// is noting to do
-	if !startup && !n.hasUpdate {
+	if !startup && !n.hasUpdate && len(n.updatedChecks) == 0 {
 		return
 	}
 
@@ -186,12 +197,19 @@ func (n *Node) removeDisabledCheckInstances() {
 	// its check instances. And with disable we mean delete.
 	for chk := range n.Checks {
 		disableThis := false
+		// a check that was switched to childrenOnly no longer
+		// has instances on its source object
+		if !n.Checks[chk].Inherited && n.Checks[chk].ChildrenOnly {
+			disableThis = true
+		}
 		// disable this check if the system property
 		// `disable_all_monitoring` is set for the view that the check
 		// uses
 		if _, hit, _ := n.evalSystemProp(
-			msg.SystemPropertyDisableAllMonitoring,
-			`true`,
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableAllMonitoring,
+				Value: `true`,
+			},
 			n.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -200,8 +218,10 @@ func (n *Node) removeDisabledCheckInstances() {
 		// `disable_check_configuration` is set to the
 		// check_configuration that spawned this check
 		if _, hit, _ := n.evalSystemProp(
-			msg.SystemPropertyDisableCheckConfiguration,
-			n.Checks[chk].ConfigID.String(),
+			CheckConstraint{
+				Key:   msg.SystemPropertyDisableCheckConfiguration,
+				Value: n.Checks[chk].ConfigID.String(),
+			},
 			n.Checks[chk].View,
 		); hit {
 			disableThis = true
@@ -229,6 +249,10 @@ func (n *Node) calculateCheckInstances(startup bool) {
 	wg := sync.WaitGroup{}
 	n.lock.RLock()
 	for i := range n.Checks {
+		if _, ok := n.updatedChecks[i]; !ok && !startup && !n.hasUpdate {
+			// only updated checks need to be recomputed
+			continue
+		}
 		wg.Add(1)
 		go func(name string) {
 			defer wg.Done()
@@ -238,8 +262,9 @@ func (n *Node) calculateCheckInstances(startup bool) {
 	n.lock.RUnlock()
 	wg.Wait()
 
-	// completed the pass, reset update flag
+	// completed the pass, reset update flags
 	n.hasUpdate = false
+	n.updatedChecks = make(map[string]bool)
 }
 
 func (n *Node) processCheckForUpdates(chkName string, startup bool) {
@@ -251,8 +276,10 @@ func (n *Node) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := n.evalSystemProp(
 		// skip check if `disable_all_monitoring` property is set
-		msg.SystemPropertyDisableAllMonitoring,
-		`true`,
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableAllMonitoring,
+			Value: `true`,
+		},
 		n.Checks[chkName].View,
 	); hit {
 		n.lock.RUnlock()
@@ -260,8 +287,10 @@ func (n *Node) processCheckForUpdates(chkName string, startup bool) {
 	}
 	if _, hit, _ := n.evalSystemProp(
 		// skip check if `disable_check_configuration` property is set
-		msg.SystemPropertyDisableCheckConfiguration,
-		n.Checks[chkName].ConfigID.String(),
+		CheckConstraint{
+			Key:   msg.SystemPropertyDisableCheckConfiguration,
+			Value: n.Checks[chkName].ConfigID.String(),
+		},
 		n.Checks[chkName].View,
 	); hit {
 		n.lock.RUnlock()
@@ -269,6 +298,7 @@ func (n *Node) processCheckForUpdates(chkName string, startup bool) {
 	}
 
 	ctx := newCheckContext(chkName, n.Checks[chkName].View, startup)
+	ctx.configUpdate = n.hasUpdate || n.updatedChecks[chkName]
 	n.lock.RUnlock()
 	n.constraintCheck(ctx)
 	n.log.Printf(
@@ -330,50 +360,57 @@ func (n *Node) constraintCheck(ctx *checkContext) {
 	defer n.lock.RUnlock()
 	// these constaint types must always match for the instance to
 	// be valid. defer service and attribute
+	// OR groups hit if any of their constraints hit
+	groups := constraintGroups{}
 	for _, cc := range n.Checks[ctx.uuid].Constraints {
+		if !cc.isDefault() {
+			ctx.expressions = append(ctx.expressions, cc.expression())
+		}
+		var hit bool
 		switch cc.Type {
 		case msg.ConstraintNative:
-			if n.evalNativeProp(cc.Key, cc.Value) {
-				ctx.nativeConstr[cc.Key] = cc.Value
-				continue
+			var bind string
+			if hit, bind = n.evalNativeProp(cc); hit && !cc.negated() {
+				ctx.nativeConstr[cc.Key] = bind
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintSystem:
-			if id, hit, bind := n.evalSystemProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = n.evalSystemProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.systemConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintOncall:
-			if id, hit := n.evalOncallProp(cc.Key, cc.Value, ctx.view); hit {
+			var id string
+			if id, hit = n.evalOncallProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.oncallConstr = id
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintCustom:
-			if id, hit, bind := n.evalCustomProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = n.evalCustomProp(cc, ctx.view); hit && !cc.negated() {
 				ctx.customConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintService:
-
-			ctx.hasServiceConstraint = true
-			if id, hit, bind := n.evalServiceProp(cc.Key, cc.Value, ctx.view); hit {
+			var id, bind string
+			if id, hit, bind = n.evalServiceProp(cc, ctx.view); hit && !cc.negated() {
+				ctx.hasServiceConstraint = true
 				ctx.serviceConstr[id] = bind
-				continue
 			}
-			ctx.brokeConstraint = true
-			return
 		case msg.ConstraintAttribute:
 			ctx.hasAttributeConstraint = true
 			ctx.attributes = append(ctx.attributes, cc)
+			continue
+		}
+		switch {
+		case cc.Group != ``:
+			groups.record(cc.Group, hit)
+		case !hit:
+			ctx.brokeConstraint = true
+			return
 		}
 	}
+	if !groups.satisfied() {
+		ctx.brokeConstraint = true
+		return
+	}
 
 	switch {
 	case ctx.hasServiceConstraint && ctx.hasAttributeConstraint:
@@ -384,7 +421,7 @@ func (n *Node) constraintCheck(ctx *checkContext) {
 		 */
 		for id := range ctx.serviceConstr {
 			for _, attr := range ctx.attributes {
-				hit, bind := n.evalAttributeOfService(id, ctx.view, attr.Key, attr.Value)
+				hit, bind := n.evalAttributeOfService(id, ctx.view, attr)
 				if hit {
 					// attributeC[id] might still be a nil map
 					if ctx.attributeConstr[id] == nil {
@@ -408,7 +445,7 @@ func (n *Node) constraintCheck(ctx *checkContext) {
 		 */
 		attrCount := len(ctx.attributes)
 		for _, attr := range ctx.attributes {
-			if hit, svcIDMap := n.evalAttributeProp(ctx.view, attr.Key, attr.Value); hit {
+			if hit, svcIDMap := n.evalAttributeProp(ctx.view, attr); hit {
 				for id, bind := range svcIDMap {
 					ctx.serviceConstr[id] = svcIDMap[id]
 					// attributeC[id] might still be a nil map
@@ -464,6 +501,7 @@ func (n *Node) createNoServiceCheckInstance(ctx *checkContext) {
 		ConstraintCustom:      ctx.customConstr,
 		ConstraintNative:      ctx.nativeConstr,
 		ConstraintAttribute:   ctx.attributeConstr,
+		ConstraintExpression:  ctx.expressions,
 		InstanceService:       ``,
 		InstanceServiceConfig: nil,
 		InstanceSvcCfgHash:    ``,
@@ -561,36 +599,8 @@ func (n *Node) createPerServiceCheckInstances(ctx *checkContext) {
 	for svcID := range ctx.serviceConstr {
 		svcCfg := n.getServiceMap(svcID)
 
-		// calculate how many instances this service spawns
-		combinations := 1
-		for attr := range svcCfg {
-			combinations = combinations * len(svcCfg[attr])
-		}
-
 		// build all attribute combinations
-		results := make([]map[string]string, 0, combinations)
-		for attr := range svcCfg {
-			if len(results) == 0 {
-				for i := range svcCfg[attr] {
-					res := map[string]string{}
-					res[attr] = svcCfg[attr][i]
-					results = append(results, res)
-				}
-				continue
-			}
-			ires := make([]map[string]string, 0, combinations)
-			for r := range results {
-				for j := range svcCfg[attr] {
-					res := map[string]string{}
-					for k, v := range results[r] {
-						res[k] = v
-					}
-					res[attr] = svcCfg[attr][j]
-					ires = append(ires, res)
-				}
-			}
-			results = ires
-		}
+		results := serviceConfigCombinations(svcCfg)
 		// build a CheckInstance for every result
 		for _, y := range results {
 			// ensure we have a full copy and not a header copy
@@ -615,6 +625,7 @@ func (n *Node) createPerServiceCheckInstances(ctx *checkContext) {
 				ConstraintCustom:      ctx.customConstr,
 				ConstraintNative:      ctx.nativeConstr,
 				ConstraintAttribute:   ctx.attributeConstr,
+				ConstraintExpression:  ctx.expressions,
 				InstanceService:       svcID,
 				InstanceServiceConfig: cfg,
 			}
@@ -740,6 +751,12 @@ func (n *Node) dispatchCheckInstanceUpdates(ctx *checkContext) {
 	defer n.lock.Unlock()
 
 	for _, oldInstanceID := range n.CheckInstances[ctx.uuid] {
+		if !ctx.configUpdate && n.Instances[oldInstanceID].ConstraintValHash ==
+			ctx.newInstances[oldInstanceID].ConstraintValHash {
+			// neither the check configuration nor the bound
+			// constraint values changed, keep the current version
+			continue
+		}
 		delete(n.Instances, oldInstanceID)
 		n.Instances[oldInstanceID] = ctx.newInstances[oldInstanceID]
 		n.actionCheckInstanceUpdate(n.Instances[oldInstanceID].MakeAction())
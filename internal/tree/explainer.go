@@ -0,0 +1,252 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"sort"
+
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// Explainer is implemented by tree elements that can spawn check
+// instances. ExplainCheck evaluates the check configuration configID
+// against the element without modifying the tree.
+type Explainer interface {
+	ExplainCheck(configID string) proto.CheckExplanation
+}
+
+// checkExplainer is implemented by all tree elements that checks can
+// be set on or inherited through
+type checkExplainer interface {
+	Builder
+
+	explainCheck(configID string) (Check, bool)
+	explainParent() checkExplainer
+}
+
+// instanceExplainer is implemented by all tree elements that spawn
+// check instances
+type instanceExplainer interface {
+	checkExplainer
+
+	constraintCheck(ctx *checkContext)
+	explainNative(key string) (string, bool)
+	explainMatch(cc CheckConstraint, view string) bool
+	explainProperties(propType, view string) []Property
+	explainServiceMap(svcID string) map[string][]string
+	explainInstances(checkID string) []CheckInstance
+}
+
+// explainCheck evaluates the check configuration configID against e
+// the same way the check instance calculation does
+func explainCheck(e instanceExplainer, configID string) proto.CheckExplanation {
+	x := proto.CheckExplanation{
+		CheckConfigID: configID,
+		ObjectType:    e.GetType(),
+		ObjectID:      e.GetID(),
+		ObjectName:    e.GetName(),
+		Inheritance:   explainInheritance(e, configID),
+		Disabled:      []proto.CheckExplanationProperty{},
+		Constraints:   []proto.CheckExplanationConstraint{},
+		Instances:     []proto.CheckExplanationInstance{},
+	}
+
+	chk, ok := e.explainCheck(configID)
+	if !ok {
+		x.Reason = `Check configuration is neither set on nor inherited` +
+			` to this object`
+		return x
+	}
+	x.CheckID = chk.ID.String()
+	x.View = chk.View
+	x.ChildrenOnly = chk.ChildrenOnly
+
+	if !chk.Inherited && chk.ChildrenOnly {
+		x.Reason = `Check configuration is set children-only on this object`
+	}
+
+	for _, prop := range e.explainProperties(msg.PropertySystem, chk.View) {
+		var effective bool
+		switch prop.GetKey() {
+		case msg.SystemPropertyDisableAllMonitoring:
+			effective = prop.GetValue() == `true`
+		case msg.SystemPropertyDisableCheckConfiguration:
+			effective = prop.GetValue() == configID
+		default:
+			continue
+		}
+		x.Disabled = append(x.Disabled, proto.CheckExplanationProperty{
+			Name:      prop.GetKey(),
+			Value:     prop.GetValue(),
+			View:      prop.GetView(),
+			Inherited: prop.GetIsInherited(),
+			Effective: effective,
+		})
+		if effective && x.Reason == `` {
+			x.Reason = `Check configuration is disabled by system property ` +
+				prop.GetKey()
+		}
+	}
+
+	for _, cc := range chk.Constraints {
+		x.Constraints = append(x.Constraints,
+			explainConstraint(e, cc, chk.View))
+	}
+	ctx := newCheckContext(x.CheckID, chk.View, false)
+	e.constraintCheck(ctx)
+	if ctx.brokeConstraint && x.Reason == `` {
+		x.Reason = `Check constraints are not satisfied`
+	}
+	if x.Reason != `` {
+		return x
+	}
+
+	existing := e.explainInstances(x.CheckID)
+	if !ctx.hasServiceConstraint {
+		x.Instances = append(x.Instances, proto.CheckExplanationInstance{
+			InstanceID: matchExplainedInstance(existing, ``, nil),
+		})
+	} else {
+		services := map[string]*PropertyService{}
+		for _, prop := range e.explainProperties(msg.PropertyService, chk.View) {
+			services[prop.GetID()] = prop.(*PropertyService)
+		}
+		svcIDs := make([]string, 0, len(ctx.serviceConstr))
+		for svcID := range ctx.serviceConstr {
+			svcIDs = append(svcIDs, svcID)
+		}
+		sort.Strings(svcIDs)
+
+		for _, svcID := range svcIDs {
+			for _, cfg := range serviceConfigCombinations(
+				e.explainServiceMap(svcID)) {
+				inst := proto.CheckExplanationInstance{
+					InstanceID: matchExplainedInstance(
+						existing, svcID, cfg),
+					ServiceConfig: cfg,
+				}
+				if svc, ok := services[svcID]; ok {
+					inst.ServiceID = svc.ServiceID.String()
+					inst.ServiceName = svc.ServiceName
+				}
+				x.Instances = append(x.Instances, inst)
+			}
+		}
+	}
+
+	if len(x.Instances) == 0 {
+		x.Reason = `Matched services have no attributes to build check` +
+			` instances from`
+		return x
+	}
+	x.Applies = true
+	return x
+}
+
+// explainInheritance returns the path from e upward to the object
+// the check configuration configID is defined on. If no object
+// defines the check configuration, the path ends at the repository.
+func explainInheritance(e checkExplainer, configID string) []proto.CheckExplanationPath {
+	path := []proto.CheckExplanationPath{}
+	for hop := e; hop != nil; hop = hop.explainParent() {
+		chk, ok := hop.explainCheck(configID)
+		path = append(path, proto.CheckExplanationPath{
+			ObjectType:   hop.GetType(),
+			ObjectID:     hop.GetID(),
+			ObjectName:   hop.GetName(),
+			HasCheck:     ok,
+			IsSource:     ok && !chk.Inherited,
+			Inheritance:  ok && chk.Inheritance,
+			ChildrenOnly: ok && chk.ChildrenOnly,
+		})
+		if ok && !chk.Inherited {
+			break
+		}
+	}
+	return path
+}
+
+// explainConstraint evaluates constraint cc against e and lists the
+// values of the matching properties of e that are visible in view
+func explainConstraint(e instanceExplainer, cc CheckConstraint,
+	view string) proto.CheckExplanationConstraint {
+	x := proto.CheckExplanationConstraint{
+		ConstraintType: cc.Type,
+		Key:            cc.Key,
+		Operator:       cc.Operator,
+		Group:          cc.Group,
+		Expected:       cc.Value,
+		Actual:         []string{},
+	}
+
+	switch cc.Type {
+	case msg.ConstraintNative:
+		// unsupported native properties have no value
+		if value, ok := e.explainNative(cc.Key); ok {
+			x.Actual = append(x.Actual, value)
+		}
+	case msg.ConstraintSystem, msg.ConstraintCustom:
+		for _, prop := range e.explainProperties(cc.Type, view) {
+			if prop.GetKey() == cc.Key {
+				x.Actual = append(x.Actual, prop.GetValue())
+			}
+		}
+	case msg.ConstraintOncall:
+		if cc.Key != `OncallID` {
+			break
+		}
+		for _, prop := range e.explainProperties(msg.PropertyOncall, view) {
+			x.Actual = append(x.Actual, prop.GetID())
+		}
+	case msg.ConstraintService:
+		for _, prop := range e.explainProperties(msg.PropertyService, view) {
+			svc := prop.(*PropertyService)
+			switch cc.Key {
+			case `name`:
+				x.Actual = append(x.Actual, svc.ServiceName)
+			case `id`:
+				x.Actual = append(x.Actual, svc.ServiceID.String())
+			}
+		}
+	case msg.ConstraintAttribute:
+		for _, prop := range e.explainProperties(msg.PropertyService, view) {
+			for _, attr := range prop.(*PropertyService).Attributes {
+				if attr.Name == cc.Key {
+					x.Actual = append(x.Actual, attr.Value)
+				}
+			}
+		}
+	}
+	// the verdict comes from the same evaluation constraintCheck
+	// uses, Actual only lists the compared values
+	x.Match = e.explainMatch(cc, view)
+	return x
+}
+
+// matchExplainedInstance returns the ID of the instance in existing
+// that was built for service svcID with service configuration cfg
+func matchExplainedInstance(existing []CheckInstance, svcID string,
+	cfg map[string]string) string {
+instanceloop:
+	for _, inst := range existing {
+		if inst.InstanceService != svcID ||
+			len(inst.InstanceServiceConfig) != len(cfg) {
+			continue
+		}
+		for k, v := range cfg {
+			if val, ok := inst.InstanceServiceConfig[k]; !ok || val != v {
+				continue instanceloop
+			}
+		}
+		return inst.InstanceID.String()
+	}
+	return ``
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
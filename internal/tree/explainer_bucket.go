@@ -0,0 +1,30 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+// Implementation of the `checkExplainer` interface
+
+func (teb *Bucket) explainCheck(configID string) (Check, bool) {
+	teb.lock.RLock()
+	defer teb.lock.RUnlock()
+	for _, chk := range teb.Checks {
+		if chk.ConfigID.String() == configID {
+			return chk.Clone(), true
+		}
+	}
+	return Check{}, false
+}
+
+func (teb *Bucket) explainParent() checkExplainer {
+	if p, ok := teb.Parent.(checkExplainer); ok {
+		return p
+	}
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
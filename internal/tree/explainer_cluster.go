@@ -0,0 +1,111 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// Implementation of the `Explainer` interface
+
+func (tec *Cluster) ExplainCheck(configID string) proto.CheckExplanation {
+	return explainCheck(tec, configID)
+}
+
+func (tec *Cluster) explainCheck(configID string) (Check, bool) {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	for _, chk := range tec.Checks {
+		if chk.ConfigID.String() == configID {
+			return chk.Clone(), true
+		}
+	}
+	return Check{}, false
+}
+
+func (tec *Cluster) explainParent() checkExplainer {
+	if p, ok := tec.Parent.(checkExplainer); ok {
+		return p
+	}
+	return nil
+}
+
+func (tec *Cluster) explainNative(key string) (string, bool) {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	// @defined matches every value of a supported native property
+	hit, val := tec.evalNativeProp(CheckConstraint{Key: key, Value: `@defined`})
+	return val, hit
+}
+
+// explainMatch reports whether constraint cc hits, using the same
+// evaluation as constraintCheck. Attribute constraints hit if any
+// service in view has a matching attribute.
+func (tec *Cluster) explainMatch(cc CheckConstraint, view string) bool {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	var hit bool
+	switch cc.Type {
+	case msg.ConstraintNative:
+		hit, _ = tec.evalNativeProp(cc)
+	case msg.ConstraintSystem:
+		_, hit, _ = tec.evalSystemProp(cc, view)
+	case msg.ConstraintOncall:
+		_, hit = tec.evalOncallProp(cc, view)
+	case msg.ConstraintCustom:
+		_, hit, _ = tec.evalCustomProp(cc, view)
+	case msg.ConstraintService:
+		_, hit, _ = tec.evalServiceProp(cc, view)
+	case msg.ConstraintAttribute:
+		hit, _ = tec.evalAttributeProp(view, cc)
+	}
+	return hit
+}
+
+func (tec *Cluster) explainProperties(propType, view string) []Property {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	var props map[string]Property
+	switch propType {
+	case msg.PropertyCustom:
+		props = tec.PropertyCustom
+	case msg.PropertyOncall:
+		props = tec.PropertyOncall
+	case msg.PropertyService:
+		props = tec.PropertyService
+	case msg.PropertySystem:
+		props = tec.PropertySystem
+	}
+	res := []Property{}
+	for _, prop := range props {
+		if prop.GetView() == view || prop.GetView() == `any` {
+			res = append(res, prop.Clone())
+		}
+	}
+	return res
+}
+
+func (tec *Cluster) explainServiceMap(svcID string) map[string][]string {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	return tec.getServiceMap(svcID)
+}
+
+func (tec *Cluster) explainInstances(checkID string) []CheckInstance {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+	res := []CheckInstance{}
+	for _, instanceID := range tec.CheckInstances[checkID] {
+		inst := tec.Instances[instanceID]
+		res = append(res, inst.Clone())
+	}
+	return res
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,111 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// Implementation of the `Explainer` interface
+
+func (teg *Group) ExplainCheck(configID string) proto.CheckExplanation {
+	return explainCheck(teg, configID)
+}
+
+func (teg *Group) explainCheck(configID string) (Check, bool) {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	for _, chk := range teg.Checks {
+		if chk.ConfigID.String() == configID {
+			return chk.Clone(), true
+		}
+	}
+	return Check{}, false
+}
+
+func (teg *Group) explainParent() checkExplainer {
+	if p, ok := teg.Parent.(checkExplainer); ok {
+		return p
+	}
+	return nil
+}
+
+func (teg *Group) explainNative(key string) (string, bool) {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	// @defined matches every value of a supported native property
+	hit, val := teg.evalNativeProp(CheckConstraint{Key: key, Value: `@defined`})
+	return val, hit
+}
+
+// explainMatch reports whether constraint cc hits, using the same
+// evaluation as constraintCheck. Attribute constraints hit if any
+// service in view has a matching attribute.
+func (teg *Group) explainMatch(cc CheckConstraint, view string) bool {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	var hit bool
+	switch cc.Type {
+	case msg.ConstraintNative:
+		hit, _ = teg.evalNativeProp(cc)
+	case msg.ConstraintSystem:
+		_, hit, _ = teg.evalSystemProp(cc, view)
+	case msg.ConstraintOncall:
+		_, hit = teg.evalOncallProp(cc, view)
+	case msg.ConstraintCustom:
+		_, hit, _ = teg.evalCustomProp(cc, view)
+	case msg.ConstraintService:
+		_, hit, _ = teg.evalServiceProp(cc, view)
+	case msg.ConstraintAttribute:
+		hit, _ = teg.evalAttributeProp(view, cc)
+	}
+	return hit
+}
+
+func (teg *Group) explainProperties(propType, view string) []Property {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	var props map[string]Property
+	switch propType {
+	case msg.PropertyCustom:
+		props = teg.PropertyCustom
+	case msg.PropertyOncall:
+		props = teg.PropertyOncall
+	case msg.PropertyService:
+		props = teg.PropertyService
+	case msg.PropertySystem:
+		props = teg.PropertySystem
+	}
+	res := []Property{}
+	for _, prop := range props {
+		if prop.GetView() == view || prop.GetView() == `any` {
+			res = append(res, prop.Clone())
+		}
+	}
+	return res
+}
+
+func (teg *Group) explainServiceMap(svcID string) map[string][]string {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	return teg.getServiceMap(svcID)
+}
+
+func (teg *Group) explainInstances(checkID string) []CheckInstance {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+	res := []CheckInstance{}
+	for _, instanceID := range teg.CheckInstances[checkID] {
+		inst := teg.Instances[instanceID]
+		res = append(res, inst.Clone())
+	}
+	return res
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
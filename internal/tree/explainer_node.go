@@ -0,0 +1,111 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+import (
+	"github.com/mjolnir42/soma/internal/msg"
+	"github.com/mjolnir42/soma/lib/proto"
+)
+
+// Implementation of the `Explainer` interface
+
+func (ten *Node) ExplainCheck(configID string) proto.CheckExplanation {
+	return explainCheck(ten, configID)
+}
+
+func (ten *Node) explainCheck(configID string) (Check, bool) {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	for _, chk := range ten.Checks {
+		if chk.ConfigID.String() == configID {
+			return chk.Clone(), true
+		}
+	}
+	return Check{}, false
+}
+
+func (ten *Node) explainParent() checkExplainer {
+	if p, ok := ten.Parent.(checkExplainer); ok {
+		return p
+	}
+	return nil
+}
+
+func (ten *Node) explainNative(key string) (string, bool) {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	// @defined matches every value of a supported native property
+	hit, val := ten.evalNativeProp(CheckConstraint{Key: key, Value: `@defined`})
+	return val, hit
+}
+
+// explainMatch reports whether constraint cc hits, using the same
+// evaluation as constraintCheck. Attribute constraints hit if any
+// service in view has a matching attribute.
+func (ten *Node) explainMatch(cc CheckConstraint, view string) bool {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	var hit bool
+	switch cc.Type {
+	case msg.ConstraintNative:
+		hit, _ = ten.evalNativeProp(cc)
+	case msg.ConstraintSystem:
+		_, hit, _ = ten.evalSystemProp(cc, view)
+	case msg.ConstraintOncall:
+		_, hit = ten.evalOncallProp(cc, view)
+	case msg.ConstraintCustom:
+		_, hit, _ = ten.evalCustomProp(cc, view)
+	case msg.ConstraintService:
+		_, hit, _ = ten.evalServiceProp(cc, view)
+	case msg.ConstraintAttribute:
+		hit, _ = ten.evalAttributeProp(view, cc)
+	}
+	return hit
+}
+
+func (ten *Node) explainProperties(propType, view string) []Property {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	var props map[string]Property
+	switch propType {
+	case msg.PropertyCustom:
+		props = ten.PropertyCustom
+	case msg.PropertyOncall:
+		props = ten.PropertyOncall
+	case msg.PropertyService:
+		props = ten.PropertyService
+	case msg.PropertySystem:
+		props = ten.PropertySystem
+	}
+	res := []Property{}
+	for _, prop := range props {
+		if prop.GetView() == view || prop.GetView() == `any` {
+			res = append(res, prop.Clone())
+		}
+	}
+	return res
+}
+
+func (ten *Node) explainServiceMap(svcID string) map[string][]string {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	return ten.getServiceMap(svcID)
+}
+
+func (ten *Node) explainInstances(checkID string) []CheckInstance {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+	res := []CheckInstance{}
+	for _, instanceID := range ten.CheckInstances[checkID] {
+		inst := ten.Instances[instanceID]
+		res = append(res, inst.Clone())
+	}
+	return res
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,28 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package tree
+
+// Implementation of the `checkExplainer` interface
+
+func (ter *Repository) explainCheck(configID string) (Check, bool) {
+	ter.lock.RLock()
+	defer ter.lock.RUnlock()
+	for _, chk := range ter.Checks {
+		if chk.ConfigID.String() == configID {
+			return chk.Clone(), true
+		}
+	}
+	return Check{}, false
+}
+
+func (ter *Repository) explainParent() checkExplainer {
+	// the repository is the top of the inheritance path
+	return nil
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -43,6 +43,7 @@ type Group struct {
 	ordChildrenClr  map[int]string
 	ordChildrenNod  map[int]string
 	hasUpdate       bool
+	updatedChecks   map[string]bool
 	log             *log.Logger
 	lock            *sync.RWMutex
 }
@@ -78,6 +79,7 @@ func NewGroup(spec GroupSpec) *Group {
 	teg.CheckInstances = make(map[string][]string)
 	teg.Instances = make(map[string]CheckInstance)
 	teg.loadedInstances = make(map[string]map[string]CheckInstance)
+	teg.updatedChecks = make(map[string]bool)
 	teg.ordNumChildGrp = 0
 	teg.ordNumChildClr = 0
 	teg.ordNumChildNod = 0
@@ -146,6 +148,7 @@ func (teg Group) Clone() *Group {
 	}
 	cl.Instances = cki
 	cl.loadedInstances = make(map[string]map[string]CheckInstance)
+	cl.updatedChecks = make(map[string]bool)
 
 	ci := make(map[string][]string)
 	for k := range teg.CheckInstances {
@@ -40,6 +40,7 @@ type Node struct {
 	Instances       map[string]CheckInstance
 	loadedInstances map[string]map[string]CheckInstance
 	hasUpdate       bool
+	updatedChecks   map[string]bool
 	log             *log.Logger
 	lock            *sync.RWMutex
 }
@@ -82,6 +83,7 @@ func NewNode(spec NodeSpec) *Node {
 	ten.CheckInstances = make(map[string][]string)
 	ten.Instances = make(map[string]CheckInstance)
 	ten.loadedInstances = make(map[string]map[string]CheckInstance)
+	ten.updatedChecks = make(map[string]bool)
 
 	return ten
 }
@@ -139,6 +141,7 @@ func (ten Node) Clone() *Node {
 	}
 	cl.Instances = cki
 	cl.loadedInstances = make(map[string]map[string]CheckInstance)
+	cl.updatedChecks = make(map[string]bool)
 
 	ci := make(map[string][]string)
 	for k := range ten.CheckInstances {
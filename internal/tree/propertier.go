@@ -33,6 +33,8 @@ type Propertier interface {
 	checkProperty(propType, propID string) bool
 	checkDuplicate(p Property) (bool, bool, Property)
 	resyncProperty(srcID, pType, childID string)
+
+	LocalProperties(propType string) []Property
 }
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -664,6 +664,34 @@ func (teb *Bucket) checkProperty(propType string, propID string) bool {
 	return false
 }
 
+// LocalProperties returns copies of all properties of type propType
+// that are set on teb itself and not inherited
+func (teb *Bucket) LocalProperties(propType string) []Property {
+	teb.lock.RLock()
+	defer teb.lock.RUnlock()
+
+	var props map[string]Property
+	switch propType {
+	case `custom`:
+		props = teb.PropertyCustom
+	case `system`:
+		props = teb.PropertySystem
+	case `service`:
+		props = teb.PropertyService
+	case `oncall`:
+		props = teb.PropertyOncall
+	}
+
+	local := []Property{}
+	for _, p := range props {
+		if p.GetIsInherited() {
+			continue
+		}
+		local = append(local, p.Clone())
+	}
+	return local
+}
+
 // Checks if this property is already defined on this node, and
 // whether it was inherited, ie. can be deleted so it can be
 // overwritten
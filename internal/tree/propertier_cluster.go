@@ -664,6 +664,34 @@ func (tec *Cluster) checkProperty(propType string, propID string) bool {
 	return false
 }
 
+// LocalProperties returns copies of all properties of type propType
+// that are set on tec itself and not inherited
+func (tec *Cluster) LocalProperties(propType string) []Property {
+	tec.lock.RLock()
+	defer tec.lock.RUnlock()
+
+	var props map[string]Property
+	switch propType {
+	case `custom`:
+		props = tec.PropertyCustom
+	case `system`:
+		props = tec.PropertySystem
+	case `service`:
+		props = tec.PropertyService
+	case `oncall`:
+		props = tec.PropertyOncall
+	}
+
+	local := []Property{}
+	for _, p := range props {
+		if p.GetIsInherited() {
+			continue
+		}
+		local = append(local, p.Clone())
+	}
+	return local
+}
+
 // Checks if this property is already defined on this node, and
 // whether it was inherited, ie. can be deleted so it can be
 // overwritten
@@ -78,4 +78,8 @@ func (tef *Fault) checkDuplicate(p Property) (bool, bool, Property) {
 func (tef *Fault) resyncProperty(srcID, pType, childID string) {
 }
 
+func (tef *Fault) LocalProperties(propType string) []Property {
+	return []Property{}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
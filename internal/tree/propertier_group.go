@@ -664,6 +664,34 @@ func (teg *Group) checkProperty(propType string, propID string) bool {
 	return false
 }
 
+// LocalProperties returns copies of all properties of type propType
+// that are set on teg itself and not inherited
+func (teg *Group) LocalProperties(propType string) []Property {
+	teg.lock.RLock()
+	defer teg.lock.RUnlock()
+
+	var props map[string]Property
+	switch propType {
+	case `custom`:
+		props = teg.PropertyCustom
+	case `system`:
+		props = teg.PropertySystem
+	case `service`:
+		props = teg.PropertyService
+	case `oncall`:
+		props = teg.PropertyOncall
+	}
+
+	local := []Property{}
+	for _, p := range props {
+		if p.GetIsInherited() {
+			continue
+		}
+		local = append(local, p.Clone())
+	}
+	return local
+}
+
 // Checks if this property is already defined on this node, and
 // whether it was inherited, ie. can be deleted so it can be
 // overwritten
@@ -477,6 +477,34 @@ func (ten *Node) checkProperty(propType string, propID string) bool {
 	return false
 }
 
+// LocalProperties returns copies of all properties of type propType
+// that are set on ten itself and not inherited
+func (ten *Node) LocalProperties(propType string) []Property {
+	ten.lock.RLock()
+	defer ten.lock.RUnlock()
+
+	var props map[string]Property
+	switch propType {
+	case `custom`:
+		props = ten.PropertyCustom
+	case `system`:
+		props = ten.PropertySystem
+	case `service`:
+		props = ten.PropertyService
+	case `oncall`:
+		props = ten.PropertyOncall
+	}
+
+	local := []Property{}
+	for _, p := range props {
+		if p.GetIsInherited() {
+			continue
+		}
+		local = append(local, p.Clone())
+	}
+	return local
+}
+
 // Checks if this property is already defined on this node, and
 // whether it was inherited, ie. can be deleted so it can be
 // overwritten
@@ -618,6 +618,34 @@ func (ter *Repository) checkProperty(propType string, propID string) bool {
 	return false
 }
 
+// LocalProperties returns copies of all properties of type propType
+// that are set on ter itself and not inherited
+func (ter *Repository) LocalProperties(propType string) []Property {
+	ter.lock.RLock()
+	defer ter.lock.RUnlock()
+
+	var props map[string]Property
+	switch propType {
+	case `custom`:
+		props = ter.PropertyCustom
+	case `system`:
+		props = ter.PropertySystem
+	case `service`:
+		props = ter.PropertyService
+	case `oncall`:
+		props = ter.PropertyOncall
+	}
+
+	local := []Property{}
+	for _, p := range props {
+		if p.GetIsInherited() {
+			continue
+		}
+		local = append(local, p.Clone())
+	}
+	return local
+}
+
 // Checks if this property is already defined on this node, and
 // whether it was inherited, ie. can be deleted so it can be
 // overwritten
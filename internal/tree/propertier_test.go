@@ -3910,4 +3910,161 @@ func TestSetPropertyDuplicateDetectOnBucket(t *testing.T) {
 	}
 }
 
+func TestLocalProperties(t *testing.T) {
+	actionChan := make(chan *Action, 1024)
+	errChan := make(chan *Error, 1024)
+
+	treeID := `10001000-1000-4000-1000-100010001000`
+	repoID := `20002000-2000-4000-2000-200020002000`
+	propID := `30003000-3000-4000-3000-300030003000`
+	teamID := `40004000-4000-4000-4000-400040004000`
+	buckID := `50005000-5000-4000-5000-500050005000`
+	grupID := `60006000-6000-4000-6000-600060006000`
+	nodeID := `80008000-8000-4000-8000-800080008000`
+	overID := `90009000-9000-4000-9000-900090009000`
+
+	propUUID, _ := uuid.FromString(propID)
+	overUUID, _ := uuid.FromString(overID)
+
+	// create tree
+	sTree := New(Spec{
+		ID:     treeID,
+		Name:   `root_testing`,
+		Action: actionChan,
+	})
+	sTree.RegisterErrChan(errChan)
+
+	// create repository
+	NewRepository(RepositorySpec{
+		ID:      repoID,
+		Name:    `testrepo`,
+		Team:    teamID,
+		Deleted: false,
+		Active:  true,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `root`,
+		ParentID:   treeID,
+	})
+	sTree.SetError()
+
+	// create bucket
+	NewBucket(BucketSpec{
+		ID:          buckID,
+		Name:        `testrepo_test`,
+		Environment: `testing`,
+		Team:        teamID,
+		Deleted:     false,
+		Frozen:      false,
+		Repository:  repoID,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: "repository",
+		ParentID:   `repoID`,
+		ParentName: `testrepo`,
+	})
+
+	// create group
+	NewGroup(GroupSpec{
+		ID:   grupID,
+		Name: `testgroup`,
+		Team: teamID,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `bucket`,
+		ParentID:   buckID,
+	})
+
+	// assign node
+	NewNode(NodeSpec{
+		ID:       nodeID,
+		AssetID:  1,
+		Name:     `testnode`,
+		Team:     teamID,
+		ServerID: `00000000-0000-0000-0000-000000000000`,
+		Online:   true,
+		Deleted:  false,
+	}).Attach(AttachRequest{
+		Root:       sTree,
+		ParentType: `group`,
+		ParentID:   grupID,
+	})
+
+	// set property on bucket
+	sTree.Find(FindRequest{
+		ElementType: `bucket`,
+		ElementID:   buckID,
+	}, true).(Propertier).SetProperty(&PropertySystem{
+		ID:           overUUID,
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `testview`,
+		Key:          `testkeyHIGH`,
+		Value:        `testvalueHIGH`,
+	})
+
+	// set property on group
+	sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementID:   grupID,
+	}, true).(Propertier).SetProperty(&PropertySystem{
+		ID:           propUUID,
+		Inheritance:  true,
+		ChildrenOnly: false,
+		View:         `testview`,
+		Key:          `testkeyLOW`,
+		Value:        `testvalueLOW`,
+	})
+
+	close(actionChan)
+	close(errChan)
+
+	if len(errChan) != 0 {
+		t.Error(
+			`Expected 0 actions in errorChan, got`,
+			len(errChan),
+		)
+	}
+
+	group := sTree.Find(FindRequest{
+		ElementType: `group`,
+		ElementID:   grupID,
+	}, true).(Propertier)
+
+	if len(group.(*Group).PropertySystem) != 2 {
+		t.Error(
+			`Group has wrong system property count`,
+		)
+	}
+
+	local := group.LocalProperties(`system`)
+	if len(local) != 1 {
+		t.Fatal(
+			`Expected 1 local system property on group, got`,
+			len(local),
+		)
+	}
+	if local[0].GetSourceInstance() != propID {
+		t.Error(`Wrong source id`, local[0].GetSourceInstance())
+	}
+	if local[0].GetKey() != `testkeyLOW` {
+		t.Error(`Wrong key:`, local[0].GetKey())
+	}
+
+	if len(group.LocalProperties(`custom`)) != 0 {
+		t.Error(
+			`Expected 0 local custom properties on group`,
+		)
+	}
+
+	if len(sTree.Find(FindRequest{
+		ElementType: `node`,
+		ElementID:   nodeID,
+	}, true).(Propertier).LocalProperties(`system`)) != 0 {
+		t.Error(
+			`Expected 0 local system properties on node`,
+		)
+	}
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -37,6 +37,41 @@ func countAttributeConstraints(attributeC map[string][]string) int {
 	return count
 }
 
+// serviceConfigCombinations returns all permutations of the attribute
+// values in svcCfg. A service with no attributes has no combinations.
+func serviceConfigCombinations(svcCfg map[string][]string) []map[string]string {
+	// calculate how many instances this service spawns
+	combinations := 1
+	for attr := range svcCfg {
+		combinations = combinations * len(svcCfg[attr])
+	}
+
+	results := make([]map[string]string, 0, combinations)
+	for attr := range svcCfg {
+		if len(results) == 0 {
+			for i := range svcCfg[attr] {
+				res := map[string]string{}
+				res[attr] = svcCfg[attr][i]
+				results = append(results, res)
+			}
+			continue
+		}
+		ires := make([]map[string]string, 0, combinations)
+		for r := range results {
+			for j := range svcCfg[attr] {
+				res := map[string]string{}
+				for k, v := range results[r] {
+					res[k] = v
+				}
+				res[attr] = svcCfg[attr][j]
+				ires = append(ires, res)
+			}
+		}
+		results = ires
+	}
+	return results
+}
+
 func removeFromArray(s []string, r string) []string {
 	for i, v := range s {
 		if v == r {
@@ -0,0 +1,69 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// APIKey describes a long-lived API key for automation accounts. It
+// is bound to either a user or a team, and can only be used for the
+// listed permissions from the listed networks.
+type APIKey struct {
+	ID          string           `json:"id,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Secret      string           `json:"secret,omitempty"`
+	UserID      string           `json:"userID,omitempty"`
+	UserName    string           `json:"userName,omitempty"`
+	TeamID      string           `json:"teamID,omitempty"`
+	Permissions []Permission     `json:"permissions,omitempty"`
+	Networks    []string         `json:"networks,omitempty"`
+	ExpiresAt   string           `json:"expiresAt,omitempty"`
+	RevokedAt   string           `json:"revokedAt,omitempty"`
+	Details     *DetailsCreation `json:"details,omitempty"`
+}
+
+// Clone returns a copy of k
+func (k *APIKey) Clone() APIKey {
+	clone := APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Secret:    k.Secret,
+		UserID:    k.UserID,
+		UserName:  k.UserName,
+		TeamID:    k.TeamID,
+		ExpiresAt: k.ExpiresAt,
+		RevokedAt: k.RevokedAt,
+	}
+	if k.Permissions != nil {
+		clone.Permissions = make([]Permission, len(k.Permissions))
+		for i := range k.Permissions {
+			clone.Permissions[i] = k.Permissions[i].Clone()
+		}
+	}
+	if k.Networks != nil {
+		clone.Networks = make([]string, len(k.Networks))
+		copy(clone.Networks, k.Networks)
+	}
+	if k.Details != nil {
+		clone.Details = k.Details.Clone()
+	}
+	return clone
+}
+
+func NewAPIKeyRequest() Request {
+	return Request{
+		Flags:  &Flags{},
+		APIKey: &APIKey{},
+	}
+}
+
+func NewAPIKeyResult() Result {
+	return Result{
+		Errors:  &[]string{},
+		APIKeys: &[]APIKey{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,64 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// Audit is a single entry in the change history of a repository. It
+// describes a finished job and the object that was changed by it.
+type Audit struct {
+	JobID        string         `json:"jobId,omitempty"`
+	JobSerial    int            `json:"jobSerial,omitempty"`
+	JobType      string         `json:"jobType,omitempty"`
+	Status       string         `json:"status,omitempty"`
+	Result       string         `json:"result,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RepositoryID string         `json:"repositoryId,omitempty"`
+	UserName     string         `json:"userName,omitempty"`
+	TeamID       string         `json:"teamId,omitempty"`
+	ObjectType   string         `json:"objectType,omitempty"`
+	ObjectID     string         `json:"objectId,omitempty"`
+	ObjectName   string         `json:"objectName,omitempty"`
+	Properties   *[]Property    `json:"properties,omitempty"`
+	TsQueued     string         `json:"queued,omitempty"`
+	TsStarted    string         `json:"started,omitempty"`
+	TsFinished   string         `json:"finished,omitempty"`
+	Actions      *[]AuditAction `json:"actions,omitempty"`
+}
+
+// AuditAction is a change to the repository tree that was recorded
+// in the log of the job that caused it
+type AuditAction struct {
+	Action          string `json:"action,omitempty"`
+	ObjectType      string `json:"objectType,omitempty"`
+	ObjectID        string `json:"objectId,omitempty"`
+	ObjectName      string `json:"objectName,omitempty"`
+	CheckID         string `json:"checkId,omitempty"`
+	CheckInstanceID string `json:"checkInstanceId,omitempty"`
+}
+
+// AuditFilter selects the entries of a repository's change history
+// that are returned. Since and Until are RFC3339 timestamps that are
+// compared against the time the job finished.
+type AuditFilter struct {
+	ObjectType string `json:"objectType,omitempty"`
+	ObjectID   string `json:"objectId,omitempty"`
+	UserName   string `json:"userName,omitempty"`
+	JobType    string `json:"jobType,omitempty"`
+	Since      string `json:"since,omitempty"`
+	Until      string `json:"until,omitempty"`
+}
+
+// NewAuditResult returns a new Result with fields preallocated for
+// filling in Audit entries
+func NewAuditResult() Result {
+	return Result{
+		Errors: &[]string{},
+		Audits: &[]Audit{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,37 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// Batch is an ordered list of operations on a repository that are
+// processed as a single job. Either all operations are applied or
+// none.
+type Batch struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation is a single operation within a Batch. Section and
+// Action select the operation, Request carries the same request body
+// that would be sent to the corresponding single object endpoint.
+type BatchOperation struct {
+	Section string  `json:"section"`
+	Action  string  `json:"action"`
+	Request Request `json:"request"`
+}
+
+// NewBatchRequest returns a new Request with fields preallocated for
+// filling in a Batch
+func NewBatchRequest() Request {
+	return Request{
+		Flags: &Flags{},
+		Batch: &Batch{
+			Operations: []BatchOperation{},
+		},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,84 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// CheckExplanation describes how a check configuration is evaluated
+// against a group, cluster or node of the repository tree and why it
+// does or does not spawn check instances there
+type CheckExplanation struct {
+	CheckConfigID string                       `json:"checkConfigId"`
+	CheckID       string                       `json:"checkId,omitempty"`
+	ObjectType    string                       `json:"objectType"`
+	ObjectID      string                       `json:"objectId"`
+	ObjectName    string                       `json:"objectName,omitempty"`
+	View          string                       `json:"view,omitempty"`
+	Applies       bool                         `json:"applies"`
+	Reason        string                       `json:"reason,omitempty"`
+	ChildrenOnly  bool                         `json:"childrenOnly"`
+	Inheritance   []CheckExplanationPath       `json:"inheritance,omitempty"`
+	Disabled      []CheckExplanationProperty   `json:"disabled,omitempty"`
+	Constraints   []CheckExplanationConstraint `json:"constraints,omitempty"`
+	Instances     []CheckExplanationInstance   `json:"instances,omitempty"`
+}
+
+// CheckExplanationPath is one object on the inheritance path of a
+// check, starting with the explained object and ending with the
+// object the check configuration is defined on
+type CheckExplanationPath struct {
+	ObjectType   string `json:"objectType"`
+	ObjectID     string `json:"objectId"`
+	ObjectName   string `json:"objectName,omitempty"`
+	HasCheck     bool   `json:"hasCheck"`
+	IsSource     bool   `json:"isSource"`
+	Inheritance  bool   `json:"inheritance"`
+	ChildrenOnly bool   `json:"childrenOnly"`
+}
+
+// CheckExplanationProperty is a disable_* system property that is
+// set on the explained object. Effective is true if the property
+// disables the explained check.
+type CheckExplanationProperty struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	View      string `json:"view"`
+	Inherited bool   `json:"inherited"`
+	Effective bool   `json:"effective"`
+}
+
+// CheckExplanationConstraint is the evaluation of a single check
+// constraint, comparing the expected value with the actual values
+// of the explained object
+type CheckExplanationConstraint struct {
+	ConstraintType string   `json:"constraintType"`
+	Key            string   `json:"key"`
+	Operator       string   `json:"operator,omitempty"`
+	Group          string   `json:"group,omitempty"`
+	Expected       string   `json:"expected"`
+	Actual         []string `json:"actual,omitempty"`
+	Match          bool     `json:"match"`
+}
+
+// CheckExplanationInstance is a check instance the check spawns on
+// the explained object. InstanceID is set if the instance already
+// exists.
+type CheckExplanationInstance struct {
+	InstanceID    string            `json:"instanceId,omitempty"`
+	ServiceID     string            `json:"serviceId,omitempty"`
+	ServiceName   string            `json:"serviceName,omitempty"`
+	ServiceConfig map[string]string `json:"serviceConfig,omitempty"`
+}
+
+// NewCheckExplanationResult returns a Result for check explanations
+func NewCheckExplanationResult() Result {
+	return Result{
+		Errors:            &[]string{},
+		CheckExplanations: &[]CheckExplanation{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
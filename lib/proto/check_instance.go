@@ -20,6 +20,17 @@ type CheckInstance struct {
 	InstanceSvcCfgHash    string `json:"instanceSvcCfghash,omitempty"`
 	InstanceService       string `json:"instanceService,omitempty"`
 	InstanceServiceConfig string `json:"instanceServiceCfg,omitempty"`
+	// property values the instance matched against the constraints
+	// of its check configuration, keyed by the property instance ID
+	// for oncall, service, system and custom properties, the property
+	// name for native properties and the service property instance ID
+	// and attribute name for service attributes
+	ConstraintOncall    string                         `json:"constraintOncall,omitempty"`
+	ConstraintService   map[string]string              `json:"constraintService,omitempty"`
+	ConstraintSystem    map[string]string              `json:"constraintSystem,omitempty"`
+	ConstraintCustom    map[string]string              `json:"constraintCustom,omitempty"`
+	ConstraintNative    map[string]string              `json:"constraintNative,omitempty"`
+	ConstraintAttribute map[string]map[string][]string `json:"constraintAttribute,omitempty"`
 }
 
 func (t *CheckInstance) DeepCompare(a *CheckInstance) bool {
@@ -29,9 +40,34 @@ func (t *CheckInstance) DeepCompare(a *CheckInstance) bool {
 		// - InstanceConfigID is a randomly generated uuid on every instance calculation
 		// - Version is incremented on every instance calculation
 		// - InstanceServiceConfig is compared as deploymentdetails.Service
+		// - Constraint values are compared via ConstraintValHash
 		return false
 	}
 	return true
 }
 
+// ConstraintValues returns a partial CheckInstance that only contains
+// the matched constraint values of t
+func (t *CheckInstance) ConstraintValues() CheckInstance {
+	return CheckInstance{
+		ConstraintOncall:    t.ConstraintOncall,
+		ConstraintService:   t.ConstraintService,
+		ConstraintSystem:    t.ConstraintSystem,
+		ConstraintCustom:    t.ConstraintCustom,
+		ConstraintNative:    t.ConstraintNative,
+		ConstraintAttribute: t.ConstraintAttribute,
+	}
+}
+
+// MergeConstraintValues sets the matched constraint values of t to
+// the ones of the partial CheckInstance v
+func (t *CheckInstance) MergeConstraintValues(v *CheckInstance) {
+	t.ConstraintOncall = v.ConstraintOncall
+	t.ConstraintService = v.ConstraintService
+	t.ConstraintSystem = v.ConstraintSystem
+	t.ConstraintCustom = v.ConstraintCustom
+	t.ConstraintNative = v.ConstraintNative
+	t.ConstraintAttribute = v.ConstraintAttribute
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -60,6 +60,8 @@ func (c *CheckConfig) Clone() CheckConfig {
 
 type CheckConfigConstraint struct {
 	ConstraintType string            `json:"constraintType,omitempty"`
+	Operator       string            `json:"operator,omitempty"`
+	Group          string            `json:"group,omitempty"`
 	Native         *PropertyNative   `json:"native,omitempty"`
 	Oncall         *PropertyOncall   `json:"oncall,omitempty"`
 	Custom         *PropertyCustom   `json:"custom,omitempty"`
@@ -71,6 +73,8 @@ type CheckConfigConstraint struct {
 func (c *CheckConfigConstraint) Clone() CheckConfigConstraint {
 	clone := CheckConfigConstraint{
 		ConstraintType: c.ConstraintType,
+		Operator:       c.Operator,
+		Group:          c.Group,
 	}
 	if c.Native != nil {
 		clone.Native = c.Native.Clone()
@@ -98,6 +102,9 @@ func (c *CheckConfigConstraint) DeepCompare(a *CheckConfigConstraint) bool {
 	if c.ConstraintType != a.ConstraintType {
 		return false
 	}
+	if c.GetOperator() != a.GetOperator() || c.Group != a.Group {
+		return false
+	}
 	switch c.ConstraintType {
 	case "native":
 		if c.Native.DeepCompare(a.Native) {
@@ -127,6 +134,15 @@ func (c *CheckConfigConstraint) DeepCompare(a *CheckConfigConstraint) bool {
 	return false
 }
 
+// GetOperator returns the operator of the constraint, defaulting
+// to ConstraintOpEqual
+func (c *CheckConfigConstraint) GetOperator() string {
+	if c.Operator == `` {
+		return ConstraintOpEqual
+	}
+	return c.Operator
+}
+
 func (c *CheckConfigConstraint) DeepCompareSlice(a []CheckConfigConstraint) bool {
 	if a == nil {
 		return false
@@ -279,4 +295,33 @@ func NewCheckConfigResult() Result {
 	}
 }
 
+// Constraint operators. The negated operators match if the property
+// is not set or its value does not match.
+const (
+	ConstraintOpEqual        = `==`
+	ConstraintOpNotEqual     = `!=`
+	ConstraintOpMatch        = `=~`
+	ConstraintOpNotMatch     = `!~`
+	ConstraintOpGlob         = `glob`
+	ConstraintOpNotGlob      = `!glob`
+	ConstraintOpLess         = `<`
+	ConstraintOpLessEqual    = `<=`
+	ConstraintOpGreater      = `>`
+	ConstraintOpGreaterEqual = `>=`
+)
+
+// ConstraintOperators lists all valid constraint operators
+var ConstraintOperators = []string{
+	ConstraintOpEqual,
+	ConstraintOpNotEqual,
+	ConstraintOpMatch,
+	ConstraintOpNotMatch,
+	ConstraintOpGlob,
+	ConstraintOpNotGlob,
+	ConstraintOpLess,
+	ConstraintOpLessEqual,
+	ConstraintOpGreater,
+	ConstraintOpGreaterEqual,
+}
+
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -59,6 +59,7 @@ type Deployment struct {
 	CheckConfig      *CheckConfig      `json:"checkConfig"`
 	Check            *Check            `json:"check"`
 	CheckInstance    *CheckInstance    `json:"checkInstance"`
+	SilencedUntil    string            `json:"silencedUntil,omitempty"`
 }
 
 func (dd *Deployment) DeepCompare(alternate *Deployment) bool {
@@ -178,6 +179,21 @@ func (dd *Deployment) DeepCompare(alternate *Deployment) bool {
 	return true
 }
 
+// DeploymentFilter selects the deployments of a monitoring system
+// that are returned by the deployment filter endpoint. Repository
+// and Bucket match either by ID or by name, ChangedSince is a RFC3339
+// timestamp.
+type DeploymentFilter struct {
+	State        string `json:"state,omitempty"`
+	Task         string `json:"task,omitempty"`
+	Repository   string `json:"repository,omitempty"`
+	Bucket       string `json:"bucket,omitempty"`
+	View         string `json:"view,omitempty"`
+	CapabilityID string `json:"capabilityID,omitempty"`
+	Datacenter   string `json:"datacenter,omitempty"`
+	ChangedSince string `json:"changedSince,omitempty"`
+}
+
 func NewDeploymentResult() Result {
 	return Result{
 		Errors:      &[]string{},
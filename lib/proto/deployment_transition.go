@@ -0,0 +1,112 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+import "fmt"
+
+// deploymentTransitions is the deployment workflow. It lists for every
+// state the states a check instance configuration may move into from
+// it. DeploymentAwaitingDeletion is the final state.
+var deploymentTransitions = map[string][]string{
+	DeploymentAwaitingComputation: {
+		DeploymentComputed,
+	},
+	DeploymentComputed: {
+		DeploymentAwaitingRollout,
+		DeploymentBlocked,
+	},
+	DeploymentBlocked: {
+		DeploymentAwaitingRollout,
+		DeploymentAwaitingDeletion,
+	},
+	DeploymentAwaitingRollout: {
+		DeploymentRolloutInProgress,
+		DeploymentAwaitingDeletion,
+	},
+	DeploymentRolloutInProgress: {
+		DeploymentActive,
+		DeploymentRolloutFailed,
+		DeploymentAwaitingRollout,
+	},
+	DeploymentActive: {
+		DeploymentAwaitingDeprovision,
+	},
+	DeploymentRolloutFailed: {
+		DeploymentRolloutInProgress,
+		DeploymentAwaitingRollout,
+		DeploymentAwaitingDeletion,
+	},
+	DeploymentAwaitingDeprovision: {
+		DeploymentDeprovisionInProgress,
+	},
+	DeploymentDeprovisionInProgress: {
+		DeploymentDeprovisioned,
+		DeploymentDeprovisionFailed,
+		DeploymentAwaitingDeprovision,
+	},
+	DeploymentDeprovisionFailed: {
+		DeploymentDeprovisionInProgress,
+		DeploymentAwaitingDeprovision,
+	},
+	DeploymentDeprovisioned: {
+		DeploymentAwaitingDeletion,
+	},
+	DeploymentAwaitingDeletion: {},
+}
+
+// deploymentNextStatus is the next status that is recorded together
+// with each state
+var deploymentNextStatus = map[string]string{
+	DeploymentAwaitingComputation:   DeploymentNone,
+	DeploymentComputed:              DeploymentNone,
+	DeploymentBlocked:               DeploymentAwaitingRollout,
+	DeploymentAwaitingRollout:       DeploymentRolloutInProgress,
+	DeploymentRolloutInProgress:     DeploymentActive,
+	DeploymentActive:                DeploymentNone,
+	DeploymentRolloutFailed:         DeploymentNone,
+	DeploymentAwaitingDeprovision:   DeploymentDeprovisionInProgress,
+	DeploymentDeprovisionInProgress: DeploymentDeprovisioned,
+	DeploymentDeprovisionFailed:     DeploymentNone,
+	DeploymentDeprovisioned:         DeploymentNone,
+	DeploymentAwaitingDeletion:      DeploymentNone,
+}
+
+// DeploymentNextStatus returns the next status that accompanies the
+// deployment state status. The returned bool is false if status is
+// not a deployment state.
+func DeploymentNextStatus(status string) (string, bool) {
+	next, ok := deploymentNextStatus[status]
+	return next, ok
+}
+
+// DeploymentTransitionsFrom returns the states a check instance
+// configuration in state status may move into
+func DeploymentTransitionsFrom(status string) []string {
+	return append([]string{}, deploymentTransitions[status]...)
+}
+
+// ValidateDeploymentTransition returns an error if the deployment
+// workflow does not allow a check instance configuration to move
+// from state from into state to
+func ValidateDeploymentTransition(from, to string) error {
+	if _, ok := deploymentTransitions[from]; !ok {
+		return fmt.Errorf("Unknown deployment state: %s", from)
+	}
+	if _, ok := deploymentTransitions[to]; !ok {
+		return fmt.Errorf("Unknown deployment state: %s", to)
+	}
+	for _, state := range deploymentTransitions[from] {
+		if state == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid deployment state transition: %s -> %s",
+		from, to)
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,153 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+import "testing"
+
+// testDeploymentStates lists every deployment state of the workflow
+var testDeploymentStates = []string{
+	DeploymentAwaitingComputation,
+	DeploymentComputed,
+	DeploymentBlocked,
+	DeploymentAwaitingRollout,
+	DeploymentRolloutInProgress,
+	DeploymentActive,
+	DeploymentRolloutFailed,
+	DeploymentAwaitingDeprovision,
+	DeploymentDeprovisionInProgress,
+	DeploymentDeprovisionFailed,
+	DeploymentDeprovisioned,
+	DeploymentAwaitingDeletion,
+}
+
+func TestValidateDeploymentTransition(t *testing.T) {
+	allowed := map[[2]string]bool{
+		{DeploymentAwaitingComputation, DeploymentComputed}:              true,
+		{DeploymentComputed, DeploymentAwaitingRollout}:                  true,
+		{DeploymentComputed, DeploymentBlocked}:                          true,
+		{DeploymentBlocked, DeploymentAwaitingRollout}:                   true,
+		{DeploymentBlocked, DeploymentAwaitingDeletion}:                  true,
+		{DeploymentAwaitingRollout, DeploymentRolloutInProgress}:         true,
+		{DeploymentAwaitingRollout, DeploymentAwaitingDeletion}:          true,
+		{DeploymentRolloutInProgress, DeploymentActive}:                  true,
+		{DeploymentRolloutInProgress, DeploymentRolloutFailed}:           true,
+		{DeploymentRolloutInProgress, DeploymentAwaitingRollout}:         true,
+		{DeploymentActive, DeploymentAwaitingDeprovision}:                true,
+		{DeploymentRolloutFailed, DeploymentRolloutInProgress}:           true,
+		{DeploymentRolloutFailed, DeploymentAwaitingRollout}:             true,
+		{DeploymentRolloutFailed, DeploymentAwaitingDeletion}:            true,
+		{DeploymentAwaitingDeprovision, DeploymentDeprovisionInProgress}: true,
+		{DeploymentDeprovisionInProgress, DeploymentDeprovisioned}:       true,
+		{DeploymentDeprovisionInProgress, DeploymentDeprovisionFailed}:   true,
+		{DeploymentDeprovisionInProgress, DeploymentAwaitingDeprovision}: true,
+		{DeploymentDeprovisionFailed, DeploymentDeprovisionInProgress}:   true,
+		{DeploymentDeprovisionFailed, DeploymentAwaitingDeprovision}:     true,
+		{DeploymentDeprovisioned, DeploymentAwaitingDeletion}:            true,
+	}
+
+	// every pair of states is either an allowed edge or rejected
+	for _, from := range testDeploymentStates {
+		for _, to := range testDeploymentStates {
+			err := ValidateDeploymentTransition(from, to)
+			switch {
+			case allowed[[2]string{from, to}] && err != nil:
+				t.Errorf("%s -> %s: expected valid transition, got %s",
+					from, to, err)
+			case !allowed[[2]string{from, to}] && err == nil:
+				t.Errorf("%s -> %s: expected invalid transition",
+					from, to)
+			}
+		}
+	}
+}
+
+func TestValidateDeploymentTransitionRejected(t *testing.T) {
+	tests := []struct {
+		from, to string
+	}{
+		{DeploymentAwaitingComputation, DeploymentActive},
+		{DeploymentComputed, DeploymentRolloutInProgress},
+		{DeploymentActive, DeploymentAwaitingDeletion},
+		{DeploymentActive, DeploymentActive},
+		{DeploymentDeprovisioned, DeploymentAwaitingRollout},
+		{DeploymentAwaitingDeletion, DeploymentAwaitingRollout},
+		{DeploymentAwaitingDeletion, DeploymentAwaitingDeletion},
+		{DeploymentActive, DeploymentNone},
+		{DeploymentNone, DeploymentActive},
+		{`unknown`, DeploymentActive},
+		{DeploymentActive, `unknown`},
+		{``, ``},
+	}
+
+	for _, test := range tests {
+		if err := ValidateDeploymentTransition(test.from, test.to); err == nil {
+			t.Errorf("%s -> %s: expected invalid transition",
+				test.from, test.to)
+		}
+	}
+}
+
+func TestDeploymentNextStatus(t *testing.T) {
+	tests := []struct {
+		status, next string
+	}{
+		{DeploymentAwaitingComputation, DeploymentNone},
+		{DeploymentComputed, DeploymentNone},
+		{DeploymentBlocked, DeploymentAwaitingRollout},
+		{DeploymentAwaitingRollout, DeploymentRolloutInProgress},
+		{DeploymentRolloutInProgress, DeploymentActive},
+		{DeploymentActive, DeploymentNone},
+		{DeploymentRolloutFailed, DeploymentNone},
+		{DeploymentAwaitingDeprovision, DeploymentDeprovisionInProgress},
+		{DeploymentDeprovisionInProgress, DeploymentDeprovisioned},
+		{DeploymentDeprovisionFailed, DeploymentNone},
+		{DeploymentDeprovisioned, DeploymentNone},
+		{DeploymentAwaitingDeletion, DeploymentNone},
+	}
+
+	if len(tests) != len(testDeploymentStates) {
+		t.Errorf("Expected %d deployment states, tested %d",
+			len(testDeploymentStates), len(tests))
+	}
+	for _, test := range tests {
+		next, ok := DeploymentNextStatus(test.status)
+		if !ok {
+			t.Errorf("%s: expected a known deployment state", test.status)
+			continue
+		}
+		if next != test.next {
+			t.Errorf("%s: expected next status %s, got %s",
+				test.status, test.next, next)
+		}
+	}
+
+	for _, status := range []string{DeploymentNone, `unknown`, ``} {
+		if _, ok := DeploymentNextStatus(status); ok {
+			t.Errorf("%s: expected an unknown deployment state", status)
+		}
+	}
+}
+
+func TestDeploymentTransitionsFromCopy(t *testing.T) {
+	states := DeploymentTransitionsFrom(DeploymentComputed)
+	if len(states) != 2 {
+		t.Fatalf("Expected 2 transitions from %s, got %d",
+			DeploymentComputed, len(states))
+	}
+	states[0] = DeploymentActive
+	if err := ValidateDeploymentTransition(
+		DeploymentComputed, DeploymentActive,
+	); err == nil {
+		t.Errorf(`Modifying the returned states changed the workflow`)
+	}
+	if len(DeploymentTransitionsFrom(`unknown`)) != 0 {
+		t.Errorf(`Expected no transitions from an unknown state`)
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -65,6 +65,7 @@ const (
 	EntityGroup      = `group`
 	EntityCluster    = `cluster`
 	EntityNode       = `node`
+	EntityCheck      = `check`
 )
 
 // vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
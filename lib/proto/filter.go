@@ -15,6 +15,7 @@ type Filter struct {
 	Capability  *CapabilityFilter  `json:"capability,omitempty"`
 	CheckConfig *CheckConfigFilter `json:"checkConfig,omitempty"`
 	Cluster     *ClusterFilter     `json:"cluster,omitempty"`
+	Deployment  *DeploymentFilter  `json:"deployment,omitempty"`
 	Grant       *GrantFilter       `json:"grant,omitempty"`
 	Group       *GroupFilter       `json:"group,omitempty"`
 	Job         *JobFilter         `json:"job,omitempty"`
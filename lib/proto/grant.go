@@ -17,6 +17,8 @@ type Grant struct {
 	Category      string           `json:"category"`
 	ObjectType    string           `json:"objectType"`
 	ObjectID      string           `json:"objectId"`
+	NotBefore     string           `json:"notBefore,omitempty"`
+	NotAfter      string           `json:"notAfter,omitempty"`
 	Details       *DetailsCreation `json:"details,omitempty"`
 }
 
@@ -29,6 +31,8 @@ func (g *Grant) Clone() Grant {
 		Category:      g.Category,
 		ObjectType:    g.ObjectType,
 		ObjectID:      g.ObjectID,
+		NotBefore:     g.NotBefore,
+		NotAfter:      g.NotAfter,
 	}
 	if g.Details != nil {
 		clone.Details = g.Details.Clone()
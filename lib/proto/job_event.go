@@ -0,0 +1,32 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// Types of job events
+const (
+	JobEventQueued   = `queued`
+	JobEventStarted  = `started`
+	JobEventAction   = `action`
+	JobEventFinished = `finished`
+)
+
+// JobEvent is a single step in the lifecycle of a job, as streamed to
+// clients watching the job. Action is set for action events, Result
+// and Errors for finished events. Sequence numbers are consecutive
+// per job, a gap means that events were not retained by the server.
+type JobEvent struct {
+	JobID      string      `json:"jobId"`
+	Sequence   uint64      `json:"sequence"`
+	Type       string      `json:"type"`
+	OccurredAt string      `json:"occurredAt"`
+	Action     *PlanAction `json:"action,omitempty"`
+	Result     string      `json:"result,omitempty"`
+	Errors     []string    `json:"errors,omitempty"`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
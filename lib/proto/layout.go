@@ -0,0 +1,253 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+import "sort"
+
+// Layout is the declarative description of a repository. All objects
+// are referenced by name, which allows the layout to be kept in
+// version control and applied to a repository. IDs are only set on
+// layouts exported by the server and are ignored when a layout is
+// compared against the live repository.
+type Layout struct {
+	ID           string              `json:"id,omitempty" yaml:"id,omitempty"`
+	Repository   string              `json:"repository" yaml:"repository"`
+	Properties   []LayoutProperty    `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Buckets      []LayoutBucket      `json:"buckets,omitempty" yaml:"buckets,omitempty"`
+	CheckConfigs []LayoutCheckConfig `json:"checkConfigs,omitempty" yaml:"checkConfigs,omitempty"`
+}
+
+// LayoutBucket describes a bucket and the objects directly assigned
+// to it
+type LayoutBucket struct {
+	ID          string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string           `json:"name" yaml:"name"`
+	Environment string           `json:"environment" yaml:"environment"`
+	Properties  []LayoutProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Groups      []LayoutGroup    `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Clusters    []LayoutCluster  `json:"clusters,omitempty" yaml:"clusters,omitempty"`
+	Nodes       []LayoutNode     `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// LayoutGroup describes a group and its member objects
+type LayoutGroup struct {
+	ID         string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       string           `json:"name" yaml:"name"`
+	Properties []LayoutProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Groups     []LayoutGroup    `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Clusters   []LayoutCluster  `json:"clusters,omitempty" yaml:"clusters,omitempty"`
+	Nodes      []LayoutNode     `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// LayoutCluster describes a cluster and its member nodes
+type LayoutCluster struct {
+	ID         string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       string           `json:"name" yaml:"name"`
+	Properties []LayoutProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Nodes      []LayoutNode     `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// LayoutNode describes a node assigned to the repository
+type LayoutNode struct {
+	ID         string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       string           `json:"name" yaml:"name"`
+	Properties []LayoutProperty `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// LayoutProperty describes a property that is set on an object.
+// Inherited properties are not part of the layout. Value is only
+// used by system and custom properties.
+type LayoutProperty struct {
+	SourceInstanceID string `json:"sourceInstanceId,omitempty" yaml:"sourceInstanceId,omitempty"`
+	Type             string `json:"type" yaml:"type"`
+	Name             string `json:"name" yaml:"name"`
+	Value            string `json:"value,omitempty" yaml:"value,omitempty"`
+	View             string `json:"view" yaml:"view"`
+	Inheritance      bool   `json:"inheritance" yaml:"inheritance"`
+	ChildrenOnly     bool   `json:"childrenOnly,omitempty" yaml:"childrenOnly,omitempty"`
+}
+
+// LayoutCheckConfig describes a check configuration. Groups and
+// clusters are referenced by name and the name of their bucket.
+type LayoutCheckConfig struct {
+	ID           string             `json:"id,omitempty" yaml:"id,omitempty"`
+	Name         string             `json:"name" yaml:"name"`
+	Capability   string             `json:"capability" yaml:"capability"`
+	ObjectType   string             `json:"objectType" yaml:"objectType"`
+	Object       string             `json:"object" yaml:"object"`
+	Bucket       string             `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	Interval     uint64             `json:"interval" yaml:"interval"`
+	Inheritance  bool               `json:"inheritance" yaml:"inheritance"`
+	ChildrenOnly bool               `json:"childrenOnly,omitempty" yaml:"childrenOnly,omitempty"`
+	ExternalID   string             `json:"externalId,omitempty" yaml:"externalId,omitempty"`
+	Thresholds   []LayoutThreshold  `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	Constraints  []LayoutConstraint `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// LayoutThreshold describes a threshold of a check configuration
+type LayoutThreshold struct {
+	Predicate string `json:"predicate" yaml:"predicate"`
+	Level     string `json:"level" yaml:"level"`
+	Value     int64  `json:"value" yaml:"value"`
+}
+
+// LayoutConstraint describes a constraint of a check configuration.
+// Value is not used by service and oncall constraints.
+type LayoutConstraint struct {
+	Type     string `json:"type" yaml:"type"`
+	Operator string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Group    string `json:"group,omitempty" yaml:"group,omitempty"`
+	Name     string `json:"name" yaml:"name"`
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// NewLayoutResult returns a Result with an empty Layout
+func NewLayoutResult() Result {
+	return Result{
+		Errors: &[]string{},
+		Layout: &Layout{},
+	}
+}
+
+// Sort orders all lists of the layout by name, which makes the
+// serialized layout stable
+func (l *Layout) Sort() {
+	sortLayoutProperties(l.Properties)
+	sort.Slice(l.Buckets, func(i, j int) bool {
+		return l.Buckets[i].Name < l.Buckets[j].Name
+	})
+	for i := range l.Buckets {
+		sortLayoutProperties(l.Buckets[i].Properties)
+		sortLayoutGroups(l.Buckets[i].Groups)
+		sortLayoutClusters(l.Buckets[i].Clusters)
+		sortLayoutNodes(l.Buckets[i].Nodes)
+	}
+	sort.Slice(l.CheckConfigs, func(i, j int) bool {
+		return l.CheckConfigs[i].Name < l.CheckConfigs[j].Name
+	})
+	for i := range l.CheckConfigs {
+		c := &l.CheckConfigs[i]
+		sort.Slice(c.Thresholds, func(i, j int) bool {
+			return c.Thresholds[i].Level < c.Thresholds[j].Level
+		})
+		sort.Slice(c.Constraints, func(i, j int) bool {
+			a, b := c.Constraints[i], c.Constraints[j]
+			switch {
+			case a.Type != b.Type:
+				return a.Type < b.Type
+			case a.Name != b.Name:
+				return a.Name < b.Name
+			case a.Value != b.Value:
+				return a.Value < b.Value
+			case a.Operator != b.Operator:
+				return a.Operator < b.Operator
+			}
+			return a.Group < b.Group
+		})
+	}
+}
+
+// ClearIDs removes all IDs from the layout
+func (l *Layout) ClearIDs() {
+	l.ID = ``
+	clearPropertyIDs(l.Properties)
+	for i := range l.Buckets {
+		l.Buckets[i].ID = ``
+		clearPropertyIDs(l.Buckets[i].Properties)
+		clearGroupIDs(l.Buckets[i].Groups)
+		clearClusterIDs(l.Buckets[i].Clusters)
+		clearNodeIDs(l.Buckets[i].Nodes)
+	}
+	for i := range l.CheckConfigs {
+		l.CheckConfigs[i].ID = ``
+	}
+}
+
+// sortLayoutGroups orders groups and their members by name
+func sortLayoutGroups(g []LayoutGroup) {
+	sort.Slice(g, func(i, j int) bool {
+		return g[i].Name < g[j].Name
+	})
+	for i := range g {
+		sortLayoutProperties(g[i].Properties)
+		sortLayoutGroups(g[i].Groups)
+		sortLayoutClusters(g[i].Clusters)
+		sortLayoutNodes(g[i].Nodes)
+	}
+}
+
+// sortLayoutClusters orders clusters and their members by name
+func sortLayoutClusters(c []LayoutCluster) {
+	sort.Slice(c, func(i, j int) bool {
+		return c[i].Name < c[j].Name
+	})
+	for i := range c {
+		sortLayoutProperties(c[i].Properties)
+		sortLayoutNodes(c[i].Nodes)
+	}
+}
+
+// sortLayoutNodes orders nodes by name
+func sortLayoutNodes(n []LayoutNode) {
+	sort.Slice(n, func(i, j int) bool {
+		return n[i].Name < n[j].Name
+	})
+	for i := range n {
+		sortLayoutProperties(n[i].Properties)
+	}
+}
+
+// sortLayoutProperties orders properties by type, name and view
+func sortLayoutProperties(p []LayoutProperty) {
+	sort.Slice(p, func(i, j int) bool {
+		switch {
+		case p[i].Type != p[j].Type:
+			return p[i].Type < p[j].Type
+		case p[i].Name != p[j].Name:
+			return p[i].Name < p[j].Name
+		}
+		return p[i].View < p[j].View
+	})
+}
+
+// clearGroupIDs removes all IDs from groups and their members
+func clearGroupIDs(g []LayoutGroup) {
+	for i := range g {
+		g[i].ID = ``
+		clearPropertyIDs(g[i].Properties)
+		clearGroupIDs(g[i].Groups)
+		clearClusterIDs(g[i].Clusters)
+		clearNodeIDs(g[i].Nodes)
+	}
+}
+
+// clearClusterIDs removes all IDs from clusters and their members
+func clearClusterIDs(c []LayoutCluster) {
+	for i := range c {
+		c[i].ID = ``
+		clearPropertyIDs(c[i].Properties)
+		clearNodeIDs(c[i].Nodes)
+	}
+}
+
+// clearNodeIDs removes all IDs from nodes
+func clearNodeIDs(n []LayoutNode) {
+	for i := range n {
+		n[i].ID = ``
+		clearPropertyIDs(n[i].Properties)
+	}
+}
+
+// clearPropertyIDs removes the source instance IDs from properties
+func clearPropertyIDs(p []LayoutProperty) {
+	for i := range p {
+		p[i].SourceInstanceID = ``
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
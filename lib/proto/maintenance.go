@@ -0,0 +1,57 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// Maintenance is a scheduled maintenance window. While the window is
+// active, all check instances on the object and its children are
+// reported as silenced in their deployment details. ObjectType is
+// one of repository, bucket, group, cluster or node. StartsAt and
+// EndsAt are RFC3339 timestamps.
+type Maintenance struct {
+	ID           string           `json:"id,omitempty"`
+	RepositoryID string           `json:"repositoryId,omitempty"`
+	ObjectType   string           `json:"objectType,omitempty"`
+	ObjectID     string           `json:"objectId,omitempty"`
+	StartsAt     string           `json:"startsAt,omitempty"`
+	EndsAt       string           `json:"endsAt,omitempty"`
+	Reason       string           `json:"reason,omitempty"`
+	Details      *DetailsCreation `json:"details,omitempty"`
+}
+
+// Clone returns a copy of m
+func (m *Maintenance) Clone() Maintenance {
+	clone := Maintenance{
+		ID:           m.ID,
+		RepositoryID: m.RepositoryID,
+		ObjectType:   m.ObjectType,
+		ObjectID:     m.ObjectID,
+		StartsAt:     m.StartsAt,
+		EndsAt:       m.EndsAt,
+		Reason:       m.Reason,
+	}
+	if m.Details != nil {
+		clone.Details = m.Details.Clone()
+	}
+	return clone
+}
+
+func NewMaintenanceRequest() Request {
+	return Request{
+		Flags:       &Flags{},
+		Maintenance: &Maintenance{},
+	}
+}
+
+func NewMaintenanceResult() Result {
+	return Result{
+		Errors:       &[]string{},
+		Maintenances: &[]Maintenance{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
@@ -0,0 +1,31 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// PlanAction is a change to the repository tree that a job would
+// cause. Plans are returned for requests that were submitted as dry
+// run.
+type PlanAction struct {
+	Action          string `json:"action"`
+	ObjectType      string `json:"objectType,omitempty"`
+	ObjectID        string `json:"objectId,omitempty"`
+	ObjectName      string `json:"objectName,omitempty"`
+	ChildType       string `json:"childType,omitempty"`
+	ChildID         string `json:"childId,omitempty"`
+	ChildName       string `json:"childName,omitempty"`
+	PropertyType    string `json:"propertyType,omitempty"`
+	PropertyName    string `json:"propertyName,omitempty"`
+	PropertyView    string `json:"propertyView,omitempty"`
+	CheckID         string `json:"checkId,omitempty"`
+	CheckInstanceID string `json:"checkInstanceId,omitempty"`
+	CapabilityID    string `json:"capabilityId,omitempty"`
+	MonitoringID    string `json:"monitoringId,omitempty"`
+	MonitoringName  string `json:"monitoringName,omitempty"`
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
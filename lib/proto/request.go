@@ -13,9 +13,11 @@ type Request struct {
 	Filter *Filter `json:"filter,omitempty"`
 	Flags  *Flags  `json:"flags,omitempty"`
 
+	APIKey          *APIKey          `json:"apiKey,omitempty"`
 	Action          *Action          `json:"action,omitempty"`
 	Admin           *Admin           `json:"admin,omitempty"`
 	Attribute       *Attribute       `json:"attribute,omitempty"`
+	Batch           *Batch           `json:"batch,omitempty"`
 	Bucket          *Bucket          `json:"bucket,omitempty"`
 	Capability      *Capability      `json:"capability,omitempty"`
 	Category        *Category        `json:"category,omitempty"`
@@ -32,6 +34,7 @@ type Request struct {
 	JobStatus       *JobStatus       `json:"jobStatus,omitempty"`
 	JobType         *JobType         `json:"jobType,omitempty"`
 	Level           *Level           `json:"level,omitempty"`
+	Maintenance     *Maintenance     `json:"maintenance,omitempty"`
 	Metric          *Metric          `json:"metric,omitempty"`
 	Mode            *Mode            `json:"mode,omitempty"`
 	Monitoring      *Monitoring      `json:"monitoring,omitempty"`
@@ -52,6 +55,7 @@ type Request struct {
 	User            *User            `json:"user,omitempty"`
 	Validity        *Validity        `json:"validity,omitempty"`
 	View            *View            `json:"view,omitempty"`
+	Webhook         *Webhook         `json:"webhook,omitempty"`
 	Workflow        *Workflow        `json:"workflow,omitempty"`
 }
 
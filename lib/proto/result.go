@@ -54,50 +54,57 @@ type Result struct {
 	DeploymentsList *[]string `json:"deploymentsList,omitempty"`
 
 	// Request dependent data
-	Actions          *[]Action          `json:"actions,omitempty"`
-	Admins           *[]Admin           `json:"admins,omitempty"`
-	Attributes       *[]Attribute       `json:"attributes,omitempty"`
-	Buckets          *[]Bucket          `json:"buckets,omitempty"`
-	Capabilities     *[]Capability      `json:"capability,omitempty"`
-	Categories       *[]Category        `json:"categories,omitempty"`
-	CheckConfigs     *[]CheckConfig     `json:"checkConfigs,omitempty"`
-	Clusters         *[]Cluster         `json:"clusters,omitempty"`
-	DatacenterGroups *[]DatacenterGroup `json:"datacenterGroups,omitempty"`
-	Datacenters      *[]Datacenter      `json:"datacenter,omitempty"`
-	Deployments      *[]Deployment      `json:"deployments,omitempty"`
-	Entities         *[]Entity          `json:"entities,omitempty"`
-	Environments     *[]Environment     `json:"environment,omitempty"`
-	Grants           *[]Grant           `json:"grants,omitempty"`
-	Groups           *[]Group           `json:"groups,omitempty"`
-	HostDeployments  *[]HostDeployment  `json:"hostDeployments,omitempty"`
-	Instances        *[]Instance        `json:"instances,omitempty"`
-	JobResults       *[]JobResult       `json:"jobResults,omitempty"`
-	JobStatus        *[]JobStatus       `json:"jobStatus,omitempty"`
-	JobTypes         *[]JobType         `json:"jobTypes,omitempty"`
-	Jobs             *[]Job             `json:"jobs,omitempty"`
-	Levels           *[]Level           `json:"levels,omitempty"`
-	Metrics          *[]Metric          `json:"metrics,omitempty"`
-	Modes            *[]Mode            `json:"modes,omitempty"`
-	Monitorings      *[]Monitoring      `json:"monitorings,omitempty"`
-	Nodes            *[]Node            `json:"nodes,omitempty"`
-	Oncalls          *[]Oncall          `json:"oncall,omitempty"`
-	Permissions      *[]Permission      `json:"permissions,omitempty"`
-	Predicates       *[]Predicate       `json:"predicates,omitempty"`
-	Properties       *[]Property        `json:"properties,omitempty"`
-	Providers        *[]Provider        `json:"providers,omitempty"`
-	Repositories     *[]Repository      `json:"repositories,omitempty"`
-	Sections         *[]Section         `json:"sections,omitempty"`
-	Servers          *[]Server          `json:"servers,omitempty"`
-	States           *[]State           `json:"states,omitempty"`
-	Status           *[]Status          `json:"status,omitempty"`
-	Systems          *[]System          `json:"system,omitempty"`
-	Teams            *[]Team            `json:"teams,omitempty"`
-	Tree             *Tree              `json:"tree,omitempty"`
-	Units            *[]Unit            `json:"units,omitempty"`
-	Users            *[]User            `json:"users,omitempty"`
-	Validities       *[]Validity        `json:"validities,omitempty"`
-	Views            *[]View            `json:"views,omitempty"`
-	Workflows        *[]Workflow        `json:"workflows,omitempty"`
+	APIKeys           *[]APIKey           `json:"apiKeys,omitempty"`
+	Actions           *[]Action           `json:"actions,omitempty"`
+	Admins            *[]Admin            `json:"admins,omitempty"`
+	Attributes        *[]Attribute        `json:"attributes,omitempty"`
+	Audits            *[]Audit            `json:"audits,omitempty"`
+	Buckets           *[]Bucket           `json:"buckets,omitempty"`
+	Capabilities      *[]Capability       `json:"capability,omitempty"`
+	Categories        *[]Category         `json:"categories,omitempty"`
+	CheckConfigs      *[]CheckConfig      `json:"checkConfigs,omitempty"`
+	CheckExplanations *[]CheckExplanation `json:"checkExplanations,omitempty"`
+	Clusters          *[]Cluster          `json:"clusters,omitempty"`
+	DatacenterGroups  *[]DatacenterGroup  `json:"datacenterGroups,omitempty"`
+	Datacenters       *[]Datacenter       `json:"datacenter,omitempty"`
+	Deployments       *[]Deployment       `json:"deployments,omitempty"`
+	Entities          *[]Entity           `json:"entities,omitempty"`
+	Environments      *[]Environment      `json:"environment,omitempty"`
+	Grants            *[]Grant            `json:"grants,omitempty"`
+	Groups            *[]Group            `json:"groups,omitempty"`
+	HostDeployments   *[]HostDeployment   `json:"hostDeployments,omitempty"`
+	Instances         *[]Instance         `json:"instances,omitempty"`
+	JobResults        *[]JobResult        `json:"jobResults,omitempty"`
+	JobStatus         *[]JobStatus        `json:"jobStatus,omitempty"`
+	JobTypes          *[]JobType          `json:"jobTypes,omitempty"`
+	Jobs              *[]Job              `json:"jobs,omitempty"`
+	Layout            *Layout             `json:"layout,omitempty"`
+	Levels            *[]Level            `json:"levels,omitempty"`
+	Maintenances      *[]Maintenance      `json:"maintenances,omitempty"`
+	Metrics           *[]Metric           `json:"metrics,omitempty"`
+	Modes             *[]Mode             `json:"modes,omitempty"`
+	Monitorings       *[]Monitoring       `json:"monitorings,omitempty"`
+	Nodes             *[]Node             `json:"nodes,omitempty"`
+	Oncalls           *[]Oncall           `json:"oncall,omitempty"`
+	Permissions       *[]Permission       `json:"permissions,omitempty"`
+	Plan              *[]PlanAction       `json:"plan,omitempty"`
+	Predicates        *[]Predicate        `json:"predicates,omitempty"`
+	Properties        *[]Property         `json:"properties,omitempty"`
+	Providers         *[]Provider         `json:"providers,omitempty"`
+	Repositories      *[]Repository       `json:"repositories,omitempty"`
+	Sections          *[]Section          `json:"sections,omitempty"`
+	Servers           *[]Server           `json:"servers,omitempty"`
+	States            *[]State            `json:"states,omitempty"`
+	Status            *[]Status           `json:"status,omitempty"`
+	Systems           *[]System           `json:"system,omitempty"`
+	Teams             *[]Team             `json:"teams,omitempty"`
+	Tree              *Tree               `json:"tree,omitempty"`
+	Units             *[]Unit             `json:"units,omitempty"`
+	Users             *[]User             `json:"users,omitempty"`
+	Validities        *[]Validity         `json:"validities,omitempty"`
+	Views             *[]View             `json:"views,omitempty"`
+	Webhooks          *[]Webhook          `json:"webhooks,omitempty"`
+	Workflows         *[]Workflow         `json:"workflows,omitempty"`
 }
 
 func (r *Result) Error(err error) bool {
@@ -196,13 +203,16 @@ func NewResult() Result {
 func (r *Result) DataClean() {
 	r.Errors = &[]string{`Internal server error forced empty result`}
 	r.DeploymentsList = nil
+	r.APIKeys = nil
 	r.Actions = nil
 	r.Admins = nil
 	r.Attributes = nil
+	r.Audits = nil
 	r.Buckets = nil
 	r.Capabilities = nil
 	r.Categories = nil
 	r.CheckConfigs = nil
+	r.CheckExplanations = nil
 	r.Clusters = nil
 	r.DatacenterGroups = nil
 	r.Datacenters = nil
@@ -218,12 +228,14 @@ func (r *Result) DataClean() {
 	r.JobTypes = nil
 	r.Jobs = nil
 	r.Levels = nil
+	r.Maintenances = nil
 	r.Metrics = nil
 	r.Modes = nil
 	r.Monitorings = nil
 	r.Nodes = nil
 	r.Oncalls = nil
 	r.Permissions = nil
+	r.Plan = nil
 	r.Predicates = nil
 	r.Properties = nil
 	r.Providers = nil
@@ -239,6 +251,7 @@ func (r *Result) DataClean() {
 	r.Users = nil
 	r.Validities = nil
 	r.Views = nil
+	r.Webhooks = nil
 	r.Workflows = nil
 }
 
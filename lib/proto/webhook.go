@@ -0,0 +1,85 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto // import "github.com/mjolnir42/soma/lib/proto"
+
+// Headers set on webhook deliveries. The signature is the hex encoded
+// HMAC-SHA256 of the request body, keyed with the webhook secret.
+const (
+	WebhookHeaderDelivery  = `X-Soma-Delivery`
+	WebhookHeaderEvent     = `X-Soma-Event`
+	WebhookHeaderSignature = `X-Soma-Signature`
+)
+
+// WebhookEventDeployment is the event type for check instance
+// configuration state changes
+const WebhookEventDeployment = `deployment.state`
+
+// Webhook describes a subscription to deployment state changes. Empty
+// filter fields match every repository, monitoring system or state.
+type Webhook struct {
+	ID           string           `json:"id,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	URL          string           `json:"url,omitempty"`
+	Secret       string           `json:"secret,omitempty"`
+	RepositoryID string           `json:"repositoryId,omitempty"`
+	MonitoringID string           `json:"monitoringId,omitempty"`
+	States       []string         `json:"states,omitempty"`
+	Details      *DetailsCreation `json:"details,omitempty"`
+}
+
+// Clone returns a copy of w
+func (w *Webhook) Clone() Webhook {
+	clone := Webhook{
+		ID:           w.ID,
+		Name:         w.Name,
+		URL:          w.URL,
+		Secret:       w.Secret,
+		RepositoryID: w.RepositoryID,
+		MonitoringID: w.MonitoringID,
+	}
+	if w.States != nil {
+		clone.States = make([]string, len(w.States))
+		copy(clone.States, w.States)
+	}
+	if w.Details != nil {
+		clone.Details = w.Details.Clone()
+	}
+	return clone
+}
+
+// DeploymentEvent is the body of a webhook delivery
+type DeploymentEvent struct {
+	EventID               uint64 `json:"eventId"`
+	Type                  string `json:"type"`
+	OccurredAt            string `json:"occurredAt"`
+	CheckID               string `json:"checkId,omitempty"`
+	CheckInstanceID       string `json:"checkInstanceId"`
+	CheckInstanceConfigID string `json:"checkInstanceConfigId"`
+	Version               uint64 `json:"version"`
+	RepositoryID          string `json:"repositoryId,omitempty"`
+	MonitoringID          string `json:"monitoringId,omitempty"`
+	PreviousState         string `json:"previousState,omitempty"`
+	State                 string `json:"state"`
+	NextState             string `json:"nextState"`
+}
+
+func NewWebhookRequest() Request {
+	return Request{
+		Flags:   &Flags{},
+		Webhook: &Webhook{},
+	}
+}
+
+func NewWebhookResult() Result {
+	return Result{
+		Errors:   &[]string{},
+		Webhooks: &[]Webhook{},
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix
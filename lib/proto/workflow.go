@@ -10,12 +10,25 @@
 package proto
 
 type Workflow struct {
-	InstanceID       string           `json:"instanceId,omitempty"`
-	InstanceConfigID string           `json:"instanceConfigID,omitempty"`
-	Status           string           `json:"status,omitempty"`
-	NextStatus       string           `json:"nextStatus,omitempty"`
-	Summary          *WorkflowSummary `json:"summary,omitempty"`
-	Instances        *[]Instance      `json:"instances,omitempty"`
+	InstanceID       string                `json:"instanceId,omitempty"`
+	InstanceConfigID string                `json:"instanceConfigID,omitempty"`
+	Status           string                `json:"status,omitempty"`
+	NextStatus       string                `json:"nextStatus,omitempty"`
+	Summary          *WorkflowSummary      `json:"summary,omitempty"`
+	Instances        *[]Instance           `json:"instances,omitempty"`
+	Transitions      *[]WorkflowTransition `json:"transitions,omitempty"`
+}
+
+// WorkflowTransition is a recorded change of the deployment state of
+// a check instance configuration
+type WorkflowTransition struct {
+	InstanceConfigID   string `json:"instanceConfigID"`
+	PreviousStatus     string `json:"previousStatus"`
+	PreviousNextStatus string `json:"previousNextStatus"`
+	Status             string `json:"status"`
+	NextStatus         string `json:"nextStatus"`
+	Actor              string `json:"actor"`
+	OccurredAt         string `json:"occurredAt"`
 }
 
 type WorkflowSummary struct {
@@ -33,8 +46,48 @@ type WorkflowSummary struct {
 	Blocked               uint64 `json:"blocked"`
 }
 
+// Add adds count to the number of check instances in deployment
+// state status
+func (s *WorkflowSummary) Add(status string, count uint64) {
+	switch status {
+	case DeploymentAwaitingComputation:
+		s.AwaitingComputation += count
+	case DeploymentComputed:
+		s.Computed += count
+	case DeploymentAwaitingRollout:
+		s.AwaitingRollout += count
+	case DeploymentRolloutInProgress:
+		s.RolloutInProgress += count
+	case DeploymentRolloutFailed:
+		s.RolloutFailed += count
+	case DeploymentActive:
+		s.Active += count
+	case DeploymentAwaitingDeprovision:
+		s.AwaitingDeprovision += count
+	case DeploymentDeprovisionInProgress:
+		s.DeprovisionInProgress += count
+	case DeploymentDeprovisionFailed:
+		s.DeprovisionFailed += count
+	case DeploymentDeprovisioned:
+		s.Deprovisioned += count
+	case DeploymentAwaitingDeletion:
+		s.AwaitingDeletion += count
+	case DeploymentBlocked:
+		s.Blocked += count
+	}
+}
+
+// WorkflowFilter selects check instances by the deployment state of
+// their current configuration. Bulk operations additionally select
+// by monitoring system, repository, capability and by MinAge, the
+// minimum time since the last state change as duration string.
 type WorkflowFilter struct {
-	Status string `json:"status"`
+	Status       string   `json:"status"`
+	States       []string `json:"states,omitempty"`
+	MonitoringID string   `json:"monitoringID,omitempty"`
+	RepositoryID string   `json:"repositoryID,omitempty"`
+	CapabilityID string   `json:"capabilityID,omitempty"`
+	MinAge       string   `json:"minAge,omitempty"`
 }
 
 func NewWorkflowRequest() Request {
@@ -52,6 +105,18 @@ func NewWorkflowFilter() Request {
 	}
 }
 
+// NewWorkflowBulkRequest returns a request for a workflow operation
+// on all check instances matching a filter
+func NewWorkflowBulkRequest() Request {
+	return Request{
+		Flags:    &Flags{},
+		Workflow: &Workflow{},
+		Filter: &Filter{
+			Workflow: &WorkflowFilter{},
+		},
+	}
+}
+
 func NewWorkflowResult() Result {
 	return Result{
 		Errors:    &[]string{},
@@ -0,0 +1,60 @@
+/*-
+ * Copyright (c) 2026, Jörg Pernfuß
+ *
+ * Use of this source code is governed by a 2-clause BSD license
+ * that can be found in the LICENSE file.
+ */
+
+package proto
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkflowSummaryAdd(t *testing.T) {
+	s := WorkflowSummary{}
+	for i, status := range testDeploymentStates {
+		s.Add(status, uint64(i+1))
+	}
+	// unknown states are not counted
+	s.Add(DeploymentNone, 100)
+	s.Add(`unknown`, 100)
+	// counts accumulate per state
+	s.Add(DeploymentAwaitingRollout, 10)
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	if err = json.Unmarshal(raw, &counts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]uint64{
+		`awaitingComputation`:   1,
+		`computed`:              2,
+		`blocked`:               3,
+		`awaitingRollout`:       14,
+		`rolloutInProgress`:     5,
+		`active`:                6,
+		`rolloutFailed`:         7,
+		`awaitingDeprovision`:   8,
+		`deprovisionInProgress`: 9,
+		`deprovisionFailed`:     10,
+		`deprovisioned`:         11,
+		`awaitingDeletion`:      12,
+	}
+	if len(counts) != len(expected) {
+		t.Errorf("expected %d states, got %d", len(expected), len(counts))
+	}
+	for state, count := range expected {
+		if counts[state] != count {
+			t.Errorf("%s: expected %d, got %d", state, count,
+				counts[state])
+		}
+	}
+}
+
+// vim: ts=4 sw=4 sts=4 noet fenc=utf-8 ffs=unix